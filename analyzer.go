@@ -1,11 +1,14 @@
 package leakhound
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 
 	"github.com/nilpoona/leakhound/config"
 	"github.com/nilpoona/leakhound/detector"
-	"github.com/nilpoona/leakhound/reporter"
+	"github.com/nilpoona/leakhound/reporter/text"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
 )
@@ -31,19 +34,37 @@ var Analyzer = &analysis.Analyzer{
 	Run:        run,
 	Requires:   []*analysis.Analyzer{inspect.Analyzer},
 	ResultType: reflect.TypeOf((*ResultType)(nil)),
+	// Files with type errors are skipped with a notification rather than
+	// the whole package, e.g. when cgo is disabled
+	RunDespiteErrors: true,
 }
 
 var outputFormat string
 var configPath string
+var verbosity int
+var severityOverrides string
+var maxMemory string
+var structRuleScope string
+var allowTypeErrors bool
+var minConfidence string
+var maxFlowHops string
 
 func init() {
 	Analyzer.Flags.StringVar(&outputFormat, "format", "text", "Output format: text or sarif")
 	Analyzer.Flags.StringVar(&configPath, "config", "", "path to config file (default: .leakhound.yaml)")
+	Analyzer.Flags.IntVar(&verbosity, "verbosity", 1, "text output verbosity: 1 prints findings, 2 adds taint flows")
+	Analyzer.Flags.StringVar(&severityOverrides, "severity-overrides", "", "comma-separated RULE=SEVERITY pairs overriding the config file, e.g. LH0003=warning or all=note")
+	Analyzer.Flags.StringVar(&maxMemory, "max-memory", "", "per-package budget for tracked data flow facts, e.g. 512MiB; packages over it get direct-access-only detection")
+	Analyzer.Flags.StringVar(&structRuleScope, "struct-rule-scope", "", "structs LH0003 reports when logged as a whole: local (the package's), module (the module's) or all; overrides the config file")
+	Analyzer.Flags.BoolVar(&allowTypeErrors, "allow-type-errors", false, "match sinks syntactically in files that do not type-check, with best-effort findings")
+	Analyzer.Flags.StringVar(&minConfidence, "min-confidence", "", "lowest confidence reported: high, medium, low or a score between 0.0 and 1.0; overrides the config file")
+	Analyzer.Flags.StringVar(&maxFlowHops, "max-flow-hops", "", "data flow hops after which findings are reported as warnings or suppressed (see long-flows), 0 for no limit; overrides the config file")
 }
 
 // ResultType holds the findings from analysis
 type ResultType struct {
-	Findings []detector.Finding
+	Findings      []detector.Finding
+	Notifications []detector.Notification // E.g. the package went over its memory budget
 }
 
 func run(pass *analysis.Pass) (interface{}, error) {
@@ -52,6 +73,25 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
+	overrides, err := config.ParseSeverityOverrides(severityOverrides)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ApplySeverityOverrides(overrides)
+	if err := cfg.ApplyMaxMemory(maxMemory); err != nil {
+		return nil, err
+	}
+	if err := cfg.ApplyStructRuleScope(structRuleScope); err != nil {
+		return nil, err
+	}
+	if err := cfg.ApplyMinConfidence(minConfidence); err != nil {
+		return nil, err
+	}
+	if err := cfg.ApplyMaxFlowHops(maxFlowHops); err != nil {
+		return nil, err
+	}
+	cfg.AllowTypeErrors = cfg.AllowTypeErrors || allowTypeErrors
+	cfg = *cfg.ForPackage(pass.Pkg.Path(), packageDir(pass))
 
 	// Phase 1: Collection
 	collector := detector.NewDataFlowCollector(pass, &cfg)
@@ -60,28 +100,38 @@ func run(pass *analysis.Pass) (interface{}, error) {
 	// Phase 2: Detection (returns findings)
 	findings := collector.Analyze()
 
-	// Phase 2.5: Apply suppression filter (inline comments + config rules)
+	// Phase 2.5: Post-process (suppressions, severities, ...) like the
+	// whole-program driver
 	filter := &detector.SuppressionFilter{}
 	filter.Build(pass.Files, pass.Fset)
-	findings = filter.Apply(findings, pass.Fset, &cfg)
+	findings = detector.NewPipeline(filter, pass.Fset).Run(findings, &cfg)
 
-	// For text format, report immediately
-	// For SARIF format, the custom driver in cmd/leakhound/main.go handles output
-	if outputFormat != "sarif" {
-		repConfig := reporter.Config{
-			Format: reporter.Format(outputFormat),
-		}
-
-		rep, err := reporter.New(pass, repConfig)
-		if err != nil {
-			return nil, err
-		}
-
-		if err := rep.Report(findings); err != nil {
+	switch outputFormat {
+	case "text", "":
+		// For text format, report immediately
+		if err := text.NewDiagnosticReporter(pass, verbosity).Report(findings); err != nil {
 			return nil, err
 		}
+	case "sarif":
+		// For SARIF format, the custom driver in cmd/leakhound/main.go handles output
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", outputFormat)
 	}
 
 	// Always return ResultType since it's declared in Analyzer.ResultType
-	return &ResultType{Findings: findings}, nil
+	return &ResultType{Findings: findings, Notifications: collector.Notifications()}, nil
+}
+
+// packageDir returns the directory of the package pass analyzes as matched by
+// the overrides of the config (see config.OverrideDir), or "" if unknown
+func packageDir(pass *analysis.Pass) string {
+	if len(pass.Files) == 0 {
+		return ""
+	}
+	workDir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	file := pass.Fset.File(pass.Files[0].Pos())
+	return config.OverrideDir(workDir, filepath.Dir(file.Name()))
 }
@@ -1,15 +1,23 @@
 package leakhound_test
 
 import (
+	"fmt"
 	"go/ast"
+	"go/importer"
 	"go/parser"
 	"go/token"
 	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/nilpoona/leakhound"
+	"github.com/nilpoona/leakhound/config"
+	"github.com/nilpoona/leakhound/detector"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/packages"
 )
 
 // BenchmarkSmallCodebase benchmarks the analyzer on a small codebase (~100 lines)
@@ -63,8 +71,31 @@ func BenchmarkMediumCodebase(b *testing.B) {
 	}
 }
 
-func runAnalyzerOnSource(b *testing.B, src string) {
+// BenchmarkNestedExpressions benchmarks the analyzer on sink arguments built
+// from deeply nested calls, concatenations and composite literals, whose
+// sub-expressions are reached again through each enclosing level
+func BenchmarkNestedExpressions(b *testing.B) {
+	src := generateNestedCodebase(8)
+
+	// Type-check against the standard library so the sinks resolve; the
+	// importer caches the packages across iterations
 	fset := token.NewFileSet()
+	imp := importer.ForCompiler(fset, "source", nil)
+	runAnalyzer(b, fset, imp, src)
+
+	b.ResetTimer()
+	b.ReportAllocs()
+
+	for i := 0; i < b.N; i++ {
+		runAnalyzer(b, fset, imp, src)
+	}
+}
+
+func runAnalyzerOnSource(b *testing.B, src string) {
+	runAnalyzer(b, token.NewFileSet(), nil, src) // Basic importer for testing
+}
+
+func runAnalyzer(b *testing.B, fset *token.FileSet, imp types.Importer, src string) {
 	file, err := parser.ParseFile(fset, "test.go", src, 0)
 	if err != nil {
 		b.Fatal(err)
@@ -72,7 +103,7 @@ func runAnalyzerOnSource(b *testing.B, src string) {
 
 	// Create type checker
 	config := &types.Config{
-		Importer: nil, // Basic importer for testing
+		Importer: imp,
 	}
 	info := &types.Info{
 		Types: make(map[ast.Expr]types.TypeAndValue),
@@ -252,3 +283,155 @@ func logDatabaseConnection(db DatabaseConfig) {
 }
 `
 }
+
+// generateNestedCodebase returns a package whose sink arguments nest calls,
+// concatenations and composite literals depth levels deep
+func generateNestedCodebase(depth int) string {
+	nested := func(leaf string) string {
+		expr := leaf
+		for i := 0; i < depth; i++ {
+			switch i % 3 {
+			case 0:
+				expr = fmt.Sprintf("fmt.Sprint(%s, u.Name)", expr)
+			case 1:
+				expr = fmt.Sprintf(`"[" + %s + "]"`, expr)
+			case 2:
+				expr = fmt.Sprintf("Pair{A: %s, B: u.Name}.A", expr)
+			}
+		}
+		return expr
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`package main
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type User struct {
+	Name     string
+	Password string ` + "`sensitive:\"true\"`" + `
+	Token    string ` + "`sensitive:\"true\"`" + `
+}
+
+type Pair struct {
+	A, B string
+}
+
+func main() {}
+`)
+	for i := 0; i < 20; i++ {
+		fmt.Fprintf(&sb, "\nfunc handle%d(u User) {\n", i)
+		fmt.Fprintf(&sb, "\tslog.Info(\"m\", \"a\", %s, \"b\", %s)\n", nested("u.Password"), nested("u.Token"))
+		fmt.Fprintf(&sb, "\tslog.Info(\"m\", slog.Group(\"g\", \"c\", %s))\n", nested("u.Name"))
+		sb.WriteString("}\n")
+	}
+	return sb.String()
+}
+
+// corpusEnv enables corpus mode: the benchmarks and allocation checks below
+// download pinned releases of real-world modules and analyze them, e.g.
+//
+//	LEAKHOUND_CORPUS=1 go test -run Corpus -bench Corpus .
+const corpusEnv = "LEAKHOUND_CORPUS"
+
+// corpusModule is a pinned release of a real-world module analyzed in corpus
+// mode
+type corpusModule struct {
+	path    string
+	version string
+
+	// maxAllocs caps the allocations of one whole-program run over the
+	// module, with headroom over the measured count. Raise it deliberately
+	// when a change is expected to cost more.
+	maxAllocs float64
+}
+
+var corpus = []corpusModule{
+	{path: "github.com/spf13/cobra", version: "v1.8.1", maxAllocs: 66000},
+	{path: "github.com/go-chi/chi/v5", version: "v5.1.0", maxAllocs: 7600},
+	{path: "github.com/gin-gonic/gin", version: "v1.10.0", maxAllocs: 253000},
+}
+
+// BenchmarkCorpus benchmarks whole-program analysis of each corpus module
+func BenchmarkCorpus(b *testing.B) {
+	skipUnlessCorpus(b)
+	for _, m := range corpus {
+		b.Run(m.path, func(b *testing.B) {
+			fset, pkgs := loadCorpusModule(b, m)
+			b.ResetTimer()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				analyzeCorpus(fset, pkgs)
+			}
+		})
+	}
+}
+
+// TestCorpusAllocations fails when analyzing a corpus module allocates more
+// than its maxAllocs, so data flow features do not silently blow up the cost
+// of analysis
+func TestCorpusAllocations(t *testing.T) {
+	skipUnlessCorpus(t)
+	for _, m := range corpus {
+		t.Run(m.path, func(t *testing.T) {
+			fset, pkgs := loadCorpusModule(t, m)
+			allocs := testing.AllocsPerRun(1, func() { analyzeCorpus(fset, pkgs) })
+			t.Logf("%s@%s: %.0f allocations (max %.0f)", m.path, m.version, allocs, m.maxAllocs)
+			if allocs > m.maxAllocs {
+				t.Errorf("%s@%s: %.0f allocations, want at most %.0f", m.path, m.version, allocs, m.maxAllocs)
+			}
+		})
+	}
+}
+
+func skipUnlessCorpus(tb testing.TB) {
+	tb.Helper()
+	if os.Getenv(corpusEnv) == "" {
+		tb.Skipf("set %s=1 to download and analyze the corpus modules", corpusEnv)
+	}
+}
+
+// loadCorpusModule loads the packages of m from a module under
+// testdata/.corpus that requires it. The go command downloads m and its
+// dependencies into the module cache on first use.
+func loadCorpusModule(tb testing.TB, m corpusModule) (*token.FileSet, []*packages.Package) {
+	tb.Helper()
+	dir, err := filepath.Abs(filepath.Join("testdata", ".corpus", strings.ReplaceAll(m.path, "/", "_")+"@"+m.version))
+	if err != nil {
+		tb.Fatalf("abs path: %v", err)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		tb.Fatalf("failed to create corpus dir: %v", err)
+	}
+	gomod := fmt.Sprintf("module corpus\n\ngo 1.22\n\nrequire %s %s\n", m.path, m.version)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0644); err != nil {
+		tb.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+			packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:  dir,
+		Env:  append(os.Environ(), "GOFLAGS=-mod=mod"),
+		Fset: token.NewFileSet(),
+	}
+	roots, err := packages.Load(cfg, m.path+"/...")
+	if err != nil {
+		tb.Fatalf("packages.Load: %v", err)
+	}
+	if packages.PrintErrors(roots) > 0 {
+		tb.Fatalf("failed to load %s@%s", m.path, m.version)
+	}
+	return cfg.Fset, flattenForTest(roots)
+}
+
+// analyzeCorpus runs whole-program analysis, the default of the CLI
+func analyzeCorpus(fset *token.FileSet, pkgs []*packages.Package) []detector.Finding {
+	wp := detector.NewWholeProgramCollector(detector.NewWorldView(fset, pkgs), &config.Config{})
+	wp.Collect()
+	return wp.Analyze()
+}
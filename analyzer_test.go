@@ -1,9 +1,13 @@
 package leakhound_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/nilpoona/leakhound"
+	"github.com/nilpoona/leakhound/detector"
 	"golang.org/x/tools/go/analysis/analysistest"
 )
 
@@ -19,6 +23,29 @@ func Test(t *testing.T) {
 		"flowcases",
 		"containers",
 		"transforms",
+		"grouptest",
+		"compositelit",
+		"typeswitch",
+		"promoted",
+		"receivers",
+		"funcvalues",
+		"methodvalues",
+		"defaultloggers",
+		"zapbuiltin",
+		"zerologbuiltin",
+		"pkginit",
+		"lazyinit",
+		"slogroles",
+		"dsn",
+		"authheaders",
+		"redactexpose",
+		"iterators",
+		"typeerrors",
+		"errorchains",
+		"spreadcalls",
+		"stringers",
+		"flagusage",
+		"handlers",
 	}
 
 	for _, pattern := range patterns {
@@ -28,3 +55,51 @@ func Test(t *testing.T) {
 		})
 	}
 }
+
+// TestSuggestedFixes checks the fixes wrapping sensitive values in
+// redact.New against the .golden files of the testdata packages
+func TestSuggestedFixes(t *testing.T) {
+	testdata := analysistest.TestData()
+	analysistest.RunWithSuggestedFixes(t, testdata, leakhound.Analyzer, "redactwrap")
+}
+
+// TestRules runs the package dedicated to each rule, testdata/src/rules/<id>,
+// checking both its diagnostics and the suggested fixes against the
+// package's .golden files. A package may carry a .leakhound.yaml enabling
+// its rule.
+func TestRules(t *testing.T) {
+	// Cross-package rules are reported by the whole-program driver only,
+	// which analysistest does not run
+	wholeProgram := map[string]string{
+		detector.SARIFRuleIDCrossPkgSensitiveReturn: "TestWholeProgramCrossPackage",
+		detector.SARIFRuleIDCrossPkgSensitiveSink:   "TestWholeProgramCrossPackage",
+	}
+
+	testdata := analysistest.TestData()
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(originalDir)
+
+	for _, doc := range detector.RuleDocs() {
+		pkg := "rules/" + strings.ToLower(doc.ID)
+		t.Run(doc.ID, func(t *testing.T) {
+			if test, ok := wholeProgram[doc.ID]; ok {
+				t.Skipf("%s is reported by the whole-program driver, see %s", doc.ID, test)
+			}
+			dir := filepath.Join(testdata, "src", filepath.FromSlash(pkg))
+			if _, err := os.Stat(dir); err != nil {
+				t.Fatalf("no test package for %s (%s): %v", doc.ID, doc.RuleID, err)
+			}
+			// Change to the package directory so the analyzer finds its
+			// .leakhound.yaml; subtests therefore run sequentially
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(originalDir)
+
+			analysistest.RunWithSuggestedFixes(t, testdata, leakhound.Analyzer, pkg)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+// Package annotate rewrites struct definitions to add sensitive:"true" tags
+// to fields that look sensitive. It backs the `leakhound annotate` command.
+package annotate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// sensitiveTag is the tag added to matching fields
+const sensitiveTag = `sensitive:"true"`
+
+// Options selects the fields to annotate
+type Options struct {
+	// Fields lists fields to tag explicitly, either as "Field" (any struct)
+	// or "Type.Field". When non-empty, Matcher is ignored.
+	Fields []string
+
+	// Matcher selects fields by name when Fields is empty
+	Matcher *config.NameMatcher
+}
+
+// match reports whether field name of struct typeName should be tagged
+func (o Options) match(typeName, name string) bool {
+	if len(o.Fields) > 0 {
+		for _, f := range o.Fields {
+			if f == name || f == typeName+"."+name {
+				return true
+			}
+		}
+		return false
+	}
+	return o.Matcher != nil && o.Matcher.Match(name)
+}
+
+// Change describes a field that was tagged
+type Change struct {
+	Type  string // Struct type name
+	Field string // Field name
+	Line  int    // Line of the field in the original source
+}
+
+// String formats the change as "Type.Field"
+func (c Change) String() string {
+	return c.Type + "." + c.Field
+}
+
+// edit replaces src[start:end] with text
+type edit struct {
+	start, end int
+	text       string
+}
+
+// Source adds sensitive:"true" tags to the matching fields of every named
+// struct type in src. Existing tags are preserved, and fields that already
+// carry a sensitive key (including sensitive:"false") are left alone. When
+// src is gofmt-formatted, the result is too. It returns src unchanged and no
+// changes when nothing matches.
+func Source(filename string, src []byte, opts Options) ([]byte, []Change, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var edits []edit
+	var changes []Change
+	ast.Inspect(file, func(n ast.Node) bool {
+		typeSpec, ok := n.(*ast.TypeSpec)
+		if !ok {
+			return true
+		}
+		structType, ok := typeSpec.Type.(*ast.StructType)
+		if !ok {
+			return true
+		}
+		for _, field := range structType.Fields.List {
+			if hasSensitiveKey(field.Tag) {
+				continue
+			}
+			var matched []string
+			for _, name := range field.Names {
+				if opts.match(typeSpec.Name.Name, name.Name) {
+					matched = append(matched, name.Name)
+				}
+			}
+			if len(matched) == 0 {
+				continue
+			}
+			e, err := tagEdit(fset, field)
+			if err != nil {
+				continue
+			}
+			edits = append(edits, e)
+			// A tag applies to every name in the field list, so report them all
+			for _, name := range field.Names {
+				changes = append(changes, Change{
+					Type:  typeSpec.Name.Name,
+					Field: name.Name,
+					Line:  fset.Position(name.Pos()).Line,
+				})
+			}
+		}
+		return true
+	})
+	if len(edits) == 0 {
+		return src, nil, nil
+	}
+
+	out := applyEdits(src, edits)
+	if formatted, err := format.Source(src); err == nil && bytes.Equal(formatted, src) {
+		if out, err = format.Source(out); err != nil {
+			return nil, nil, fmt.Errorf("%s: formatting annotated source: %w", filename, err)
+		}
+	}
+	return out, changes, nil
+}
+
+// tagEdit returns the edit adding sensitiveTag to field
+func tagEdit(fset *token.FileSet, field *ast.Field) (edit, error) {
+	if field.Tag == nil {
+		end := fset.Position(field.Type.End()).Offset
+		return edit{start: end, end: end, text: " `" + sensitiveTag + "`"}, nil
+	}
+
+	value, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return edit{}, err
+	}
+	if value = strings.TrimSpace(value); value != "" {
+		value += " "
+	}
+	value += sensitiveTag
+
+	lit := "`" + value + "`"
+	if strings.Contains(value, "`") {
+		lit = strconv.Quote(value)
+	}
+	return edit{
+		start: fset.Position(field.Tag.Pos()).Offset,
+		end:   fset.Position(field.Tag.End()).Offset,
+		text:  lit,
+	}, nil
+}
+
+// applyEdits applies non-overlapping edits to src
+func applyEdits(src []byte, edits []edit) []byte {
+	sort.Slice(edits, func(i, j int) bool { return edits[i].start < edits[j].start })
+	var buf bytes.Buffer
+	last := 0
+	for _, e := range edits {
+		buf.Write(src[last:e.start])
+		buf.WriteString(e.text)
+		last = e.end
+	}
+	buf.Write(src[last:])
+	return buf.Bytes()
+}
+
+// hasSensitiveKey reports whether a struct tag sets the sensitive key to any
+// value
+func hasSensitiveKey(tag *ast.BasicLit) bool {
+	if tag == nil {
+		return false
+	}
+	value, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return false
+	}
+	_, ok := reflect.StructTag(value).Lookup("sensitive")
+	return ok
+}
@@ -0,0 +1,185 @@
+package annotate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+func defaultMatcher(t *testing.T) *config.NameMatcher {
+	t.Helper()
+	m, err := config.NewNameMatcher(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func TestSource(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		src         string
+		fields      []string
+		want        string
+		wantChanges []string
+	}{
+		{
+			name: "adds tag to untagged field",
+			src: `package p
+
+type User struct {
+	Name     string
+	Password string
+}
+`,
+			want: `package p
+
+type User struct {
+	Name     string
+	Password string ` + "`sensitive:\"true\"`" + `
+}
+`,
+			wantChanges: []string{"User.Password"},
+		},
+		{
+			name: "preserves existing tags and realigns",
+			src: `package p
+
+type Account struct {
+	ID     int    ` + "`json:\"id\"`" + `
+	APIKey string ` + "`json:\"api_key\"`" + ` // issued by the gateway
+}
+`,
+			want: `package p
+
+type Account struct {
+	ID     int    ` + "`json:\"id\"`" + `
+	APIKey string ` + "`json:\"api_key\" sensitive:\"true\"`" + ` // issued by the gateway
+}
+`,
+			wantChanges: []string{"Account.APIKey"},
+		},
+		{
+			name: "skips fields with a sensitive key",
+			src: `package p
+
+type Page struct {
+	Token     string ` + "`sensitive:\"true\"`" + `
+	NextToken string ` + "`sensitive:\"false\"`" + `
+}
+`,
+			want: `package p
+
+type Page struct {
+	Token     string ` + "`sensitive:\"true\"`" + `
+	NextToken string ` + "`sensitive:\"false\"`" + `
+}
+`,
+		},
+		{
+			name: "explicit fields replace heuristics",
+			src: `package p
+
+type Customer struct {
+	IBAN     string
+	Password string
+}
+
+type Bank struct {
+	IBAN string
+}
+`,
+			fields: []string{"Customer.IBAN"},
+			want: `package p
+
+type Customer struct {
+	IBAN     string ` + "`sensitive:\"true\"`" + `
+	Password string
+}
+
+type Bank struct {
+	IBAN string
+}
+`,
+			wantChanges: []string{"Customer.IBAN"},
+		},
+		{
+			name: "multi-name field is tagged as a whole",
+			src: `package p
+
+type Client struct {
+	ID, Secret string
+}
+`,
+			want: `package p
+
+type Client struct {
+	ID, Secret string ` + "`sensitive:\"true\"`" + `
+}
+`,
+			wantChanges: []string{"Client.ID", "Client.Secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, changes, err := Source("p.go", []byte(tt.src), Options{Fields: tt.fields, Matcher: defaultMatcher(t)})
+			if err != nil {
+				t.Fatalf("Source() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Source() =\n%s\nwant:\n%s", got, tt.want)
+			}
+			var names []string
+			for _, c := range changes {
+				names = append(names, c.String())
+			}
+			if strings.Join(names, ",") != strings.Join(tt.wantChanges, ",") {
+				t.Errorf("changes = %v, want %v", names, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestSource_ParseError(t *testing.T) {
+	t.Parallel()
+
+	if _, _, err := Source("p.go", []byte("package p\ntype"), Options{Matcher: defaultMatcher(t)}); err == nil {
+		t.Error("Source() error = nil, want parse error")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	new := "a\nb\nc\nD\ne\nf\ng\nh\ni\nJ\n"
+	want := `--- a/x.go
++++ b/x.go
+@@ -1,10 +1,10 @@
+ a
+ b
+ c
+-d
++D
+ e
+ f
+ g
+ h
+ i
+-j
++J
+`
+	if got := string(Diff("x.go", []byte(old), []byte(new))); got != want {
+		t.Errorf("Diff() =\n%s\nwant:\n%s", got, want)
+	}
+	if got := Diff("x.go", []byte(old), []byte(old)); got != nil {
+		t.Errorf("Diff() of equal input = %q, want nil", got)
+	}
+}
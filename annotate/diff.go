@@ -0,0 +1,96 @@
+package annotate
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each hunk
+const diffContext = 3
+
+// Diff returns a unified diff between old and new for the file name, or nil
+// when they are equal. Annotation only edits lines in place, so lines are
+// compared pairwise; if the line counts differ the whole file is shown as a
+// single replaced hunk.
+func Diff(name string, old, new []byte) []byte {
+	if bytes.Equal(old, new) {
+		return nil
+	}
+	a := splitLines(old)
+	b := splitLines(new)
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "--- a/%s\n+++ b/%s\n", name, name)
+
+	if len(a) != len(b) {
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(0, len(a)), hunkRange(0, len(b)))
+		writeLines(&buf, "-", a)
+		writeLines(&buf, "+", b)
+		return buf.Bytes()
+	}
+
+	for i := 0; i < len(a); {
+		if a[i] == b[i] {
+			i++
+			continue
+		}
+		// Extend the hunk while changes are closer than twice the context
+		start := max(i-diffContext, 0)
+		end := i
+		for j := i; j < len(a) && j < end+2*diffContext+1; j++ {
+			if a[j] != b[j] {
+				end = j + 1
+			}
+		}
+		stop := min(end+diffContext, len(a))
+
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(start, stop-start), hunkRange(start, stop-start))
+		for j := start; j < stop; {
+			if a[j] == b[j] {
+				writeLines(&buf, " ", a[j:j+1])
+				j++
+				continue
+			}
+			// Group a run of changed lines as removals followed by additions
+			k := j
+			for k < stop && a[k] != b[k] {
+				k++
+			}
+			writeLines(&buf, "-", a[j:k])
+			writeLines(&buf, "+", b[j:k])
+			j = k
+		}
+		i = stop
+	}
+	return buf.Bytes()
+}
+
+// hunkRange formats a 0-based start and line count as a unified diff range
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if count == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+// splitLines splits src into lines without their terminators
+func splitLines(src []byte) []string {
+	s := strings.TrimSuffix(string(src), "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
+
+// writeLines writes each line with prefix
+func writeLines(buf *bytes.Buffer, prefix string, lines []string) {
+	for _, l := range lines {
+		buf.WriteString(prefix)
+		buf.WriteString(l)
+		buf.WriteByte('\n')
+	}
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nilpoona/leakhound/annotate"
+	"github.com/nilpoona/leakhound/config"
+)
+
+const annotateUsage = "usage: leakhound annotate [-w] [--fields=Type.Field,...] [--config=PATH] PATH..."
+
+// runAnnotate implements `leakhound annotate`, which adds sensitive:"true"
+// tags to struct fields that look sensitive. PATH is a Go file, a directory,
+// or a directory followed by "/..." to include subdirectories. A diff is
+// printed unless -w is given, in which case the files are rewritten. It
+// returns the process exit code.
+func runAnnotate(args []string, w io.Writer, errw io.Writer) int {
+	write := false
+	fields := ""
+	configPath := ""
+	var paths []string
+
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "-w" || a == "--w":
+			write = true
+		case strings.HasPrefix(a, "--fields=") || strings.HasPrefix(a, "-fields="):
+			_, fields, _ = strings.Cut(a, "=")
+		case a == "--fields" || a == "-fields":
+			if i+1 < len(args) {
+				fields = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--config=") || strings.HasPrefix(a, "-config="):
+			_, configPath, _ = strings.Cut(a, "=")
+		case a == "--config" || a == "-config":
+			if i+1 < len(args) {
+				configPath = args[i+1]
+				i++
+			}
+		default:
+			paths = append(paths, a)
+		}
+	}
+	if len(paths) == 0 {
+		fmt.Fprintln(errw, annotateUsage)
+		return 1
+	}
+
+	opts, err := annotateOptions(fields, configPath)
+	if err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+
+	files, err := goFiles(paths)
+	if err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+
+	code := 0
+	for _, path := range files {
+		if err := annotateFile(path, opts, write, w); err != nil {
+			fmt.Fprintf(errw, "%v\n", err)
+			code = 1
+		}
+	}
+	return code
+}
+
+// annotateOptions selects fields from the explicit --fields list, or from
+// the audit.untagged-fields patterns of the config (the default heuristics
+// when none are set)
+func annotateOptions(fields, configPath string) (annotate.Options, error) {
+	if fields != "" {
+		var list []string
+		for _, f := range strings.Split(fields, ",") {
+			if f = strings.TrimSpace(f); f != "" {
+				list = append(list, f)
+			}
+		}
+		return annotate.Options{Fields: list}, nil
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return annotate.Options{}, err
+	}
+	matcher, err := config.NewNameMatcher(cfg.Audit.UntaggedFields.Patterns)
+	if err != nil {
+		return annotate.Options{}, err
+	}
+	return annotate.Options{Matcher: matcher}, nil
+}
+
+// annotateFile annotates a single file, printing its diff or, when write is
+// set, rewriting it and listing the tagged fields
+func annotateFile(path string, opts annotate.Options, write bool, w io.Writer) error {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	out, changes, err := annotate.Source(path, src, opts)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if !write {
+		_, err := w.Write(annotate.Diff(filepath.ToSlash(path), src, out))
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, out, info.Mode().Perm()); err != nil {
+		return err
+	}
+	for _, c := range changes {
+		fmt.Fprintf(w, "%s:%d: tagged %s\n", path, c.Line, c)
+	}
+	return nil
+}
+
+// goFiles expands PATH arguments to the non-test Go files they name. A
+// trailing "/..." walks subdirectories, skipping testdata, vendor and hidden
+// directories as the go tool does.
+func goFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, p := range paths {
+		dir, recursive := strings.CutSuffix(p, "/...")
+		if recursive && dir == "" {
+			dir = "."
+		}
+
+		info, err := os.Stat(dir)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, dir)
+			continue
+		}
+
+		err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if path == dir {
+					return nil
+				}
+				name := d.Name()
+				if !recursive || name == "testdata" || name == "vendor" ||
+					strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
+				files = append(files, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
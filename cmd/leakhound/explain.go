@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// runExplain implements `leakhound explain [RULE_ID...]`. Without arguments
+// it lists every rule; with arguments it prints the full documentation for
+// each requested rule. It returns the process exit code.
+func runExplain(ids []string, w io.Writer, errw io.Writer) int {
+	if len(ids) == 0 {
+		for _, doc := range detector.RuleDocs() {
+			fmt.Fprintf(w, "%s  %-34s %s\n", doc.ID, doc.Name, doc.Short)
+		}
+		fmt.Fprintln(w, "\nRun 'leakhound explain RULE_ID' for details.")
+		return 0
+	}
+
+	code := 0
+	for i, id := range ids {
+		doc, ok := detector.LookupRuleDoc(id)
+		if !ok {
+			fmt.Fprintf(errw, "unknown rule %q (run 'leakhound explain' to list rules)\n", id)
+			code = 1
+			continue
+		}
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		writeRuleDoc(w, doc)
+	}
+	return code
+}
+
+// writeRuleDoc renders a single rule's documentation as plain text.
+func writeRuleDoc(w io.Writer, doc detector.RuleDoc) {
+	fmt.Fprintf(w, "%s %s (%s)\n", doc.ID, doc.Name, doc.RuleID)
+	fmt.Fprintf(w, "%s\n\n", doc.Short)
+	fmt.Fprintf(w, "%s\n\n", doc.Full)
+	fmt.Fprintln(w, "Bad:")
+	fmt.Fprintf(w, "%s\n\n", indent(doc.Bad))
+	fmt.Fprintln(w, "Good:")
+	fmt.Fprintf(w, "%s\n\n", indent(doc.Good))
+	fmt.Fprintln(w, "Remediation:")
+	fmt.Fprintf(w, "%s\n\n", indent(doc.Remediation))
+	fmt.Fprintf(w, "Suppress with: //noleak:%s\n", doc.ID)
+	fmt.Fprintf(w, "More: %s\n", doc.HelpURI())
+}
+
+// indent prefixes every non-empty line of s with four spaces.
+func indent(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "    " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
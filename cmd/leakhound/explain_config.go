@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+	"github.com/nilpoona/leakhound/detector"
+	"github.com/nilpoona/leakhound/reporter/text"
+)
+
+// runExplainConfig implements --explain-config: it loads the config and the
+// packages, then reports for each configured target the package it resolves
+// to, which analyzed packages import it, and the call sites that would be
+// treated as sinks. Detection is not run.
+func runExplainConfig(patterns []string, configPath string, w io.Writer) error {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("failed to get working directory: %w", err)
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	pkgCfg, allPkgs, err := loadPackages(workDir, patterns, loadOptions{})
+	if err != nil {
+		return err
+	}
+
+	reports := detector.ExplainTargets(&cfg, allPkgs, pkgCfg.Fset)
+	if len(reports) == 0 {
+		fmt.Fprintln(w, "No custom targets configured; only the built-in log, log/slog, fmt, go.uber.org/zap and github.com/rs/zerolog sinks apply.")
+		return nil
+	}
+
+	for i, report := range reports {
+		if i > 0 {
+			fmt.Fprintln(w)
+		}
+		fmt.Fprintf(w, "target %s\n", report.Target.Package)
+		if report.Package == nil {
+			fmt.Fprintln(w, "  package:     not found among the loaded packages or their imports")
+			for _, c := range report.Candidates {
+				fmt.Fprintf(w, "               did you mean %q?\n", c)
+			}
+		} else {
+			fmt.Fprintf(w, "  package:     resolved (package %s)\n", report.Package.Name())
+		}
+		if len(report.ImportedBy) == 0 {
+			fmt.Fprintln(w, "  imported by: none of the analyzed packages")
+		} else {
+			fmt.Fprintf(w, "  imported by: %s\n", strings.Join(report.ImportedBy, ", "))
+		}
+
+		for _, entry := range report.Entries {
+			name := strings.TrimPrefix(entry.Entry, report.Target.Package+".")
+			switch {
+			case !entry.Declared && report.Package != nil:
+				fmt.Fprintf(w, "  %s: not declared in package %s\n", name, report.Package.Name())
+			case len(entry.Calls) == 0:
+				fmt.Fprintf(w, "  %s: no call sites\n", name)
+			default:
+				fmt.Fprintf(w, "  %s: %d call site(s)\n", name, len(entry.Calls))
+			}
+			for _, pos := range entry.Calls {
+				p := pkgCfg.Fset.Position(pos)
+				fmt.Fprintf(w, "    %s:%d:%d\n", text.DisplayPath(p.Filename, workDir, false), p.Line, p.Column)
+			}
+		}
+	}
+	return nil
+}
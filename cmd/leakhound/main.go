@@ -1,18 +1,30 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"go/ast"
 	"go/token"
 	"os"
 	"path/filepath"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/nilpoona/leakhound"
 	"github.com/nilpoona/leakhound/config"
 	"github.com/nilpoona/leakhound/detector"
+	"github.com/nilpoona/leakhound/owners"
+	"github.com/nilpoona/leakhound/reporter"
+	_ "github.com/nilpoona/leakhound/reporter/defectdojo" // Registers the defectdojo format
+	"github.com/nilpoona/leakhound/reporter/markdown"
+	"github.com/nilpoona/leakhound/reporter/progress"
 	"github.com/nilpoona/leakhound/reporter/sarif"
+	"github.com/nilpoona/leakhound/reporter/text"
+	"github.com/nilpoona/leakhound/reporter/trend"
+	"github.com/nilpoona/leakhound/reporter/webhook"
+	"github.com/nilpoona/leakhound/triage"
 	"golang.org/x/tools/go/analysis/singlechecker"
 	"golang.org/x/tools/go/packages"
 )
@@ -24,22 +36,71 @@ import (
 // with `go vet`-style integrations and for diagnosing differences during
 // the SSA migration discussed in the design doc §7.
 func main() {
-	args := os.Args[1:]
+	args, err := changeDir(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
 
+	if len(args) > 0 && args[0] == "explain" {
+		os.Exit(runExplain(args[1:], os.Stdout, os.Stderr))
+	}
+	if len(args) > 0 && args[0] == "trend" {
+		os.Exit(runTrend(args[1:], os.Stdout, os.Stderr))
+	}
+	if len(args) > 0 && args[0] == "annotate" {
+		os.Exit(runAnnotate(args[1:], os.Stdout, os.Stderr))
+	}
+	if len(args) > 0 && args[0] == "coverage" {
+		os.Exit(runCoverage(args[1:], os.Stdout, os.Stderr))
+	}
+	if len(args) > 0 && args[0] == "triage" {
+		os.Exit(runTriage(args[1:], os.Stdout, os.Stderr))
+	}
+	if len(args) > 0 && args[0] == "selftest" {
+		os.Exit(runSelftest(args[1:], os.Stdout, os.Stderr))
+	}
+
+	help := false
 	singlePackage := false
+	explainConfig := false
 	format := "text"
 	configPath := ""
+	verbosity := text.VerbosityFinding
+	severityOverrides := ""
+	trendPath := ""
+	color := string(text.ColorAuto)
+	absPaths := false
+	srcRoot := ""
+	pathPrefixMap := ""
+	maxMemory := ""
+	structRuleScope := ""
+	allowTypeErrors := false
+	minConfidence := ""
+	maxFlowHops := ""
+	failOn := ""
+	mod := ""
+	includeVendor := false
+	validateOutput := false
+	triagePath := ""
+	webhookURL := os.Getenv(webhook.EnvURL)
+	stepSummary := stepSummaryAuto
+	progressFormat := ""
 	rest := make([]string, 0, len(args))
 
 	for i := 0; i < len(args); i++ {
 		a := args[i]
 		switch {
+		case a == "-h" || a == "-help" || a == "--help":
+			help = true
 		case a == "--single-package" || a == "-single-package":
 			singlePackage = true
-		case a == "--format=sarif" || a == "-format=sarif":
-			format = "sarif"
-		case a == "--format=text" || a == "-format=text":
-			format = "text"
+		case a == "--explain-config" || a == "-explain-config":
+			explainConfig = true
+		case strings.HasPrefix(a, "--format="):
+			format = strings.TrimPrefix(a, "--format=")
+		case strings.HasPrefix(a, "-format="):
+			format = strings.TrimPrefix(a, "-format=")
 		case a == "--format" || a == "-format":
 			if i+1 < len(args) {
 				format = args[i+1]
@@ -54,30 +115,315 @@ func main() {
 				configPath = args[i+1]
 				i++
 			}
+		case strings.HasPrefix(a, "--severity-overrides="):
+			severityOverrides = strings.TrimPrefix(a, "--severity-overrides=")
+		case strings.HasPrefix(a, "-severity-overrides="):
+			severityOverrides = strings.TrimPrefix(a, "-severity-overrides=")
+		case a == "--severity-overrides" || a == "-severity-overrides":
+			if i+1 < len(args) {
+				severityOverrides = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--trend="):
+			trendPath = strings.TrimPrefix(a, "--trend=")
+		case strings.HasPrefix(a, "-trend="):
+			trendPath = strings.TrimPrefix(a, "-trend=")
+		case a == "--trend" || a == "-trend":
+			if i+1 < len(args) {
+				trendPath = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--color=") || strings.HasPrefix(a, "-color="):
+			_, color, _ = strings.Cut(a, "=")
+		case a == "--color" || a == "-color":
+			if i+1 < len(args) {
+				color = args[i+1]
+				i++
+			}
+		case a == "--abs-paths" || a == "-abs-paths":
+			absPaths = true
+		case strings.HasPrefix(a, "--srcroot=") || strings.HasPrefix(a, "-srcroot="):
+			_, srcRoot, _ = strings.Cut(a, "=")
+		case a == "--srcroot" || a == "-srcroot":
+			if i+1 < len(args) {
+				srcRoot = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--path-prefix-map=") || strings.HasPrefix(a, "-path-prefix-map="):
+			_, pathPrefixMap, _ = strings.Cut(a, "=")
+		case a == "--path-prefix-map" || a == "-path-prefix-map":
+			if i+1 < len(args) {
+				pathPrefixMap = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--max-memory=") || strings.HasPrefix(a, "-max-memory="):
+			_, maxMemory, _ = strings.Cut(a, "=")
+		case a == "--max-memory" || a == "-max-memory":
+			if i+1 < len(args) {
+				maxMemory = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--struct-rule-scope=") || strings.HasPrefix(a, "-struct-rule-scope="):
+			_, structRuleScope, _ = strings.Cut(a, "=")
+		case a == "--struct-rule-scope" || a == "-struct-rule-scope":
+			if i+1 < len(args) {
+				structRuleScope = args[i+1]
+				i++
+			}
+		case a == "--allow-type-errors" || a == "-allow-type-errors":
+			allowTypeErrors = true
+		case strings.HasPrefix(a, "--min-confidence=") || strings.HasPrefix(a, "-min-confidence="):
+			_, minConfidence, _ = strings.Cut(a, "=")
+		case a == "--min-confidence" || a == "-min-confidence":
+			if i+1 < len(args) {
+				minConfidence = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--max-flow-hops=") || strings.HasPrefix(a, "-max-flow-hops="):
+			_, maxFlowHops, _ = strings.Cut(a, "=")
+		case a == "--max-flow-hops" || a == "-max-flow-hops":
+			if i+1 < len(args) {
+				maxFlowHops = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--fail-on=") || strings.HasPrefix(a, "-fail-on="):
+			_, failOn, _ = strings.Cut(a, "=")
+		case a == "--fail-on" || a == "-fail-on":
+			if i+1 < len(args) {
+				failOn = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--mod=") || strings.HasPrefix(a, "-mod="):
+			_, mod, _ = strings.Cut(a, "=")
+		case a == "--mod" || a == "-mod":
+			if i+1 < len(args) {
+				mod = args[i+1]
+				i++
+			}
+		case a == "--include-vendor" || a == "-include-vendor":
+			includeVendor = true
+		case a == "--validate-output" || a == "-validate-output":
+			validateOutput = true
+		case strings.HasPrefix(a, "--triage=") || strings.HasPrefix(a, "-triage="):
+			_, triagePath, _ = strings.Cut(a, "=")
+		case a == "--triage" || a == "-triage":
+			if i+1 < len(args) {
+				triagePath = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--webhook=") || strings.HasPrefix(a, "-webhook="):
+			_, webhookURL, _ = strings.Cut(a, "=")
+		case a == "--webhook" || a == "-webhook":
+			if i+1 < len(args) {
+				webhookURL = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--step-summary=") || strings.HasPrefix(a, "-step-summary="):
+			_, stepSummary, _ = strings.Cut(a, "=")
+		case a == "--step-summary" || a == "-step-summary":
+			if i+1 < len(args) {
+				stepSummary = args[i+1]
+				i++
+			}
+		case a == "--progress" || a == "-progress":
+			progressFormat = progress.FormatText
+		case strings.HasPrefix(a, "--progress=") || strings.HasPrefix(a, "-progress="):
+			_, progressFormat, _ = strings.Cut(a, "=")
+		case a == "-v" || a == "--v":
+			verbosity = text.VerbosityFinding
+		case a == "-vv" || a == "--vv":
+			verbosity = text.VerbosityFlow
+		case strings.HasPrefix(a, "-v=") || strings.HasPrefix(a, "--v=") ||
+			strings.HasPrefix(a, "-verbosity=") || strings.HasPrefix(a, "--verbosity="):
+			_, value, _ := strings.Cut(a, "=")
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "invalid verbosity %q\n", value)
+				os.Exit(1)
+			}
+			verbosity = n
 		default:
 			rest = append(rest, a)
 		}
 	}
 
+	if explainConfig {
+		if len(rest) == 0 {
+			fmt.Fprintln(os.Stderr, "usage: leakhound --explain-config [--config=PATH] <package patterns>")
+			os.Exit(1)
+		}
+		if err := runExplainConfig(rest, configPath, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if !slices.Contains(reporter.Formats(), format) {
+		fmt.Fprintf(os.Stderr, "invalid format %q: want %s\n", format, strings.Join(reporter.Formats(), ", "))
+		os.Exit(1)
+	}
+
 	if singlePackage {
+		// The analysis driver prints diagnostics itself, as text
+		if format != "text" {
+			fmt.Fprintf(os.Stderr, "invalid format %q: only text is supported with --single-package\n", format)
+			os.Exit(1)
+		}
 		// Restore the original argv (minus --single-package) so the standard
 		// driver parses --format / --config itself.
-		os.Args = append([]string{os.Args[0]}, filterArgs(args, "--single-package", "-single-package")...)
+		os.Args = append([]string{os.Args[0]}, singlePackageArgs(args, verbosity)...)
 		singlechecker.Main(leakhound.Analyzer)
 		return
 	}
 
-	if len(rest) == 0 {
-		fmt.Fprintln(os.Stderr, "usage: leakhound [--format=text|sarif] [--config=PATH] [--single-package] <package patterns>")
+	colorMode, err := text.ParseColorMode(color)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if stepSummary != stepSummaryAuto && stepSummary != stepSummaryNever {
+		fmt.Fprintf(os.Stderr, "invalid step summary mode %q: want %s or %s\n", stepSummary, stepSummaryAuto, stepSummaryNever)
+		os.Exit(1)
+	}
+
+	if progressFormat != "" && !slices.Contains(progress.Formats, progressFormat) {
+		fmt.Fprintf(os.Stderr, "invalid progress format %q: want %s\n", progressFormat, strings.Join(progress.Formats, " or "))
+		os.Exit(1)
+	}
+
+	if mod != "" && !slices.Contains(modModes, mod) {
+		fmt.Fprintf(os.Stderr, "invalid -mod mode %q: want %s\n", mod, strings.Join(modModes, ", "))
 		os.Exit(1)
 	}
 
-	if err := runWholeProgram(rest, format, configPath); err != nil {
+	pathMappings, err := sarif.ParsePathMappings(pathPrefixMap)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if help {
+		fmt.Fprintln(os.Stderr, "usage: leakhound [-C dir] [--format="+strings.Join(reporter.Formats(), "|")+"] [--config=PATH] [--severity-overrides=RULE=SEVERITY,...] [--trend=PATH] [--color=auto|always|never] [--abs-paths] [--srcroot=DIR] [--path-prefix-map=FROM=TO,...] [--max-memory=SIZE] [--struct-rule-scope=local|module|all] [--allow-type-errors] [--min-confidence=high|medium|low|SCORE] [--max-flow-hops=N] [--fail-on=error|warning|note|never] [--mod=readonly|vendor|mod] [--include-vendor] [--validate-output] [--triage=PATH] [--webhook=URL] [--step-summary=auto|never] [--progress[=text|ndjson]] [-v|-vv|--verbosity=N] [--single-package] [--explain-config] [package patterns]")
+		fmt.Fprintln(os.Stderr, "       leakhound explain [RULE_ID...]")
+		fmt.Fprintln(os.Stderr, "       leakhound trend PATH")
+		fmt.Fprintln(os.Stderr, "       leakhound annotate [-w] [--fields=Type.Field,...] [--config=PATH] PATH...")
+		fmt.Fprintln(os.Stderr, "       leakhound coverage [--config=PATH] <package patterns>")
+		fmt.Fprintln(os.Stderr, "       "+strings.TrimPrefix(triageUsage, "usage: "))
+		fmt.Fprintln(os.Stderr, "       leakhound selftest")
+		os.Exit(0)
+	}
+
+	opts := runOptions{
+		format:            format,
+		configPath:        configPath,
+		severityOverrides: severityOverrides,
+		trendPath:         trendPath,
+		verbosity:         verbosity,
+		color:             text.Colorizer{Enabled: text.UseColor(colorMode, os.Stderr, os.Getenv)},
+		// Outside CI paths are shortened relative to the working directory;
+		// CI logs are read away from the checkout, so they get absolute paths.
+		absPaths:        absPaths || text.IsCI(os.Getenv),
+		srcRoot:         srcRoot,
+		pathMappings:    pathMappings,
+		maxMemory:       maxMemory,
+		structRuleScope: structRuleScope,
+		allowTypeErrors: allowTypeErrors,
+		minConfidence:   minConfidence,
+		maxFlowHops:     maxFlowHops,
+		failOn:          failOn,
+		load:            loadOptions{mod: mod, includeVendor: includeVendor},
+		validateOutput:  validateOutput,
+		triagePath:      triagePath,
+		webhookURL:      webhookURL,
+		stepSummary:     stepSummary == stepSummaryAuto,
+		progress:        progressFormat,
+	}
+	if err := runWholeProgram(rest, opts); err != nil {
 		fmt.Fprintf(os.Stderr, "%v\n", err)
+		if errors.Is(err, errFailOn) {
+			os.Exit(exitFindings)
+		}
 		os.Exit(1)
 	}
 }
 
+// exitFindings is the exit status of a run failed by its findings (see
+// --fail-on), the status go vet exits with when diagnostics are reported
+const exitFindings = 3
+
+// errFailOn is returned by runWholeProgram when a finding is at the fail-on
+// severity or above
+var errFailOn = errors.New("leakhound: findings at or above the fail-on severity")
+
+// runOptions carries the parsed CLI flags for whole-program mode.
+type runOptions struct {
+	format            string
+	configPath        string
+	severityOverrides string
+	trendPath         string
+	verbosity         int
+	color             text.Colorizer // Colors for text output
+	absPaths          bool           // Print absolute instead of shortened paths in text output
+	srcRoot           string         // Base directory for SARIF paths; defaults to the working directory
+	pathMappings      []sarif.PathMapping
+	maxMemory         string // Per-package budget for tracked data flow facts, overrides the config file
+	structRuleScope   string // Structs reported by LH0003, overrides the config file
+	allowTypeErrors   bool   // Match sinks syntactically in files that do not type-check, on top of the config file
+	minConfidence     string // Lowest confidence of the findings reported, overrides the config file
+	maxFlowHops       string // Data flow hops after which long-flows applies, overrides the config file
+	failOn            string // Lowest severity of the findings failing the run, overrides the config file
+	load              loadOptions
+	validateOutput    bool   // Validate SARIF output against the SARIF schema before writing it
+	triagePath        string // Triage file whose statuses suppress or flag findings, see runTriage
+	webhookURL        string // Webhook notified with a summary of the run, see notifyWebhook
+	stepSummary       bool   // Append a Markdown summary to the GitHub Actions step summary, when in a step
+	progress          string // Progress format written to stderr, progress.FormatText or progress.FormatNDJSON; "" for none
+}
+
+// Values of --step-summary
+const (
+	stepSummaryAuto  = "auto"  // Append the summary when GITHUB_STEP_SUMMARY is set
+	stepSummaryNever = "never" // Never append it
+)
+
+// wholeProgramValueFlags take a value and only apply to the whole-program
+// driver; singlePackageArgs drops them along with their values.
+var wholeProgramValueFlags = []string{"color", "srcroot", "path-prefix-map", "trend", "triage", "webhook", "step-summary", "mod", "fail-on"}
+
+// singlePackageArgs rewrites the CLI arguments for the singlechecker driver:
+// --single-package is dropped and the -v shorthands are translated to the
+// analyzer's -verbosity flag, since the driver reserves -v for itself. Flags
+// that only affect whole-program output are dropped too, since the driver
+// prints diagnostics itself.
+func singlePackageArgs(args []string, verbosity int) []string {
+	out := filterArgs(args, "--single-package", "-single-package", "-v", "--v", "-vv", "--vv", "--abs-paths", "-abs-paths", "--include-vendor", "-include-vendor", "--validate-output", "-validate-output", "--progress", "-progress")
+	out = slices.DeleteFunc(out, func(a string) bool {
+		return strings.HasPrefix(a, "-v=") || strings.HasPrefix(a, "--v=") ||
+			strings.HasPrefix(a, "-progress=") || strings.HasPrefix(a, "--progress=")
+	})
+	out = dropValueFlags(out, wholeProgramValueFlags)
+	return append([]string{fmt.Sprintf("-verbosity=%d", verbosity)}, out...)
+}
+
+// dropValueFlags removes each of the named flags in its -name=value,
+// --name=value, -name value and --name value forms.
+func dropValueFlags(args []string, names []string) []string {
+	out := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		if !strings.HasPrefix(args[i], "-") || !slices.Contains(names, name) {
+			out = append(out, args[i])
+			continue
+		}
+		if !hasValue {
+			i++ // skip the separate value
+		}
+	}
+	return out
+}
+
 func filterArgs(args []string, drop ...string) []string {
 	out := make([]string, 0, len(args))
 	for _, a := range args {
@@ -89,65 +435,375 @@ func filterArgs(args []string, drop ...string) []string {
 	return out
 }
 
-func runWholeProgram(patterns []string, format, configPath string) error {
+func runWholeProgram(patterns []string, opts runOptions) error {
+	start := time.Now()
 	workDir, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("failed to get working directory: %w", err)
 	}
+	if len(patterns) == 0 {
+		patterns = defaultPatterns(workDir)
+	}
 
-	cfg, err := config.LoadConfig(configPath)
+	var triageFile *triage.File
+	if opts.triagePath != "" {
+		if triageFile, err = triage.Load(opts.triagePath); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadRunConfig(opts)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	hooks := analysisHooks{
+		report:   true,
+		baseline: []detector.Stage{triage.Stage(triageFile, now)},
+	}
+	if opts.progress != "" {
+		rep, err := progress.NewReporter(os.Stderr, opts.progress)
+		if err != nil {
+			return err
+		}
+		hooks.progress = rep.Report
+	}
+	fset, findings, notes, err := analyzeWholeProgram(workDir, patterns, &cfg, opts.load, hooks)
 	if err != nil {
 		return err
 	}
+	if err := annotateOwners(findings, fset, workDir, &cfg); err != nil {
+		return err
+	}
 
-	pkgCfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
-			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
-			packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo,
-		Tests: false,
-		Dir:   workDir,
-		Fset:  token.NewFileSet(),
+	entry := trend.NewEntry(findings, time.Now())
+	if opts.webhookURL != "" {
+		// A webhook outage should not fail the analysis
+		if err := notifyWebhook(opts, entry, findings, triageFile); err != nil {
+			fmt.Fprintf(os.Stderr, "leakhound: warning: %v\n", err)
+		}
+	}
+	if opts.trendPath != "" {
+		if err := trend.Append(opts.trendPath, entry); err != nil {
+			return err
+		}
+	}
+	if opts.stepSummary {
+		if err := appendStepSummary(findings, fset, workDir); err != nil {
+			fmt.Fprintf(os.Stderr, "leakhound: warning: %v\n", err)
+		}
 	}
 
-	pkgs, err := packages.Load(pkgCfg, patterns...)
+	failed := detector.FailsRun(findings, &cfg)
+	// Paths are relative to the working directory, or for SARIF to the source
+	// root
+	baseDir := workDir
+	if opts.format == "sarif" && opts.srcRoot != "" {
+		if baseDir, err = filepath.Abs(opts.srcRoot); err != nil {
+			return fmt.Errorf("invalid srcroot: %w", err)
+		}
+	}
+	rep, err := reporter.New(opts.format, reporter.Options{
+		WorkDir:   baseDir,
+		Rules:     &cfg,
+		Verbosity: opts.verbosity,
+		Color:     opts.color.Enabled,
+		AbsPaths:  opts.absPaths,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to load packages: %w", err)
+		return err
+	}
+	// Text findings go to stderr like the diagnostics of go vet
+	out := os.Stdout
+	if opts.format == "text" {
+		out = os.Stderr
+	}
+	if rep, ok := rep.(*sarif.AggregatingReporter); ok {
+		rep.SetPathMappings(opts.pathMappings)
+		rep.SetValidate(opts.validateOutput)
+		inv := sarif.NewInvocation(redactValueFlags(os.Args, "webhook"), workDir, start)
+		if failed {
+			// The analysis itself succeeded
+			exitCode := exitFindings
+			inv.ExitCode, inv.ExecutionSuccessful = &exitCode, true
+		}
+		rep.SetInvocation(inv)
+		rep.AddNotifications(notes)
+	}
+	err = rep.Report(out, reporter.Resolve(findings, fset))
+	if err == nil && failed {
+		return fmt.Errorf("%w: %s", errFailOn, cfg.FailOn)
 	}
+	return err
+}
 
-	// Surface load errors but continue with whatever loaded successfully —
-	// matches staticcheck/gosec behavior for partial successes.
-	for _, pkg := range pkgs {
-		for _, perr := range pkg.Errors {
-			fmt.Fprintf(os.Stderr, "%v\n", perr)
+// notifyWebhook posts a summary of the run counted in entry to the webhook,
+// compared with the previous run of the trend file and the triage file when
+// they are given
+func notifyWebhook(opts runOptions, entry trend.Entry, findings []detector.Finding, triageFile *triage.File) error {
+	var previous *trend.Entry
+	if opts.trendPath != "" {
+		var err error
+		if previous, err = trend.Last(opts.trendPath); err != nil {
+			return err
 		}
 	}
+	var untriaged *int
+	if triageFile != nil {
+		n := triageFile.Untriaged(findings)
+		untriaged = &n
+	}
+	summary := webhook.NewSummary(entry, previous, untriaged, webhook.RunLink(os.Getenv))
+	return webhook.Post(nil, opts.webhookURL, summary)
+}
 
-	allPkgs := flattenWithDeps(pkgs)
+// appendStepSummary appends a Markdown summary of the findings to the
+// GitHub Actions step summary, when running in a step
+func appendStepSummary(findings []detector.Finding, fset *token.FileSet, workDir string) error {
+	path := os.Getenv(markdown.EnvStepSummary)
+	if path == "" {
+		return nil
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open step summary: %w", err)
+	}
+	if err := markdown.WriteSummary(f, findings, fset, workDir); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write step summary: %w", err)
+	}
+	return f.Close()
+}
+
+// redactValueFlags replaces the values of the named flags, in their
+// -name=value, --name=value, -name value and --name value forms, so secrets
+// such as webhook URLs are not recorded with the command line
+func redactValueFlags(args []string, names ...string) []string {
+	out := append([]string(nil), args...)
+	for i := 1; i < len(out); i++ {
+		if !strings.HasPrefix(out[i], "-") {
+			continue
+		}
+		name, _, hasValue := strings.Cut(strings.TrimLeft(out[i], "-"), "=")
+		if !slices.Contains(names, name) {
+			continue
+		}
+		if hasValue {
+			out[i] = out[i][:strings.Index(out[i], "=")+1] + "[redacted]"
+		} else if i+1 < len(out) {
+			out[i+1] = "[redacted]"
+			i++
+		}
+	}
+	return out
+}
+
+// annotateOwners sets the owners of the findings from the CODEOWNERS file
+// configured in cfg, whose paths are relative to workDir, or else the one
+// GitHub would read in the repository containing workDir, if any, whose paths
+// are relative to the repository root
+func annotateOwners(findings []detector.Finding, fset *token.FileSet, workDir string, cfg *config.Config) error {
+	path, root := cfg.Codeowners, workDir
+	if path == "" {
+		if path = owners.Find(workDir); path == "" {
+			return nil
+		}
+		root = owners.Root(path)
+	}
+	m, err := owners.Load(path)
+	if err != nil {
+		return err
+	}
+	m.Annotate(findings, fset, root)
+	return nil
+}
+
+// loadRunConfig loads the config file and applies the command-line flags
+// that override it
+func loadRunConfig(opts runOptions) (config.Config, error) {
+	cfg, err := config.LoadConfig(opts.configPath)
+	if err != nil {
+		return config.Config{}, err
+	}
+	overrides, err := config.ParseSeverityOverrides(opts.severityOverrides)
+	if err != nil {
+		return config.Config{}, err
+	}
+	cfg.ApplySeverityOverrides(overrides)
+	if err := cfg.ApplyMaxMemory(opts.maxMemory); err != nil {
+		return config.Config{}, err
+	}
+	if err := cfg.ApplyStructRuleScope(opts.structRuleScope); err != nil {
+		return config.Config{}, err
+	}
+	if err := cfg.ApplyMinConfidence(opts.minConfidence); err != nil {
+		return config.Config{}, err
+	}
+	if err := cfg.ApplyMaxFlowHops(opts.maxFlowHops); err != nil {
+		return config.Config{}, err
+	}
+	if err := cfg.ApplyFailOn(opts.failOn); err != nil {
+		return config.Config{}, err
+	}
+	cfg.AllowTypeErrors = cfg.AllowTypeErrors || opts.allowTypeErrors
+	return cfg, nil
+}
+
+// analysisHooks customize analyzeWholeProgram; the zero value adds nothing
+type analysisHooks struct {
+	// report runs the whole post-processing pipeline (see
+	// detector.NewPipeline) rather than only suppressions, with baseline as
+	// its baseline stages
+	report   bool
+	baseline []detector.Stage
+
+	// progress is notified as packages are loaded, collected and analyzed
+	progress func(detector.Progress)
+}
+
+// analyzeWholeProgram loads and analyzes patterns, and returns the findings
+// deduplicated, with inline and config suppressions applied and their IDs
+// assigned (see detector.NewSuppressionPipeline), or with the whole pipeline
+// when hooks.report is set, along with the notifications raised by the
+// analysis, which are also printed to stderr. Findings are post-processed
+// with the config of their package, cfg with the overrides matching it.
+func analyzeWholeProgram(workDir string, patterns []string, cfg *config.Config, load loadOptions, hooks analysisHooks) (*token.FileSet, []detector.Finding, []detector.Notification, error) {
+	pkgCfg, allPkgs, err := loadPackages(workDir, patterns, load)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if hooks.progress != nil {
+		paths := make([]string, 0, len(allPkgs))
+		for _, p := range allPkgs {
+			paths = append(paths, p.PkgPath)
+		}
+		slices.Sort(paths)
+		for i, path := range paths {
+			hooks.progress(detector.Progress{Phase: detector.PhaseLoaded, Package: path, Done: i + 1, Total: len(paths)})
+		}
+	}
 
 	world := detector.NewWorldView(pkgCfg.Fset, allPkgs)
-	wp := detector.NewWholeProgramCollector(world, &cfg)
+	wp := detector.NewWholeProgramCollector(world, cfg)
+	wp.SetProgress(hooks.progress)
 	wp.Collect()
 	findings := wp.Analyze()
 
+	// Notifications are about the run rather than the code, so they go to
+	// stderr whatever the format; SARIF also records them
+	notes := wp.Notifications()
+	for _, note := range notes {
+		fmt.Fprintf(os.Stderr, "leakhound: %s\n", note)
+	}
+
 	filter := &detector.SuppressionFilter{}
 	filter.Build(collectFiles(allPkgs), pkgCfg.Fset)
-	findings = filter.Apply(findings, pkgCfg.Fset, &cfg)
-
-	switch format {
-	case "sarif":
-		rep := sarif.NewAggregatingReporter(workDir)
-		rep.AddFindings(findings, pkgCfg.Fset)
-		return rep.Report(os.Stdout)
-	default:
-		emitText(findings, pkgCfg.Fset, workDir)
+	configs := packageConfigs(allPkgs, workDir, cfg)
+	pipeline := detector.NewSuppressionPipeline(filter, pkgCfg.Fset)
+	if hooks.report {
+		pipeline = detector.NewPipeline(filter, pkgCfg.Fset, hooks.baseline...)
+	}
+	findings = perPackage(findings, pkgCfg.Fset, configs, cfg, pipeline.Run)
+	return pkgCfg.Fset, findings, notes, nil
+}
+
+// packageConfigs maps the files of pkgs to the config of their package when
+// overrides of cfg match it (see config.Config.ForPackage); files missing
+// from the map use cfg
+func packageConfigs(pkgs []*packages.Package, workDir string, cfg *config.Config) map[string]*config.Config {
+	if len(cfg.Overrides) == 0 {
 		return nil
 	}
+	configs := make(map[string]*config.Config)
+	for _, p := range pkgs {
+		if len(p.CompiledGoFiles) == 0 {
+			continue
+		}
+		dir := config.OverrideDir(workDir, filepath.Dir(p.CompiledGoFiles[0]))
+		pkgCfg := cfg.ForPackage(p.PkgPath, dir)
+		if pkgCfg == cfg {
+			continue
+		}
+		for _, file := range p.CompiledGoFiles {
+			configs[file] = pkgCfg
+		}
+	}
+	return configs
+}
+
+// perPackage passes the findings to process grouped by the config of their
+// file in configs, cfg by default, and returns the results sorted by position
+func perPackage(findings []detector.Finding, fset *token.FileSet, configs map[string]*config.Config, cfg *config.Config, process func([]detector.Finding, *config.Config) []detector.Finding) []detector.Finding {
+	if len(configs) == 0 {
+		return process(findings, cfg)
+	}
+	var order []*config.Config
+	groups := make(map[*config.Config][]detector.Finding)
+	for _, f := range findings {
+		c, ok := configs[fset.Position(f.Pos).Filename]
+		if !ok {
+			c = cfg
+		}
+		if _, seen := groups[c]; !seen {
+			order = append(order, c)
+		}
+		groups[c] = append(groups[c], f)
+	}
+	var out []detector.Finding
+	for _, c := range order {
+		out = append(out, process(groups[c], c)...)
+	}
+	detector.SortFindings(out, fset)
+	return out
+}
+
+// modModes are the values of the go command's -mod build flag
+var modModes = []string{"readonly", "vendor", "mod"}
+
+// loadOptions controls how packages are loaded
+type loadOptions struct {
+	mod           string // -mod build flag; "" leaves the mode to the go command, GOFLAGS included
+	includeVendor bool   // Analyze vendored dependencies too, e.g. forks of loggers
+}
+
+// loadPackages loads patterns with full syntax and type information and
+// returns them together with their non-stdlib dependencies, vendored ones
+// only if load.includeVendor is set. The go command honors GOFLAGS and the
+// vendor directory as for a build. Package load errors are printed to
+// stderr; analysis continues with whatever loaded.
+func loadPackages(workDir string, patterns []string, load loadOptions) (*packages.Config, []*packages.Package, error) {
+	pkgCfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+			packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo |
+			packages.NeedModule,
+		Tests: false,
+		Dir:   workDir,
+		Fset:  token.NewFileSet(),
+	}
+	if load.mod != "" {
+		pkgCfg.BuildFlags = []string{"-mod=" + load.mod}
+	}
+
+	pkgs, err := packages.Load(pkgCfg, patterns...)
+	if err != nil {
+		return nil, nil, &detector.PackageLoadError{Err: err}
+	}
+
+	// Surface load errors but continue with whatever loaded successfully —
+	// matches staticcheck/gosec behavior for partial successes.
+	for _, perr := range detector.PackageLoadErrors(pkgs) {
+		fmt.Fprintf(os.Stderr, "%v\n", perr.Err)
+	}
+
+	return pkgCfg, flattenWithDeps(pkgs, load.includeVendor), nil
 }
 
 // flattenWithDeps returns the input packages plus all transitively imported
 // packages with parsed syntax. Whole-program analysis needs callee bodies in
 // every package the user's code touches, not just the top-level patterns.
-func flattenWithDeps(roots []*packages.Package) []*packages.Package {
+// Vendored dependencies are left out unless includeVendor is set.
+func flattenWithDeps(roots []*packages.Package, includeVendor bool) []*packages.Package {
 	seen := make(map[string]*packages.Package)
 	var visit func(p *packages.Package, isRoot bool)
 	visit = func(p *packages.Package, isRoot bool) {
@@ -168,7 +824,12 @@ func flattenWithDeps(roots []*packages.Package) []*packages.Package {
 		// iteration for no benefit (logging calls are detected directly via
 		// type info). Roots are always kept in case the user explicitly targets
 		// such a package.
-		if !isRoot && detector.IsStdlibPackagePath(p.PkgPath) {
+		if !isRoot && detector.IsStdlibPackage(p) {
+			return
+		}
+		// Vendored code is third-party code checked into the repository:
+		// its findings are not the user's to fix
+		if !isRoot && !includeVendor && isVendored(p) {
 			return
 		}
 		seen[p.PkgPath] = p
@@ -186,6 +847,16 @@ func flattenWithDeps(roots []*packages.Package) []*packages.Package {
 	return out
 }
 
+// isVendored reports whether p was loaded from a vendor directory, which
+// holds each package at vendor/<import path>
+func isVendored(p *packages.Package) bool {
+	if len(p.GoFiles) == 0 {
+		return false
+	}
+	dir := filepath.ToSlash(filepath.Dir(p.GoFiles[0]))
+	return strings.HasSuffix(dir, "/vendor/"+p.PkgPath)
+}
+
 func collectFiles(pkgs []*packages.Package) []*ast.File {
 	var out []*ast.File
 	for _, p := range pkgs {
@@ -193,20 +864,3 @@ func collectFiles(pkgs []*packages.Package) []*ast.File {
 	}
 	return out
 }
-
-// emitText writes findings to stderr in the per-line format used by the
-// per-package singlechecker mode, so existing tooling and the user-visible
-// rule-ID suffix stay unchanged.
-func emitText(findings []detector.Finding, fset *token.FileSet, workDir string) {
-	for _, f := range findings {
-		if f.Suppressed {
-			continue
-		}
-		pos := fset.Position(f.Pos)
-		path := pos.Filename
-		if rel, err := filepath.Rel(workDir, path); err == nil && !strings.HasPrefix(rel, "..") {
-			path = "./" + filepath.ToSlash(rel)
-		}
-		fmt.Fprintf(os.Stderr, "%s:%d:%d: %s [%s]\n", path, pos.Line, pos.Column, f.Message, f.SARIFRuleID())
-	}
-}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// changeDir handles a leading -C dir flag like the go command: it changes to
+// dir before anything else, so config, triage and trend paths as well as
+// package patterns are relative to dir. It returns the remaining arguments.
+func changeDir(args []string) ([]string, error) {
+	if len(args) == 0 {
+		return args, nil
+	}
+	var dir string
+	switch a := args[0]; {
+	case a == "-C" || a == "--C":
+		if len(args) < 2 {
+			return nil, fmt.Errorf("missing directory for %s", a)
+		}
+		dir, args = args[1], args[2:]
+	case strings.HasPrefix(a, "-C=") || strings.HasPrefix(a, "--C="):
+		_, dir, _ = strings.Cut(a, "=")
+		args = args[1:]
+	default:
+		return args, nil
+	}
+	if err := os.Chdir(dir); err != nil {
+		return nil, fmt.Errorf("-C: %w", err)
+	}
+	return args, nil
+}
+
+// defaultPatterns returns the patterns analyzed when none are given: every
+// package of the module containing workDir, as "./..." would from the module
+// root, or of workDir itself outside a module
+func defaultPatterns(workDir string) []string {
+	root := moduleRoot(workDir)
+	if root == "" {
+		return []string{"./..."}
+	}
+	rel, err := filepath.Rel(workDir, root)
+	if err != nil || rel == "." {
+		return []string{"./..."}
+	}
+	return []string{path.Join(filepath.ToSlash(rel), "...")}
+}
+
+// moduleRoot returns the nearest directory at or above dir holding a go.mod
+// file, or "" if there is none
+func moduleRoot(dir string) string {
+	for {
+		if info, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil && !info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
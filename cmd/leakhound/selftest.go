@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nilpoona/leakhound/config"
+	"github.com/nilpoona/leakhound/selftest"
+)
+
+// runSelftest implements `leakhound selftest`, analyzing the embedded corpus
+// of leak patterns with the built-in rules and printing which patterns were
+// detected. It returns the process exit code: 1 if any pattern was missed.
+func runSelftest(args []string, w io.Writer, errw io.Writer) int {
+	if len(args) != 0 {
+		fmt.Fprintln(errw, "usage: leakhound selftest")
+		return 1
+	}
+
+	cases, err := selftest.Cases()
+	if err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+	dir, err := os.MkdirTemp("", "leakhound-selftest")
+	if err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+	if err := selftest.WriteCorpus(dir); err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+
+	// The user's config file does not apply: the corpus checks the
+	// analyzer's built-in capabilities
+	fset, findings, _, err := analyzeWholeProgram(dir, []string{"./..."}, &config.Config{}, loadOptions{}, analysisHooks{})
+	if err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+	results := selftest.Match(cases, findings, fset, dir)
+	if err := selftest.WriteMatrix(w, results); err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+	for _, r := range results {
+		if !r.Detected() {
+			return 1
+		}
+	}
+	return 0
+}
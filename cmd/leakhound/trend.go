@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/nilpoona/leakhound/reporter/trend"
+)
+
+// runTrend implements `leakhound trend PATH`, rendering the history recorded
+// with -trend=PATH as a table. It returns the process exit code.
+func runTrend(args []string, w io.Writer, errw io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintln(errw, "usage: leakhound trend PATH")
+		return 1
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+	defer f.Close()
+
+	entries, err := trend.Read(f)
+	if err != nil {
+		fmt.Fprintf(errw, "%s: %v\n", args[0], err)
+		return 1
+	}
+	if len(entries) == 0 {
+		fmt.Fprintf(errw, "%s: no runs recorded\n", args[0])
+		return 1
+	}
+	if err := trend.WriteTable(w, entries); err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nilpoona/leakhound/triage"
+)
+
+const triageUsage = "usage: leakhound triage [--file=PATH] [--config=PATH] <package patterns>"
+
+// runTriage implements `leakhound triage`, which analyzes the packages like
+// a normal run and writes the findings to an editable triage file,
+// .leakhound-triage.yaml by default. Statuses recorded in an existing file
+// are kept, new findings are added untriaged and fixed ones are dropped.
+// Normal runs given the file with --triage apply the statuses. It returns
+// the process exit code.
+func runTriage(args []string, w io.Writer, errw io.Writer) int {
+	path := triage.DefaultFile
+	opts := runOptions{}
+	var patterns []string
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case strings.HasPrefix(a, "--file=") || strings.HasPrefix(a, "-file="):
+			_, path, _ = strings.Cut(a, "=")
+		case a == "--file" || a == "-file":
+			if i+1 < len(args) {
+				path = args[i+1]
+				i++
+			}
+		case strings.HasPrefix(a, "--config=") || strings.HasPrefix(a, "-config="):
+			_, opts.configPath, _ = strings.Cut(a, "=")
+		case a == "--config" || a == "-config":
+			if i+1 < len(args) {
+				opts.configPath = args[i+1]
+				i++
+			}
+		default:
+			patterns = append(patterns, a)
+		}
+	}
+	if len(patterns) == 0 || path == "" {
+		fmt.Fprintln(errw, triageUsage)
+		return 1
+	}
+
+	stats, err := updateTriage(path, patterns, opts)
+	if err != nil {
+		fmt.Fprintf(errw, "%v\n", err)
+		return 1
+	}
+	fmt.Fprintf(w, "%s: %d findings (%d new, %d resolved, %d untriaged, %d overdue)\n",
+		path, stats.Total, stats.New, stats.Resolved, stats.Untriaged, stats.Overdue)
+	return 0
+}
+
+// updateTriage merges the current findings into the triage file at path,
+// creating it if needed
+func updateTriage(path string, patterns []string, opts runOptions) (triage.MergeStats, error) {
+	workDir, err := os.Getwd()
+	if err != nil {
+		return triage.MergeStats{}, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	prev, err := triage.Load(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return triage.MergeStats{}, err
+	}
+
+	cfg, err := loadRunConfig(opts)
+	if err != nil {
+		return triage.MergeStats{}, err
+	}
+	fset, findings, _, err := analyzeWholeProgram(workDir, patterns, &cfg, opts.load, analysisHooks{})
+	if err != nil {
+		return triage.MergeStats{}, err
+	}
+
+	merged, stats := triage.Merge(prev, findings, fset, workDir, time.Now())
+	return stats, triage.Write(path, merged)
+}
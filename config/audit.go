@@ -0,0 +1,220 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultSensitiveNamePatterns are the field-name heuristics used when the
+// untagged-fields audit is enabled without explicit patterns. They are
+// matched case-insensitively against Go field names.
+var DefaultSensitiveNamePatterns = []string{
+	`passw(or)?d`,
+	`passphrase`,
+	`secret`,
+	`token`,
+	`api_?key`,
+	`access_?key`,
+	`private_?key`,
+	`credential`,
+	`^pin$`,
+	`ssn`,
+	`card_?number`,
+	`cvv`,
+}
+
+// DefaultConfigPackagePatterns are the package-name heuristics used when the
+// config-dumps audit is enabled without explicit packages. They are matched
+// case-insensitively against Go package names.
+var DefaultConfigPackagePatterns = []string{
+	`config`,
+	`^conf$`,
+	`^cfg$`,
+	`settings`,
+}
+
+// DefaultConfigDumpMaxFields is the number of fields a configuration struct
+// may have before the config-dumps audit reports printing it whole
+const DefaultConfigDumpMaxFields = 5
+
+// AuditConfig holds opt-in audit rules
+type AuditConfig struct {
+	UntaggedFields   UntaggedFieldsConfig   `yaml:"untagged-fields"`
+	ConfigDumps      ConfigDumpsConfig      `yaml:"config-dumps,omitempty"`
+	HardcodedSecrets HardcodedSecretsConfig `yaml:"hardcoded-secrets,omitempty"`
+	CrashHandlers    CrashHandlersConfig    `yaml:"crash-handlers,omitempty"`
+}
+
+// UntaggedFieldsConfig configures the LH0007 audit, which reports fields of
+// exported structs that look sensitive but carry no sensitive tag
+type UntaggedFieldsConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Patterns []string `yaml:"patterns,omitempty"` // Field-name regexes; DefaultSensitiveNamePatterns when empty
+}
+
+// ConfigDumpsConfig configures the LH0013 audit, which reports configuration
+// structs printed whole with fmt or log, a debugging habit that leaks
+// whatever the struct holds whether its fields are tagged or not
+type ConfigDumpsConfig struct {
+	Enabled   bool     `yaml:"enabled"`
+	MaxFields int      `yaml:"max-fields,omitempty"` // Structs with more fields are reported; DefaultConfigDumpMaxFields when 0
+	Packages  []string `yaml:"packages,omitempty"`   // Package-name regexes; DefaultConfigPackagePatterns when empty
+}
+
+// HardcodedSecretsConfig configures the LH0018 audit, which reports secrets
+// written into the code itself: the default values in struct tags and the
+// constants of sensitive-looking fields and names, and connection strings
+// with a password anywhere in tags and constants
+type HardcodedSecretsConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Patterns []string `yaml:"patterns,omitempty"` // Field and constant name regexes; DefaultSensitiveNamePatterns when empty
+}
+
+// CrashHandlersConfig configures the LH0019 audit, which reports local
+// variables with sensitive-looking names logged by crash handlers: the
+// functions calling recover that also dump the stack with debug.PrintStack,
+// debug.Stack or runtime.Stack
+type CrashHandlersConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Patterns []string `yaml:"patterns,omitempty"` // Variable-name regexes; DefaultSensitiveNamePatterns when empty
+}
+
+// ConfigDumpAudit is an enabled config-dumps audit
+type ConfigDumpAudit struct {
+	MaxFields int          // Structs with more fields are reported
+	Packages  *NameMatcher // Names of the packages declaring configuration structs
+}
+
+// NameMatcher matches field names against sensitive-name heuristics
+type NameMatcher struct {
+	patterns   []*regexp.Regexp
+	sources    []string // The patterns as written
+	configured bool     // The patterns come from the config file, not the defaults
+	file       string   // Config file the patterns were declared in, if known
+}
+
+// NewNameMatcher compiles patterns, matching case-insensitively. An empty
+// list uses DefaultSensitiveNamePatterns.
+func NewNameMatcher(patterns []string) (*NameMatcher, error) {
+	return newNameMatcher(patterns, DefaultSensitiveNamePatterns)
+}
+
+// newNameMatcher compiles patterns, or defaults when patterns is empty
+func newNameMatcher(patterns, defaults []string) (*NameMatcher, error) {
+	m := &NameMatcher{configured: len(patterns) > 0}
+	if len(patterns) == 0 {
+		patterns = defaults
+	}
+	for _, p := range patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", p, err)
+		}
+		m.patterns = append(m.patterns, re)
+		m.sources = append(m.sources, p)
+	}
+	return m, nil
+}
+
+// Match reports whether name matches any pattern
+func (m *NameMatcher) Match(name string) bool {
+	return m.MatchedPattern(name) != ""
+}
+
+// MatchedPattern returns the first pattern matching name as written, e.g.
+// "api_?key", or "" if none does
+func (m *NameMatcher) MatchedPattern(name string) string {
+	for i, re := range m.patterns {
+		if re.MatchString(name) {
+			return m.sources[i]
+		}
+	}
+	return ""
+}
+
+// Configured reports whether the patterns were declared in the config file
+// rather than defaulted
+func (m *NameMatcher) Configured() bool {
+	return m.configured
+}
+
+// File returns the config file declaring the patterns, as given to
+// LoadConfig, or "" when they are defaulted or the config was not read from
+// a file
+func (m *NameMatcher) File() string {
+	if !m.configured {
+		return ""
+	}
+	return m.file
+}
+
+// nameMatcher compiles patterns, or defaults when patterns is empty, noting
+// the config file of c they were declared in
+func (c *Config) nameMatcher(patterns, defaults []string) (*NameMatcher, error) {
+	m, err := newNameMatcher(patterns, defaults)
+	if err != nil {
+		return nil, err
+	}
+	m.file = c.Path
+	return m, nil
+}
+
+// UntaggedFieldMatcher returns the matcher for the untagged-fields audit, or
+// nil when the audit is disabled. Patterns are validated by LoadConfig, so an
+// invalid pattern here also yields nil.
+func (c *Config) UntaggedFieldMatcher() *NameMatcher {
+	if c == nil || !c.Audit.UntaggedFields.Enabled {
+		return nil
+	}
+	m, err := c.nameMatcher(c.Audit.UntaggedFields.Patterns, DefaultSensitiveNamePatterns)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// ConfigDumpAudit returns the config-dumps audit, or nil when it is disabled.
+// Patterns are validated by LoadConfig, so an invalid pattern here also
+// yields nil.
+func (c *Config) ConfigDumpAudit() *ConfigDumpAudit {
+	if c == nil || !c.Audit.ConfigDumps.Enabled {
+		return nil
+	}
+	m, err := c.nameMatcher(c.Audit.ConfigDumps.Packages, DefaultConfigPackagePatterns)
+	if err != nil {
+		return nil
+	}
+	maxFields := c.Audit.ConfigDumps.MaxFields
+	if maxFields == 0 {
+		maxFields = DefaultConfigDumpMaxFields
+	}
+	return &ConfigDumpAudit{MaxFields: maxFields, Packages: m}
+}
+
+// HardcodedSecretMatcher returns the name matcher for the hardcoded-secrets
+// audit, or nil when the audit is disabled. Patterns are validated by
+// LoadConfig, so an invalid pattern here also yields nil.
+func (c *Config) HardcodedSecretMatcher() *NameMatcher {
+	if c == nil || !c.Audit.HardcodedSecrets.Enabled {
+		return nil
+	}
+	m, err := c.nameMatcher(c.Audit.HardcodedSecrets.Patterns, DefaultSensitiveNamePatterns)
+	if err != nil {
+		return nil
+	}
+	return m
+}
+
+// CrashHandlerMatcher returns the name matcher for the crash-handlers audit,
+// or nil when the audit is disabled. Patterns are validated by LoadConfig, so
+// an invalid pattern here also yields nil.
+func (c *Config) CrashHandlerMatcher() *NameMatcher {
+	if c == nil || !c.Audit.CrashHandlers.Enabled {
+		return nil
+	}
+	m, err := c.nameMatcher(c.Audit.CrashHandlers.Patterns, DefaultSensitiveNamePatterns)
+	if err != nil {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,251 @@
+package config
+
+import "testing"
+
+func TestNameMatcher_DefaultPatterns(t *testing.T) {
+	m, err := NewNameMatcher(nil)
+	if err != nil {
+		t.Fatalf("NewNameMatcher(nil) error = %v", err)
+	}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Password", true},
+		{"DBPasswd", true},
+		{"APIKey", true},
+		{"api_key", true},
+		{"AccessToken", true},
+		{"ClientSecret", true},
+		{"PrivateKey", true},
+		{"CardNumber", true},
+		{"PIN", true},
+		{"Name", false},
+		{"Email", false},
+		{"Pinned", false},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.name); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNameMatcher_CustomPatterns(t *testing.T) {
+	m, err := NewNameMatcher([]string{`^iban$`})
+	if err != nil {
+		t.Fatalf("NewNameMatcher() error = %v", err)
+	}
+	if !m.Match("IBAN") {
+		t.Errorf("Match(IBAN) = false, want true")
+	}
+	if m.Match("Password") {
+		t.Errorf("Match(Password) = true, want false: custom patterns replace the defaults")
+	}
+}
+
+func TestNameMatcher_MatchedPattern(t *testing.T) {
+	defaults, err := NewNameMatcher(nil)
+	if err != nil {
+		t.Fatalf("NewNameMatcher(nil) error = %v", err)
+	}
+	if got := defaults.MatchedPattern("ServiceAPIKey"); got != `api_?key` {
+		t.Errorf("MatchedPattern(ServiceAPIKey) = %q, want %q", got, `api_?key`)
+	}
+	if got := defaults.MatchedPattern("Name"); got != "" {
+		t.Errorf("MatchedPattern(Name) = %q, want \"\"", got)
+	}
+	if defaults.Configured() {
+		t.Errorf("Configured() = true for the default patterns")
+	}
+
+	custom, err := NewNameMatcher([]string{`^iban$`, `sort_?code`})
+	if err != nil {
+		t.Fatalf("NewNameMatcher() error = %v", err)
+	}
+	if got := custom.MatchedPattern("SortCode"); got != `sort_?code` {
+		t.Errorf("MatchedPattern(SortCode) = %q, want %q", got, `sort_?code`)
+	}
+	if !custom.Configured() {
+		t.Errorf("Configured() = false for patterns from the config file")
+	}
+}
+
+func TestValidateConfig_AuditPatterns(t *testing.T) {
+	cfg := &Config{Audit: AuditConfig{UntaggedFields: UntaggedFieldsConfig{
+		Enabled:  true,
+		Patterns: []string{`(unclosed`},
+	}}}
+	if err := ValidateConfig(cfg); err == nil {
+		t.Errorf("ValidateConfig() error = nil, want error for invalid pattern")
+	}
+}
+
+func TestLoadConfig_Audit(t *testing.T) {
+	yaml := `audit:
+  untagged-fields:
+    enabled: true
+    patterns:
+      - "^iban$"
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	m := cfg.UntaggedFieldMatcher()
+	if m == nil {
+		t.Fatal("UntaggedFieldMatcher() = nil, want matcher")
+	}
+	if !m.Match("Iban") {
+		t.Errorf("Match(Iban) = false, want true")
+	}
+	if m.File() != tmpFile {
+		t.Errorf("File() = %q, want the loaded file %q", m.File(), tmpFile)
+	}
+}
+
+func TestNameMatcher_File(t *testing.T) {
+	cfg := &Config{
+		Path:  "ci/leakhound.yaml",
+		Audit: AuditConfig{UntaggedFields: UntaggedFieldsConfig{Enabled: true}},
+		PII:   PIIConfig{Enabled: true, Patterns: []string{"^iban$"}},
+	}
+	if got := cfg.PIIFieldMatcher().File(); got != "ci/leakhound.yaml" {
+		t.Errorf("PIIFieldMatcher().File() = %q, want the config file", got)
+	}
+	if got := cfg.UntaggedFieldMatcher().File(); got != "" {
+		t.Errorf("UntaggedFieldMatcher().File() = %q, want none for default patterns", got)
+	}
+}
+
+func TestConfig_UntaggedFieldMatcher_Disabled(t *testing.T) {
+	var nilCfg *Config
+	if nilCfg.UntaggedFieldMatcher() != nil {
+		t.Errorf("nil config: UntaggedFieldMatcher() != nil")
+	}
+	if (&Config{}).UntaggedFieldMatcher() != nil {
+		t.Errorf("default config: UntaggedFieldMatcher() != nil, want audit disabled by default")
+	}
+}
+
+func TestConfig_ConfigDumpAudit(t *testing.T) {
+	if (&Config{}).ConfigDumpAudit() != nil {
+		t.Errorf("default config: ConfigDumpAudit() != nil, want audit disabled by default")
+	}
+
+	a := (&Config{Audit: AuditConfig{ConfigDumps: ConfigDumpsConfig{Enabled: true}}}).ConfigDumpAudit()
+	if a == nil {
+		t.Fatal("ConfigDumpAudit() = nil, want audit")
+	}
+	if a.MaxFields != DefaultConfigDumpMaxFields {
+		t.Errorf("MaxFields = %d, want %d", a.MaxFields, DefaultConfigDumpMaxFields)
+	}
+	for name, want := range map[string]bool{"config": true, "appconfig": true, "settings": true, "cfg": true, "conf": true, "confluence": false, "server": false} {
+		if got := a.Packages.Match(name); got != want {
+			t.Errorf("Packages.Match(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestLoadConfig_ConfigDumps(t *testing.T) {
+	yaml := `audit:
+  config-dumps:
+    enabled: true
+    max-fields: 10
+    packages:
+      - "^env$"
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	a := cfg.ConfigDumpAudit()
+	if a == nil {
+		t.Fatal("ConfigDumpAudit() = nil, want audit")
+	}
+	if a.MaxFields != 10 {
+		t.Errorf("MaxFields = %d, want 10", a.MaxFields)
+	}
+	if !a.Packages.Match("env") || a.Packages.Match("config") {
+		t.Errorf("Packages = %v, want only the configured pattern", a.Packages.sources)
+	}
+}
+
+func TestValidateConfig_ConfigDumps(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  ConfigDumpsConfig
+	}{
+		{"invalid pattern", ConfigDumpsConfig{Enabled: true, Packages: []string{`(unclosed`}}},
+		{"negative max-fields", ConfigDumpsConfig{Enabled: true, MaxFields: -1}},
+	}
+	for _, tt := range tests {
+		cfg := &Config{Audit: AuditConfig{ConfigDumps: tt.cfg}}
+		if err := ValidateConfig(cfg); err == nil {
+			t.Errorf("%s: ValidateConfig() error = nil, want error", tt.name)
+		}
+	}
+}
+
+func TestLoadConfig_HardcodedSecrets(t *testing.T) {
+	if (&Config{}).HardcodedSecretMatcher() != nil {
+		t.Errorf("default config: HardcodedSecretMatcher() != nil, want audit disabled by default")
+	}
+
+	yaml := `audit:
+  hardcoded-secrets:
+    enabled: true
+    patterns:
+      - "^dsn$"
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	m := cfg.HardcodedSecretMatcher()
+	if m == nil {
+		t.Fatal("HardcodedSecretMatcher() = nil, want matcher")
+	}
+	if !m.Match("DSN") || m.Match("password") {
+		t.Errorf("HardcodedSecretMatcher() patterns = %v, want only the configured pattern", m.sources)
+	}
+
+	invalid := &Config{Audit: AuditConfig{HardcodedSecrets: HardcodedSecretsConfig{Enabled: true, Patterns: []string{`(unclosed`}}}}
+	if err := ValidateConfig(invalid); err == nil {
+		t.Error("ValidateConfig() error = nil, want error for an invalid pattern")
+	}
+}
+
+func TestLoadConfig_CrashHandlers(t *testing.T) {
+	if (&Config{}).CrashHandlerMatcher() != nil {
+		t.Errorf("default config: CrashHandlerMatcher() != nil, want audit disabled by default")
+	}
+
+	yaml := `audit:
+  crash-handlers:
+    enabled: true
+    patterns:
+      - "^dsn$"
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	m := cfg.CrashHandlerMatcher()
+	if m == nil {
+		t.Fatal("CrashHandlerMatcher() = nil, want matcher")
+	}
+	if !m.Match("dsn") || m.Match("password") {
+		t.Errorf("CrashHandlerMatcher() patterns = %v, want only the configured pattern", m.sources)
+	}
+
+	invalid := &Config{Audit: AuditConfig{CrashHandlers: CrashHandlersConfig{Enabled: true, Patterns: []string{`(unclosed`}}}}
+	if err := ValidateConfig(invalid); err == nil {
+		t.Error("ValidateConfig() error = nil, want error for an invalid pattern")
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import "fmt"
+
+// BoundaryConfig declares packages that values of sensitive types must never
+// cross into, such as logging or telemetry packages exporting what they are
+// given. Functions declared in them that accept a struct with sensitive
+// fields are reported under LH0015, catching leaks at the API design level.
+type BoundaryConfig struct {
+	Packages []string `yaml:"packages"`         // Package paths, see MatchPackage, e.g. "example.com/app/telemetry/..."
+	Reason   string   `yaml:"reason,omitempty"` // Appended to the findings, e.g. "telemetry is exported to a vendor"
+}
+
+// Boundary returns the first boundary whose packages match the package with
+// import path pkgPath, or nil when it is not a boundary package
+func (c *Config) Boundary(pkgPath string) *BoundaryConfig {
+	if c == nil {
+		return nil
+	}
+	for i, b := range c.Boundaries {
+		for _, pattern := range b.Packages {
+			if MatchPackage(pattern, pkgPath) {
+				return &c.Boundaries[i]
+			}
+		}
+	}
+	return nil
+}
+
+func validateBoundaries(boundaries []BoundaryConfig) error {
+	if len(boundaries) > maxTargets {
+		return fmt.Errorf("boundaries: too many entries: %d (max: %d)", len(boundaries), maxTargets)
+	}
+	for i, b := range boundaries {
+		if len(b.Packages) == 0 {
+			return fmt.Errorf("boundaries[%d]: packages must not be empty", i)
+		}
+		for _, pattern := range b.Packages {
+			if err := validatePackagePattern(pattern); err != nil {
+				return fmt.Errorf("boundaries[%d]: %w", i, err)
+			}
+		}
+	}
+	return nil
+}
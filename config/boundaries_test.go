@@ -0,0 +1,81 @@
+package config
+
+import "testing"
+
+func TestConfig_Boundary(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{Boundaries: []BoundaryConfig{
+		{Packages: []string{"example.com/app/telemetry/..."}, Reason: "exported to a vendor"},
+		{Packages: []string{"example.com/app/log"}},
+	}}
+
+	tests := []struct {
+		name    string
+		pkgPath string
+		want    string // Reason of the boundary, "-" for none
+	}{
+		{"subpackage pattern", "example.com/app/telemetry/otel", "exported to a vendor"},
+		{"exact path", "example.com/app/log", ""},
+		{"not a boundary", "example.com/app/logger", "-"},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b := cfg.Boundary(tt.pkgPath)
+			switch {
+			case tt.want == "-" && b != nil:
+				t.Errorf("Boundary(%q) = %+v, want nil", tt.pkgPath, b)
+			case tt.want != "-" && (b == nil || b.Reason != tt.want):
+				t.Errorf("Boundary(%q) = %+v, want reason %q", tt.pkgPath, b, tt.want)
+			}
+		})
+	}
+
+	if (*Config)(nil).Boundary("example.com/app/log") != nil {
+		t.Error("nil config: Boundary() != nil")
+	}
+}
+
+func TestValidateConfig_Boundaries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		boundary BoundaryConfig
+		wantErr  bool
+	}{
+		{"valid", BoundaryConfig{Packages: []string{"example.com/app/telemetry/..."}}, false},
+		{"no packages", BoundaryConfig{Reason: "telemetry"}, true},
+		{"invalid package", BoundaryConfig{Packages: []string{"example.com/.../log"}}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Boundaries: []BoundaryConfig{tt.boundary}}
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Boundaries(t *testing.T) {
+	yaml := `boundaries:
+  - packages: ["example.com/app/telemetry/..."]
+    reason: telemetry is exported to a vendor
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	b := cfg.Boundary("example.com/app/telemetry")
+	if b == nil || b.Reason != "telemetry is exported to a vendor" {
+		t.Errorf("Boundary() = %+v, want the telemetry boundary", b)
+	}
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CommandLineConfig declares values passed on the command line as sensitive
+// sources: the values of flags by name, such as "db-password" for
+// -db-password, and the elements of os.Args by index. Values read from them
+// are reported under LH0012 once they reach a sink.
+type CommandLineConfig struct {
+	Flags []string `yaml:"flags,omitempty"` // Flag names, with or without the leading dashes, e.g. "db-password"
+	Args  []int    `yaml:"args,omitempty"`  // Indices into os.Args, e.g. 1 for the first argument after the program name
+}
+
+// FlagNames returns the configured flag names without their leading dashes
+func (c CommandLineConfig) FlagNames() []string {
+	names := make([]string, 0, len(c.Flags))
+	for _, flag := range c.Flags {
+		names = append(names, strings.TrimLeft(flag, "-"))
+	}
+	return names
+}
+
+func validateCommandLine(c CommandLineConfig) error {
+	for _, name := range c.FlagNames() {
+		if name == "" || strings.ContainsAny(name, "= \t") {
+			return fmt.Errorf("command-line.flags: invalid flag name %q", name)
+		}
+	}
+	for _, index := range c.Args {
+		if index < 0 {
+			return fmt.Errorf("command-line.args: invalid index %d (must be at least 0)", index)
+		}
+	}
+	return nil
+}
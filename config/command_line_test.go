@@ -0,0 +1,57 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestValidateConfig_CommandLine(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		commandLine CommandLineConfig
+		wantErr     bool
+	}{
+		{
+			name:        "flags and args",
+			commandLine: CommandLineConfig{Flags: []string{"db-password", "-api-key", "--token"}, Args: []int{0, 2}},
+		},
+		{
+			name:        "empty flag name",
+			commandLine: CommandLineConfig{Flags: []string{"--"}},
+			wantErr:     true,
+		},
+		{
+			name:        "flag with value",
+			commandLine: CommandLineConfig{Flags: []string{"db-password=secret"}},
+			wantErr:     true,
+		},
+		{
+			name:        "negative index",
+			commandLine: CommandLineConfig{Args: []int{-1}},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{CommandLine: tt.commandLine}
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommandLineConfig_FlagNames(t *testing.T) {
+	t.Parallel()
+
+	c := CommandLineConfig{Flags: []string{"db-password", "-api-key", "--token"}}
+	want := []string{"db-password", "api-key", "token"}
+	if got := c.FlagNames(); !slices.Equal(got, want) {
+		t.Errorf("FlagNames() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Lowest confidence scores of the high and medium confidence levels; lower
+// scores are low confidence
+const (
+	ConfidenceHighScore   = 0.8
+	ConfidenceMediumScore = 0.5
+)
+
+// MinConfidenceScore returns the lowest confidence score of the findings
+// reported, 0 when min-confidence is unset. The setting is validated when the
+// config is loaded.
+func (c *Config) MinConfidenceScore() float64 {
+	if c == nil {
+		return 0
+	}
+	score, _ := parseMinConfidence(c.MinConfidence)
+	return score
+}
+
+// ApplyMinConfidence overrides the config file's min-confidence setting with
+// value, the value of the command-line flag. An empty value keeps the config
+// value.
+func (c *Config) ApplyMinConfidence(value string) error {
+	if value == "" {
+		return nil
+	}
+	if _, err := parseMinConfidence(value); err != nil {
+		return invalid("", err)
+	}
+	c.MinConfidence = value
+	return nil
+}
+
+// parseMinConfidence parses a min-confidence setting: a level, high, medium
+// or low, or a score between 0 and 1
+func parseMinConfidence(value string) (float64, error) {
+	switch value {
+	case "", "low":
+		return 0, nil
+	case "medium":
+		return ConfidenceMediumScore, nil
+	case "high":
+		return ConfidenceHighScore, nil
+	}
+	score, err := strconv.ParseFloat(value, 64)
+	if err != nil || score < 0 || score > 1 {
+		return 0, fmt.Errorf("min-confidence: invalid value %q (valid values: high, medium, low or a score between 0.0 and 1.0)", value)
+	}
+	return score, nil
+}
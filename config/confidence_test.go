@@ -0,0 +1,51 @@
+package config
+
+import "testing"
+
+func TestConfig_MinConfidenceScore(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    float64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"low", 0, false},
+		{"medium", ConfidenceMediumScore, false},
+		{"high", ConfidenceHighScore, false},
+		{"0.6", 0.6, false},
+		{"1", 1, false},
+		{"1.5", 0, true},
+		{"-0.1", 0, true},
+		{"certain", 0, true},
+	}
+	for _, tt := range tests {
+		err := ValidateConfig(&Config{MinConfidence: tt.value})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ValidateConfig(min-confidence %q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+		}
+		if tt.wantErr {
+			continue
+		}
+		if got := (&Config{MinConfidence: tt.value}).MinConfidenceScore(); got != tt.want {
+			t.Errorf("MinConfidenceScore(%q) = %v, want %v", tt.value, got, tt.want)
+		}
+	}
+
+	var nilCfg *Config
+	if got := nilCfg.MinConfidenceScore(); got != 0 {
+		t.Errorf("nil config: MinConfidenceScore() = %v, want 0", got)
+	}
+}
+
+func TestConfig_ApplyMinConfidence(t *testing.T) {
+	cfg := &Config{MinConfidence: "high"}
+	if err := cfg.ApplyMinConfidence(""); err != nil || cfg.MinConfidence != "high" {
+		t.Errorf("ApplyMinConfidence(\"\") = %v, min-confidence %q, want config value kept", err, cfg.MinConfidence)
+	}
+	if err := cfg.ApplyMinConfidence("medium"); err != nil || cfg.MinConfidence != "medium" {
+		t.Errorf("ApplyMinConfidence(medium) = %v, min-confidence %q, want medium", err, cfg.MinConfidence)
+	}
+	if err := cfg.ApplyMinConfidence("sure"); err == nil {
+		t.Errorf("ApplyMinConfidence(sure) error = nil, want error")
+	}
+}
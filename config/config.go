@@ -28,8 +28,43 @@ const (
 
 // Config represents the configuration file structure
 type Config struct {
-	Targets  []TargetConfig `yaml:"targets"`
-	Suppress SuppressConfig `yaml:"suppress"`
+	Targets           []TargetConfig        `yaml:"targets"`
+	Suppress          SuppressConfig        `yaml:"suppress"`
+	Rules             map[string]RuleConfig `yaml:"rules,omitempty"`              // Per-rule settings keyed by SARIF rule ID or "all"
+	Audit             AuditConfig           `yaml:"audit,omitempty"`              // Opt-in audit rules
+	Redaction         RedactionConfig       `yaml:"redaction,omitempty"`          // Types exempted for rendering a redacted view
+	KeySinks          []KeySinkConfig       `yaml:"key-sinks,omitempty"`          // Cache key and metric name builders (LH0008)
+	ProducerSinks     []ProducerSinkConfig  `yaml:"producer-sinks,omitempty"`     // Message-queue producers feeding logging pipelines (LH0017)
+	Sanitizers        []TargetConfig        `yaml:"sanitizers,omitempty"`         // Functions and methods whose results are safe to log
+	EventBuilders     []EventBuilderConfig  `yaml:"event-builders,omitempty"`     // Functions and methods attaching values to structured events
+	Templates         TemplateConfig        `yaml:"templates,omitempty"`          // Writers that make template execution a sink
+	PII               PIIConfig             `yaml:"pii,omitempty"`                // Opt-in reporting of personal data (LH0009)
+	ReportGranularity string                `yaml:"report-granularity,omitempty"` // "arg" (default) or "call", see ReportsPerCall
+	MaxMemory         string                `yaml:"max-memory,omitempty"`         // Per-package budget for tracked data flow facts, see MemoryBudget
+	StructRuleScope   string                `yaml:"struct-rule-scope,omitempty"`  // Structs reported by LH0003: "local", "module" or "all" (default), see StructScope
+	Codeowners        string                `yaml:"codeowners,omitempty"`         // CODEOWNERS-format file assigning findings to owners; .github/CODEOWNERS, CODEOWNERS or docs/CODEOWNERS when empty
+	AllowTypeErrors   bool                  `yaml:"allow-type-errors,omitempty"`  // Match sinks syntactically in files that do not type-check, with best-effort findings
+	CommandLine       CommandLineConfig     `yaml:"command-line,omitempty"`       // Flags and os.Args indices holding secrets (LH0012)
+	MinConfidence     string                `yaml:"min-confidence,omitempty"`     // Lowest confidence reported: high, medium, low or a score, see MinConfidenceScore
+	MaxFlowHops       int                   `yaml:"max-flow-hops,omitempty"`      // Data flow hops after which long-flows applies to a finding, 0 for no limit, see FlowHopLimit
+	LongFlows         string                `yaml:"long-flows,omitempty"`         // "warning" (default) or "suppress", for findings beyond max-flow-hops
+	SinkSeverities    []SinkSeverityConfig  `yaml:"sink-severities,omitempty"`    // Severities of the findings per sink family, e.g. fmt → warning, see SinkSeverity
+	FailOn            string                `yaml:"fail-on,omitempty"`            // Lowest severity of the findings failing the CLI run: error, warning, note or never (default)
+	Boundaries        []BoundaryConfig      `yaml:"boundaries,omitempty"`         // Packages sensitive types must not cross into (LH0015)
+	Hooks             HookConfig            `yaml:"hooks,omitempty"`              // Logger hook registrations and the external sinks hooks must not forward to (LH0016)
+	Overrides         []OverrideConfig      `yaml:"overrides,omitempty"`          // Reporting settings for some packages, see ForPackage
+
+	// Path is the file the config was loaded from, as given to LoadConfig,
+	// or "" for a config not read from a file, such as plugin settings
+	Path string `yaml:"-"`
+}
+
+// RuleConfig holds per-rule reporting settings
+type RuleConfig struct {
+	Severity         string   `yaml:"severity,omitempty"`          // error, warning or note
+	SecuritySeverity *float64 `yaml:"security-severity,omitempty"` // SARIF security-severity score, 0.0-10.0
+	Rank             *float64 `yaml:"rank,omitempty"`              // SARIF result rank, 0.0-100.0
+	Message          string   `yaml:"message,omitempty"`           // Go template of the finding message (see MessageTemplateFields)
 }
 
 // SuppressConfig holds rule-level suppression settings
@@ -42,6 +77,11 @@ type TargetConfig struct {
 	Package   string         `yaml:"package"`
 	Functions []string       `yaml:"functions,omitempty"`
 	Methods   []MethodConfig `yaml:"methods,omitempty"`
+
+	// FormatArg is the 0-based index of the printf-style format string among
+	// the arguments of this target's functions and methods (receiver
+	// excluded). When set, arguments are checked per verb like fmt.Printf.
+	FormatArg *int `yaml:"format-arg,omitempty"`
 }
 
 // MethodConfig represents a method configuration for a specific receiver type
@@ -50,7 +90,9 @@ type MethodConfig struct {
 	Names    []string `yaml:"names"`
 }
 
-var packagePathPattern = regexp.MustCompile(`^[a-z0-9.\-/]+$`)
+// packagePathPattern matches package paths. As in module paths, only the first
+// element must be lower case, as github.com/IBM/sarama.
+var packagePathPattern = regexp.MustCompile(`^[a-z0-9._\-]+(?:/[A-Za-z0-9._\-]*)*$`)
 
 // validSARIFRuleIDs is the set of rule IDs that can be used in suppress.rules.
 var validSARIFRuleIDs = map[string]bool{
@@ -60,12 +102,26 @@ var validSARIFRuleIDs = map[string]bool{
 	"LH0004": true,
 	"LH0005": true,
 	"LH0006": true,
+	"LH0007": true,
+	"LH0008": true,
+	"LH0009": true,
+	"LH0010": true,
+	"LH0011": true,
+	"LH0012": true,
+	"LH0013": true,
+	"LH0014": true,
+	"LH0015": true,
+	"LH0016": true,
+	"LH0017": true,
+	"LH0018": true,
+	"LH0019": true,
 }
 
 // LoadConfig loads the configuration file from the specified path.
 // If path is empty, it looks for the default configuration file in the current directory.
 // Returns an empty Config if the file does not exist and no path was specified.
-// Returns an empty Config and an error if loading or validation fails.
+// Returns an empty Config and an error if loading or validation fails; an
+// invalid configuration is reported as a ConfigError.
 func LoadConfig(path string) (Config, error) {
 	// If no path specified, try default file
 	if path == "" {
@@ -94,7 +150,7 @@ func LoadConfig(path string) (Config, error) {
 		// Ensure the config file is within or relative to the working directory
 		relPath, err := filepath.Rel(wd, absPath)
 		if err != nil || strings.HasPrefix(relPath, "..") {
-			return Config{}, fmt.Errorf("config file must be within the working directory: %s", path)
+			return Config{}, invalid(path, fmt.Errorf("config file must be within the working directory: %s", path))
 		}
 	}
 
@@ -105,7 +161,7 @@ func LoadConfig(path string) (Config, error) {
 	}
 
 	if fileInfo.Size() > maxConfigSize {
-		return Config{}, fmt.Errorf("config file size (%d bytes) exceeds maximum allowed size (%d bytes)", fileInfo.Size(), maxConfigSize)
+		return Config{}, invalid(path, fmt.Errorf("config file size (%d bytes) exceeds maximum allowed size (%d bytes)", fileInfo.Size(), maxConfigSize))
 	}
 
 	// Open and read the file
@@ -124,19 +180,25 @@ func LoadConfig(path string) (Config, error) {
 
 	var config Config
 	if err := decoder.Decode(&config); err != nil {
-		return Config{}, fmt.Errorf("failed to parse config file: %w", err)
+		return Config{}, invalid(path, fmt.Errorf("failed to parse config file: %w", err))
 	}
 
 	// Validate the configuration
-	if err := ValidateConfig(&config); err != nil {
-		return Config{}, fmt.Errorf("invalid configuration: %w", err)
+	if err := validateConfig(&config); err != nil {
+		return Config{}, invalid(path, fmt.Errorf("invalid configuration: %w", err))
 	}
 
+	config.Path = path
 	return config, nil
 }
 
-// ValidateConfig validates the configuration structure and content
+// ValidateConfig validates the configuration structure and content. An
+// invalid configuration is reported as a ConfigError.
 func ValidateConfig(config *Config) error {
+	return invalid("", validateConfig(config))
+}
+
+func validateConfig(config *Config) error {
 	if config == nil {
 		return fmt.Errorf("config is nil")
 	}
@@ -156,7 +218,121 @@ func ValidateConfig(config *Config) error {
 	// Validate suppress.rules
 	for _, ruleID := range config.Suppress.Rules {
 		if !validSARIFRuleIDs[ruleID] {
-			return fmt.Errorf("suppress.rules: invalid rule ID %q (valid values: LH0001, LH0002, LH0003, LH0004, LH0005, LH0006)", ruleID)
+			return fmt.Errorf("suppress.rules: invalid rule ID %q (valid values: LH0001, LH0002, LH0003, LH0004, LH0005, LH0006, LH0007, LH0008, LH0009, LH0010, LH0011, LH0012, LH0013, LH0014, LH0015, LH0016, LH0017, LH0018, LH0019)", ruleID)
+		}
+	}
+
+	// Validate rules
+	for ruleID, rule := range config.Rules {
+		if ruleID != AllRules && !validSARIFRuleIDs[ruleID] {
+			return fmt.Errorf("rules: invalid rule ID %q (valid values: all, LH0001, LH0002, LH0003, LH0004, LH0005, LH0006, LH0007, LH0008, LH0009, LH0010, LH0011, LH0012, LH0013, LH0014, LH0015, LH0016, LH0017, LH0018, LH0019)", ruleID)
+		}
+		if rule.Severity != "" && !validSeverities[rule.Severity] {
+			return fmt.Errorf("rules.%s: invalid severity %q (valid values: error, warning, note)", ruleID, rule.Severity)
+		}
+		if s := rule.SecuritySeverity; s != nil && (*s < 0 || *s > 10) {
+			return fmt.Errorf("rules.%s: security-severity %g out of range (0.0-10.0)", ruleID, *s)
+		}
+		if r := rule.Rank; r != nil && (*r < 0 || *r > 100) {
+			return fmt.Errorf("rules.%s: rank %g out of range (0.0-100.0)", ruleID, *r)
+		}
+		if rule.Message != "" {
+			if err := validateMessageTemplate(ruleID, rule.Message); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Validate audit patterns
+	if _, err := NewNameMatcher(config.Audit.UntaggedFields.Patterns); err != nil {
+		return fmt.Errorf("audit.untagged-fields: %w", err)
+	}
+	if _, err := newNameMatcher(config.Audit.ConfigDumps.Packages, DefaultConfigPackagePatterns); err != nil {
+		return fmt.Errorf("audit.config-dumps: %w", err)
+	}
+	if n := config.Audit.ConfigDumps.MaxFields; n < 0 {
+		return fmt.Errorf("audit.config-dumps: max-fields must not be negative, got %d", n)
+	}
+	if _, err := NewNameMatcher(config.Audit.HardcodedSecrets.Patterns); err != nil {
+		return fmt.Errorf("audit.hardcoded-secrets: %w", err)
+	}
+	if _, err := NewNameMatcher(config.Audit.CrashHandlers.Patterns); err != nil {
+		return fmt.Errorf("audit.crash-handlers: %w", err)
+	}
+
+	// Validate PII patterns
+	if _, err := newNameMatcher(config.PII.Patterns, DefaultPIINamePatterns); err != nil {
+		return fmt.Errorf("pii: %w", err)
+	}
+
+	if err := validateKeySinks(config.KeySinks); err != nil {
+		return err
+	}
+
+	if err := validateProducerSinks(config.ProducerSinks); err != nil {
+		return err
+	}
+
+	if err := validateSanitizers(config.Sanitizers); err != nil {
+		return err
+	}
+
+	if err := validateEventBuilders(config.EventBuilders); err != nil {
+		return err
+	}
+
+	if err := validateCommandLine(config.CommandLine); err != nil {
+		return err
+	}
+
+	if err := validateBoundaries(config.Boundaries); err != nil {
+		return err
+	}
+
+	if err := validateHooks(config.Hooks); err != nil {
+		return err
+	}
+
+	if err := validateOverrides(config.Overrides); err != nil {
+		return err
+	}
+
+	if err := validateReportGranularity(config.ReportGranularity); err != nil {
+		return err
+	}
+
+	if err := validateMaxMemory(config.MaxMemory); err != nil {
+		return err
+	}
+
+	if err := validateStructRuleScope(config.StructRuleScope); err != nil {
+		return err
+	}
+
+	if _, err := parseMinConfidence(config.MinConfidence); err != nil {
+		return err
+	}
+	if err := validateFlowHops(config.MaxFlowHops, config.LongFlows); err != nil {
+		return err
+	}
+	if err := validateSinkSeverities(config.SinkSeverities); err != nil {
+		return err
+	}
+	if err := validateFailOn(config.FailOn); err != nil {
+		return err
+	}
+
+	// Validate template writers
+	for i, w := range config.Templates.Writers {
+		if err := validateTemplateWriter(i, w); err != nil {
+			return err
+		}
+	}
+
+	// Validate redaction marshalers
+	for i, m := range config.Redaction.Marshalers {
+		if err := validateMarshaler(i, &m); err != nil {
+			return err
 		}
 	}
 
@@ -169,7 +345,7 @@ func validateTarget(index int, target *TargetConfig) error {
 		return fmt.Errorf("target[%d]: package path is required", index)
 	}
 
-	if err := validatePackagePath(target.Package); err != nil {
+	if err := validatePackagePattern(target.Package); err != nil {
 		return fmt.Errorf("target[%d]: %w", index, err)
 	}
 
@@ -193,6 +369,11 @@ func validateTarget(index int, target *TargetConfig) error {
 		}
 	}
 
+	if target.FormatArg != nil && *target.FormatArg < 0 {
+		return fmt.Errorf("target[%d] (%s): format-arg must not be negative: %d",
+			index, target.Package, *target.FormatArg)
+	}
+
 	// Check number of method configs
 	if len(target.Methods) > maxMethods {
 		return fmt.Errorf("target[%d] (%s): too many method configs: %d (max: %d)",
@@ -254,14 +435,9 @@ func validateIdentifier(name string) error {
 	return nil
 }
 
-// validateReceiver validates a receiver type specification (e.g., "*Logger", "Logger")
+// validateReceiver validates a receiver type specification (e.g., "*Logger",
+// "Logger", "*Logger[T]")
 func validateReceiver(receiver string) error {
-	// Remove optional pointer prefix
-	name := strings.TrimPrefix(receiver, "*")
-
-	if !token.IsIdentifier(name) {
-		return fmt.Errorf("invalid receiver type: %s", receiver)
-	}
-
-	return nil
+	_, err := ParseReceiver(receiver)
+	return err
 }
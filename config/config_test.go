@@ -243,6 +243,54 @@ func TestValidateConfig_NoFunctionsOrMethods(t *testing.T) {
 	}
 }
 
+func TestValidateConfig_FormatArg(t *testing.T) {
+	negative, zero := -1, 0
+	tests := []struct {
+		name      string
+		formatArg *int
+		wantErr   bool
+	}{
+		{"unset", nil, false},
+		{"zero", &zero, false},
+		{"negative", &negative, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{
+				Targets: []TargetConfig{
+					{
+						Package:   "example.com/mylog",
+						Functions: []string{"Errorf"},
+						FormatArg: tt.formatArg,
+					},
+				},
+			}
+			err := ValidateConfig(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_FormatArg(t *testing.T) {
+	yaml := `targets:
+  - package: "example.com/mylog"
+    functions:
+      - "Errorf"
+    format-arg: 1
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	if got := cfg.Targets[0].FormatArg; got == nil || *got != 1 {
+		t.Errorf("FormatArg = %v, want 1", got)
+	}
+}
+
 func TestValidateConfig_TooManyFunctions(t *testing.T) {
 	functions := make([]string, maxFunctions+1)
 	for i := range functions {
@@ -519,6 +567,7 @@ func TestValidatePackagePath(t *testing.T) {
 		{"valid third party", "go.uber.org/zap", false},
 		{"valid with dash", "github.com/rs/zerolog", false},
 		{"valid with numbers", "github.com/user/lib2", false},
+		{"valid with underscore", "github.com/prometheus/client_golang/prometheus", false},
 		{"invalid uppercase", "Go.Uber.Org/Zap", true},
 		{"invalid space", "go.uber.org /zap", true},
 		{"invalid special char", "go.uber.org/zap!", true},
@@ -569,6 +618,12 @@ func TestValidateReceiver(t *testing.T) {
 		{"valid with underscore", "*Custom_Logger", false},
 		{"invalid with dash", "*Invalid-Logger", true},
 		{"invalid with space", "* Logger", true},
+		{"valid generic", "*Logger[T]", false},
+		{"valid generic value", "Pair[K, V]", false},
+		{"invalid empty type params", "*Logger[]", true},
+		{"invalid unclosed type params", "*Logger[T", true},
+		{"invalid duplicate type params", "Pair[T, T]", true},
+		{"invalid type argument", "*Logger[*T]", true},
 	}
 
 	for _, tt := range tests {
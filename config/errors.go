@@ -0,0 +1,39 @@
+package config
+
+import "errors"
+
+// ErrConfigInvalid is matched, with errors.Is, by the errors reporting an
+// invalid configuration: a config file that does not parse or validate, or an
+// invalid command-line setting. These are user errors, to be fixed in the
+// configuration rather than reported as leakhound bugs. Failing to read the
+// config file is not one of them: that error wraps the underlying error, such
+// as fs.ErrNotExist.
+var ErrConfigInvalid = errors.New("invalid configuration")
+
+// ConfigError reports an invalid configuration. Its message is the message of
+// Err, which says what is invalid; it matches ErrConfigInvalid.
+type ConfigError struct {
+	Path string // The config file, or "" for a config not loaded from a file
+	Err  error
+}
+
+func (e *ConfigError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrConfigInvalid
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrConfigInvalid
+}
+
+// invalid returns err, if any, as a ConfigError for the config file at path
+func invalid(path string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &ConfigError{Path: path, Err: err}
+}
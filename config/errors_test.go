@@ -0,0 +1,82 @@
+package config
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	invalidFile := filepath.Join(dir, "invalid.yaml")
+	if err := os.WriteFile(invalidFile, []byte("min-confidence: certain\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	unparsable := filepath.Join(dir, "unparsable.yaml")
+	if err := os.WriteFile(unparsable, []byte("unknown-field: true\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		err         func() error
+		wantInvalid bool
+		wantPath    string
+	}{
+		{
+			name:        "invalid setting",
+			err:         func() error { _, err := LoadConfig(invalidFile); return err },
+			wantInvalid: true,
+			wantPath:    invalidFile,
+		},
+		{
+			name:        "unknown field",
+			err:         func() error { _, err := LoadConfig(unparsable); return err },
+			wantInvalid: true,
+			wantPath:    unparsable,
+		},
+		{
+			name: "missing file",
+			err:  func() error { _, err := LoadConfig(filepath.Join(dir, "missing.yaml")); return err },
+		},
+		{
+			name:        "validated config",
+			err:         func() error { return ValidateConfig(&Config{StructRuleScope: "global"}) },
+			wantInvalid: true,
+		},
+		{
+			name:        "command-line setting",
+			err:         func() error { return (&Config{}).ApplyMaxMemory("lots") },
+			wantInvalid: true,
+		},
+		{
+			name:        "severity override",
+			err:         func() error { _, err := ParseSeverityOverrides("LH0003=fatal"); return err },
+			wantInvalid: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.err()
+			if err == nil {
+				t.Fatal("error = nil, want error")
+			}
+			if got := errors.Is(err, ErrConfigInvalid); got != tt.wantInvalid {
+				t.Errorf("errors.Is(%v, ErrConfigInvalid) = %v, want %v", err, got, tt.wantInvalid)
+			}
+			var configErr *ConfigError
+			if errors.As(err, &configErr) && configErr.Path != tt.wantPath {
+				t.Errorf("ConfigError.Path = %q, want %q", configErr.Path, tt.wantPath)
+			}
+			if !tt.wantInvalid && !errors.Is(err, fs.ErrNotExist) {
+				t.Errorf("error = %v, want it to wrap fs.ErrNotExist", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package config
+
+import "fmt"
+
+// EventBuilderConfig configures the functions and methods attaching values
+// to structured events, such as (*audit.Event).WithDetail. A sensitive value
+// attached to an event taints the event, which is reported once it reaches
+// a log sink. Logged marks builders whose events are always logged, e.g. by
+// an event bus writing every event to a log: values attached to them are
+// reported at the attaching call instead.
+type EventBuilderConfig struct {
+	TargetConfig `yaml:",inline"`
+
+	Logged bool `yaml:"logged,omitempty"`
+}
+
+// WithLoggedEventBuilders returns c with the event builders configured as
+// eventually logged added to its targets, as their calls are log sinks. c is
+// returned as is when there are none.
+func (c *Config) WithLoggedEventBuilders() *Config {
+	var logged []TargetConfig
+	for _, builder := range c.EventBuilders {
+		if builder.Logged {
+			logged = append(logged, builder.TargetConfig)
+		}
+	}
+	if len(logged) == 0 {
+		return c
+	}
+	withBuilders := *c
+	withBuilders.Targets = append(append([]TargetConfig(nil), c.Targets...), logged...)
+	return &withBuilders
+}
+
+func validateEventBuilders(builders []EventBuilderConfig) error {
+	if len(builders) > maxTargets {
+		return fmt.Errorf("event-builders: too many entries: %d (max: %d)", len(builders), maxTargets)
+	}
+	for i, builder := range builders {
+		if err := validateTarget(i, &builder.TargetConfig); err != nil {
+			return fmt.Errorf("event-builders: %w", err)
+		}
+	}
+	return nil
+}
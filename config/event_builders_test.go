@@ -0,0 +1,69 @@
+package config
+
+import "testing"
+
+func TestValidateConfig_EventBuilders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		builder EventBuilderConfig
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			builder: EventBuilderConfig{
+				TargetConfig: TargetConfig{Package: "example.com/audit", Methods: []MethodConfig{{Receiver: "*Event", Names: []string{"WithDetail"}}}},
+			},
+		},
+		{
+			name:    "missing functions and methods",
+			builder: EventBuilderConfig{TargetConfig: TargetConfig{Package: "example.com/audit"}, Logged: true},
+			wantErr: true,
+		},
+		{
+			name:    "invalid package",
+			builder: EventBuilderConfig{TargetConfig: TargetConfig{Package: "Example.com/Audit", Functions: []string{"Detail"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{EventBuilders: []EventBuilderConfig{tt.builder}}
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_WithLoggedEventBuilders(t *testing.T) {
+	t.Parallel()
+
+	target := TargetConfig{Package: "log"}
+	event := TargetConfig{Package: "example.com/audit", Functions: []string{"Detail"}}
+	message := TargetConfig{Package: "example.com/bus", Functions: []string{"Attach"}}
+	cfg := &Config{
+		Targets: []TargetConfig{target},
+		EventBuilders: []EventBuilderConfig{
+			{TargetConfig: event},
+			{TargetConfig: message, Logged: true},
+		},
+	}
+
+	got := cfg.WithLoggedEventBuilders()
+	if len(got.Targets) != 2 || got.Targets[1].Package != message.Package {
+		t.Errorf("Targets = %+v, want the logged builder added", got.Targets)
+	}
+	if len(cfg.Targets) != 1 {
+		t.Errorf("original Targets = %+v, want them unchanged", cfg.Targets)
+	}
+
+	unlogged := &Config{EventBuilders: []EventBuilderConfig{{TargetConfig: event}}}
+	if unlogged.WithLoggedEventBuilders() != unlogged {
+		t.Errorf("WithLoggedEventBuilders() copied a config without logged builders")
+	}
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Actions taken on the findings whose data flow takes more hops than
+// max-flow-hops
+const (
+	LongFlowsWarning  = "warning"  // Report them at warning level (the default)
+	LongFlowsSuppress = "suppress" // Suppress them, like config-level suppressions
+)
+
+// FlowHopLimit returns the number of data flow hops a finding may take before
+// long-flows applies to it, 0 when max-flow-hops is unset, and the action,
+// LongFlowsWarning or LongFlowsSuppress
+func (c *Config) FlowHopLimit() (int, string) {
+	if c == nil || c.MaxFlowHops == 0 {
+		return 0, ""
+	}
+	if c.LongFlows == "" {
+		return c.MaxFlowHops, LongFlowsWarning
+	}
+	return c.MaxFlowHops, c.LongFlows
+}
+
+// ApplyMaxFlowHops overrides the config file's max-flow-hops setting with
+// value, the value of the command-line flag. An empty value keeps the config
+// value.
+func (c *Config) ApplyMaxFlowHops(value string) error {
+	if value == "" {
+		return nil
+	}
+	hops, err := strconv.Atoi(value)
+	if err == nil {
+		err = validateFlowHops(hops, "")
+	}
+	if err != nil {
+		return invalid("", fmt.Errorf("max-flow-hops: invalid value %q (must be a number of hops, 0 for no limit)", value))
+	}
+	c.MaxFlowHops = hops
+	return nil
+}
+
+// validateFlowHops validates the max-flow-hops and long-flows settings
+func validateFlowHops(hops int, action string) error {
+	if hops < 0 {
+		return fmt.Errorf("max-flow-hops must not be negative, got %d", hops)
+	}
+	switch action {
+	case "", LongFlowsWarning, LongFlowsSuppress:
+		return nil
+	}
+	return fmt.Errorf("long-flows: invalid value %q (valid values: %s, %s)", action, LongFlowsWarning, LongFlowsSuppress)
+}
@@ -0,0 +1,105 @@
+package config
+
+import "testing"
+
+func TestConfig_FlowHopLimit(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		cfg        *Config
+		wantHops   int
+		wantAction string
+	}{
+		{"nil config", nil, 0, ""},
+		{"unset", &Config{}, 0, ""},
+		{"default action", &Config{MaxFlowHops: 3}, 3, LongFlowsWarning},
+		{"suppress", &Config{MaxFlowHops: 3, LongFlows: LongFlowsSuppress}, 3, LongFlowsSuppress},
+		{"action without limit", &Config{LongFlows: LongFlowsSuppress}, 0, ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			hops, action := tt.cfg.FlowHopLimit()
+			if hops != tt.wantHops || action != tt.wantAction {
+				t.Errorf("FlowHopLimit() = %d, %q, want %d, %q", hops, action, tt.wantHops, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestConfig_ApplyMaxFlowHops(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{"", 5, false},
+		{"2", 2, false},
+		{"0", 0, false},
+		{"-1", 5, true},
+		{"many", 5, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{MaxFlowHops: 5}
+			err := cfg.ApplyMaxFlowHops(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyMaxFlowHops(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if cfg.MaxFlowHops != tt.want {
+				t.Errorf("ApplyMaxFlowHops(%q): MaxFlowHops = %d, want %d", tt.value, cfg.MaxFlowHops, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_FlowHops(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr bool
+	}{
+		{"valid", &Config{MaxFlowHops: 3, LongFlows: LongFlowsSuppress}, false},
+		{"negative hops", &Config{MaxFlowHops: -1}, true},
+		{"invalid action", &Config{MaxFlowHops: 3, LongFlows: "drop"}, true},
+		{"invalid override", &Config{Overrides: []OverrideConfig{{Packages: []string{"example.com/app"}, LongFlows: "drop"}}}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ValidateConfig(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestConfig_ForPackage_FlowHops(t *testing.T) {
+	t.Parallel()
+
+	hops := 2
+	cfg := &Config{
+		MaxFlowHops: 4,
+		Overrides: []OverrideConfig{
+			{Packages: []string{"example.com/app/auth/..."}, MaxFlowHops: &hops, LongFlows: LongFlowsSuppress},
+		},
+	}
+	if hops, action := cfg.ForPackage("example.com/app/auth", "").FlowHopLimit(); hops != 2 || action != LongFlowsSuppress {
+		t.Errorf("auth package: FlowHopLimit() = %d, %q, want 2, %q", hops, action, LongFlowsSuppress)
+	}
+	if hops, action := cfg.ForPackage("example.com/app/billing", "").FlowHopLimit(); hops != 4 || action != LongFlowsWarning {
+		t.Errorf("billing package: FlowHopLimit() = %d, %q, want 4, %q", hops, action, LongFlowsWarning)
+	}
+}
@@ -0,0 +1,23 @@
+package config
+
+import "fmt"
+
+// Report granularities for the report-granularity setting
+const (
+	GranularityArg  = "arg"  // One finding per tainted sink argument (default)
+	GranularityCall = "call" // One finding per sink call, listing every tainted argument
+)
+
+// ReportsPerCall reports whether findings are aggregated per sink call
+func (c *Config) ReportsPerCall() bool {
+	return c != nil && c.ReportGranularity == GranularityCall
+}
+
+// validateReportGranularity checks the report-granularity setting
+func validateReportGranularity(granularity string) error {
+	switch granularity {
+	case "", GranularityArg, GranularityCall:
+		return nil
+	}
+	return fmt.Errorf("report-granularity: invalid value %q (valid values: %s, %s)", granularity, GranularityArg, GranularityCall)
+}
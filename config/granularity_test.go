@@ -0,0 +1,68 @@
+package config
+
+import "testing"
+
+func TestConfig_ReportsPerCall(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		granularity string
+		want        bool
+	}{
+		{"default", "", false},
+		{"arg", GranularityArg, false},
+		{"call", GranularityCall, true},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{ReportGranularity: tt.granularity}
+			if got := cfg.ReportsPerCall(); got != tt.want {
+				t.Errorf("ReportsPerCall() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var nilCfg *Config
+	if nilCfg.ReportsPerCall() {
+		t.Errorf("nil config: ReportsPerCall() = true, want false")
+	}
+}
+
+func TestValidateConfig_ReportGranularity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		granularity string
+		wantErr     bool
+	}{
+		{"", false},
+		{"arg", false},
+		{"call", false},
+		{"statement", true},
+		{"Call", true},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.granularity, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateConfig(&Config{ReportGranularity: tt.granularity})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_ReportGranularity(t *testing.T) {
+	tmpFile := createTempConfigFile(t, "report-granularity: call\n")
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	if !cfg.ReportsPerCall() {
+		t.Errorf("ReportsPerCall() = false, want true")
+	}
+}
@@ -0,0 +1,53 @@
+package config
+
+import "fmt"
+
+// DefaultHookForwarders are the external sinks the implementations of logger
+// hooks are checked against: the net/http client, which ships entries to
+// log collectors and chat webhooks
+var DefaultHookForwarders = []TargetConfig{
+	{
+		Package:   "net/http",
+		Functions: []string{"Post", "PostForm", "NewRequest", "NewRequestWithContext"},
+		Methods:   []MethodConfig{{Receiver: "*Client", Names: []string{"Do", "Post", "PostForm"}}},
+	},
+}
+
+// HookConfig configures the registration points of logger hooks, such as
+// logrus.AddHook or zap.Hooks (LH0016). A hook sees every entry logged, so
+// one forwarding entries to an external sink, an HTTP client or a Kafka
+// producer for instance, sends whatever sensitive data is logged out of the
+// process, past the redaction of the log pipeline.
+type HookConfig struct {
+	Registrations []TargetConfig `yaml:"registrations,omitempty"` // Functions and methods registering hooks
+	Forwarders    []TargetConfig `yaml:"forwarders,omitempty"`    // External sinks honored in addition to DefaultHookForwarders
+}
+
+// HookForwarders returns the external sinks hooks are checked against: the
+// defaults followed by any configured ones
+func (c *Config) HookForwarders() []TargetConfig {
+	if c == nil {
+		return DefaultHookForwarders
+	}
+	return append(append([]TargetConfig{}, DefaultHookForwarders...), c.Hooks.Forwarders...)
+}
+
+func validateHooks(hooks HookConfig) error {
+	for _, section := range []struct {
+		name    string
+		targets []TargetConfig
+	}{
+		{"hooks.registrations", hooks.Registrations},
+		{"hooks.forwarders", hooks.Forwarders},
+	} {
+		if len(section.targets) > maxTargets {
+			return fmt.Errorf("%s: too many entries: %d (max: %d)", section.name, len(section.targets), maxTargets)
+		}
+		for i, target := range section.targets {
+			if err := validateTarget(i, &target); err != nil {
+				return fmt.Errorf("%s: %w", section.name, err)
+			}
+		}
+	}
+	return nil
+}
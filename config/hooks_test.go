@@ -0,0 +1,50 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfig_HookForwarders(t *testing.T) {
+	t.Parallel()
+
+	producer := TargetConfig{
+		Package: "github.com/segmentio/kafka-go",
+		Methods: []MethodConfig{{Receiver: "*Writer", Names: []string{"WriteMessages"}}},
+	}
+	cfg := &Config{Hooks: HookConfig{Forwarders: []TargetConfig{producer}}}
+
+	want := append(append([]TargetConfig{}, DefaultHookForwarders...), producer)
+	if got := cfg.HookForwarders(); !reflect.DeepEqual(got, want) {
+		t.Errorf("HookForwarders() = %+v, want %+v", got, want)
+	}
+	if got := (*Config)(nil).HookForwarders(); !reflect.DeepEqual(got, DefaultHookForwarders) {
+		t.Errorf("nil config: HookForwarders() = %+v, want the defaults", got)
+	}
+}
+
+func TestValidateConfig_Hooks(t *testing.T) {
+	t.Parallel()
+
+	logrus := TargetConfig{Package: "github.com/sirupsen/logrus", Functions: []string{"AddHook"}}
+	tests := []struct {
+		name    string
+		hooks   HookConfig
+		wantErr bool
+	}{
+		{"valid", HookConfig{Registrations: []TargetConfig{logrus}}, false},
+		{"invalid registration", HookConfig{Registrations: []TargetConfig{{Package: "github.com/sirupsen/logrus", Functions: []string{"Add Hook"}}}}, true},
+		{"invalid forwarder", HookConfig{Forwarders: []TargetConfig{{Package: "", Functions: []string{"Publish"}}}}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Hooks: tt.hooks}
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
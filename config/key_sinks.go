@@ -0,0 +1,45 @@
+package config
+
+import "fmt"
+
+// KeySinkConfig configures functions whose arguments become cache keys or
+// metric names (LH0008). Such keys routinely show up in infrastructure logs
+// and dashboards, so sensitive data must not be built into them.
+type KeySinkConfig struct {
+	TargetConfig `yaml:",inline"`
+
+	// KeyArgs are the 0-based indexes of the key arguments (receiver
+	// excluded), e.g. [1] for rdb.Set(ctx, key, value, ttl). Every argument
+	// is a key when empty, as for WithLabelValues(values...).
+	KeyArgs []int `yaml:"key-args,omitempty"`
+}
+
+// IsKeyArg reports whether argument i of a call to the sink is a key
+func (k KeySinkConfig) IsKeyArg(i int) bool {
+	if len(k.KeyArgs) == 0 {
+		return true
+	}
+	for _, arg := range k.KeyArgs {
+		if arg == i {
+			return true
+		}
+	}
+	return false
+}
+
+func validateKeySinks(sinks []KeySinkConfig) error {
+	if len(sinks) > maxTargets {
+		return fmt.Errorf("key-sinks: too many entries: %d (max: %d)", len(sinks), maxTargets)
+	}
+	for i, sink := range sinks {
+		if err := validateTarget(i, &sink.TargetConfig); err != nil {
+			return fmt.Errorf("key-sinks: %w", err)
+		}
+		for _, arg := range sink.KeyArgs {
+			if arg < 0 {
+				return fmt.Errorf("key-sinks: target[%d] (%s): key-args must not be negative: %d", i, sink.Package, arg)
+			}
+		}
+	}
+	return nil
+}
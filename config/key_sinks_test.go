@@ -0,0 +1,93 @@
+package config
+
+import "testing"
+
+func TestKeySinkConfig_IsKeyArg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		keyArgs []int
+		arg     int
+		want    bool
+	}{
+		{"listed", []int{1}, 1, true},
+		{"not listed", []int{1}, 0, false},
+		{"every argument by default", nil, 3, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := (KeySinkConfig{KeyArgs: tt.keyArgs}).IsKeyArg(tt.arg); got != tt.want {
+				t.Errorf("IsKeyArg(%d) = %v, want %v", tt.arg, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_KeySinks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		sink    KeySinkConfig
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			sink: KeySinkConfig{
+				TargetConfig: TargetConfig{Package: "github.com/redis/go-redis/v9", Methods: []MethodConfig{{Receiver: "*Client", Names: []string{"Set"}}}},
+				KeyArgs:      []int{1},
+			},
+		},
+		{
+			name:    "missing functions and methods",
+			sink:    KeySinkConfig{TargetConfig: TargetConfig{Package: "github.com/redis/go-redis/v9"}},
+			wantErr: true,
+		},
+		{
+			name: "negative key arg",
+			sink: KeySinkConfig{
+				TargetConfig: TargetConfig{Package: "example.com/cache", Functions: []string{"Set"}},
+				KeyArgs:      []int{-1},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{KeySinks: []KeySinkConfig{tt.sink}}
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_KeySinks(t *testing.T) {
+	yaml := `key-sinks:
+  - package: "github.com/redis/go-redis/v9"
+    methods:
+      - receiver: "*Client"
+        names:
+          - "Set"
+    key-args: [1]
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	if len(cfg.KeySinks) != 1 {
+		t.Fatalf("KeySinks = %+v, want 1 entry", cfg.KeySinks)
+	}
+	sink := cfg.KeySinks[0]
+	if sink.Package != "github.com/redis/go-redis/v9" || len(sink.Methods) != 1 || !sink.IsKeyArg(1) || sink.IsKeyArg(2) {
+		t.Errorf("KeySinks[0] = %+v, want go-redis *Client.Set keyed by argument 1", sink)
+	}
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// memoryUnits are the suffixes accepted by ParseMemorySize, longest first so
+// that "MiB" is not read as "B"
+var memoryUnits = []struct {
+	suffix string
+	bytes  int64
+}{
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"KB", 1000},
+	{"MB", 1000 * 1000},
+	{"GB", 1000 * 1000 * 1000},
+	{"B", 1},
+}
+
+// ParseMemorySize parses a size such as "512MiB", "2GB" or "1048576" (bytes)
+// for the max-memory setting. An empty string parses to 0, no budget.
+func ParseMemorySize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	number, unit := s, int64(1)
+	for _, u := range memoryUnits {
+		if rest, ok := strings.CutSuffix(s, u.suffix); ok {
+			number, unit = strings.TrimSpace(rest), u.bytes
+			break
+		}
+	}
+	n, err := strconv.ParseInt(number, 10, 64)
+	if err != nil || n <= 0 || n > (1<<62)/unit {
+		return 0, fmt.Errorf("invalid memory size %q (e.g. 512MiB, 2GB or a number of bytes)", s)
+	}
+	return n * unit, nil
+}
+
+// FormatMemorySize renders a number of bytes with a binary unit, e.g. "1.5MiB"
+func FormatMemorySize(bytes int64) string {
+	units := []string{"KiB", "MiB", "GiB"}
+	if bytes < 1<<10 {
+		return fmt.Sprintf("%dB", bytes)
+	}
+	size, unit := float64(bytes)/(1<<10), units[0]
+	for _, u := range units[1:] {
+		if size < 1<<10 {
+			break
+		}
+		size, unit = size/(1<<10), u
+	}
+	return strconv.FormatFloat(size, 'f', 1, 64) + unit
+}
+
+// MemoryBudget returns the max-memory budget in bytes for the data flow facts
+// tracked per package, or 0 when there is none. The setting is validated when
+// the config is loaded.
+func (c *Config) MemoryBudget() int64 {
+	if c == nil {
+		return 0
+	}
+	budget, _ := ParseMemorySize(c.MaxMemory)
+	return budget
+}
+
+// ApplyMaxMemory overrides the config file's max-memory setting with size,
+// the value of the command-line flag. An empty size keeps the config value.
+func (c *Config) ApplyMaxMemory(size string) error {
+	if size == "" {
+		return nil
+	}
+	if _, err := ParseMemorySize(size); err != nil {
+		return invalid("", fmt.Errorf("max-memory: %w", err))
+	}
+	c.MaxMemory = size
+	return nil
+}
+
+// validateMaxMemory checks the max-memory setting
+func validateMaxMemory(size string) error {
+	if _, err := ParseMemorySize(size); err != nil {
+		return fmt.Errorf("max-memory: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package config
+
+import "testing"
+
+func TestParseMemorySize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		size    string
+		want    int64
+		wantErr bool
+	}{
+		{"", 0, false},
+		{"1048576", 1 << 20, false},
+		{"512B", 512, false},
+		{"64KiB", 64 << 10, false},
+		{"512MiB", 512 << 20, false},
+		{"2GiB", 2 << 30, false},
+		{"10KB", 10000, false},
+		{"3MB", 3000000, false},
+		{"2GB", 2000000000, false},
+		{" 4 GiB ", 4 << 30, false},
+		{"0", 0, true},
+		{"-1MiB", 0, true},
+		{"1.5GiB", 0, true},
+		{"MiB", 0, true},
+		{"1TiB", 0, true},
+		{"9999999999GiB", 0, true},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.size, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseMemorySize(tt.size)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseMemorySize(%q) error = %v, wantErr %v", tt.size, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseMemorySize(%q) = %d, want %d", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatMemorySize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		bytes int64
+		want  string
+	}{
+		{0, "0B"},
+		{1023, "1023B"},
+		{1 << 10, "1.0KiB"},
+		{1536 << 10, "1.5MiB"},
+		{3 << 30, "3.0GiB"},
+		{4096 << 30, "4096.0GiB"},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.want, func(t *testing.T) {
+			t.Parallel()
+			if got := FormatMemorySize(tt.bytes); got != tt.want {
+				t.Errorf("FormatMemorySize(%d) = %q, want %q", tt.bytes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_MaxMemory(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{MaxMemory: "512MiB"}
+	if err := ValidateConfig(cfg); err != nil {
+		t.Fatalf("ValidateConfig() error = %v", err)
+	}
+	if got := cfg.MemoryBudget(); got != 512<<20 {
+		t.Errorf("MemoryBudget() = %d, want %d", got, 512<<20)
+	}
+
+	// The command-line flag overrides the config file, unless it is empty
+	if err := cfg.ApplyMaxMemory(""); err != nil || cfg.MemoryBudget() != 512<<20 {
+		t.Errorf("ApplyMaxMemory(\"\") = %v, budget %d, want the config value kept", err, cfg.MemoryBudget())
+	}
+	if err := cfg.ApplyMaxMemory("1GiB"); err != nil || cfg.MemoryBudget() != 1<<30 {
+		t.Errorf("ApplyMaxMemory(\"1GiB\") = %v, budget %d, want %d", err, cfg.MemoryBudget(), 1<<30)
+	}
+	if err := cfg.ApplyMaxMemory("lots"); err == nil {
+		t.Errorf("ApplyMaxMemory(\"lots\") succeeded, want an error")
+	}
+
+	if err := ValidateConfig(&Config{MaxMemory: "lots"}); err == nil {
+		t.Errorf("ValidateConfig() accepted max-memory %q", "lots")
+	}
+
+	var nilCfg *Config
+	if got := nilCfg.MemoryBudget(); got != 0 {
+		t.Errorf("nil config: MemoryBudget() = %d, want 0", got)
+	}
+}
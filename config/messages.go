@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"text/template"
+)
+
+// MessageTemplateFields are the values a rules.<ID>.message template can
+// refer to, e.g. {{.Field}}. Flow is the flow path on one line, and
+// FlowPath its steps.
+var MessageTemplateFields = map[string]any{
+	"Message":  "",         // Default message of the finding
+	"Rule":     "",         // SARIF rule ID, e.g. "LH0004"
+	"RuleName": "",         // Rule name, e.g. "SensitiveFieldLogged"
+	"Severity": "",         // error, warning or note
+	"Field":    "",         // Sensitive field, e.g. "User.Password"
+	"Type":     "",         // Struct type declaring Field, e.g. "User"
+	"Sink":     "",         // Sink function, e.g. "slog.Info"
+	"Arg":      0,          // 1-based sink argument, 0 if unknown
+	"Expr":     "",         // Offending expression, e.g. "u.Password"
+	"Func":     "",         // Enclosing function of the sink call
+	"Flow":     "",         // e.g. "User.Password → password → slog.Info"
+	"FlowPath": []string{}, // Labels of the flow steps
+}
+
+// ParseMessageTemplate parses a rules.<ID>.message template. Referring to a
+// value missing from MessageTemplateFields is an error when it is executed.
+func ParseMessageTemplate(ruleID, text string) (*template.Template, error) {
+	return template.New(ruleID).Option("missingkey=error").Parse(text)
+}
+
+// RuleMessage returns the configured message template for a SARIF rule ID,
+// falling back to the "all" entry. It returns "" when neither is set.
+func (c *Config) RuleMessage(ruleID string) string {
+	if c == nil {
+		return ""
+	}
+	if m := c.Rules[ruleID].Message; m != "" {
+		return m
+	}
+	return c.Rules[AllRules].Message
+}
+
+// validateMessageTemplate parses a message template and executes it with
+// empty values, so unknown names fail when loading the config rather than
+// when reporting a finding
+func validateMessageTemplate(ruleID, text string) error {
+	tmpl, err := ParseMessageTemplate(ruleID, text)
+	if err != nil {
+		return fmt.Errorf("rules.%s: invalid message template: %w", ruleID, err)
+	}
+	if err := tmpl.Execute(io.Discard, MessageTemplateFields); err != nil {
+		return fmt.Errorf("rules.%s: invalid message template: %w", ruleID, err)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import "testing"
+
+func TestValidateConfig_RuleMessages(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		wantErr bool
+	}{
+		{"default message", "{{.Message}}", false},
+		{"every field", "{{.Rule}} {{.RuleName}} {{.Severity}} {{.Field}} {{.Type}} {{.Sink}} {{.Arg}} {{.Expr}} {{.Func}} {{.Flow}} {{range .FlowPath}}{{.}}{{end}}", false},
+		{"plain text", "see the runbook", false},
+		{"syntax error", "{{.Message", true},
+		{"unknown field", "{{.Owner}}", true},
+		{"field of a string", "{{.Field.Name}}", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfig(&Config{Rules: map[string]RuleConfig{"LH0004": {Message: tt.message}}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_RuleMessages(t *testing.T) {
+	yaml := `rules:
+  LH0004:
+    message: "{{.Message}} (runbook: https://wiki.example.com/leaks#{{.Rule}})"
+  all:
+    message: "{{.Message}}, see SEC-123"
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	if got, want := cfg.RuleMessage("LH0004"), "{{.Message}} (runbook: https://wiki.example.com/leaks#{{.Rule}})"; got != want {
+		t.Errorf("RuleMessage(LH0004) = %q, want %q", got, want)
+	}
+	if got, want := cfg.RuleMessage("LH0001"), "{{.Message}}, see SEC-123"; got != want {
+		t.Errorf("RuleMessage(LH0001) = %q, want %q", got, want)
+	}
+
+	// A severity override for all rules keeps the templates
+	cfg.ApplySeverityOverrides(map[string]string{"all": "warning"})
+	if got, want := cfg.RuleMessage("LH0001"), "{{.Message}}, see SEC-123"; got != want {
+		t.Errorf("RuleMessage(LH0001) after override = %q, want %q", got, want)
+	}
+
+	var nilCfg *Config
+	if got := nilCfg.RuleMessage("LH0001"); got != "" {
+		t.Errorf("nil config: RuleMessage() = %q, want \"\"", got)
+	}
+}
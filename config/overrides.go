@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// OverrideConfig applies its reporting settings to the findings in the
+// packages it matches, on top of the top-level settings, the command-line
+// flags included. One invocation can thus hold different service tiers of a
+// monorepo to different standards:
+//
+//	overrides:
+//	  - packages: ["./services/auth/..."]
+//	    rules:
+//	      all: {severity: error}
+//	  - packages: ["./services/billing/..."]
+//	    min-confidence: high
+//	    max-flow-hops: 2
+//	    suppress:
+//	      rules: ["LH0007"]
+//
+// Detection settings, such as targets or audits, apply to the whole program
+// and cannot be overridden.
+type OverrideConfig struct {
+	// Packages are matched like target packages (see MatchPackage). A pattern
+	// starting with "./" is matched against the package directory relative
+	// to the working directory instead, as in "./services/auth/...".
+	Packages      []string              `yaml:"packages"`
+	Rules         map[string]RuleConfig `yaml:"rules,omitempty"`          // Merged into the top-level rules; "all" clears the rules' settings it sets
+	Suppress      SuppressConfig        `yaml:"suppress,omitempty"`       // Added to the top-level suppressions
+	MinConfidence string                `yaml:"min-confidence,omitempty"` // Replaces the top-level min-confidence
+	MaxFlowHops   *int                  `yaml:"max-flow-hops,omitempty"`  // Replaces the top-level max-flow-hops when set; 0 lifts the limit
+	LongFlows     string                `yaml:"long-flows,omitempty"`     // Replaces the top-level long-flows
+}
+
+// Matches reports whether the override applies to the package with import
+// path pkgPath in directory dir, "./" followed by its path relative to the
+// working directory ("." for the working directory itself)
+func (o OverrideConfig) Matches(pkgPath, dir string) bool {
+	for _, pattern := range o.Packages {
+		if isDirPattern(pattern) {
+			if dir != "" && MatchPackage(pattern, dir) {
+				return true
+			}
+		} else if MatchPackage(pattern, pkgPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// OverrideDir returns dir, a package directory, as matched by the override
+// patterns starting with "./": "./" followed by its path relative to workDir,
+// "." for workDir itself, or "" when it is outside workDir
+func OverrideDir(workDir, dir string) string {
+	rel, err := filepath.Rel(workDir, dir)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	if rel == "." {
+		return "."
+	}
+	return "./" + filepath.ToSlash(rel)
+}
+
+// isDirPattern reports whether an override package pattern is a directory
+// relative to the working directory
+func isDirPattern(pattern string) bool {
+	return pattern == "." || strings.HasPrefix(pattern, "./")
+}
+
+// ForPackage returns the config of the package with import path pkgPath in
+// directory dir (see OverrideConfig.Matches): c with the overrides matching
+// the package applied in order, or c itself when none does. c is left
+// unchanged.
+func (c *Config) ForPackage(pkgPath, dir string) *Config {
+	if c == nil {
+		return nil
+	}
+	var pkgCfg *Config
+	for _, o := range c.Overrides {
+		if !o.Matches(pkgPath, dir) {
+			continue
+		}
+		if pkgCfg == nil {
+			copied := *c
+			copied.Rules = maps.Clone(c.Rules)
+			copied.Suppress.Rules = slices.Clone(c.Suppress.Rules)
+			pkgCfg = &copied
+		}
+		pkgCfg.applyOverride(o)
+	}
+	if pkgCfg == nil {
+		return c
+	}
+	return pkgCfg
+}
+
+// applyOverride applies the settings of o to c, whose maps and slices must
+// not be shared
+func (c *Config) applyOverride(o OverrideConfig) {
+	if len(o.Rules) > 0 && c.Rules == nil {
+		c.Rules = make(map[string]RuleConfig)
+	}
+	if all, ok := o.Rules[AllRules]; ok {
+		for ruleID, rule := range c.Rules {
+			c.Rules[ruleID] = rule.without(all)
+		}
+	}
+	for ruleID, rule := range o.Rules {
+		c.Rules[ruleID] = c.Rules[ruleID].merge(rule)
+	}
+	c.Suppress.Rules = append(c.Suppress.Rules, o.Suppress.Rules...)
+	if o.MinConfidence != "" {
+		c.MinConfidence = o.MinConfidence
+	}
+	if o.MaxFlowHops != nil {
+		c.MaxFlowHops = *o.MaxFlowHops
+	}
+	if o.LongFlows != "" {
+		c.LongFlows = o.LongFlows
+	}
+}
+
+// merge returns r with the settings of o that are set
+func (r RuleConfig) merge(o RuleConfig) RuleConfig {
+	if o.Severity != "" {
+		r.Severity = o.Severity
+	}
+	if o.SecuritySeverity != nil {
+		r.SecuritySeverity = o.SecuritySeverity
+	}
+	if o.Rank != nil {
+		r.Rank = o.Rank
+	}
+	if o.Message != "" {
+		r.Message = o.Message
+	}
+	return r
+}
+
+// without returns r with the settings of o that are set cleared, so the
+// settings of an overriding "all" entry apply to every rule
+func (r RuleConfig) without(o RuleConfig) RuleConfig {
+	if o.Severity != "" {
+		r.Severity = ""
+	}
+	if o.SecuritySeverity != nil {
+		r.SecuritySeverity = nil
+	}
+	if o.Rank != nil {
+		r.Rank = nil
+	}
+	if o.Message != "" {
+		r.Message = ""
+	}
+	return r
+}
+
+func validateOverrides(overrides []OverrideConfig) error {
+	if len(overrides) > maxTargets {
+		return fmt.Errorf("overrides: too many entries: %d (max: %d)", len(overrides), maxTargets)
+	}
+	for i, o := range overrides {
+		if len(o.Packages) == 0 {
+			return fmt.Errorf("overrides[%d]: packages must not be empty", i)
+		}
+		for _, pattern := range o.Packages {
+			if err := validatePackagePattern(pattern); err != nil {
+				return fmt.Errorf("overrides[%d]: %w", i, err)
+			}
+		}
+		// The settings are validated like the top-level ones
+		sub := &Config{Rules: o.Rules, Suppress: o.Suppress, MinConfidence: o.MinConfidence, LongFlows: o.LongFlows}
+		if o.MaxFlowHops != nil {
+			sub.MaxFlowHops = *o.MaxFlowHops
+		}
+		if err := validateConfig(sub); err != nil {
+			return fmt.Errorf("overrides[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
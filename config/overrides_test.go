@@ -0,0 +1,204 @@
+package config
+
+import "testing"
+
+func TestOverrideConfig_Matches(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		packages []string
+		pkgPath  string
+		dir      string
+		want     bool
+	}{
+		{"import path", []string{"example.com/mono/services/auth/..."}, "example.com/mono/services/auth/api", "./services/auth/api", true},
+		{"directory", []string{"./services/auth/..."}, "example.com/mono/services/auth", "./services/auth", true},
+		{"working directory", []string{"./..."}, "example.com/mono", ".", true},
+		{"other directory", []string{"./services/auth/..."}, "example.com/mono/services/billing", "./services/billing", false},
+		{"directory outside the working directory", []string{"./..."}, "example.com/other", "", false},
+		{"directory pattern is not an import path", []string{"./services/auth"}, "./services/auth", "", false},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := (OverrideConfig{Packages: tt.packages}).Matches(tt.pkgPath, tt.dir); got != tt.want {
+				t.Errorf("Matches(%q, %q) = %v, want %v", tt.pkgPath, tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOverrideDir(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"/repo", "."},
+		{"/repo/services/auth", "./services/auth"},
+		{"/other", ""},
+		{"/repo-fork/services", ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.dir, func(t *testing.T) {
+			t.Parallel()
+			if got := OverrideDir("/repo", tt.dir); got != tt.want {
+				t.Errorf("OverrideDir(%q) = %q, want %q", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_ForPackage(t *testing.T) {
+	t.Parallel()
+
+	high := 9.0
+	cfg := &Config{
+		Rules: map[string]RuleConfig{
+			"LH0007": {Severity: "note"},
+			"LH0004": {SecuritySeverity: &high},
+		},
+		Suppress:      SuppressConfig{Rules: []string{"LH0013"}},
+		MinConfidence: "low",
+		Overrides: []OverrideConfig{
+			{
+				Packages: []string{"./services/auth/..."},
+				Rules:    map[string]RuleConfig{AllRules: {Severity: "error"}},
+			},
+			{
+				Packages:      []string{"./services/..."},
+				Suppress:      SuppressConfig{Rules: []string{"LH0007"}},
+				MinConfidence: "high",
+			},
+		},
+	}
+
+	if got := cfg.ForPackage("example.com/mono/tools", "./tools"); got != cfg {
+		t.Errorf("ForPackage() without matching overrides = %p, want the config itself", got)
+	}
+
+	auth := cfg.ForPackage("example.com/mono/services/auth", "./services/auth")
+	if got := auth.RuleSeverity("LH0007"); got != "error" {
+		t.Errorf("auth: RuleSeverity(LH0007) = %q, want error from the overriding all entry", got)
+	}
+	if got, ok := auth.RuleSecuritySeverity("LH0004"); !ok || got != high {
+		t.Errorf("auth: RuleSecuritySeverity(LH0004) = %v, %v, want the top-level %v", got, ok, high)
+	}
+	if got := auth.Suppress.Rules; len(got) != 2 || got[0] != "LH0013" || got[1] != "LH0007" {
+		t.Errorf("auth: Suppress.Rules = %v, want [LH0013 LH0007]", got)
+	}
+	if auth.MinConfidence != "high" {
+		t.Errorf("auth: MinConfidence = %q, want high", auth.MinConfidence)
+	}
+
+	// The top-level config is left unchanged
+	if got := cfg.RuleSeverity("LH0007"); got != "note" {
+		t.Errorf("RuleSeverity(LH0007) = %q after ForPackage, want note", got)
+	}
+	if len(cfg.Suppress.Rules) != 1 || cfg.MinConfidence != "low" {
+		t.Errorf("config changed by ForPackage: %+v", cfg)
+	}
+}
+
+func TestValidateConfig_Overrides(t *testing.T) {
+	t.Parallel()
+
+	negative := -1
+
+	tests := []struct {
+		name     string
+		override OverrideConfig
+		wantErr  bool
+	}{
+		{"valid", OverrideConfig{Packages: []string{"./services/auth/..."}, MinConfidence: "high"}, false},
+		{"no packages", OverrideConfig{MinConfidence: "high"}, true},
+		{"invalid package", OverrideConfig{Packages: []string{"./.../auth"}}, true},
+		{"invalid rule", OverrideConfig{Packages: []string{"./..."}, Rules: map[string]RuleConfig{"LH9999": {Severity: "error"}}}, true},
+		{"invalid severity", OverrideConfig{Packages: []string{"./..."}, Rules: map[string]RuleConfig{AllRules: {Severity: "fatal"}}}, true},
+		{"invalid min-confidence", OverrideConfig{Packages: []string{"./..."}, MinConfidence: "certain"}, true},
+		{"invalid suppressed rule", OverrideConfig{Packages: []string{"./..."}, Suppress: SuppressConfig{Rules: []string{"LH9999"}}}, true},
+		{"negative max-flow-hops", OverrideConfig{Packages: []string{"./..."}, MaxFlowHops: &negative}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Overrides: []OverrideConfig{tt.override}}
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Overrides(t *testing.T) {
+	yaml := `overrides:
+  - packages: ["./services/auth/...", "example.com/mono/services/session"]
+    rules:
+      all:
+        severity: error
+    min-confidence: low
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	session := cfg.ForPackage("example.com/mono/services/session", "./services/session")
+	if got := session.RuleSeverity("LH0001"); got != "error" {
+		t.Errorf("RuleSeverity(LH0001) = %q, want error", got)
+	}
+}
+
+func TestConfig_ForPackage_MaxFlowHops(t *testing.T) {
+	t.Parallel()
+
+	noLimit := 0
+	cfg := &Config{
+		MaxFlowHops: 3,
+		Overrides: []OverrideConfig{
+			{Packages: []string{"./tools/..."}, MaxFlowHops: &noLimit},
+			{Packages: []string{"./services/..."}, MinConfidence: "high"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		pkgPath string
+		dir     string
+		want    int
+	}{
+		{"set to 0 lifts the limit", "example.com/mono/tools/gen", "./tools/gen", 0},
+		{"unset keeps the top-level limit", "example.com/mono/services/auth", "./services/auth", 3},
+		{"no override", "example.com/mono/cmd", "./cmd", 3},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := cfg.ForPackage(tt.pkgPath, tt.dir).MaxFlowHops; got != tt.want {
+				t.Errorf("ForPackage(%q).MaxFlowHops = %d, want %d", tt.pkgPath, got, tt.want)
+			}
+		})
+	}
+
+	yaml := `max-flow-hops: 3
+overrides:
+  - packages: ["./tools/..."]
+    max-flow-hops: 0
+`
+	loaded, err := LoadConfig(createTempConfigFile(t, yaml))
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	if got := loaded.ForPackage("example.com/mono/tools/gen", "./tools/gen").MaxFlowHops; got != 0 {
+		t.Errorf("loaded: ForPackage().MaxFlowHops = %d, want 0 from max-flow-hops: 0", got)
+	}
+}
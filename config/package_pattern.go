@@ -0,0 +1,70 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// MatchPackage reports whether the import path pkgPath matches pattern, the
+// package of a target. A pattern without "*" matches its exact path only; in
+// a glob, "*" matches any run of characters, "/" included, so forks published
+// under several module paths can share a target: "github.com/org/zap*"
+// matches "github.com/org/zap", "github.com/org/zapfork" and
+// "github.com/org/zap/v2". Like a go command pattern, a trailing "/..."
+// matches the package and all its subpackages: "example.com/app/log/..."
+// matches "example.com/app/log" and "example.com/app/log/audit" but not
+// "example.com/app/logger".
+func MatchPackage(pattern, pkgPath string) bool {
+	if base, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return MatchPackage(base, pkgPath) || MatchPackage(base+"/*", pkgPath)
+	}
+	if !strings.Contains(pattern, "*") {
+		return pattern == pkgPath
+	}
+	parts := strings.Split(pattern, "*")
+	rest, ok := strings.CutPrefix(pkgPath, parts[0])
+	if !ok {
+		return false
+	}
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	return len(rest) >= len(last) && strings.HasSuffix(rest, last)
+}
+
+// IsPackageGlob reports whether pattern, the package of a target, is a glob
+// matching several import paths
+func IsPackageGlob(pattern string) bool {
+	return strings.Contains(pattern, "*") || strings.HasSuffix(pattern, "/...")
+}
+
+// MatchesPackage reports whether the target applies to the package with
+// import path pkgPath (see MatchPackage)
+func (t TargetConfig) MatchesPackage(pkgPath string) bool {
+	return MatchPackage(t.Package, pkgPath)
+}
+
+// packagePatternPattern matches target packages: a package path, possibly
+// followed by "*" globs. A glob must start with a literal path so it cannot
+// match every package, the standard library's loggers included. As in module
+// paths, only the first element must be lower case, so github.com/IBM/sarama
+// is a valid package.
+var packagePatternPattern = regexp.MustCompile(`^[a-z0-9._\-]+[a-z0-9._\-*]*(?:/[A-Za-z0-9._\-*]*)*$`)
+
+// validatePackagePattern validates a target package, a package path that may
+// contain "*" globs and end in "/..."
+func validatePackagePattern(pattern string) error {
+	if !packagePatternPattern.MatchString(pattern) {
+		return fmt.Errorf("invalid package path: %s (must match pattern: %s)", pattern, packagePatternPattern.String())
+	}
+	if base, _ := strings.CutSuffix(pattern, "/..."); base == "" || strings.Contains(base, "...") {
+		return fmt.Errorf("invalid package path: %s (\"...\" is only allowed as a trailing \"/...\")", pattern)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package config
+
+import "testing"
+
+func TestMatchPackage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		pkgPath string
+		want    bool
+	}{
+		{"go.uber.org/zap", "go.uber.org/zap", true},
+		{"go.uber.org/zap", "go.uber.org/zap/zapcore", false},
+		{"log", "example.com/app/log", false},
+		{"github.com/org/zap*", "github.com/org/zap", true},
+		{"github.com/org/zap*", "github.com/org/zapfork", true},
+		{"github.com/org/zap*", "github.com/org/zap/v2", true},
+		{"github.com/org/zap*", "github.com/other/zap", false},
+		{"github.com/org/zap*", "github.com/org/za", false},
+		{"github.com/*/zap", "github.com/org/zap", true},
+		{"github.com/*/zap", "github.com/org/zap/v2", false},
+		{"github.com/*/zap*/logger", "github.com/org/zapfork/logger", true},
+		{"github.com/*/zap*/logger", "github.com/org/zapfork", false},
+		{"github.com/org/zap*zap", "github.com/org/zap", false},
+		{"example.com/app/log/...", "example.com/app/log", true},
+		{"example.com/app/log/...", "example.com/app/log/audit", true},
+		{"example.com/app/log/...", "example.com/app/log/audit/v2", true},
+		{"example.com/app/log/...", "example.com/app/logger", false},
+		{"example.com/app/log/...", "example.com/app", false},
+		{"example.com/*/log/...", "example.com/billing/log/audit", true},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.pattern+" "+tt.pkgPath, func(t *testing.T) {
+			t.Parallel()
+			if got := MatchPackage(tt.pattern, tt.pkgPath); got != tt.want {
+				t.Errorf("MatchPackage(%q, %q) = %v, want %v", tt.pattern, tt.pkgPath, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePackagePattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{"go.uber.org/zap", false},
+		{"github.com/org/zap*", false},
+		{"github.com/*/zap", false},
+		{"example.com/app/log/...", false},
+		{"example.com/*/log/...", false},
+		{"github.com/IBM/sarama", false},
+		{"*", true},
+		{"/...", true},
+		{"example.com/.../log", true},
+		{"*/zap", true},
+		{"GitHub.com/org/zap*", true},
+		{"github.com/org/zap?", true},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.pattern, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateConfig(&Config{Targets: []TargetConfig{{Package: tt.pattern, Functions: []string{"Info"}}}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() with package %q error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+			}
+		})
+	}
+}
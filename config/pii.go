@@ -0,0 +1,44 @@
+package config
+
+// DefaultPIINamePatterns are the field-name heuristics for personal data
+// used when PII mode is enabled without explicit patterns. They are matched
+// case-insensitively against Go field names.
+var DefaultPIINamePatterns = []string{
+	`e_?mail`,
+	`^ssn$`,
+	`social_?security`,
+	`phone`,
+	`^mobile`,
+	`^dob$`,
+	`birth_?date`,
+	`date_?of_?birth`,
+	`birthday`,
+}
+
+// PIIConfig configures the opt-in PII mode, which reports personal data
+// reaching a sink under its own rule (LH0009), separately from secrets.
+// Fields tagged pii:"true" are personal data, and so are untagged fields
+// whose names match the patterns.
+type PIIConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Patterns []string `yaml:"patterns,omitempty"` // Field-name regexes; DefaultPIINamePatterns when empty
+}
+
+// PIIEnabled reports whether PII mode is enabled
+func (c *Config) PIIEnabled() bool {
+	return c != nil && c.PII.Enabled
+}
+
+// PIIFieldMatcher returns the matcher for personal-data field names, or nil
+// when PII mode is disabled. Patterns are validated by LoadConfig, so an
+// invalid pattern here also yields nil.
+func (c *Config) PIIFieldMatcher() *NameMatcher {
+	if !c.PIIEnabled() {
+		return nil
+	}
+	m, err := c.nameMatcher(c.PII.Patterns, DefaultPIINamePatterns)
+	if err != nil {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,77 @@
+package config
+
+import "testing"
+
+func TestConfig_PIIFieldMatcher_DefaultPatterns(t *testing.T) {
+	m := (&Config{PII: PIIConfig{Enabled: true}}).PIIFieldMatcher()
+	if m == nil {
+		t.Fatal("PIIFieldMatcher() = nil, want matcher")
+	}
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Email", true},
+		{"EmailAddress", true},
+		{"e_mail", true},
+		{"SSN", true},
+		{"SocialSecurityNumber", true},
+		{"Phone", true},
+		{"PhoneNumber", true},
+		{"Mobile", true},
+		{"DOB", true},
+		{"BirthDate", true},
+		{"DateOfBirth", true},
+		{"Birthday", true},
+		{"Name", false},
+		{"Password", false},
+		{"Automobile", false},
+		{"Lessn", false},
+	}
+	for _, tt := range tests {
+		if got := m.Match(tt.name); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestConfig_PIIFieldMatcher_Disabled(t *testing.T) {
+	var nilCfg *Config
+	if nilCfg.PIIEnabled() || nilCfg.PIIFieldMatcher() != nil {
+		t.Errorf("nil config: PII mode enabled")
+	}
+	if (&Config{}).PIIFieldMatcher() != nil {
+		t.Errorf("default config: PIIFieldMatcher() != nil, want PII mode disabled by default")
+	}
+}
+
+func TestValidateConfig_PIIPatterns(t *testing.T) {
+	cfg := &Config{PII: PIIConfig{Enabled: true, Patterns: []string{`(unclosed`}}}
+	if err := ValidateConfig(cfg); err == nil {
+		t.Errorf("ValidateConfig() error = nil, want error for invalid pattern")
+	}
+}
+
+func TestLoadConfig_PII(t *testing.T) {
+	yaml := `pii:
+  enabled: true
+  patterns:
+    - "^iban$"
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	m := cfg.PIIFieldMatcher()
+	if m == nil {
+		t.Fatal("PIIFieldMatcher() = nil, want matcher")
+	}
+	if !m.Match("Iban") {
+		t.Errorf("Match(Iban) = false, want true")
+	}
+	if m.Match("Email") {
+		t.Errorf("Match(Email) = true, want false: custom patterns replace the defaults")
+	}
+}
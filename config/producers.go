@@ -0,0 +1,52 @@
+package config
+
+import "fmt"
+
+// ProducerSinkConfig configures message-queue producers whose messages feed
+// a logging pipeline (LH0017), such as a Kafka writer publishing to a topic
+// a log indexer consumes, or an SQS queue drained into a SIEM. Sensitive
+// data published by them ends up in the logs as surely as if it had been
+// logged.
+type ProducerSinkConfig struct {
+	TargetConfig `yaml:",inline"`
+
+	// PayloadArgs are the 0-based indexes of the payload arguments
+	// (receiver excluded), e.g. [1] for w.WriteMessages(ctx, msgs...); the
+	// index of a variadic parameter covers all its arguments. Every
+	// argument is a payload when empty.
+	PayloadArgs []int `yaml:"payload-args,omitempty"`
+}
+
+// IsPayloadArg reports whether argument i of a call to the sink is a
+// payload. variadic is the index of the sink's variadic parameter, or -1.
+func (p ProducerSinkConfig) IsPayloadArg(i, variadic int) bool {
+	if len(p.PayloadArgs) == 0 {
+		return true
+	}
+	if variadic >= 0 && i > variadic {
+		i = variadic
+	}
+	for _, arg := range p.PayloadArgs {
+		if arg == i {
+			return true
+		}
+	}
+	return false
+}
+
+func validateProducerSinks(sinks []ProducerSinkConfig) error {
+	if len(sinks) > maxTargets {
+		return fmt.Errorf("producer-sinks: too many entries: %d (max: %d)", len(sinks), maxTargets)
+	}
+	for i, sink := range sinks {
+		if err := validateTarget(i, &sink.TargetConfig); err != nil {
+			return fmt.Errorf("producer-sinks: %w", err)
+		}
+		for _, arg := range sink.PayloadArgs {
+			if arg < 0 {
+				return fmt.Errorf("producer-sinks: target[%d] (%s): payload-args must not be negative: %d", i, sink.Package, arg)
+			}
+		}
+	}
+	return nil
+}
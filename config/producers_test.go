@@ -0,0 +1,62 @@
+package config
+
+import "testing"
+
+func TestProducerSinkConfig_IsPayloadArg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		payloadArgs []int
+		i, variadic int
+		want        bool
+	}{
+		{"every argument without payload-args", nil, 0, -1, true},
+		{"listed argument", []int{1}, 1, -1, true},
+		{"unlisted argument", []int{1}, 0, -1, false},
+		{"first variadic argument", []int{1}, 1, 1, true},
+		{"later variadic argument", []int{1}, 3, 1, true},
+		{"argument after an unlisted variadic parameter", []int{0}, 2, 1, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sink := ProducerSinkConfig{PayloadArgs: tt.payloadArgs}
+			if got := sink.IsPayloadArg(tt.i, tt.variadic); got != tt.want {
+				t.Errorf("IsPayloadArg(%d, %d) = %v, want %v", tt.i, tt.variadic, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_ProducerSinks(t *testing.T) {
+	t.Parallel()
+
+	writer := TargetConfig{
+		Package: "github.com/segmentio/kafka-go",
+		Methods: []MethodConfig{{Receiver: "*Writer", Names: []string{"WriteMessages"}}},
+	}
+	tests := []struct {
+		name    string
+		sinks   []ProducerSinkConfig
+		wantErr bool
+	}{
+		{"valid", []ProducerSinkConfig{{TargetConfig: writer, PayloadArgs: []int{1}}}, false},
+		{"upper-case path element", []ProducerSinkConfig{{TargetConfig: TargetConfig{Package: "github.com/IBM/sarama", Methods: []MethodConfig{{Receiver: "SyncProducer", Names: []string{"SendMessage"}}}}}}, false},
+		{"negative payload arg", []ProducerSinkConfig{{TargetConfig: writer, PayloadArgs: []int{-1}}}, true},
+		{"invalid target", []ProducerSinkConfig{{TargetConfig: TargetConfig{Package: "github.com/segmentio/kafka-go"}}}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{ProducerSinks: tt.sinks}
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"go/token"
+	"slices"
+	"strings"
+)
+
+// Receiver is a parsed method receiver of a target, e.g. "*Logger[T]"
+type Receiver struct {
+	Name       string   // Type name, e.g. "Logger"
+	Pointer    bool     // Methods are called on a pointer, e.g. "*Logger"
+	TypeParams []string // Type parameter names of a generic receiver, nil otherwise
+}
+
+// ParseReceiver parses a receiver such as "Logger", "*Logger" or
+// "*Logger[K, V]". The type parameter names of a generic receiver are
+// placeholders: only their number must match the type's declaration.
+func ParseReceiver(receiver string) (Receiver, error) {
+	var r Receiver
+	name, pointer := strings.CutPrefix(receiver, "*")
+	r.Pointer = pointer
+	if base, params, ok := strings.Cut(name, "["); ok {
+		params, closed := strings.CutSuffix(params, "]")
+		if !closed {
+			return Receiver{}, fmt.Errorf("invalid receiver type: %s (missing \"]\")", receiver)
+		}
+		for _, param := range strings.Split(params, ",") {
+			param = strings.TrimSpace(param)
+			if !token.IsIdentifier(param) || slices.Contains(r.TypeParams, param) {
+				return Receiver{}, fmt.Errorf("invalid receiver type: %s (type parameters must be distinct identifiers)", receiver)
+			}
+			r.TypeParams = append(r.TypeParams, param)
+		}
+		name = base
+	}
+	if !token.IsIdentifier(name) {
+		return Receiver{}, fmt.Errorf("invalid receiver type: %s", receiver)
+	}
+	r.Name = name
+	return r, nil
+}
+
+// Generic reports whether the receiver names type parameters, e.g.
+// "*Logger[T]", and so matches instantiations of a generic type only
+func (r Receiver) Generic() bool {
+	return len(r.TypeParams) > 0
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseReceiver(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		receiver string
+		want     Receiver
+	}{
+		{"Logger", Receiver{Name: "Logger"}},
+		{"*Logger", Receiver{Name: "Logger", Pointer: true}},
+		{"*Logger[T]", Receiver{Name: "Logger", Pointer: true, TypeParams: []string{"T"}}},
+		{"Pair[K, V]", Receiver{Name: "Pair", TypeParams: []string{"K", "V"}}},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.receiver, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseReceiver(tt.receiver)
+			if err != nil {
+				t.Fatalf("ParseReceiver(%q) error = %v", tt.receiver, err)
+			}
+			if got.Name != tt.want.Name || got.Pointer != tt.want.Pointer || !slices.Equal(got.TypeParams, tt.want.TypeParams) {
+				t.Errorf("ParseReceiver(%q) = %+v, want %+v", tt.receiver, got, tt.want)
+			}
+			if got.Generic() != (len(tt.want.TypeParams) > 0) {
+				t.Errorf("ParseReceiver(%q).Generic() = %v", tt.receiver, got.Generic())
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultMarshalers are the redaction interfaces honored unless redaction is
+// disabled: a value implementing one of them is rendered through it, not
+// field by field, when passed to a call in one of the listed packages.
+var DefaultMarshalers = []MarshalerConfig{
+	{Interface: "log/slog.LogValuer", Packages: []string{"log/slog"}},
+	{Interface: "go.uber.org/zap/zapcore.ObjectMarshaler", Packages: []string{"go.uber.org/zap"}},
+}
+
+// RedactionConfig controls the exemption of types that render a redacted
+// view of themselves, such as a slog.LogValuer or zapcore.ObjectMarshaler
+// that omits its sensitive fields
+type RedactionConfig struct {
+	Disabled   bool              `yaml:"disabled,omitempty"`   // Report redacting types like any other struct
+	Marshalers []MarshalerConfig `yaml:"marshalers,omitempty"` // Honored in addition to DefaultMarshalers
+}
+
+// MarshalerConfig names a redaction interface and the packages whose calls
+// render values through it
+type MarshalerConfig struct {
+	Interface string   `yaml:"interface"` // Qualified interface, e.g. "go.uber.org/zap/zapcore.ObjectMarshaler"
+	Packages  []string `yaml:"packages"`  // Package paths; subpackages are included
+}
+
+// InterfacePath splits Interface into its package path and type name
+func (m MarshalerConfig) InterfacePath() (pkgPath, name string) {
+	i := strings.LastIndex(m.Interface, ".")
+	if i < 0 {
+		return "", m.Interface
+	}
+	return m.Interface[:i], m.Interface[i+1:]
+}
+
+// RedactionMarshalers returns the redaction interfaces to honor: the
+// defaults followed by any configured ones, or nil when redaction is disabled
+func (c *Config) RedactionMarshalers() []MarshalerConfig {
+	if c == nil {
+		return DefaultMarshalers
+	}
+	if c.Redaction.Disabled {
+		return nil
+	}
+	return append(append([]MarshalerConfig{}, DefaultMarshalers...), c.Redaction.Marshalers...)
+}
+
+func validateMarshaler(index int, m *MarshalerConfig) error {
+	pkgPath, name := m.InterfacePath()
+	if pkgPath == "" {
+		return fmt.Errorf("redaction.marshalers[%d]: interface %q must be qualified, e.g. \"example.com/log.Redactor\"", index, m.Interface)
+	}
+	if err := validatePackagePath(pkgPath); err != nil {
+		return fmt.Errorf("redaction.marshalers[%d]: %w", index, err)
+	}
+	if err := validateIdentifier(name); err != nil {
+		return fmt.Errorf("redaction.marshalers[%d]: %w", index, err)
+	}
+	if len(m.Packages) == 0 {
+		return fmt.Errorf("redaction.marshalers[%d]: at least one package is required", index)
+	}
+	for _, pkg := range m.Packages {
+		if err := validatePackagePath(pkg); err != nil {
+			return fmt.Errorf("redaction.marshalers[%d]: %w", index, err)
+		}
+	}
+	return nil
+}
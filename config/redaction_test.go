@@ -0,0 +1,74 @@
+package config
+
+import "testing"
+
+func TestConfig_RedactionMarshalers(t *testing.T) {
+	var nilCfg *Config
+	if got := nilCfg.RedactionMarshalers(); len(got) != len(DefaultMarshalers) {
+		t.Errorf("nil config: RedactionMarshalers() = %v, want defaults", got)
+	}
+
+	cfg := &Config{Redaction: RedactionConfig{Marshalers: []MarshalerConfig{
+		{Interface: "example.com/log.Redactor", Packages: []string{"example.com/log"}},
+	}}}
+	got := cfg.RedactionMarshalers()
+	if len(got) != len(DefaultMarshalers)+1 || got[len(got)-1].Interface != "example.com/log.Redactor" {
+		t.Errorf("RedactionMarshalers() = %v, want defaults followed by example.com/log.Redactor", got)
+	}
+
+	cfg.Redaction.Disabled = true
+	if got := cfg.RedactionMarshalers(); got != nil {
+		t.Errorf("disabled: RedactionMarshalers() = %v, want nil", got)
+	}
+}
+
+func TestMarshalerConfig_InterfacePath(t *testing.T) {
+	pkgPath, name := MarshalerConfig{Interface: "go.uber.org/zap/zapcore.ObjectMarshaler"}.InterfacePath()
+	if pkgPath != "go.uber.org/zap/zapcore" || name != "ObjectMarshaler" {
+		t.Errorf("InterfacePath() = %q, %q, want go.uber.org/zap/zapcore, ObjectMarshaler", pkgPath, name)
+	}
+}
+
+func TestValidateConfig_Redaction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		marshaler MarshalerConfig
+		wantErr   bool
+	}{
+		{"valid", MarshalerConfig{Interface: "example.com/log.Redactor", Packages: []string{"example.com/log"}}, false},
+		{"unqualified interface", MarshalerConfig{Interface: "Redactor", Packages: []string{"example.com/log"}}, true},
+		{"invalid interface name", MarshalerConfig{Interface: "example.com/log.1Redactor", Packages: []string{"example.com/log"}}, true},
+		{"no packages", MarshalerConfig{Interface: "example.com/log.Redactor"}, true},
+		{"invalid package", MarshalerConfig{Interface: "example.com/log.Redactor", Packages: []string{"Example.com/Log"}}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Redaction: RedactionConfig{Marshalers: []MarshalerConfig{tt.marshaler}}}
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Redaction(t *testing.T) {
+	yaml := `redaction:
+  marshalers:
+    - interface: "example.com/log.Redactor"
+      packages:
+        - "example.com/log"
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	if len(cfg.Redaction.Marshalers) != 1 || cfg.Redaction.Marshalers[0].Packages[0] != "example.com/log" {
+		t.Errorf("Redaction.Marshalers = %+v, want example.com/log.Redactor", cfg.Redaction.Marshalers)
+	}
+}
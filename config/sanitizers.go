@@ -0,0 +1,18 @@
+package config
+
+import "fmt"
+
+// validateSanitizers checks the sanitizers section: functions and methods
+// whose results are safe to log whatever flowed into them, configured and
+// limited like targets
+func validateSanitizers(sanitizers []TargetConfig) error {
+	if len(sanitizers) > maxTargets {
+		return fmt.Errorf("sanitizers: too many entries: %d (max: %d)", len(sanitizers), maxTargets)
+	}
+	for i, sanitizer := range sanitizers {
+		if err := validateTarget(i, &sanitizer); err != nil {
+			return fmt.Errorf("sanitizers: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateConfig_Sanitizers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		sanitizer TargetConfig
+		wantErr   bool
+	}{
+		{
+			name:      "valid methods",
+			sanitizer: TargetConfig{Package: "example.com/app/redact", Methods: []MethodConfig{{Receiver: "*Redactor", Names: []string{"Mask", "Hash"}}}},
+		},
+		{
+			name:      "valid functions",
+			sanitizer: TargetConfig{Package: "example.com/app/redact", Functions: []string{"Mask"}},
+		},
+		{
+			name:      "missing functions and methods",
+			sanitizer: TargetConfig{Package: "example.com/app/redact"},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid receiver",
+			sanitizer: TargetConfig{Package: "example.com/app/redact", Methods: []MethodConfig{{Receiver: "*Red-actor", Names: []string{"Mask"}}}},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Sanitizers: []TargetConfig{tt.sanitizer}}
+			err := ValidateConfig(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.HasPrefix(err.Error(), "sanitizers: ") {
+				t.Errorf("ValidateConfig() error = %v, want it prefixed with the section", err)
+			}
+		})
+	}
+
+	// Sanitizers share the limits of targets
+	tooMany := make([]TargetConfig, maxTargets+1)
+	for i := range tooMany {
+		tooMany[i] = TargetConfig{Package: "example.com/app/redact", Functions: []string{"Mask"}}
+	}
+	if err := ValidateConfig(&Config{Sanitizers: tooMany}); err == nil {
+		t.Errorf("ValidateConfig() accepted %d sanitizers, want at most %d", len(tooMany), maxTargets)
+	}
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// AllRules is the rule key that applies a setting to every rule.
+const AllRules = "all"
+
+// validSeverities is the set of severities accepted in rules.<ID>.severity
+// and -severity-overrides. The values mirror SARIF result levels.
+var validSeverities = map[string]bool{
+	"error":   true,
+	"warning": true,
+	"note":    true,
+}
+
+// ParseSeverityOverrides parses a comma-separated list of RULE=SEVERITY pairs,
+// e.g. "LH0003=warning,LH0004=note" or "all=warning". An empty string yields
+// no overrides.
+func ParseSeverityOverrides(s string) (map[string]string, error) {
+	overrides := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		ruleID, severity, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, invalid("", fmt.Errorf("severity override %q: want RULE=SEVERITY", pair))
+		}
+		ruleID = strings.TrimSpace(ruleID)
+		severity = strings.ToLower(strings.TrimSpace(severity))
+		if ruleID != AllRules && !validSARIFRuleIDs[ruleID] {
+			return nil, invalid("", fmt.Errorf("severity override %q: invalid rule ID %q (valid values: all, LH0001, LH0002, LH0003, LH0004, LH0005, LH0006, LH0007, LH0008, LH0009, LH0010, LH0011, LH0012, LH0013, LH0014, LH0015, LH0016, LH0017, LH0018, LH0019)", pair, ruleID))
+		}
+		if !validSeverities[severity] {
+			return nil, invalid("", fmt.Errorf("severity override %q: invalid severity %q (valid values: error, warning, note)", pair, severity))
+		}
+		overrides[ruleID] = severity
+	}
+	return overrides, nil
+}
+
+// ApplySeverityOverrides merges command-line severity overrides into the
+// rules section. Overrides take precedence over the config file: a specific
+// rule replaces that rule's setting, and an "all" override replaces the
+// config file's per-rule severities as well as its "all" entry.
+func (c *Config) ApplySeverityOverrides(overrides map[string]string) {
+	if len(overrides) == 0 {
+		return
+	}
+	if c.Rules == nil {
+		c.Rules = make(map[string]RuleConfig)
+	}
+	if all, ok := overrides[AllRules]; ok {
+		for ruleID, rule := range c.Rules {
+			rule.Severity = ""
+			c.Rules[ruleID] = rule
+		}
+		rule := c.Rules[AllRules]
+		rule.Severity = all
+		c.Rules[AllRules] = rule
+	}
+	for ruleID, severity := range overrides {
+		rule := c.Rules[ruleID]
+		rule.Severity = severity
+		c.Rules[ruleID] = rule
+	}
+}
+
+// RuleSeverity returns the configured severity for a SARIF rule ID, falling
+// back to the "all" entry. It returns "" when neither is set.
+func (c *Config) RuleSeverity(ruleID string) string {
+	if c == nil {
+		return ""
+	}
+	if s := c.Rules[ruleID].Severity; s != "" {
+		return s
+	}
+	return c.Rules[AllRules].Severity
+}
+
+// RuleSecuritySeverity returns the configured security-severity score for a
+// SARIF rule ID, falling back to the "all" entry. The second result is false
+// when neither is set.
+func (c *Config) RuleSecuritySeverity(ruleID string) (float64, bool) {
+	if c == nil {
+		return 0, false
+	}
+	if s := c.Rules[ruleID].SecuritySeverity; s != nil {
+		return *s, true
+	}
+	if s := c.Rules[AllRules].SecuritySeverity; s != nil {
+		return *s, true
+	}
+	return 0, false
+}
+
+// RuleRank returns the configured result rank for a SARIF rule ID, falling
+// back to the "all" entry. The second result is false when neither is set.
+func (c *Config) RuleRank(ruleID string) (float64, bool) {
+	if c == nil {
+		return 0, false
+	}
+	if r := c.Rules[ruleID].Rank; r != nil {
+		return *r, true
+	}
+	if r := c.Rules[AllRules].Rank; r != nil {
+		return *r, true
+	}
+	return 0, false
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSeverityOverrides(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    map[string]string
+		wantErr bool
+	}{
+		{"empty", "", map[string]string{}, false},
+		{"single", "LH0003=warning", map[string]string{"LH0003": "warning"}, false},
+		{"multiple with spaces", "LH0003=warning, LH0004 = note", map[string]string{"LH0003": "warning", "LH0004": "note"}, false},
+		{"all", "all=note", map[string]string{"all": "note"}, false},
+		{"severity is case-insensitive", "LH0001=ERROR", map[string]string{"LH0001": "error"}, false},
+		{"trailing comma", "LH0001=error,", map[string]string{"LH0001": "error"}, false},
+		{"missing equals", "LH0003", nil, true},
+		{"invalid rule ID", "sensitive-var=warning", nil, true},
+		{"invalid severity", "LH0003=fatal", nil, true},
+		{"empty severity", "LH0003=", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSeverityOverrides(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSeverityOverrides(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSeverityOverrides(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfig_RuleSeverity(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     map[string]RuleConfig
+		overrides map[string]string
+		want      map[string]string // rule ID -> expected severity
+	}{
+		{
+			name: "no settings",
+			want: map[string]string{"LH0001": "", "LH0003": ""},
+		},
+		{
+			name:  "config rule and all fallback",
+			rules: map[string]RuleConfig{"LH0003": {Severity: "warning"}, "all": {Severity: "note"}},
+			want:  map[string]string{"LH0001": "note", "LH0003": "warning"},
+		},
+		{
+			name:      "override replaces the same rule",
+			rules:     map[string]RuleConfig{"LH0003": {Severity: "warning"}},
+			overrides: map[string]string{"LH0003": "error"},
+			want:      map[string]string{"LH0001": "", "LH0003": "error"},
+		},
+		{
+			name:      "override all beats config per-rule settings",
+			rules:     map[string]RuleConfig{"LH0003": {Severity: "note"}, "all": {Severity: "note"}},
+			overrides: map[string]string{"all": "error"},
+			want:      map[string]string{"LH0001": "error", "LH0003": "error"},
+		},
+		{
+			name:      "override rule beats override all",
+			overrides: map[string]string{"all": "error", "LH0003": "warning"},
+			want:      map[string]string{"LH0001": "error", "LH0003": "warning"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Rules: tt.rules}
+			cfg.ApplySeverityOverrides(tt.overrides)
+			for ruleID, want := range tt.want {
+				if got := cfg.RuleSeverity(ruleID); got != want {
+					t.Errorf("RuleSeverity(%q) = %q, want %q", ruleID, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateConfig_Rules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   map[string]RuleConfig
+		wantErr bool
+	}{
+		{"nil rules", nil, false},
+		{"valid rule", map[string]RuleConfig{"LH0003": {Severity: "warning"}}, false},
+		{"all", map[string]RuleConfig{"all": {Severity: "note"}}, false},
+		{"empty severity", map[string]RuleConfig{"LH0001": {}}, false},
+		{"invalid rule ID", map[string]RuleConfig{"LH0099": {Severity: "warning"}}, true},
+		{"invalid severity", map[string]RuleConfig{"LH0001": {Severity: "critical"}}, true},
+		{"security-severity in range", map[string]RuleConfig{"LH0001": {SecuritySeverity: float(9.8), Rank: float(100)}}, false},
+		{"security-severity too high", map[string]RuleConfig{"LH0001": {SecuritySeverity: float(10.5)}}, true},
+		{"negative rank", map[string]RuleConfig{"LH0001": {Rank: float(-1)}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfig(&Config{Rules: tt.rules})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Rules(t *testing.T) {
+	yaml := `rules:
+  LH0003:
+    severity: warning
+  all:
+    severity: note
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	if got := cfg.RuleSeverity("LH0003"); got != "warning" {
+		t.Errorf("RuleSeverity(LH0003) = %q, want %q", got, "warning")
+	}
+	if got := cfg.RuleSeverity("LH0001"); got != "note" {
+		t.Errorf("RuleSeverity(LH0001) = %q, want %q", got, "note")
+	}
+}
+
+func TestLoadConfig_RuleScores(t *testing.T) {
+	yaml := `rules:
+  LH0004:
+    security-severity: 9.1
+    rank: 95
+`
+	tmpFile := createTempConfigFile(t, yaml)
+	cfg, err := LoadConfig(tmpFile)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v, want nil", err)
+	}
+	if got, ok := cfg.RuleSecuritySeverity("LH0004"); !ok || got != 9.1 {
+		t.Errorf("RuleSecuritySeverity(LH0004) = %v, %v, want 9.1, true", got, ok)
+	}
+	if got, ok := cfg.RuleRank("LH0004"); !ok || got != 95 {
+		t.Errorf("RuleRank(LH0004) = %v, %v, want 95, true", got, ok)
+	}
+	if _, ok := cfg.RuleRank("LH0001"); ok {
+		t.Errorf("RuleRank(LH0001) should be unset")
+	}
+}
+
+func float(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FailOnNever is the fail-on value for runs that findings never fail, the
+// default
+const FailOnNever = "never"
+
+// SinkSeverityConfig sets the severity of the findings whose sink is
+// declared in one of a family of packages, as debug prints to stdout and
+// production structured logs carry different risk:
+//
+//	sink-severities:
+//	  - packages: ["fmt"]
+//	    severity: warning
+//	  - packages: ["log/slog", "go.uber.org/zap*"]
+//	    severity: error
+//	  - packages: ["testing"]
+//	    severity: note
+type SinkSeverityConfig struct {
+	Packages []string `yaml:"packages"` // Packages declaring the sinks, matched like target packages (see MatchPackage)
+	Severity string   `yaml:"severity"` // error, warning or note
+}
+
+// SinkSeverity returns the severity of the first sink-severities entry
+// matching sinkPkg, the import path of the package declaring a finding's
+// sink, or "" when none matches
+func (c *Config) SinkSeverity(sinkPkg string) string {
+	if c == nil || sinkPkg == "" {
+		return ""
+	}
+	for _, entry := range c.SinkSeverities {
+		for _, pattern := range entry.Packages {
+			if MatchPackage(pattern, sinkPkg) {
+				return entry.Severity
+			}
+		}
+	}
+	return ""
+}
+
+// ApplyFailOn overrides the config file's fail-on setting with value, the
+// value of the command-line flag. An empty value keeps the config value.
+func (c *Config) ApplyFailOn(value string) error {
+	if value == "" {
+		return nil
+	}
+	value = strings.ToLower(value)
+	if err := validateFailOn(value); err != nil {
+		return invalid("", err)
+	}
+	c.FailOn = value
+	return nil
+}
+
+func validateSinkSeverities(entries []SinkSeverityConfig) error {
+	if len(entries) > maxTargets {
+		return fmt.Errorf("sink-severities: too many entries: %d (max: %d)", len(entries), maxTargets)
+	}
+	for i, entry := range entries {
+		if len(entry.Packages) == 0 {
+			return fmt.Errorf("sink-severities[%d]: packages is required", i)
+		}
+		for _, pattern := range entry.Packages {
+			if err := validatePackagePattern(pattern); err != nil {
+				return fmt.Errorf("sink-severities[%d]: %w", i, err)
+			}
+		}
+		if !validSeverities[entry.Severity] {
+			return fmt.Errorf("sink-severities[%d]: invalid severity %q (valid values: error, warning, note)", i, entry.Severity)
+		}
+	}
+	return nil
+}
+
+// validateFailOn validates the fail-on setting, the lowest severity of the
+// findings that fail the run
+func validateFailOn(failOn string) error {
+	if failOn != "" && failOn != FailOnNever && !validSeverities[failOn] {
+		return fmt.Errorf("fail-on: invalid value %q (valid values: error, warning, note, never)", failOn)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package config
+
+import "testing"
+
+func TestConfig_SinkSeverity(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{SinkSeverities: []SinkSeverityConfig{
+		{Packages: []string{"fmt", "log"}, Severity: "warning"},
+		{Packages: []string{"log/slog", "go.uber.org/zap*"}, Severity: "error"},
+		{Packages: []string{"testing"}, Severity: "note"},
+		{Packages: []string{"go.uber.org/zap"}, Severity: "note"}, // Shadowed by the entry above
+	}}
+
+	tests := []struct {
+		sinkPkg string
+		want    string
+	}{
+		{"fmt", "warning"},
+		{"log", "warning"},
+		{"log/slog", "error"},
+		{"go.uber.org/zap", "error"},
+		{"go.uber.org/zap/zapcore", "error"},
+		{"testing", "note"},
+		{"github.com/sirupsen/logrus", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.sinkPkg, func(t *testing.T) {
+			t.Parallel()
+			if got := cfg.SinkSeverity(tt.sinkPkg); got != tt.want {
+				t.Errorf("SinkSeverity(%q) = %q, want %q", tt.sinkPkg, got, tt.want)
+			}
+		})
+	}
+
+	if got := (*Config)(nil).SinkSeverity("fmt"); got != "" {
+		t.Errorf("nil config: SinkSeverity() = %q, want empty", got)
+	}
+}
+
+func TestConfig_ApplyFailOn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value   string
+		want    string
+		wantErr bool
+	}{
+		{"", "error", false},
+		{"warning", "warning", false},
+		{"Note", "note", false},
+		{"never", FailOnNever, false},
+		{"critical", "error", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.value, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{FailOn: "error"}
+			err := cfg.ApplyFailOn(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ApplyFailOn(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if cfg.FailOn != tt.want {
+				t.Errorf("ApplyFailOn(%q): FailOn = %q, want %q", tt.value, cfg.FailOn, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfig_SinkSeverities(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     *Config
+		wantErr bool
+	}{
+		{"valid", &Config{SinkSeverities: []SinkSeverityConfig{{Packages: []string{"fmt", "go.uber.org/zap*"}, Severity: "warning"}}, FailOn: "warning"}, false},
+		{"missing packages", &Config{SinkSeverities: []SinkSeverityConfig{{Severity: "warning"}}}, true},
+		{"invalid package", &Config{SinkSeverities: []SinkSeverityConfig{{Packages: []string{"*"}, Severity: "warning"}}}, true},
+		{"missing severity", &Config{SinkSeverities: []SinkSeverityConfig{{Packages: []string{"fmt"}}}}, true},
+		{"invalid severity", &Config{SinkSeverities: []SinkSeverityConfig{{Packages: []string{"fmt"}, Severity: "info"}}}, true},
+		{"invalid fail-on", &Config{FailOn: "critical"}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := ValidateConfig(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
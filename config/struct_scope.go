@@ -0,0 +1,43 @@
+package config
+
+import "fmt"
+
+// Scopes for the struct-rule-scope setting: the structs LH0003 reports when
+// logged as a whole
+const (
+	StructScopeLocal  = "local"  // Structs declared in the analyzed package
+	StructScopeModule = "module" // Structs declared in the analyzed package's module
+	StructScopeAll    = "all"    // Every struct, third-party ones included (default)
+)
+
+// StructScope returns the struct-rule-scope setting, StructScopeAll when
+// unset. The setting is validated when the config is loaded.
+func (c *Config) StructScope() string {
+	if c == nil || c.StructRuleScope == "" {
+		return StructScopeAll
+	}
+	return c.StructRuleScope
+}
+
+// ApplyStructRuleScope overrides the config file's struct-rule-scope setting
+// with scope, the value of the command-line flag. An empty scope keeps the
+// config value.
+func (c *Config) ApplyStructRuleScope(scope string) error {
+	if scope == "" {
+		return nil
+	}
+	if err := validateStructRuleScope(scope); err != nil {
+		return invalid("", err)
+	}
+	c.StructRuleScope = scope
+	return nil
+}
+
+// validateStructRuleScope checks the struct-rule-scope setting
+func validateStructRuleScope(scope string) error {
+	switch scope {
+	case "", StructScopeLocal, StructScopeModule, StructScopeAll:
+		return nil
+	}
+	return fmt.Errorf("struct-rule-scope: invalid value %q (valid values: %s, %s, %s)", scope, StructScopeLocal, StructScopeModule, StructScopeAll)
+}
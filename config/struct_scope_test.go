@@ -0,0 +1,36 @@
+package config
+
+import "testing"
+
+func TestConfig_StructRuleScope(t *testing.T) {
+	t.Parallel()
+
+	var nilCfg *Config
+	if got := nilCfg.StructScope(); got != StructScopeAll {
+		t.Errorf("nil config: StructScope() = %q, want %q", got, StructScopeAll)
+	}
+	if got := (&Config{}).StructScope(); got != StructScopeAll {
+		t.Errorf("unset: StructScope() = %q, want %q", got, StructScopeAll)
+	}
+
+	for _, scope := range []string{StructScopeLocal, StructScopeModule, StructScopeAll} {
+		if err := ValidateConfig(&Config{StructRuleScope: scope}); err != nil {
+			t.Errorf("ValidateConfig() with struct-rule-scope %q error = %v", scope, err)
+		}
+	}
+	if err := ValidateConfig(&Config{StructRuleScope: "package"}); err == nil {
+		t.Errorf("ValidateConfig() accepted struct-rule-scope %q", "package")
+	}
+
+	// The command-line flag overrides the config file, unless it is empty
+	cfg := &Config{StructRuleScope: StructScopeModule}
+	if err := cfg.ApplyStructRuleScope(""); err != nil || cfg.StructScope() != StructScopeModule {
+		t.Errorf("ApplyStructRuleScope(\"\") = %v, scope %q, want the config value kept", err, cfg.StructScope())
+	}
+	if err := cfg.ApplyStructRuleScope(StructScopeLocal); err != nil || cfg.StructScope() != StructScopeLocal {
+		t.Errorf("ApplyStructRuleScope(%q) = %v, scope %q", StructScopeLocal, err, cfg.StructScope())
+	}
+	if err := cfg.ApplyStructRuleScope("everywhere"); err == nil {
+		t.Errorf("ApplyStructRuleScope(\"everywhere\") succeeded, want an error")
+	}
+}
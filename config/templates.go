@@ -0,0 +1,49 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// DefaultTemplateWriters are the writers a text/template or html/template
+// execution is checked against unless template modeling is disabled: data
+// executed into one of them ends up in a log or an HTTP response.
+var DefaultTemplateWriters = []string{
+	"os.Stdout",
+	"os.Stderr",
+	"net/http.ResponseWriter",
+	"log.Writer",
+	"(*log.Logger).Writer",
+}
+
+// TemplateConfig controls the modeling of template execution:
+// tmpl.Execute(w, data) with w a sink writer is checked like a log call
+// taking data
+type TemplateConfig struct {
+	Disabled bool     `yaml:"disabled,omitempty"` // Do not treat template execution as a sink
+	Writers  []string `yaml:"writers,omitempty"`  // Honored in addition to DefaultTemplateWriters
+}
+
+// writerPattern matches a qualified writer: a package-level variable or
+// type ("os.Stdout", "net/http.ResponseWriter") or a function or method
+// returning the writer ("log.Writer", "(*log.Logger).Writer")
+var writerPattern = regexp.MustCompile(`^(?:[a-z0-9._\-/]+\.[A-Za-z_][A-Za-z0-9_]*|\(\*?[a-z0-9._\-/]+\.[A-Za-z_][A-Za-z0-9_]*\)\.[A-Za-z_][A-Za-z0-9_]*)$`)
+
+// TemplateWriters returns the sink writers for template execution: the
+// defaults followed by any configured ones, or nil when disabled
+func (c *Config) TemplateWriters() []string {
+	if c == nil {
+		return DefaultTemplateWriters
+	}
+	if c.Templates.Disabled {
+		return nil
+	}
+	return append(append([]string{}, DefaultTemplateWriters...), c.Templates.Writers...)
+}
+
+func validateTemplateWriter(index int, writer string) error {
+	if !writerPattern.MatchString(writer) {
+		return fmt.Errorf("templates.writers[%d]: invalid writer %q (want a qualified variable, type or function, e.g. \"os.Stdout\" or \"(*log.Logger).Writer\")", index, writer)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import "testing"
+
+func TestConfig_TemplateWriters(t *testing.T) {
+	var nilCfg *Config
+	if got := nilCfg.TemplateWriters(); len(got) != len(DefaultTemplateWriters) {
+		t.Errorf("nil config: TemplateWriters() = %v, want defaults", got)
+	}
+
+	cfg := &Config{Templates: TemplateConfig{Writers: []string{"example.com/audit.Log"}}}
+	got := cfg.TemplateWriters()
+	if len(got) != len(DefaultTemplateWriters)+1 || got[len(got)-1] != "example.com/audit.Log" {
+		t.Errorf("TemplateWriters() = %v, want defaults followed by example.com/audit.Log", got)
+	}
+
+	cfg.Templates.Disabled = true
+	if got := cfg.TemplateWriters(); got != nil {
+		t.Errorf("disabled: TemplateWriters() = %v, want nil", got)
+	}
+}
+
+func TestValidateConfig_TemplateWriters(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		writer  string
+		wantErr bool
+	}{
+		{"variable", "os.Stdout", false},
+		{"type", "net/http.ResponseWriter", false},
+		{"function", "example.com/audit.Writer", false},
+		{"pointer method", "(*log.Logger).Writer", false},
+		{"value method", "(example.com/audit.Trail).Writer", false},
+		{"unqualified", "Stdout", true},
+		{"unbalanced parenthesis", "(*log.Logger.Writer", true},
+		{"invalid package", "Example.com/audit.Log", true},
+		{"empty", "", true},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := &Config{Templates: TemplateConfig{Writers: []string{tt.writer}}}
+			if err := ValidateConfig(cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
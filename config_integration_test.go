@@ -28,3 +28,91 @@ func TestWithConfig(t *testing.T) {
 	// Run the analyzer - it should detect custom logger calls
 	analysistest.Run(t, testdata, leakhound.Analyzer, "customlogger")
 }
+
+// TestWithPackageConfig runs the analyzer on testdata packages that carry
+// their own .leakhound.yaml
+func TestWithPackageConfig(t *testing.T) {
+	tests := []struct {
+		pkg string
+	}{
+		{"audit"},           // untagged-fields audit (LH0007) enabled
+		{"fluentlogger"},    // targets *Logger; chains through *Entry must still match
+		{"fieldlogger"},     // targets wrapper and interface types held in struct fields
+		{"formatlogger"},    // printf-style targets with format-arg
+		{"redaction"},       // zap target; LogValuer and ObjectMarshaler types are exempt
+		{"keysinks"},        // cache key and metric name builders (LH0008)
+		{"templates"},       // template execution into sink writers and os.Expand
+		{"pii"},             // PII mode (LH0009): pii tags and name heuristics
+		{"callgranularity"}, // report-granularity: call aggregates findings per sink call
+		{"memorybudget"},    // max-memory: over budget, only direct field accesses are reported
+		{"zapforks"},        // glob target package matching forks by module path prefix
+		{"logtree"},         // "/..." target package matching a package and its subpackages
+		{"genericlogger"},   // generic receivers such as *Logger[T] match every instantiation
+		{"sanitizers"},      // sanitizer functions and methods clear the taint of their results
+		{"piipatterns"},     // PII name patterns from the config file are named in the findings
+		{"zapexample"},      // zap *Logger and *SugaredLogger targets from the README
+		{"eventbuilders"},   // event-builders: attached values taint events; logged builders are sinks
+		{"besteffort"},      // allow-type-errors: sinks matched syntactically in files that do not type-check
+		{"zapfields"},       // zap.Fields built ahead of time and spread into a target
+		{"commandline"},     // command-line: flags and os.Args indices declared sensitive (LH0012)
+		{"printdumps"},      // config-dumps audit (LH0013): configuration structs printed whole
+		{"messages"},        // rules.<ID>.message: finding messages from templates
+		{"boundaries"},      // boundaries: sensitive types accepted by boundary packages (LH0015)
+		{"tiers"},           // overrides: per-package rules and suppressions, by import path or directory
+		{"hooks"},           // hooks: logger hooks forwarding entries to HTTP clients and producers (LH0016)
+		{"producers"},       // producer-sinks: Kafka, SQS and Pub/Sub payloads feeding logging pipelines (LH0017)
+		{"hardcoded"},       // hardcoded-secrets audit (LH0018): secrets in struct tags and constants
+		{"flowhops"},        // max-flow-hops: findings over the hop limit are reported as warnings
+		{"sinkseverity"},    // sink-severities: severities per sink family, fmt → warning, testing → note
+		{"crashdump"},       // crash-handlers audit (LH0019): locals logged by handlers recovering and dumping the stack
+	}
+
+	testdata := analysistest.TestData()
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(originalDir)
+
+	for _, tt := range tests {
+		t.Run(tt.pkg, func(t *testing.T) {
+			// Change to the test package directory so the analyzer finds its
+			// .leakhound.yaml; subtests therefore run sequentially
+			if err := os.Chdir(filepath.Join(testdata, "src", tt.pkg)); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(originalDir)
+
+			analysistest.Run(t, testdata, leakhound.Analyzer, tt.pkg)
+		})
+	}
+}
+
+// TestWithModuleConfig runs the analyzer in module mode on testdata modules
+// that carry their own .leakhound.yaml, for settings that depend on the
+// packages' module
+func TestWithModuleConfig(t *testing.T) {
+	tests := []struct {
+		module string
+	}{
+		{"structscope"}, // struct-rule-scope: module reports whole structs of the module only
+	}
+
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(originalDir)
+
+	for _, tt := range tests {
+		t.Run(tt.module, func(t *testing.T) {
+			dir := filepath.Join(analysistest.TestData(), "src", tt.module)
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(originalDir)
+
+			analysistest.Run(t, dir, leakhound.Analyzer, "./...")
+		})
+	}
+}
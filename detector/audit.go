@@ -0,0 +1,83 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"reflect"
+	"strconv"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// AuditUntaggedFields reports fields of exported struct types whose names
+// match the sensitive-name heuristics but carry no sensitive tag (LH0007).
+// A sensitive:"false" or pii tag marks a field as reviewed and silences the
+// audit.
+// pkgPath qualifies the findings so identical type names in different
+// packages fingerprint differently.
+func AuditUntaggedFields(files []*ast.File, pkgPath string, matcher *config.NameMatcher) []Finding {
+	if matcher == nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok || !typeSpec.Name.IsExported() {
+				return true
+			}
+			findings = append(findings, auditStruct(typeSpec.Name.Name, structType, pkgPath, matcher)...)
+			return true
+		})
+	}
+	return findings
+}
+
+// auditStruct checks the named fields of a single struct type
+func auditStruct(typeName string, structType *ast.StructType, pkgPath string, matcher *config.NameMatcher) []Finding {
+	var findings []Finding
+	for _, field := range structType.Fields.List {
+		if hasTagKey(field.Tag, "sensitive") || hasTagKey(field.Tag, "pii") {
+			continue
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() || !matcher.Match(name.Name) {
+				continue
+			}
+			qualified := typeName + "." + name.Name
+			finding := Finding{
+				Pos:      name.Pos(),
+				End:      name.End(),
+				Message:  fmt.Sprintf("field '%s' looks sensitive but is not tagged with sensitive:\"true\"", qualified),
+				RuleID:   RuleIDUntaggedSensitiveField,
+				Severity: SeverityWarning,
+				Field:    qualified,
+				FieldPos: name.Pos(),
+				Expr:     qualified,
+				Func:     pkgPath,
+			}
+			finding.classify(patternClassification(matcher, name.Name))
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// hasTagKey reports whether a struct tag sets key to any value
+func hasTagKey(tag *ast.BasicLit, key string) bool {
+	if tag == nil || tag.Kind != token.STRING {
+		return false
+	}
+	value, err := strconv.Unquote(tag.Value)
+	if err != nil {
+		return false
+	}
+	_, ok := reflect.StructTag(value).Lookup(key)
+	return ok
+}
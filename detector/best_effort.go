@@ -0,0 +1,235 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// bestEffortSuffix is appended to the message of the findings in files that
+// do not type-check
+const bestEffortSuffix = " (best-effort: the file does not type-check)"
+
+// majorVersion matches the major version suffix of a module path, e.g. v2
+var majorVersion = regexp.MustCompile(`^v[0-9]+$`)
+
+// syntacticField is a sensitive field matched by name in files that do not
+// type-check
+type syntacticField struct {
+	qualified string // e.g. "User.Password"
+	level     Severity
+}
+
+// BestEffortFindings returns the findings (LH0004) in the files left out of
+// the analysis because they do not type-check, when allow-type-errors is set.
+// Without type information only calls of sink functions qualified by an
+// imported package, such as slog.Info or a configured target function, are
+// matched, and a field is sensitive when a field of that name is tagged in the
+// package or known from the analyzed packages. Type-dependent rules such as
+// data flow tracking do not apply, so the findings are marked best-effort.
+func (c *DataFlowCollector) BestEffortFindings() []Finding {
+	if !c.bestEffort || len(c.broken) == 0 {
+		return nil
+	}
+	fields := c.syntacticFields()
+	if len(fields) == 0 {
+		return nil
+	}
+
+	var findings []Finding
+	for _, file := range c.broken {
+		imports := importNames(file)
+		for _, decl := range file.Decls {
+			caller := ""
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				caller = funcName(c.pass.TypesInfo.Defs[fn.Name])
+			}
+			ast.Inspect(decl, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				sink := c.syntacticSink(call, imports)
+				if sink == "" {
+					return true
+				}
+				for i, arg := range call.Args {
+					argFindings := syntacticFieldFindings(arg, fields)
+					annotateSink(argFindings, call, sink, caller, i+1)
+					findings = append(findings, argFindings...)
+				}
+				return true
+			})
+		}
+	}
+	for i := range findings {
+		findings[i].Message += bestEffortSuffix
+		findings[i].BestEffort = true
+	}
+	return findings
+}
+
+// syntacticFields indexes the sensitive fields by field name: those tagged in
+// the structs of the files that do not type-check, then those collected from
+// the analyzed files. When several types have a field of the same name, the
+// first tagged one, or else the first type name in sort order, is used.
+func (c *DataFlowCollector) syntacticFields() map[string]syntacticField {
+	fields := make(map[string]syntacticField)
+	for _, file := range c.broken {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, field := range st.Fields.List {
+				if field.Tag == nil {
+					continue
+				}
+				tag, err := strconv.Unquote(field.Tag.Value)
+				if err != nil || !HasSensitiveTag(tag) {
+					continue
+				}
+				for _, name := range field.Names {
+					if _, ok := fields[name.Name]; !ok {
+						fields[name.Name] = syntacticField{
+							qualified: spec.Name.Name + "." + name.Name,
+							level:     SensitiveTagLevel(tag),
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	known := make([]sensitiveField, 0, len(c.fieldCollector.GetSensitiveFields()))
+	for sf, ok := range c.fieldCollector.GetSensitiveFields() {
+		if ok {
+			known = append(known, sf)
+		}
+	}
+	sort.Slice(known, func(i, j int) bool {
+		if known[i].typeName != known[j].typeName {
+			return known[i].typeName < known[j].typeName
+		}
+		return known[i].fieldName < known[j].fieldName
+	})
+	for _, sf := range known {
+		if _, ok := fields[sf.fieldName]; !ok {
+			fields[sf.fieldName] = syntacticField{qualified: sf.typeName + "." + sf.fieldName}
+		}
+	}
+	return fields
+}
+
+// syntacticSink returns the fully qualified sink function of a call such as
+// slog.Info(...), whose function is qualified by the name of an imported
+// package: a print function of slog, log or fmt, or a function of a
+// configured target. It returns "" for any other call.
+func (c *DataFlowCollector) syntacticSink(call *ast.CallExpr, imports map[string]string) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	pkgPath, ok := imports[pkg.Name]
+	if !ok {
+		return ""
+	}
+	name := sel.Sel.Name
+	if isSyntacticSink(pkgPath, name, c.logDetector.config) {
+		return pkgPath + "." + name
+	}
+	return ""
+}
+
+// isSyntacticSink reports whether the function name of package pkgPath
+// writes its arguments to a log
+func isSyntacticSink(pkgPath, name string, cfg *config.Config) bool {
+	switch pkgPath {
+	case "log/slog":
+		return isSlogStyleMethod(name)
+	case "log":
+		return isLogStyleMethod(name)
+	case "fmt":
+		return isFmtStyleMethod(name)
+	}
+	if cfg == nil {
+		return false
+	}
+	for _, target := range cfg.Targets {
+		if target.MatchesPackage(pkgPath) && slices.Contains(target.Functions, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// syntacticFieldFindings reports the selectors of sensitive fields by name in
+// arg, e.g. u.Password
+func syntacticFieldFindings(arg ast.Expr, fields map[string]syntacticField) []Finding {
+	var findings []Finding
+	ast.Inspect(arg, func(n ast.Node) bool {
+		sel, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		field, ok := fields[sel.Sel.Name]
+		if !ok {
+			return true
+		}
+		findings = append(findings, Finding{
+			Pos:  sel.Sel.Pos(),
+			End:  sel.End(),
+			Expr: types.ExprString(sel),
+			Message: fmt.Sprintf(
+				"sensitive field '%s' should not be logged (tagged with sensitive:\"true\")",
+				field.qualified),
+			RuleID:   RuleIDSensitiveField,
+			Severity: levelOrError(field.level),
+			Field:    field.qualified,
+			FlowPath: []FlowStep{{Label: field.qualified, Pos: sel.Sel.Pos()}},
+		})
+		return false
+	})
+	return findings
+}
+
+// importNames maps the names under which file refers to its imports to their
+// paths. The name of an import without one is guessed from the last element
+// of its path other than a major version, as the package name is unknown
+// without type information.
+func importNames(file *ast.File) map[string]string {
+	names := make(map[string]string)
+	for _, imp := range file.Imports {
+		p, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := path.Base(p)
+		if majorVersion.MatchString(name) {
+			name = path.Base(path.Dir(p))
+		}
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if name != "_" && name != "." {
+			names[name] = p
+		}
+	}
+	return names
+}
@@ -0,0 +1,102 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// BoundaryFindings returns the findings (LH0015) for the functions and
+// methods of a boundary package, one the config declares sensitive types must
+// not cross into, that accept a struct with sensitive fields: any value
+// handed to such a package, a logging or telemetry client for instance, is
+// as good as logged. Parameters holding the struct in a pointer, slice, array,
+// map or channel are reported too. It returns nil outside boundary packages.
+func (c *DataFlowCollector) BoundaryFindings() []Finding {
+	if c.boundary == nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, file := range c.pass.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok {
+				continue
+			}
+			obj := c.pass.TypesInfo.Defs[fn.Name]
+			for _, field := range fn.Type.Params.List {
+				typeExpr := field.Type
+				if ellipsis, ok := typeExpr.(*ast.Ellipsis); ok {
+					typeExpr = ellipsis.Elt
+				}
+				named := c.sensitiveElem(c.pass.TypesInfo.TypeOf(typeExpr))
+				if named == nil {
+					continue
+				}
+				if len(field.Names) == 0 {
+					findings = append(findings, c.boundaryFinding(fn, field, "", named, funcName(obj)))
+				}
+				// func Track(a, b models.User) accepts the type twice
+				for _, name := range field.Names {
+					findings = append(findings, c.boundaryFinding(fn, field, name.Name, named, funcName(obj)))
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// boundaryFinding returns the finding for the parameter name, "" if unnamed,
+// declared by param of fn with a type holding the sensitive struct named
+func (c *DataFlowCollector) boundaryFinding(fn *ast.FuncDecl, param *ast.Field, name string, named *types.Named, caller string) Finding {
+	typeName := types.TypeString(named, (*types.Package).Name)
+	what := "a parameter"
+	if name != "" && name != "_" {
+		what = fmt.Sprintf("parameter '%s'", name)
+	}
+	msg := fmt.Sprintf("%s in boundary package '%s' accepts %s of sensitive type '%s'",
+		fn.Name.Name, packagePath(c.pass), what, typeName)
+	if c.boundary.Reason != "" {
+		msg += ": " + c.boundary.Reason
+	}
+	return Finding{
+		Pos:      param.Type.Pos(),
+		End:      param.Type.End(),
+		Message:  msg,
+		RuleID:   RuleIDSensitiveBoundary,
+		Severity: SeverityWarning,
+		Expr:     types.ExprString(param.Type),
+		Func:     caller,
+	}
+}
+
+// sensitiveElem returns the struct with sensitive fields t is, or holds in a
+// pointer, slice, array, map or channel, or nil
+func (c *DataFlowCollector) sensitiveElem(t types.Type) *types.Named {
+	for t != nil {
+		switch u := types.Unalias(t).(type) {
+		case *types.Named:
+			if _, ok := u.Underlying().(*types.Struct); ok && c.detector.hasSensitiveFields(u) {
+				return u
+			}
+			return nil
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		case *types.Chan:
+			t = u.Elem()
+		case *types.Map:
+			if named := c.sensitiveElem(u.Key()); named != nil {
+				return named
+			}
+			t = u.Elem()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
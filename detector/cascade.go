@@ -0,0 +1,48 @@
+package detector
+
+import "go/token"
+
+// selectionSpan is the source range of a field selection reported by
+// checkFieldAccess, whose finding points at the selected field (sel) rather
+// than the start of the selector chain (pos)
+type selectionSpan struct {
+	pos, sel, end token.Pos
+}
+
+// dropCascading keeps only the most specific finding per leaf expression of
+// an argument. It removes the findings for expressions within a reported
+// field selection: logging cfg.Inner.Secret, where both Inner and Secret are
+// tagged, reports Inner.Secret but not Config.Inner, which is only the path
+// to it. Of several findings for the same expression, such as a nested call
+// and a field access reaching the same leaf, the first is kept.
+func dropCascading(findings []Finding, selections []selectionSpan) []Finding {
+	if len(findings) < 2 {
+		return findings
+	}
+	type span struct{ pos, end token.Pos }
+	seen := make(map[span]bool, len(findings))
+	kept := findings[:0]
+	for _, f := range findings {
+		key := span{f.Pos, f.End}
+		if seen[key] || withinSelection(f, selections) {
+			continue
+		}
+		seen[key] = true
+		kept = append(kept, f)
+	}
+	return kept
+}
+
+// withinSelection reports whether f lies within one of selections, other
+// than the one it was reported for
+func withinSelection(f Finding, selections []selectionSpan) bool {
+	for _, s := range selections {
+		if f.Pos == s.sel && f.End == s.end {
+			continue
+		}
+		if s.pos <= f.Pos && f.End <= s.end {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package detector
+
+import "testing"
+
+func TestDropCascading(t *testing.T) {
+	t.Parallel()
+
+	// a.Session.Key: the chain spans [10, 23), Session is at 12 and Key at 20
+	selections := []selectionSpan{
+		{pos: 10, sel: 20, end: 23},
+		{pos: 10, sel: 12, end: 19},
+	}
+	findings := []Finding{
+		{Pos: 20, End: 23, Field: "Session.Key"},
+		{Pos: 12, End: 19, Field: "Account.Session"},
+		{Pos: 30, End: 35, Field: "User.Password", RuleID: RuleIDSensitiveCall},
+		{Pos: 30, End: 35, Field: "User.Password", RuleID: RuleIDSensitiveField},
+		{Pos: 40, End: 45, Field: "User.Token"},
+	}
+
+	got := dropCascading(findings, selections)
+	want := []string{"Session.Key", "User.Password", "User.Token"}
+	if len(got) != len(want) {
+		t.Fatalf("dropCascading() kept %d findings, want %d: %+v", len(got), len(want), got)
+	}
+	for i, f := range got {
+		if f.Field != want[i] {
+			t.Errorf("findings[%d].Field = %q, want %q", i, f.Field, want[i])
+		}
+	}
+	if got[1].RuleID != RuleIDSensitiveCall {
+		t.Errorf("findings[1].RuleID = %q, want the first finding for the expression", got[1].RuleID)
+	}
+}
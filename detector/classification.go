@@ -0,0 +1,54 @@
+package detector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// patternClassification describes how m classified the field name: by one
+// of its default patterns, "matched default pattern 'e_?mail'", or by a
+// pattern from the config file, "matched pattern 'phone' declared in
+// .leakhound.yaml", naming the file the config was loaded from when known.
+// It returns "" if no pattern matches name.
+func patternClassification(m *config.NameMatcher, name string) string {
+	pattern := m.MatchedPattern(name)
+	switch {
+	case pattern == "":
+		return ""
+	case m.File() != "":
+		return fmt.Sprintf("matched pattern '%s' declared in %s", pattern, m.File())
+	case m.Configured():
+		return fmt.Sprintf("matched pattern '%s' declared in the config file", pattern)
+	default:
+		return fmt.Sprintf("matched default pattern '%s'", pattern)
+	}
+}
+
+// classify stamps the classification of a field recognized by heuristics
+// rather than by its tags onto the finding, and appends it to the message
+// so reviewers can judge and tune false positives
+func (f *Finding) classify(classification string) {
+	if classification == "" || f.Classification != "" {
+		return
+	}
+	f.Classification = classification
+	f.Message += " (" + classification + ")"
+}
+
+// classifyFields classifies the findings whose field was recognized by name
+// (see FieldCollector.SetPIIMatcher), using the classifications recorded
+// when the fields were collected
+func classifyFields(findings []Finding, classifications map[sensitiveField]string) {
+	if len(classifications) == 0 {
+		return
+	}
+	for i := range findings {
+		typeName, fieldName, ok := strings.Cut(findings[i].Field, ".")
+		if !ok {
+			continue
+		}
+		findings[i].classify(classifications[sensitiveField{typeName: typeName, fieldName: fieldName}])
+	}
+}
@@ -0,0 +1,35 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+func TestPatternClassification(t *testing.T) {
+	t.Parallel()
+
+	pii := config.PIIConfig{Enabled: true, Patterns: []string{"^iban$"}}
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		want string
+	}{
+		{"default patterns", &config.Config{PII: config.PIIConfig{Enabled: true}}, "matched default pattern 'e_?mail'"},
+		{"loaded from --config", &config.Config{PII: pii, Path: "ci/other.yaml"}, "matched pattern '^iban$' declared in ci/other.yaml"},
+		{"not read from a file", &config.Config{PII: pii}, "matched pattern '^iban$' declared in the config file"},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			name := "IBAN"
+			if len(tt.cfg.PII.Patterns) == 0 {
+				name = "Email"
+			}
+			if got := patternClassification(tt.cfg.PIIFieldMatcher(), name); got != tt.want {
+				t.Errorf("patternClassification(%q) = %q, want %q", name, got, tt.want)
+			}
+		})
+	}
+}
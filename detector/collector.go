@@ -3,6 +3,7 @@ package detector
 import (
 	"go/ast"
 	"go/types"
+	"runtime"
 
 	"github.com/nilpoona/leakhound/config"
 	"golang.org/x/tools/go/analysis"
@@ -30,22 +31,99 @@ type DataFlowCollector struct {
 
 	// Log calls collected during traversal (for single-pass optimization)
 	logCalls []*ast.CallExpr
+
+	// logCallFuncs maps each collected log call to its enclosing function.
+	logCallFuncs map[*ast.CallExpr]types.Object
+
+	// keySinks matches cache key and metric name builders (LH0008); nil
+	// when none are configured. keyCalls are the calls collected for them.
+	keySinks *KeySinkMatcher
+	keyCalls []keySinkCall
+
+	// producerSinks matches message-queue producers feeding logging
+	// pipelines (LH0017); nil when none are configured. producerCalls are
+	// the calls collected for them.
+	producerSinks *ProducerSinkMatcher
+	producerCalls []producerSinkCall
+
+	// initFuncs holds the package's init functions, whose bodies are
+	// collected after every other function (see collectPackageInit).
+	initFuncs []*ast.FuncDecl
+
+	// audit matches field names for the untagged-fields audit (LH0007);
+	// nil when the audit is disabled.
+	audit *config.NameMatcher
+
+	// configDumps is the config-dumps audit (LH0013); nil when it is
+	// disabled.
+	configDumps *config.ConfigDumpAudit
+
+	// hardcodedSecrets matches field and constant names for the
+	// hardcoded-secrets audit (LH0018); nil when the audit is disabled.
+	hardcodedSecrets *config.NameMatcher
+
+	// crashHandlers matches local variable names for the crash-handlers
+	// audit (LH0019); nil when the audit is disabled.
+	crashHandlers *config.NameMatcher
+
+	// boundary is the boundary the package belongs to (LH0015); nil when
+	// it is not a boundary package.
+	boundary *config.BoundaryConfig
+
+	// hooks matches logger hook registrations and the external sinks hooks
+	// must not forward entries to (LH0016); nil when no registration is
+	// configured.
+	hooks *HookMatcher
+
+	// budget is the max-memory budget for the package's tracked facts, 0
+	// for none. notification is set once the package went over it and was
+	// degraded to direct-access-only detection (see enforceBudget).
+	budget       int64
+	notification *Notification
+
+	// broken holds the files left out because they do not type-check, and
+	// skipped the notification listing them (see typeCheckedPass). With
+	// bestEffort they are still matched syntactically (see
+	// BestEffortFindings).
+	broken     []*ast.File
+	skipped    *Notification
+	bestEffort bool
 }
 
 // NewDataFlowCollector creates a new collector with all components initialized
 func NewDataFlowCollector(pass *analysis.Pass, cfg *config.Config) *DataFlowCollector {
+	pass, broken, skipped := typeCheckedPass(pass, cfg.AllowTypeErrors)
 	fieldCollector := NewFieldCollector(pass)
+	fieldCollector.SetPIIMatcher(cfg.PIIFieldMatcher())
 	varTracker := NewVarTracker(pass, fieldCollector.GetSensitiveFields())
-	logDetector := NewLogDetectorWithConfig(pass, cfg)
+	varTracker.SetEventBuilders(NewEventBuilderMatcher(pass, cfg))
+	varTracker.SetCommandLineSources(NewCommandLineSources(cfg))
+	logDetector := NewLogDetectorWithConfig(pass, cfg.WithLoggedEventBuilders())
 	detector := NewDetector(pass, fieldCollector.GetSensitiveFields(), varTracker)
+	detector.SetMarshalers(cfg.RedactionMarshalers())
+	detector.SetSanitizers(NewSanitizerMatcher(pass, cfg))
+	detector.SetStructScope(cfg.StructScope())
 
 	return &DataFlowCollector{
-		pass:           pass,
-		fieldCollector: fieldCollector,
-		varTracker:     varTracker,
-		logDetector:    logDetector,
-		detector:       detector,
-		logCalls:       make([]*ast.CallExpr, 0),
+		pass:             pass,
+		fieldCollector:   fieldCollector,
+		varTracker:       varTracker,
+		logDetector:      logDetector,
+		detector:         detector,
+		logCalls:         make([]*ast.CallExpr, 0),
+		logCallFuncs:     make(map[*ast.CallExpr]types.Object),
+		keySinks:         NewKeySinkMatcher(pass, cfg),
+		producerSinks:    NewProducerSinkMatcher(pass, cfg),
+		audit:            cfg.UntaggedFieldMatcher(),
+		configDumps:      cfg.ConfigDumpAudit(),
+		hardcodedSecrets: cfg.HardcodedSecretMatcher(),
+		crashHandlers:    cfg.CrashHandlerMatcher(),
+		boundary:         cfg.Boundary(packagePath(pass)),
+		hooks:            NewHookMatcher(pass, cfg),
+		budget:           cfg.MemoryBudget(),
+		broken:           broken,
+		skipped:          skipped,
+		bestEffort:       cfg.AllowTypeErrors,
 	}
 }
 
@@ -53,20 +131,41 @@ func NewDataFlowCollector(pass *analysis.Pass, cfg *config.Config) *DataFlowColl
 // a WorldView. Per-package collection writes into the world's accumulators so
 // the whole-program analyzer can iterate across packages afterwards.
 func NewDataFlowCollectorForWorld(pass *analysis.Pass, cfg *config.Config, world *WorldView, pkg *packages.Package) *DataFlowCollector {
-	fieldCollector := NewFieldCollectorWithFields(pass, world.sensitiveFields)
+	pass, broken, skipped := typeCheckedPass(pass, cfg.AllowTypeErrors)
+	fieldCollector := NewFieldCollectorWithFields(pass, world.sensitiveFields, world.fieldClasses)
+	fieldCollector.SetPIIMatcher(cfg.PIIFieldMatcher())
 	varTracker := NewVarTrackerForWorld(pass, world)
-	logDetector := NewLogDetectorWithConfig(pass, cfg)
+	varTracker.SetEventBuilders(NewEventBuilderMatcher(pass, cfg))
+	varTracker.SetCommandLineSources(NewCommandLineSources(cfg))
+	logDetector := NewLogDetectorWithConfig(pass, cfg.WithLoggedEventBuilders())
+	logDetector.sinkValues = world.sinkValues
 	detector := NewDetector(pass, world.sensitiveFields, varTracker)
+	detector.SetMarshalers(cfg.RedactionMarshalers())
+	detector.SetSanitizers(NewSanitizerMatcher(pass, cfg))
+	detector.SetStructScope(cfg.StructScope())
 
 	return &DataFlowCollector{
-		pass:           pass,
-		world:          world,
-		pkg:            pkg,
-		fieldCollector: fieldCollector,
-		varTracker:     varTracker,
-		logDetector:    logDetector,
-		detector:       detector,
-		logCalls:       make([]*ast.CallExpr, 0),
+		pass:             pass,
+		world:            world,
+		pkg:              pkg,
+		fieldCollector:   fieldCollector,
+		varTracker:       varTracker,
+		logDetector:      logDetector,
+		detector:         detector,
+		logCalls:         make([]*ast.CallExpr, 0),
+		logCallFuncs:     make(map[*ast.CallExpr]types.Object),
+		keySinks:         NewKeySinkMatcher(pass, cfg),
+		producerSinks:    NewProducerSinkMatcher(pass, cfg),
+		audit:            cfg.UntaggedFieldMatcher(),
+		configDumps:      cfg.ConfigDumpAudit(),
+		hardcodedSecrets: cfg.HardcodedSecretMatcher(),
+		crashHandlers:    cfg.CrashHandlerMatcher(),
+		boundary:         cfg.Boundary(packagePath(pass)),
+		hooks:            NewHookMatcher(pass, cfg),
+		budget:           cfg.MemoryBudget(),
+		broken:           broken,
+		skipped:          skipped,
+		bestEffort:       cfg.AllowTypeErrors,
 	}
 }
 
@@ -93,9 +192,12 @@ func (c *DataFlowCollector) Collect() {
 	c.CollectFacts()
 	// Phase 1b: Multi-pass data flow analysis. In whole-program mode this
 	// step is run once by WholeProgramCollector AFTER every package has
-	// contributed its facts, so we skip it here.
-	if c.world == nil {
+	// contributed its facts, so we skip it here. It is skipped as well once
+	// the facts go over the memory budget, and the budget is checked again
+	// after propagation.
+	if c.world == nil && !c.enforceBudget(c.trackedFacts()) {
 		c.varTracker.AnalyzeDataFlow()
+		c.enforceBudget(c.trackedFacts())
 	}
 }
 
@@ -103,13 +205,96 @@ func (c *DataFlowCollector) Collect() {
 // data flow analysis. WholeProgramCollector uses this to defer propagation
 // until cross-package facts are available.
 func (c *DataFlowCollector) CollectFacts() {
+	// Function values holding sinks must be known before log calls are
+	// collected. In whole-program mode WholeProgramCollector records them
+	// for every package up front, since callers may live in other packages.
+	if c.world == nil {
+		c.logDetector.RecordSinkValues(c.pass.Files, c.pass.TypesInfo)
+	}
+	c.collectFiles(len(c.pass.Files) >= concurrentScanMinFiles && runtime.GOMAXPROCS(0) > 1)
+	c.collectPackageInit()
+	c.recollectFunctionFacts()
+}
+
+// collectPackageInit collects package initialization in the order Go runs
+// it: package-level var initializers in dependency order, then init
+// functions. Both come after the other functions so that initializers calling
+// helpers declared further down (var token = loadToken()) see their sensitive
+// returns. The sink calls of initializers, such as flag definitions
+// (var token = flag.String("token", cfg.Token, "")), are collected too.
+func (c *DataFlowCollector) collectPackageInit() {
+	for _, init := range c.pass.TypesInfo.InitOrder {
+		c.varTracker.CollectInitializer(init)
+		c.collectInitializerCalls(init.Rhs)
+	}
+	for _, funcDecl := range c.initFuncs {
+		c.collectFromFunction(funcDecl)
+	}
+}
+
+// collectInitializerCalls records the sink calls of a package-level var
+// initializer, and the data flow facts and sink calls of the function
+// literals in it, such as handlers:
+//
+//	var handler = func(w http.ResponseWriter, r *http.Request) { ... }
+//	var mux = newMux(func(w http.ResponseWriter, r *http.Request) { ... })
+func (c *DataFlowCollector) collectInitializerCalls(rhs ast.Expr) {
+	var calls sinkCalls
+	ast.Inspect(rhs, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			c.collectBody(n.Body, true, &calls, true)
+			return false
+		case *ast.CallExpr:
+			c.scanCall(n, &calls)
+		}
+		return true
+	})
+	c.addSinkCalls(calls)
+}
+
+// recollectFunctionFacts collects function facts a second time when a
+// package-level variable is tainted or an iterator yields sensitive values.
+// Functions reading the variable may have been collected before the write
+// was seen, e.g. a lazy getter declared above the loader it hands to
+// sync.Once.Do, or any reader of a variable set during package
+// initialization; likewise for ranges over an iterator declared below them.
+func (c *DataFlowCollector) recollectFunctionFacts() {
+	if !c.hasSensitivePackageVars() && !c.varTracker.HasSensitiveYields() {
+		return
+	}
 	for _, file := range c.pass.Files {
-		c.collectFromFile(file)
+		for _, decl := range file.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && !isInitFunc(funcDecl) {
+				c.collectFunctionFacts(funcDecl, false)
+			}
+		}
 	}
 }
 
-// collectFromFile collects information from a single file
-func (c *DataFlowCollector) collectFromFile(file *ast.File) {
+// hasSensitivePackageVars reports whether a package-level variable of the
+// collector's package is tainted
+func (c *DataFlowCollector) hasSensitivePackageVars() bool {
+	scope := c.pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		if v, ok := scope.Lookup(name).(*types.Var); ok {
+			if _, found := c.varTracker.IsSensitiveVar(v); found {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isInitFunc reports whether funcDecl declares a package init function
+func isInitFunc(funcDecl *ast.FuncDecl) bool {
+	return funcDecl.Recv == nil && funcDecl.Name != nil && funcDecl.Name.Name == "init"
+}
+
+// collectFromFile collects information from a single file. The struct
+// fields and sink calls of a file scanned beforehand are taken from scan
+// rather than collected again; scan is nil otherwise.
+func (c *DataFlowCollector) collectFromFile(file *ast.File, scan *fileScan) {
 	ast.Inspect(file, func(n ast.Node) bool {
 		if n == nil {
 			return false
@@ -118,7 +303,11 @@ func (c *DataFlowCollector) collectFromFile(file *ast.File) {
 		switch node := n.(type) {
 		case *ast.TypeSpec:
 			// Collect sensitive fields from struct definitions
-			c.fieldCollector.CollectFromTypeSpec(node)
+			if scan != nil {
+				c.fieldCollector.addFields(scan.fields[node])
+			} else {
+				c.fieldCollector.CollectFromTypeSpec(node)
+			}
 
 		case *ast.FuncDecl:
 			// Register function definition for data flow analysis
@@ -131,7 +320,15 @@ func (c *DataFlowCollector) collectFromFile(file *ast.File) {
 					c.world.RegisterFunc(obj, node, c.pkg)
 				}
 			}
-			c.collectFromFunction(node)
+			switch {
+			case isInitFunc(node):
+				c.initFuncs = append(c.initFuncs, node)
+			case scan != nil:
+				c.collectFunctionFacts(node, false)
+				c.addSinkCalls(scan.calls[node])
+			default:
+				c.collectFromFunction(node)
+			}
 			return false // Don't traverse into function body again
 		}
 
@@ -141,61 +338,193 @@ func (c *DataFlowCollector) collectFromFile(file *ast.File) {
 
 // collectFromFunction collects information from within a function
 func (c *DataFlowCollector) collectFromFunction(funcDecl *ast.FuncDecl) {
+	c.collectFunctionFacts(funcDecl, true)
+}
+
+// collectFunctionFacts records the data flow facts of a function body, and
+// its log calls when collectLogCalls is set. Facts may be recorded more than
+// once; log calls must not be.
+func (c *DataFlowCollector) collectFunctionFacts(funcDecl *ast.FuncDecl, collectLogCalls bool) {
 	// Set current function context for variable tracking
-	if funcDecl.Name != nil {
-		if obj := c.pass.TypesInfo.Defs[funcDecl.Name]; obj != nil {
-			c.varTracker.SetCurrentFunction(obj)
-		}
+	funcObj := c.funcObject(funcDecl)
+	if funcObj != nil {
+		c.varTracker.SetCurrentFunction(funcObj)
 	}
+	calls := sinkCalls{caller: funcObj}
+
+	// Methods on sensitive structs start with a tainted receiver
+	c.varTracker.CollectReceiver(funcDecl)
 
 	// Traverse function body to collect assignments, returns, and log calls
 	if funcDecl.Body != nil {
-		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
-			switch node := n.(type) {
-			case *ast.AssignStmt:
-				// Track variable assignments
-				c.varTracker.CollectAssignment(node)
-
-			case *ast.ReturnStmt:
-				// Track return statements
-				c.varTracker.CollectReturn(node)
-
-			case *ast.CallExpr:
-				// Collect log calls during traversal (single-pass optimization)
-				if c.logDetector.IsLogCall(node) {
-					c.logCalls = append(c.logCalls, node)
-				}
-			}
-			return true
-		})
+		c.collectBody(funcDecl.Body, false, &calls, collectLogCalls)
 	}
+	c.addSinkCalls(calls)
 
 	// Reset current function context
 	c.varTracker.SetCurrentFunction(nil)
 }
 
+// collectBody records the data flow facts of a function body, and its sink
+// calls into calls when collectLogCalls is set. The bodies of the function
+// literals in it, such as handlers passed to http.HandleFunc, are collected
+// along with it, but their returns are their own: they are only recorded
+// for the enclosing function when inLit is unset and outside any literal,
+// or in a literal invoked by one of its returns.
+func (c *DataFlowCollector) collectBody(body ast.Node, inLit bool, calls *sinkCalls, collectLogCalls bool) {
+	// Literals invoked by a return statement of the enclosing function
+	// return its results: return func() string { return u.Password }()
+	invoked := make(map[*ast.FuncLit]bool)
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			c.collectBody(node.Body, inLit || !invoked[node], calls, collectLogCalls)
+			return false
+
+		case *ast.AssignStmt:
+			// Track variable assignments
+			c.varTracker.CollectAssignment(node)
+
+		case *ast.ValueSpec:
+			// Track var declarations with initializers
+			c.varTracker.CollectValueSpec(node)
+
+		case *ast.RangeStmt:
+			// Track iteration variables of range loops
+			c.varTracker.CollectRange(node)
+
+		case *ast.TypeSwitchStmt:
+			// Track per-clause bindings of type switches
+			c.varTracker.CollectTypeSwitch(node)
+
+		case *ast.ReturnStmt:
+			// Track return statements; those of function literals are
+			// recorded for the variables holding them (see collectFuncValue)
+			if !inLit {
+				c.varTracker.CollectReturn(node)
+				for _, result := range node.Results {
+					if call, ok := ast.Unparen(result).(*ast.CallExpr); ok {
+						if lit, ok := ast.Unparen(call.Fun).(*ast.FuncLit); ok {
+							invoked[lit] = true
+						}
+					}
+				}
+			}
+
+		case *ast.CallExpr:
+			// Track values yielded by iterators, attached to events and
+			// bound from sensitive flags. Iterators yield from function
+			// literals, so yields are the enclosing function's.
+			c.varTracker.CollectYield(node)
+			c.varTracker.CollectEventBuilder(node)
+			c.varTracker.CollectFlagBinding(node)
+
+			// Collect log calls during traversal (single-pass optimization)
+			if collectLogCalls {
+				c.scanCall(node, calls)
+			}
+		}
+		return true
+	})
+}
+
 // Analyze processes all collected log calls and returns findings
 // This method implements Phase 2 of the Two-Phase Analysis Pattern
 // Renamed from AnalyzeAndReport - reporting is now caller's responsibility
 func (c *DataFlowCollector) Analyze() []Finding {
 	// Re-initialize detector with updated sensitive fields (after collection is complete)
+	prev := c.detector
 	c.detector = NewDetector(c.pass, c.fieldCollector.GetSensitiveFields(), c.varTracker)
+	c.detector.SetMarshalers(prev.marshalers)
+	c.detector.SetSanitizers(prev.sanitizers)
+	c.detector.SetStructScope(prev.structScope)
 
 	// Collect all findings from log calls
 	var allFindings []Finding
 
 	// Process all collected log calls
 	for _, call := range c.logCalls {
-		// Inspect arguments for sensitive data
-		for _, arg := range call.Args {
-			findings := c.detector.CheckArgForSensitiveData(arg)
+		// Fluent chains also log the arguments of the chained calls, while
+		// printf-style sinks skip arguments formatted only with %T or %p
+		for _, arg := range c.logDetector.LoggedArgs(call, c.pass.TypesInfo) {
+			sink := c.logDetector.SinkName(arg.Call, c.pass.TypesInfo)
+
+			// Inspect arguments for sensitive data
+			findings := c.detector.CheckLoggedArg(arg.Call, arg.Index)
+			reclassify(findings)
+			annotateSink(findings, call, sink, funcName(c.logCallFuncs[call]), arg.Index+1)
 			allFindings = append(allFindings, findings...)
 		}
 	}
 
+	allFindings = append(allFindings, c.KeyFindings()...)
+	allFindings = append(allFindings, c.ProducerFindings()...)
+	allFindings = append(allFindings, c.StringerFindings()...)
+	allFindings = append(allFindings, c.AuditFindings()...)
+	allFindings = append(allFindings, c.HardcodedSecretFindings()...)
+	allFindings = append(allFindings, c.CrashHandlerFindings()...)
+	allFindings = append(allFindings, c.ConfigDumpFindings()...)
+	allFindings = append(allFindings, c.BoundaryFindings()...)
+	allFindings = append(allFindings, c.HookFindings()...)
+	allFindings = append(allFindings, c.BestEffortFindings()...)
+	classifyFields(allFindings, c.fieldCollector.Classifications())
+
 	return allFindings
 }
 
+// KeyFindings returns the findings (LH0008) for sensitive data in the key
+// arguments of the collected key sink calls.
+func (c *DataFlowCollector) KeyFindings() []Finding {
+	var findings []Finding
+	for _, kc := range c.keyCalls {
+		sink := SinkName(kc.call, c.pass.TypesInfo)
+		for _, i := range kc.keyArgs {
+			argFindings := c.detector.CheckArgForSensitiveData(kc.call.Args[i])
+			asKeyFindings(argFindings)
+			annotateSink(argFindings, kc.call, sink, funcName(kc.caller), i+1)
+			findings = append(findings, argFindings...)
+		}
+	}
+	return findings
+}
+
+// ProducerFindings returns the findings (LH0017) for sensitive data in the
+// payload arguments of the collected producer sink calls.
+func (c *DataFlowCollector) ProducerFindings() []Finding {
+	var findings []Finding
+	for _, pc := range c.producerCalls {
+		sink := SinkName(pc.call, c.pass.TypesInfo)
+		for _, i := range pc.payloadArgs {
+			argFindings := c.detector.CheckArgForSensitiveData(pc.call.Args[i])
+			asProducerFindings(argFindings)
+			annotateSink(argFindings, pc.call, sink, funcName(pc.caller), i+1)
+			findings = append(findings, argFindings...)
+		}
+	}
+	return findings
+}
+
+// AuditFindings returns the untagged-field audit findings (LH0007) for the
+// collector's package, or nil when the audit is disabled.
+func (c *DataFlowCollector) AuditFindings() []Finding {
+	return AuditUntaggedFields(c.pass.Files, packagePath(c.pass), c.audit)
+}
+
+// HardcodedSecretFindings returns the hardcoded-secrets audit findings
+// (LH0018) for the collector's package, or nil when the audit is disabled.
+func (c *DataFlowCollector) HardcodedSecretFindings() []Finding {
+	return AuditHardcodedSecrets(c.pass.Files, c.pass.TypesInfo, packagePath(c.pass), c.hardcodedSecrets)
+}
+
+// packagePath returns the import path of the package pass analyzes, or ""
+func packagePath(pass *analysis.Pass) string {
+	if pass.Pkg == nil {
+		return ""
+	}
+	return pass.Pkg.Path()
+}
+
 // Legacy API methods for backward compatibility
 
 // GetSensitiveFields returns the collected sensitive fields
@@ -0,0 +1,241 @@
+package detector
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+// metadataAnalyzer runs the full DataFlowCollector pipeline and reports each
+// finding's structured metadata instead of its message, so tests can assert
+// on the fields reporters consume.
+var metadataAnalyzer = &analysis.Analyzer{
+	Name: "finding_metadata",
+	Doc:  "Test analyzer: reports structured Finding metadata",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		c := NewDataFlowCollector(pass, &config.Config{})
+		c.Collect()
+		for _, f := range c.Analyze() {
+			fieldLine := 0
+			if f.FieldPos.IsValid() {
+				fieldLine = pass.Fset.Position(f.FieldPos).Line
+			}
+			pass.Reportf(f.Pos, "%s severity=%s field=%s@%d sink=%s flow=%s",
+				f.SARIFRuleID(), f.Level(), f.Field, fieldLine, f.Sink, flowLabels(pass, f.FlowPath))
+		}
+		return nil, nil
+	},
+}
+
+// flowLabels renders a flow path as "label@line" hops joined by ">".
+func flowLabels(pass *analysis.Pass, steps []FlowStep) string {
+	parts := make([]string, 0, len(steps))
+	for _, step := range steps {
+		parts = append(parts, fmt.Sprintf("%s@%d", step.Label, pass.Fset.Position(step.Pos).Line))
+	}
+	return strings.Join(parts, ">")
+}
+
+func TestDataFlowCollector_FindingMetadata(t *testing.T) {
+	src := fmt.Sprintf(`package metatest
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string %s
+}
+
+func getPassword(u User) string {
+	return u.Password
+}
+
+func test(u User, logger *slog.Logger) {
+	slog.Info("msg", u.Password) // want "LH0004 severity=error field=User.Password@7 sink=log/slog.Info flow=User.Password@15"
+	p := u.Password
+	logger.Warn("msg", p) // want "LH0001 severity=error field=User.Password@7 sink=\\(\\*log/slog.Logger\\).Warn flow=User.Password@16>p@16"
+	slog.Info("msg", getPassword(u)) // want "LH0002 severity=error field=User.Password@7 sink=log/slog.Info flow=User.Password@11>getPassword return@11"
+	slog.Info("msg", u) // want "LH0003 severity=error field=User.Password@7 sink=log/slog.Info flow=User@19"
+}
+`, sensitiveStructTag())
+
+	dir := writeTempPkg(t, "metatest", src)
+	analysistest.Run(t, dir, metadataAnalyzer, "metatest")
+}
+
+func TestDataFlowCollector_FieldLevels(t *testing.T) {
+	src := fmt.Sprintf(`package leveltest
+
+import "log/slog"
+
+type Contact struct {
+	Email string %s
+	Phone string %s
+}
+
+type Account struct {
+	Contact
+	Password string %s
+}
+
+func test(c Contact, a Account) {
+	slog.Info("msg", c.Email) // want "LH0004 severity=warning "
+	slog.Info("msg", c.Phone) // want "LH0004 severity=note "
+	email := c.Email
+	slog.Info("msg", email)      // want "LH0001 severity=warning "
+	slog.Info("msg", a.Email)    // want "LH0004 severity=warning "
+	slog.Info("msg", c)          // want "LH0003 severity=warning "
+	slog.Info("msg", a)          // want "LH0003 severity=error "
+	slog.Info("msg", a.Password) // want "LH0004 severity=error "
+}
+`, "`json:\"email\" sensitive:\"true,level=warning\"`", "`sensitive:\"true,level=note\"`", sensitiveStructTag())
+
+	dir := writeTempPkg(t, "leveltest", src)
+	analysistest.Run(t, dir, metadataAnalyzer, "leveltest")
+}
+
+func TestSensitiveTagLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag  string
+		want Severity
+	}{
+		{`sensitive:"true"`, ""},
+		{`sensitive:"true,level=warning"`, SeverityWarning},
+		{`json:"email" sensitive:"true,level=note"`, SeverityNote},
+		{`sensitive:"true,level=error"`, SeverityError},
+		{`sensitive:"true, level=warning"`, SeverityWarning},
+		{`sensitive:"true,level=low"`, ""},
+		{`sensitive:"false,level=warning"`, ""},
+		{`sensitive:\"true,level=warning\"`, SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.tag, func(t *testing.T) {
+			t.Parallel()
+			if got := SensitiveTagLevel(tt.tag); got != tt.want {
+				t.Errorf("SensitiveTagLevel(%q) = %q, want %q", tt.tag, got, tt.want)
+			}
+			if want := !strings.HasPrefix(tt.tag, `sensitive:"false`); HasSensitiveTag(tt.tag) != want {
+				t.Errorf("HasSensitiveTag(%q) = %v, want %v", tt.tag, !want, want)
+			}
+		})
+	}
+}
+
+func TestHasPIITag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tag  string
+		want bool
+	}{
+		{`pii:"true"`, true},
+		{`json:"email" pii:"true"`, true},
+		{`pii:\"true\"`, true},
+		{`pii:"false"`, false},
+		{`sensitive:"true"`, false},
+		{`json:"pii"`, false},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.tag, func(t *testing.T) {
+			t.Parallel()
+			if got := HasPIITag(tt.tag); got != tt.want {
+				t.Errorf("HasPIITag(%q) = %v, want %v", tt.tag, got, tt.want)
+			}
+		})
+	}
+}
+
+// positionAnalyzer reports the column of each finding, the sink argument it
+// was found in and the message suffix naming that argument.
+var positionAnalyzer = &analysis.Analyzer{
+	Name: "finding_position",
+	Doc:  "Test analyzer: reports Finding columns and sink arguments",
+	Run: func(pass *analysis.Pass) (interface{}, error) {
+		c := NewDataFlowCollector(pass, &config.Config{})
+		c.Collect()
+		for _, f := range c.Analyze() {
+			_, suffix, _ := strings.Cut(f.Message, ") in ")
+			pass.Reportf(f.Pos, "%s col=%d arg=%d in %s", f.SARIFRuleID(), pass.Fset.Position(f.Pos).Column, f.Arg, suffix)
+		}
+		return nil, nil
+	},
+}
+
+func TestDataFlowCollector_FindingPosition(t *testing.T) {
+	src := fmt.Sprintf(`package postest
+
+import "log/slog"
+
+type Credentials struct {
+	Password string %s
+}
+
+type Session struct {
+	User struct{ Creds Credentials }
+}
+
+func test(s Session) {
+	slog.Info("msg", "pw", slog.Any("creds", s.User.Creds.Password)) // want "LH0004 col=56 arg=3 in argument 3 of slog.Info"
+	slog.Info("msg", slog.Group("g", "pw", s.User.Creds.Password)) // want "LH0004 col=54 arg=2 in argument 2 of slog.Info"
+}
+`, sensitiveStructTag())
+
+	dir := writeTempPkg(t, "postest", src)
+	analysistest.Run(t, dir, positionAnalyzer, "postest")
+}
+
+// TestDataFlowCollector_ConcurrentScan checks a package with enough files to
+// be scanned concurrently: log calls are found in every file, in file order,
+// and values flow across files, whose functions are collected serially.
+func TestDataFlowCollector_ConcurrentScan(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(4))
+
+	const files = concurrentScanMinFiles + 4
+	dir := t.TempDir()
+	pkgDir := filepath.Join(dir, "src", "scantest")
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		t.Fatalf("failed to create pkg dir: %v", err)
+	}
+	for i := 0; i < files; i++ {
+		// Each file declares a struct, a getter returning its secret, and a
+		// handler logging the getter of the previous file
+		src := fmt.Sprintf(`package scantest
+
+import "log/slog"
+
+type T%[1]d struct {
+	Name   string
+	Secret string %[3]s
+}
+
+func get%[1]d(v T%[1]d) string {
+	return v.Secret
+}
+
+func handle%[1]d(v T%[1]d, w T%[2]d) {
+	slog.Info("msg", "name", v.Name)
+	slog.Info("msg", "secret", v.Secret) // want "LH0004 severity=error field=T%[1]d.Secret@7 sink=log/slog.Info flow=T%[1]d.Secret@16"
+	s := get%[2]d(w)
+	slog.Info("msg", "secret", s) // want "LH0001 severity=error field=T%[2]d.Secret@7 sink=log/slog.Info flow=T%[2]d.Secret@11>get%[2]d return@11>s@17"
+}
+`, i, max(i-1, 0), sensitiveStructTag())
+		if err := os.WriteFile(filepath.Join(pkgDir, fmt.Sprintf("file%02d.go", i)), []byte(src), 0644); err != nil {
+			t.Fatalf("failed to write source: %v", err)
+		}
+	}
+
+	analysistest.Run(t, dir, metadataAnalyzer, "scantest")
+}
@@ -0,0 +1,172 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// flagDefiners are the flag package functions, and *flag.FlagSet methods,
+// returning a pointer to the value of the flag they define, by name
+var flagDefiners = map[string]bool{
+	"String": true, "Int": true, "Int64": true, "Uint": true, "Uint64": true,
+	"Float64": true, "Bool": true, "Duration": true,
+}
+
+// flagBinders are the flag package functions, and *flag.FlagSet methods,
+// storing the value of the flag they define into their first argument
+var flagBinders = map[string]bool{
+	"StringVar": true, "IntVar": true, "Int64Var": true, "UintVar": true, "Uint64Var": true,
+	"Float64Var": true, "BoolVar": true, "DurationVar": true, "TextVar": true, "Var": true,
+}
+
+// CommandLineSources matches the values passed on the command line that a
+// configuration declares sensitive (see config.CommandLineConfig). They are
+// reported under LH0012 (see reclassify):
+//
+//	dbPassword := flag.String("db-password", "", "")  // *dbPassword
+//	flag.StringVar(&cfg.Password, "db-password", "", "")
+//	os.Args[2]
+type CommandLineSources struct {
+	flags map[string]bool // Flag names without dashes
+	args  map[int]bool    // Indices into os.Args
+}
+
+// NewCommandLineSources creates a matcher for cfg's command-line sources, or
+// returns nil when none are configured
+func NewCommandLineSources(cfg *config.Config) *CommandLineSources {
+	if cfg == nil || len(cfg.CommandLine.Flags) == 0 && len(cfg.CommandLine.Args) == 0 {
+		return nil
+	}
+	s := &CommandLineSources{flags: make(map[string]bool), args: make(map[int]bool)}
+	for _, name := range cfg.CommandLine.FlagNames() {
+		s.flags[name] = true
+	}
+	for _, index := range cfg.CommandLine.Args {
+		s.args[index] = true
+	}
+	return s
+}
+
+// Source returns a source for expr when it reads a sensitive command-line
+// value, or nil: the pointer returned by the definition of a sensitive flag,
+// such as flag.String("db-password", ...), a sensitive element of os.Args,
+// or os.Args as a whole when one of its elements is sensitive. An element
+// read at an index that is not a constant is assumed to be sensitive.
+func (s *CommandLineSources) Source(expr ast.Expr, info *types.Info) *SensitiveSource {
+	if s == nil {
+		return nil
+	}
+	var label string
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		if name, ok := s.flagName(e, info, flagDefiners); ok {
+			label = "flag -" + name
+		}
+	case *ast.IndexExpr:
+		if !isOSArgs(e.X, info) || len(s.args) == 0 {
+			return nil
+		}
+		tv, ok := info.Types[e.Index]
+		if !ok || tv.Value == nil {
+			label = "os.Args[" + types.ExprString(e.Index) + "]"
+			break
+		}
+		if index, exact := constant.Int64Val(constant.ToInt(tv.Value)); exact && s.args[int(index)] {
+			label = fmt.Sprintf("os.Args[%d]", index)
+		}
+	case *ast.SelectorExpr, *ast.Ident:
+		if isOSArgs(e, info) && len(s.args) > 0 {
+			label = "os.Args"
+		}
+	}
+	if label == "" {
+		return nil
+	}
+	return &SensitiveSource{
+		FieldName:   label,
+		Position:    expr.Pos(),
+		FlowPath:    []FlowStep{{Label: label, Pos: expr.Pos()}},
+		CommandLine: true,
+	}
+}
+
+// BoundFlag returns the variable a call such as
+// flag.StringVar(&cfg.Password, "db-password", ...) stores the value of a
+// sensitive flag into, &cfg.Password, along with its source, or nil
+func (s *CommandLineSources) BoundFlag(call *ast.CallExpr, info *types.Info) (ast.Expr, *SensitiveSource) {
+	if s == nil {
+		return nil, nil
+	}
+	name, ok := s.flagName(call, info, flagBinders)
+	if !ok {
+		return nil, nil
+	}
+	label := "flag -" + name
+	return call.Args[0], &SensitiveSource{
+		FieldName:   label,
+		Position:    call.Pos(),
+		FlowPath:    []FlowStep{{Label: label, Pos: call.Pos()}},
+		CommandLine: true,
+	}
+}
+
+// flagName returns the name of the sensitive flag defined by call, a call of
+// one of funcs of the flag package or of *flag.FlagSet. The name is the
+// first argument of definers and the second of binders.
+func (s *CommandLineSources) flagName(call *ast.CallExpr, info *types.Info, funcs map[string]bool) (string, bool) {
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "flag" || !funcs[fn.Name()] {
+		return "", false
+	}
+	index := 0
+	if flagBinders[fn.Name()] {
+		index = 1
+	}
+	if index >= len(call.Args) {
+		return "", false
+	}
+	tv, ok := info.Types[call.Args[index]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return "", false
+	}
+	name := constant.StringVal(tv.Value)
+	return name, s.flags[name]
+}
+
+// isOSArgs reports whether expr refers to os.Args
+func isOSArgs(expr ast.Expr, info *types.Info) bool {
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	case *ast.Ident:
+		ident = e
+	default:
+		return false
+	}
+	v, ok := info.Uses[ident].(*types.Var)
+	return ok && v.Pkg() != nil && v.Pkg().Path() == "os" && v.Name() == "Args"
+}
+
+// SetCommandLineSources sets the command-line values declared sensitive
+func (vt *VarTracker) SetCommandLineSources(s *CommandLineSources) {
+	vt.checker.commandLine = s
+}
+
+// CollectFlagBinding taints the variable a sensitive flag is bound to, cfg
+// in flag.StringVar(&cfg.Password, "db-password", ...)
+func (fc *FactCollector) CollectFlagBinding(call *ast.CallExpr) {
+	target, source := fc.checker.commandLine.BoundFlag(call, fc.checker.pass.TypesInfo)
+	if source == nil {
+		return
+	}
+	if addr, ok := ast.Unparen(target).(*ast.UnaryExpr); ok && addr.Op == token.AND {
+		target = addr.X
+	}
+	fc.taintLHS(target, *source)
+}
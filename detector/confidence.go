@@ -0,0 +1,68 @@
+package detector
+
+import (
+	"math"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// Confidence levels of a finding, by score (see config.ConfidenceHighScore)
+const (
+	ConfidenceHigh   = "high"   // A tagged or configured source reaches the sink directly
+	ConfidenceMedium = "medium" // The value reaches the sink through a few data flow steps
+	ConfidenceLow    = "low"    // Long flows and heuristics: name patterns, syntactic matching or audits
+)
+
+// Factors applied to the confidence score of a finding, for each data flow
+// step after its source and for a heuristic source
+const (
+	flowStepConfidence  = 0.75
+	heuristicConfidence = 0.45
+)
+
+// ConfidenceScore returns how likely the finding is a real leak, from 0 to 1,
+// rounded to two decimals. A tagged field or configured source logged
+// directly scores 1. The score decreases with each data flow step the value
+// went through before the sink, such as a variable, parameter, return value
+// or container, and when the finding rests on heuristics: a field recognized
+// by its name, a file that does not type-check or an audit rule.
+func (f Finding) ConfidenceScore() float64 {
+	score := 1.0
+	if f.Classification != "" || f.BestEffort ||
+		f.RuleID == RuleIDUntaggedSensitiveField || f.RuleID == RuleIDConfigDump ||
+		f.RuleID == RuleIDHardcodedSecret || f.RuleID == RuleIDCrashHandlerDump {
+		score *= heuristicConfidence
+	}
+	for i := 1; i < len(f.FlowPath); i++ {
+		score *= flowStepConfidence
+	}
+	return math.Round(score*100) / 100
+}
+
+// Confidence returns the confidence level of the finding's score: high,
+// medium or low
+func (f Finding) Confidence() string {
+	switch score := f.ConfidenceScore(); {
+	case score >= config.ConfidenceHighScore:
+		return ConfidenceHigh
+	case score >= config.ConfidenceMediumScore:
+		return ConfidenceMedium
+	}
+	return ConfidenceLow
+}
+
+// FilterByConfidence drops the findings scoring below the min-confidence
+// setting of cfg (see config.Config.MinConfidenceScore)
+func FilterByConfidence(findings []Finding, cfg *config.Config) []Finding {
+	min := cfg.MinConfidenceScore()
+	if min <= 0 {
+		return findings
+	}
+	kept := findings[:0]
+	for _, f := range findings {
+		if f.ConfidenceScore() >= min {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,73 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+func TestFinding_ConfidenceScore(t *testing.T) {
+	t.Parallel()
+
+	steps := func(n int) []FlowStep { return make([]FlowStep, n) }
+	tests := []struct {
+		name      string
+		finding   Finding
+		wantScore float64
+		wantLevel string
+	}{
+		{"direct field access", Finding{RuleID: RuleIDSensitiveField, FlowPath: steps(1)}, 1, ConfidenceHigh},
+		{"whole struct", Finding{RuleID: RuleIDSensitiveStruct}, 1, ConfidenceHigh},
+		{"variable", Finding{RuleID: RuleIDSensitiveVar, FlowPath: steps(2)}, 0.75, ConfidenceMedium},
+		{"parameter and return", Finding{RuleID: RuleIDSensitiveCall, FlowPath: steps(3)}, 0.56, ConfidenceMedium},
+		{"long flow", Finding{RuleID: RuleIDCrossPkgSensitiveSink, FlowPath: steps(4)}, 0.42, ConfidenceLow},
+		{"name heuristic", Finding{RuleID: RuleIDPersonalData, Classification: "matched default pattern 'e_?mail'"}, 0.45, ConfidenceLow},
+		{"best-effort", Finding{RuleID: RuleIDSensitiveField, BestEffort: true}, 0.45, ConfidenceLow},
+		{"audit", Finding{RuleID: RuleIDUntaggedSensitiveField}, 0.45, ConfidenceLow},
+		{"hardcoded secret audit", Finding{RuleID: RuleIDHardcodedSecret}, 0.45, ConfidenceLow},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.finding.ConfidenceScore(); got != tt.wantScore {
+				t.Errorf("ConfidenceScore() = %v, want %v", got, tt.wantScore)
+			}
+			if got := tt.finding.Confidence(); got != tt.wantLevel {
+				t.Errorf("Confidence() = %q, want %q", got, tt.wantLevel)
+			}
+		})
+	}
+}
+
+func TestFilterByConfidence(t *testing.T) {
+	t.Parallel()
+
+	findings := []Finding{
+		{RuleID: RuleIDSensitiveField, Expr: "u.Password"},
+		{RuleID: RuleIDSensitiveVar, Expr: "pw", FlowPath: make([]FlowStep, 2)},
+		{RuleID: RuleIDPersonalData, Expr: "u.Email", Classification: "matched default pattern 'e_?mail'"},
+	}
+	tests := []struct {
+		min  string
+		want []string
+	}{
+		{"", []string{"u.Password", "pw", "u.Email"}},
+		{"medium", []string{"u.Password", "pw"}},
+		{"high", []string{"u.Password"}},
+		{"0.7", []string{"u.Password", "pw"}},
+	}
+	for _, tt := range tests {
+		cfg := &config.Config{MinConfidence: tt.min}
+		got := FilterByConfidence(append([]Finding(nil), findings...), cfg)
+		if len(got) != len(tt.want) {
+			t.Errorf("min-confidence %q: got %d findings, want %d", tt.min, len(got), len(tt.want))
+			continue
+		}
+		for i, f := range got {
+			if f.Expr != tt.want[i] {
+				t.Errorf("min-confidence %q: findings[%d] = %s, want %s", tt.min, i, f.Expr, tt.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// debugPrinters are the print functions of fmt and log, and the methods of
+// *log.Logger, used to dump values while debugging
+var debugPrinters = map[string]bool{"Print": true, "Printf": true, "Println": true}
+
+// ConfigDumpFindings returns the findings (LH0013) of the config-dumps audit,
+// or nil when it is disabled: a configuration struct with more than the
+// configured number of fields, declared in a package whose name matches the
+// audit's patterns, printed whole with fmt or log. The struct is reported
+// whether its fields are tagged or not, as such dumps are left over from
+// debugging and print every secret a configuration grows. Structs with
+// sensitive fields are left to LH0003, and types that format themselves
+// through String, Error or Format are not reported.
+func (c *DataFlowCollector) ConfigDumpFindings() []Finding {
+	if c.configDumps == nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, file := range c.pass.Files {
+		for _, decl := range file.Decls {
+			caller := ""
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				caller = funcName(c.pass.TypesInfo.Defs[fn.Name])
+			}
+			ast.Inspect(decl, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok {
+					return true
+				}
+				printer := debugPrinter(call, c.pass.TypesInfo)
+				if printer == "" {
+					return true
+				}
+				sink := SinkName(call, c.pass.TypesInfo)
+				for i, arg := range call.Args {
+					if i == 0 && printer == "Printf" {
+						continue // The format string
+					}
+					finding := c.configDump(arg)
+					if finding == nil {
+						continue
+					}
+					argFindings := []Finding{*finding}
+					annotateSink(argFindings, call, sink, caller, i+1)
+					findings = append(findings, argFindings...)
+				}
+				return true
+			})
+		}
+	}
+	return findings
+}
+
+// configDump returns a finding for arg when it is a configuration struct, or
+// a pointer to one, printed whole, or nil
+func (c *DataFlowCollector) configDump(arg ast.Expr) *Finding {
+	typ := c.pass.TypesInfo.TypeOf(arg)
+	if typ == nil || formatsItself(typ) {
+		return nil
+	}
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return nil
+	}
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok || st.NumFields() <= c.configDumps.MaxFields {
+		return nil
+	}
+	pkg := named.Obj().Pkg().Name()
+	if !c.configDumps.Packages.Match(pkg) || c.detector.hasSensitiveFields(named) {
+		return nil
+	}
+	qualified := pkg + "." + named.Obj().Name()
+	return &Finding{
+		Pos:  arg.Pos(),
+		End:  arg.End(),
+		Expr: types.ExprString(arg),
+		Message: fmt.Sprintf(
+			"configuration struct '%s' with %d fields is printed whole and may leak untagged secrets",
+			qualified, st.NumFields()),
+		RuleID:   RuleIDConfigDump,
+		Severity: SeverityWarning,
+	}
+}
+
+// debugPrinter returns the name of the debugPrinters function call calls,
+// e.g. "Println", or ""
+func debugPrinter(call *ast.CallExpr, info *types.Info) string {
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	if !ok || fn.Pkg() == nil || !debugPrinters[fn.Name()] {
+		return ""
+	}
+	if path := fn.Pkg().Path(); path == "log" || path == "fmt" && isPackageFunc(fn) {
+		return fn.Name()
+	}
+	return ""
+}
+
+// formatsItself reports whether fmt prints values of type t through a
+// method of theirs, String, Error or Format, rather than field by field
+func formatsItself(t types.Type) bool {
+	mset := types.NewMethodSet(t)
+	for _, name := range []string{"String", "Error", "Format"} {
+		if mset.Lookup(nil, name) != nil {
+			return true
+		}
+	}
+	return false
+}
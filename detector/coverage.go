@@ -0,0 +1,101 @@
+package detector
+
+import (
+	"go/ast"
+	"sort"
+	"strconv"
+)
+
+// PackageCoverage summarizes what the analysis saw in a single package
+type PackageCoverage struct {
+	Path            string // Import path
+	Files           int    // Number of parsed Go files
+	SinkCalls       int    // Logging calls whose arguments were inspected
+	SensitiveFields int    // Struct fields tagged with sensitive:"true"
+}
+
+// Coverage reports what a whole-program run inspected, so a run without
+// findings can be told apart from one that analyzed nothing relevant
+type Coverage struct {
+	Packages []PackageCoverage // Sorted by import path
+
+	// TargetCalls counts the call sites matched by each configured target
+	// entry (see TargetEntries); entries that never matched have a zero count.
+	TargetCalls map[string]int
+}
+
+// Totals sums the per-package counts
+func (c Coverage) Totals() PackageCoverage {
+	var total PackageCoverage
+	for _, p := range c.Packages {
+		total.Files += p.Files
+		total.SinkCalls += p.SinkCalls
+		total.SensitiveFields += p.SensitiveFields
+	}
+	return total
+}
+
+// UnmatchedTargets returns the configured target entries that matched no
+// call site, sorted
+func (c Coverage) UnmatchedTargets() []string {
+	var unmatched []string
+	for entry, n := range c.TargetCalls {
+		if n == 0 {
+			unmatched = append(unmatched, entry)
+		}
+	}
+	sort.Strings(unmatched)
+	return unmatched
+}
+
+// Coverage summarizes the packages, sink calls and sensitive fields seen by
+// Collect, and how often each configured target matched. It must be called
+// after Collect.
+func (wp *WholeProgramCollector) Coverage() Coverage {
+	cov := Coverage{TargetCalls: make(map[string]int)}
+	for _, entry := range TargetEntries(wp.cfg) {
+		cov.TargetCalls[entry] = 0
+	}
+
+	for pkg, c := range wp.pkgCollectors {
+		calls := c.LogCalls()
+		cov.Packages = append(cov.Packages, PackageCoverage{
+			Path:            pkg.PkgPath,
+			Files:           len(pkg.Syntax),
+			SinkCalls:       len(calls),
+			SensitiveFields: countSensitiveFields(pkg.Syntax),
+		})
+		for _, call := range calls {
+			if entry := c.LogDetector().CustomTarget(call, pkg.TypesInfo); entry != "" {
+				cov.TargetCalls[entry]++
+			}
+		}
+	}
+	sort.Slice(cov.Packages, func(i, j int) bool { return cov.Packages[i].Path < cov.Packages[j].Path })
+	return cov
+}
+
+// countSensitiveFields counts the struct fields tagged with sensitive:"true"
+// declared in files
+func countSensitiveFields(files []*ast.File) int {
+	n := 0
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			structType, ok := node.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, field := range structType.Fields.List {
+				if field.Tag == nil {
+					continue
+				}
+				// Tags may be raw or interpreted string literals
+				if tag, err := strconv.Unquote(field.Tag.Value); err == nil && HasSensitiveTag(tag) {
+					n += max(len(field.Names), 1)
+				}
+			}
+			return true
+		})
+	}
+	return n
+}
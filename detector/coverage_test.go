@@ -0,0 +1,30 @@
+package detector
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+)
+
+func TestCountSensitiveFields(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+type User struct {
+	Name     string
+	Password string ` + "`sensitive:\"true\"`" + `
+	Token    string "sensitive:\"true,level=warning\""
+	A, B     string ` + "`sensitive:\"true\" json:\"-\"`" + `
+	Email    string ` + "`json:\"email\"`" + `
+}
+`
+	file, err := parser.ParseFile(token.NewFileSet(), "p.go", src, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := countSensitiveFields([]*ast.File{file}); got != 4 {
+		t.Errorf("countSensitiveFields() = %d, want 4 with the double-quoted tag", got)
+	}
+}
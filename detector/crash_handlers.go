@@ -0,0 +1,164 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// CrashHandlerFindings returns the findings (LH0019) of the crash-handlers
+// audit, or nil when it is disabled: local variables and parameters whose
+// names match the audit's patterns, logged by a crash handler, a function
+// that calls recover and dumps the stack with debug.PrintStack, debug.Stack
+// or runtime.Stack. Crash handlers log whatever context is at hand, and their
+// output is copied to crash reports and error trackers.
+//
+//	defer func() {
+//		if r := recover(); r != nil {
+//			log.Printf("panic: %v token=%s\n%s", r, token, debug.Stack()) // token is reported
+//		}
+//	}()
+//
+// Variables already tracked as sensitive are left to the other rules, and
+// values passed through a function call, such as a masking helper, are not
+// reported.
+func (c *DataFlowCollector) CrashHandlerFindings() []Finding {
+	if c.crashHandlers == nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, file := range c.pass.Files {
+		for _, decl := range file.Decls {
+			caller := ""
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				caller = funcName(c.pass.TypesInfo.Defs[fn.Name])
+			}
+			ast.Inspect(decl, func(n ast.Node) bool {
+				switch n := n.(type) {
+				case *ast.FuncDecl:
+					if n.Body != nil {
+						findings = append(findings, c.crashHandler(n.Body, caller)...)
+					}
+				case *ast.FuncLit:
+					findings = append(findings, c.crashHandler(n.Body, caller)...)
+				}
+				return true
+			})
+		}
+	}
+	return findings
+}
+
+// crashHandler returns the findings for the sink calls of body, the body of
+// a function, when it is a crash handler. Function literals nested in body
+// are functions of their own and are left out.
+func (c *DataFlowCollector) crashHandler(body *ast.BlockStmt, caller string) []Finding {
+	info := c.pass.TypesInfo
+	recovers := false
+	dumper := ""
+	var sinks []*ast.CallExpr
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			if isRecoverCall(n, info) {
+				recovers = true
+			} else if name := stackDumper(n, info); name != "" && dumper == "" {
+				dumper = name
+			} else if c.logDetector.IsLogCallWithInfo(n, info) {
+				sinks = append(sinks, n)
+			}
+		}
+		return true
+	})
+	if !recovers || dumper == "" {
+		return nil
+	}
+
+	var findings []Finding
+	for _, call := range sinks {
+		sink := c.logDetector.SinkName(call, info)
+		for _, arg := range c.logDetector.LoggedArgs(call, info) {
+			ident := c.crashDumpVar(arg.Expr())
+			if ident == nil {
+				continue
+			}
+			argFindings := []Finding{{
+				Pos:  ident.Pos(),
+				End:  ident.End(),
+				Expr: ident.Name,
+				Message: fmt.Sprintf(
+					"local variable '%s' is logged by a crash handler dumping the stack with %s",
+					ident.Name, dumper),
+				RuleID:   RuleIDCrashHandlerDump,
+				Severity: SeverityWarning,
+			}}
+			annotateSink(argFindings, arg.Call, sink, caller, arg.Index+1)
+			argFindings[0].classify(patternClassification(c.crashHandlers, ident.Name))
+			findings = append(findings, argFindings...)
+		}
+	}
+	return findings
+}
+
+// crashDumpVar returns the first identifier in arg of a local variable or
+// parameter whose name matches the audit's patterns, or nil
+func (c *DataFlowCollector) crashDumpVar(arg ast.Expr) *ast.Ident {
+	info := c.pass.TypesInfo
+	var found *ast.Ident
+	ast.Inspect(arg, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.CallExpr:
+			// Only conversions pass the value through unchanged
+			return info.Types[n.Fun].IsType()
+		case *ast.Ident:
+			v, ok := info.Uses[n].(*types.Var)
+			if !ok || !isLocalVar(v) || !c.crashHandlers.Match(v.Name()) {
+				return true
+			}
+			if _, tracked := c.varTracker.IsSensitiveVar(v); !tracked {
+				found = n
+			}
+		}
+		return true
+	})
+	return found
+}
+
+// isLocalVar reports whether v is a variable or parameter declared in a
+// function, rather than a field or a package-level variable
+func isLocalVar(v *types.Var) bool {
+	return !v.IsField() && v.Pkg() != nil && v.Parent() != nil && v.Parent() != v.Pkg().Scope()
+}
+
+// isRecoverCall reports whether call is a call to the recover builtin
+func isRecoverCall(call *ast.CallExpr, info *types.Info) bool {
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	builtin, ok := info.Uses[ident].(*types.Builtin)
+	return ok && builtin.Name() == "recover"
+}
+
+// stackDumper returns the name of the function dumping the goroutine stacks
+// call calls, "debug.PrintStack", "debug.Stack" or "runtime.Stack", or ""
+func stackDumper(call *ast.CallExpr, info *types.Info) string {
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	if !ok || fn.Pkg() == nil || !isPackageFunc(fn) {
+		return ""
+	}
+	switch path, name := fn.Pkg().Path(), fn.Name(); {
+	case path == "runtime/debug" && (name == "PrintStack" || name == "Stack"),
+		path == "runtime" && name == "Stack":
+		return fn.Pkg().Name() + "." + name
+	}
+	return ""
+}
@@ -0,0 +1,114 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strings"
+)
+
+// credentialHeaders are the HTTP request headers whose values are
+// credentials: a bearer token, a JWT or base64-encoded basic auth
+var credentialHeaders = []string{"Authorization", "Proxy-Authorization"}
+
+// credentialSource returns a source for the expressions that carry a
+// credential whatever the struct tags say, or nil. They are reported under
+// LH0010 (see reclassify):
+//
+//	r.Header.Get("Authorization")    // also Values and r.Header["Authorization"]
+//	tok.Raw, tok.SignedString(key)   // tok is a *jwt.Token
+//	tok.String()
+//
+// A JWT token is a Token type declared in a package named jwt, which covers
+// github.com/golang-jwt/jwt and its forks.
+func credentialSource(expr ast.Expr, info *types.Info) *SensitiveSource {
+	var label string
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		sel, ok := ast.Unparen(e.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return nil
+		}
+		recv := info.TypeOf(sel.X)
+		switch name := sel.Sel.Name; {
+		case isHTTPHeader(recv) && (name == "Get" || name == "Values") && len(e.Args) == 1:
+			label = credentialHeader(e.Args[0], info)
+		case isJWTToken(recv) && (name == "SignedString" || name == "String"):
+			label = "jwt.Token"
+		}
+	case *ast.IndexExpr:
+		if isHTTPHeader(info.TypeOf(e.X)) {
+			label = credentialHeader(e.Index, info)
+		} else if _, ok := info.TypeOf(e.X).(*types.Slice); ok {
+			// r.Header.Values("Authorization")[0]
+			return credentialSource(e.X, info)
+		}
+	case *ast.SelectorExpr:
+		if isJWTToken(info.TypeOf(e.X)) && e.Sel.Name == "Raw" {
+			label = "jwt.Token"
+		}
+	}
+	if label == "" {
+		return nil
+	}
+	return &SensitiveSource{
+		FieldName:  label,
+		Position:   expr.Pos(),
+		FlowPath:   []FlowStep{{Label: label, Pos: expr.Pos()}},
+		Credential: true,
+	}
+}
+
+// builtinSource returns the source of expr when it is sensitive whatever
+// the struct tags say: a credential (see credentialSource) or a value
+// unwrapped from a redact wrapper (see exposedSource). Otherwise nil.
+func builtinSource(expr ast.Expr, info *types.Info) *SensitiveSource {
+	if source := credentialSource(expr, info); source != nil {
+		return source
+	}
+	return exposedSource(expr, info)
+}
+
+// credentialHeader returns the label for a header name constant naming one
+// of credentialHeaders, e.g. "Authorization header", or ""
+func credentialHeader(name ast.Expr, info *types.Info) string {
+	tv, ok := info.Types[name]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return ""
+	}
+	value := constant.StringVal(tv.Value)
+	for _, header := range credentialHeaders {
+		if strings.EqualFold(value, header) {
+			return header + " header"
+		}
+	}
+	return ""
+}
+
+// isHTTPHeader reports whether t is net/http.Header
+func isHTTPHeader(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == "net/http" && named.Obj().Name() == "Header"
+}
+
+// isJWTToken reports whether t is a Token type of a package named jwt, or a
+// pointer to it
+func isJWTToken(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Name() == "jwt" && named.Obj().Name() == "Token"
+}
+
+// credentialMessage is the message of LH0010 findings
+func credentialMessage(field string) string {
+	return fmt.Sprintf("%s carries a bearer credential and should not be logged", field)
+}
@@ -85,13 +85,15 @@ func (da *DataFlowAnalyzer) analyzeFunctionCalls(funcObj types.Object, funcDecl
 			paramNames = append(paramNames, field.Names...)
 		}
 
-		// Map each argument to its corresponding parameter
+		// Map each argument to its corresponding parameter, the variadic one
+		// for trailing arguments
 		for argIdx, arg := range call.Args {
-			if argIdx >= len(paramNames) {
+			paramIdx := paramIndex(calledFuncDecl, argIdx, len(paramNames))
+			if paramIdx < 0 {
 				break
 			}
 
-			paramName := paramNames[argIdx]
+			paramName := paramNames[paramIdx]
 
 			// Check if this argument is sensitive
 			if source := da.checker.checkSensitiveExpr(arg, da.sensitiveVars, da.sensitiveFuncs); source != nil {
@@ -99,11 +101,8 @@ func (da *DataFlowAnalyzer) analyzeFunctionCalls(funcObj types.Object, funcDecl
 				if paramObj := da.checker.pass.TypesInfo.Defs[paramName]; paramObj != nil {
 					if v, ok := paramObj.(*types.Var); ok {
 						// Create new source with updated flow path
-						newSource := SensitiveSource{
-							FieldName: source.FieldName,
-							Position:  arg.Pos(),
-							FlowPath:  append(append([]string{}, source.FlowPath...), fmt.Sprintf("parameter '%s'", paramName.Name)),
-						}
+						newSource := source.withStep(fmt.Sprintf("%s param %s", calledFunc.Name(), paramName.Name), paramName.Pos())
+						newSource.Position = arg.Pos()
 						da.sensitiveParams[v] = newSource
 						da.sensitiveVars[v] = newSource
 					}
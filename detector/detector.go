@@ -5,6 +5,7 @@ import (
 	"go/ast"
 	"go/types"
 
+	"github.com/nilpoona/leakhound/config"
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -16,6 +17,19 @@ const (
 	RuleIDSensitiveField          = "sensitive-field"
 	RuleIDCrossPkgSensitiveReturn = "cross-pkg-sensitive-return"
 	RuleIDCrossPkgSensitiveSink   = "cross-pkg-sensitive-sink"
+	RuleIDUntaggedSensitiveField  = "untagged-sensitive-field"
+	RuleIDSensitiveKey            = "sensitive-key"
+	RuleIDPersonalData            = "personal-data"
+	RuleIDBearerCredential        = "bearer-credential"
+	RuleIDExposedSecret           = "exposed-secret"
+	RuleIDCommandLineSecret       = "command-line-secret"
+	RuleIDConfigDump              = "config-dump"
+	RuleIDSensitiveStringer       = "sensitive-stringer"
+	RuleIDSensitiveBoundary       = "sensitive-boundary"
+	RuleIDHookForwarding          = "hook-forwarding"
+	RuleIDSensitivePublished      = "sensitive-published"
+	RuleIDHardcodedSecret         = "hardcoded-secret"
+	RuleIDCrashHandlerDump        = "crash-handler-dump"
 )
 
 // Detector handles detection of sensitive data leaks
@@ -23,6 +37,24 @@ type Detector struct {
 	pass            *analysis.Pass
 	sensitiveFields map[sensitiveField]bool
 	varTracker      *VarTracker
+
+	// Redaction interfaces honored for whole-struct findings (see redaction.go)
+	marshalers []config.MarshalerConfig
+	ifaces     map[string]*types.Interface
+
+	// Configured sanitizers, whose results are not reported (see sanitizers.go)
+	sanitizers *SanitizerMatcher
+
+	// Structs reported when logged as a whole (see struct_scope.go)
+	structScope string
+
+	// The sink argument redact.New may wrap, set while it is checked (see
+	// redact.go)
+	fixArg ast.Expr
+
+	// Whether a struct type has sensitive fields, memoized for the pass: the
+	// collected fields do not change once detection starts
+	structMemo map[*types.Named]bool
 }
 
 // NewDetector creates a new Detector
@@ -31,6 +63,7 @@ func NewDetector(pass *analysis.Pass, sensitiveFields map[sensitiveField]bool, v
 		pass:            pass,
 		sensitiveFields: sensitiveFields,
 		varTracker:      varTracker,
+		structMemo:      make(map[*types.Named]bool),
 	}
 }
 
@@ -38,18 +71,89 @@ func NewDetector(pass *analysis.Pass, sensitiveFields map[sensitiveField]bool, v
 // This includes: direct field access, variables, function calls, and entire structs
 // Returns a slice of Finding objects for each detected issue
 func (d *Detector) CheckArgForSensitiveData(arg ast.Expr) []Finding {
+	return d.checkArg(arg, "")
+}
+
+// checkArg implements CheckArgForSensitiveData for an argument of a call
+// into package consumer ("" if unknown), which decides whether a struct
+// implementing a redaction interface is rendered redacted.
+//
+// Each sub-expression is visited once: nested calls, concatenations and
+// composite literals hand their operands back to checkArg and are not
+// descended into again, so the cost is linear in the size of the argument.
+// The per-expression struct queries are memoized by type (see
+// hasSensitiveFields).
+func (d *Detector) checkArg(arg ast.Expr, consumer string) []Finding {
 	var findings []Finding
 
-	// First check if the argument is a sensitive variable
-	if ident, ok := arg.(*ast.Ident); ok {
+	// Values wrapped in redact.Secret render as "[REDACTED]", and sanitizers
+	// such as redactor.Mask(u.Password) return a safe value
+	if isRedactWrapper(d.pass.TypesInfo.TypeOf(arg)) || d.isSanitized(arg) {
+		return findings
+	}
+
+	// Authorization headers, JWTs, unwrapped secrets and flags declared
+	// sensitive are sensitive whatever the tags say; reclassify names their
+	// rules
+	source := builtinSource(arg, d.pass.TypesInfo)
+	if source == nil {
+		source = d.varTracker.checker.commandLine.Source(arg, d.pass.TypesInfo)
+	}
+	if source != nil {
+		findings = append(findings, Finding{
+			Pos:         arg.Pos(),
+			End:         arg.End(),
+			Expr:        types.ExprString(arg),
+			Severity:    source.severity(),
+			Field:       source.FieldName,
+			FieldPos:    source.FieldPos,
+			FlowPath:    source.FlowPath,
+			Credential:  source.Credential,
+			Exposed:     source.Exposed,
+			CommandLine: source.CommandLine,
+		})
+		reclassify(findings)
+		return findings
+	}
+
+	// *dbPassword: the value pointed to, when the pointer is tainted
+	if star, ok := arg.(*ast.StarExpr); ok {
+		if ident := varRef(star.X, d.pass.TypesInfo); ident != nil {
+			if _, found := d.varTracker.IsSensitiveVar(d.pass.TypesInfo.Uses[ident]); found {
+				findings = d.checkArg(star.X, consumer)
+				for i := range findings {
+					findings[i].Pos, findings[i].End = arg.Pos(), arg.End()
+					findings[i].Expr = types.ExprString(arg)
+					findings[i].Fixes = d.redactFixes(arg)
+				}
+				return findings
+			}
+		}
+	}
+
+	// First check if the argument is a sensitive variable. Variables whose
+	// own type is a struct with sensitive fields (e.g. u in
+	// for _, u := range users) fall through to the more specific struct check.
+	if ident := varRef(arg, d.pass.TypesInfo); ident != nil && !d.isSensitiveStructValue(arg) {
 		if obj := d.pass.TypesInfo.Uses[ident]; obj != nil {
 			if source, found := d.varTracker.IsSensitiveVar(obj); found {
 				findings = append(findings, Finding{
-					Pos: arg.Pos(),
+					Pos:  arg.Pos(),
+					End:  arg.End(),
+					Expr: types.ExprString(arg),
 					Message: fmt.Sprintf(
 						"variable %q contains sensitive field %q (tagged with sensitive:\"true\")",
 						ident.Name, source.FieldName),
-					RuleID: RuleIDSensitiveVar,
+					RuleID:      RuleIDSensitiveVar,
+					Severity:    source.severity(),
+					PII:         source.PII,
+					Credential:  source.Credential,
+					Exposed:     source.Exposed,
+					CommandLine: source.CommandLine,
+					Field:       source.FieldName,
+					FieldPos:    source.FieldPos,
+					FlowPath:    append([]FlowStep{}, source.FlowPath...),
+					Fixes:       d.redactFixes(arg),
 				})
 				return findings
 			}
@@ -60,11 +164,22 @@ func (d *Detector) CheckArgForSensitiveData(arg ast.Expr) []Finding {
 	if call, ok := arg.(*ast.CallExpr); ok {
 		if source, found := d.varTracker.IsSensitiveCall(call); found {
 			findings = append(findings, Finding{
-				Pos: arg.Pos(),
+				Pos:  arg.Pos(),
+				End:  arg.End(),
+				Expr: types.ExprString(arg),
 				Message: fmt.Sprintf(
 					"function call returns sensitive field %q (tagged with sensitive:\"true\")",
 					source.FieldName),
-				RuleID: RuleIDSensitiveCall,
+				RuleID:      RuleIDSensitiveCall,
+				Severity:    source.severity(),
+				PII:         source.PII,
+				Credential:  source.Credential,
+				Exposed:     source.Exposed,
+				CommandLine: source.CommandLine,
+				Field:       source.FieldName,
+				FieldPos:    source.FieldPos,
+				FlowPath:    append([]FlowStep{}, source.FlowPath...),
+				Fixes:       d.redactFixes(arg),
 			})
 			return findings
 		}
@@ -85,15 +200,24 @@ func (d *Detector) CheckArgForSensitiveData(arg ast.Expr) []Finding {
 				typeName := obj.Name()
 
 				// Check local cache first, then fall back to type info.
-				if hasAnySensitiveFields(typeName, d.sensitiveFields) ||
-					hasAnySensitiveFieldsFromType(d.pass, named) {
-					findings = append(findings, Finding{
-						Pos: arg.Pos(),
+				if d.isRedacted(tv.Type, named, consumer) {
+					return findings
+				}
+				if d.hasSensitiveFields(named) {
+					if !d.inStructScope(named) {
+						return findings
+					}
+					finding := Finding{
+						Pos:  arg.Pos(),
+						End:  arg.End(),
+						Expr: types.ExprString(arg),
 						Message: fmt.Sprintf(
 							"struct '%s' contains sensitive fields and should not be logged entirely",
 							typeName),
 						RuleID: RuleIDSensitiveStruct,
-					})
+					}
+					d.setStructFieldMetadata(&finding, named)
+					findings = append(findings, finding)
 					return findings
 				}
 			}
@@ -102,37 +226,120 @@ func (d *Detector) CheckArgForSensitiveData(arg ast.Expr) []Finding {
 		// Check container types (slice/array/map/chan) whose element, key, or
 		// value is a struct with sensitive fields, e.g. logging a whole
 		// []User or map[string]User.
-		if name, ok := typeContainsSensitiveStruct(d.pass, typ, make(map[string]bool)); ok {
-			findings = append(findings, Finding{
-				Pos: arg.Pos(),
+		if elem, ok := typeContainsSensitiveStruct(d.pass, typ, make(map[string]bool)); ok {
+			if !d.inStructScope(elem) {
+				return findings
+			}
+			finding := Finding{
+				Pos:  arg.Pos(),
+				End:  arg.End(),
+				Expr: types.ExprString(arg),
 				Message: fmt.Sprintf(
 					"logged value contains type '%s' with sensitive fields and should not be logged entirely",
-					name),
+					elem.Obj().Name()),
 				RuleID: RuleIDSensitiveStruct,
-			})
+			}
+			d.setStructFieldMetadata(&finding, elem)
+			findings = append(findings, finding)
 			return findings
 		}
 	}
 
 	// Check for field access within the argument (including nested function calls)
+	var selections []selectionSpan
 	ast.Inspect(arg, func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.SelectorExpr:
 			// Handle field access like config.Secret
 			if finding := d.checkFieldAccess(node); finding != nil {
 				findings = append(findings, *finding)
+				selections = append(selections, selectionSpan{node.Pos(), node.Sel.Pos(), node.End()})
 			}
 		case *ast.CallExpr:
-			// Handle function calls like slog.Any("data", config)
+			// redact.New(u.Password) and other wrapped values, and
+			// sanitized ones
+			if isRedactWrapper(d.pass.TypesInfo.TypeOf(node)) || d.isSanitized(node) {
+				return false
+			}
+			// Attr constructors such as slog.String, slog.Any and slog.Group
+			// are walked value by value so each finding points at the
+			// innermost sensitive expression rather than the key or the Attr.
+			if values, ok := slogAttrValueArgs(node, d.pass.TypesInfo); ok {
+				for _, v := range values {
+					findings = append(findings, d.checkArg(v, "log/slog")...)
+				}
+				return false
+			}
+			// Handle function calls like fmt.Sprint(config)
+			callee := calleePackage(node, d.pass.TypesInfo)
 			for _, callArg := range node.Args {
-				findings = append(findings, d.CheckArgForSensitiveData(callArg)...)
+				findings = append(findings, d.checkArg(callArg, callee)...)
+			}
+			// URL accessors rendering credentials: u.String(), u.User.Password()
+			if recv := carriedReceiver(node, d.pass.TypesInfo); recv != nil {
+				findings = append(findings, d.CheckArgForSensitiveData(recv)...)
 			}
 			return false // Don't traverse into call expr again
+		case *ast.SliceExpr:
+			// attrs[:n]: the sliced value, when it is a tainted variable
+			findings = append(findings, d.CheckArgForSensitiveData(node.X)...)
+			return false
+		case *ast.BinaryExpr:
+			// "dsn=" + dsn: operands that are tainted variables
+			if isStringConcat(node, d.pass.TypesInfo) {
+				findings = append(findings, d.CheckArgForSensitiveData(node.X)...)
+				findings = append(findings, d.CheckArgForSensitiveData(node.Y)...)
+				return false
+			}
+		case *ast.CompositeLit:
+			// slog.Attr{Key: "k", Value: v}: only the Value can leak.
+			if value, ok := slogAttrLitValue(node, d.pass.TypesInfo); ok {
+				if value != nil {
+					findings = append(findings, d.CheckArgForSensitiveData(value)...)
+				}
+				return false
+			}
+			// Config{Token: tok}: check each element so tainted variables
+			// and calls inside the literal are reported, not just field reads.
+			isMap := false
+			if t := d.pass.TypesInfo.TypeOf(node); t != nil {
+				_, isMap = t.Underlying().(*types.Map)
+			}
+			for _, elt := range compositeLitValues(node, isMap) {
+				findings = append(findings, d.CheckArgForSensitiveData(elt.value)...)
+			}
+			return false
 		}
 		return true
 	})
 
-	return findings
+	return dropCascading(findings, selections)
+}
+
+// isSensitiveStructValue reports whether expr's type is, or points to, a named
+// struct with sensitive fields.
+func (d *Detector) isSensitiveStructValue(expr ast.Expr) bool {
+	typ := d.pass.TypesInfo.TypeOf(expr)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj() == nil {
+		return false
+	}
+	return d.hasSensitiveFields(named)
+}
+
+// hasSensitiveFields reports whether named has sensitive fields, by the
+// collected fields or by its tags, memoized per type
+func (d *Detector) hasSensitiveFields(named *types.Named) bool {
+	has, ok := d.structMemo[named]
+	if !ok {
+		has = hasAnySensitiveFields(named.Obj().Name(), d.sensitiveFields) ||
+			hasAnySensitiveFieldsFromType(d.pass, named)
+		d.structMemo[named] = has
+	}
+	return has
 }
 
 // checkFieldAccess checks if a selector expression accesses a sensitive field
@@ -155,6 +362,7 @@ func (d *Detector) checkFieldAccess(sel *ast.SelectorExpr) *Finding {
 	if !ok {
 		return nil
 	}
+	named = fieldOwner(sel, d.pass.TypesInfo, named)
 
 	// Add nil check for named type object to handle build constraint issues
 	obj := named.Obj()
@@ -171,26 +379,51 @@ func (d *Detector) checkFieldAccess(sel *ast.SelectorExpr) *Finding {
 		fieldName: fieldName,
 	}
 
-	if d.sensitiveFields[sf] {
-		return &Finding{
-			Pos: sel.Pos(),
-			Message: fmt.Sprintf(
-				"sensitive field '%s.%s' should not be logged (tagged with sensitive:\"true\")",
-				typeName, fieldName),
-			RuleID: RuleIDSensitiveField,
-		}
+	// If not found in local cache, check the actual struct definition using type info.
+	// Cached fields without a sensitive tag hold personal data (PII mode).
+	tagged := checkSensitiveFieldFromTypeInfo(d.pass, named, fieldName)
+	if !d.sensitiveFields[sf] && !tagged {
+		return nil
 	}
 
-	// If not found in local cache, check the actual struct definition using type info
-	if checkSensitiveFieldFromTypeInfo(d.pass, named, fieldName) {
-		return &Finding{
-			Pos: sel.Pos(),
-			Message: fmt.Sprintf(
-				"sensitive field '%s.%s' should not be logged (tagged with sensitive:\"true\")",
-				typeName, fieldName),
-			RuleID: RuleIDSensitiveField,
-		}
+	// Point at the field itself rather than the start of the selector
+	// chain, which may be far away in req.Session.User.Password.
+	qualified := fmt.Sprintf("%s.%s", typeName, fieldName)
+	return &Finding{
+		Pos:  sel.Sel.Pos(),
+		End:  sel.End(),
+		Expr: types.ExprString(sel),
+		Message: fmt.Sprintf(
+			"sensitive field '%s' should not be logged (tagged with sensitive:\"true\")",
+			qualified),
+		RuleID:   RuleIDSensitiveField,
+		Severity: levelOrError(fieldLevel(named, fieldName)),
+		PII:      !tagged,
+		Field:    qualified,
+		FieldPos: selectedFieldPos(sel, d.pass.TypesInfo),
+		FlowPath: []FlowStep{{Label: qualified, Pos: sel.Sel.Pos()}},
+		Fixes:    d.redactFixes(sel),
 	}
+}
 
-	return nil
+// setStructFieldMetadata records the first sensitive field of a whole-struct
+// finding so reporters can point at the offending declaration, and the most
+// severe level among its sensitive fields. A struct whose only cached fields
+// hold personal data is a PII finding.
+func (d *Detector) setStructFieldMetadata(f *Finding, named *types.Named) {
+	f.Severity = levelOrError(structLevel(named, make(map[string]bool)))
+	field, owner := findSensitiveField(named, make(map[string]bool))
+	if field == nil {
+		if field = findPIIField(named, d.sensitiveFields); field != nil {
+			owner = named.Obj().Name()
+			f.PII = true
+		}
+	}
+	if field == nil {
+		f.Field = named.Obj().Name()
+		return
+	}
+	f.Field = fmt.Sprintf("%s.%s", owner, field.Name())
+	f.FieldPos = field.Pos()
+	f.FlowPath = []FlowStep{{Label: named.Obj().Name(), Pos: f.Pos}}
 }
@@ -0,0 +1,89 @@
+package detector
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// EventBuilderMatcher matches calls to the event builders of a
+// configuration: functions and methods, such as (*audit.Event).WithDetail,
+// attaching values to a structured event that is logged later. Calls are
+// matched like custom logging targets.
+type EventBuilderMatcher struct {
+	targets *LogDetector
+}
+
+// NewEventBuilderMatcher creates a matcher for cfg's event builders, or
+// returns nil when none are configured
+func NewEventBuilderMatcher(pass *analysis.Pass, cfg *config.Config) *EventBuilderMatcher {
+	if cfg == nil || len(cfg.EventBuilders) == 0 {
+		return nil
+	}
+	targets := &config.Config{}
+	for _, builder := range cfg.EventBuilders {
+		targets.Targets = append(targets.Targets, builder.TargetConfig)
+	}
+	return &EventBuilderMatcher{
+		targets: NewLogDetectorWithConfig(pass, targets),
+	}
+}
+
+// IsBuilder reports whether call calls an event builder, so that the event
+// it returns or is called on carries the taint of the attached values
+func (m *EventBuilderMatcher) IsBuilder(call *ast.CallExpr, info *types.Info) bool {
+	if m == nil {
+		return false
+	}
+	if m.targets.CustomTarget(call, info) != "" {
+		return true
+	}
+	// Builders declared in the analyzed package are called unqualified
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	fn, ok := info.Uses[ident].(*types.Func)
+	return ok && fn.Pkg() != nil && m.targets.matchCustomTarget(fn.Pkg().Path(), fn.Name(), fn) != ""
+}
+
+// eventOf returns the event a builder method is called on, e in
+// e.WithDetail("token", cfg.Token), or nil for a builder function
+func eventOf(call *ast.CallExpr, info *types.Info) ast.Expr {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if selection, ok := info.Selections[sel]; !ok || selection.Kind() != types.MethodVal {
+		return nil
+	}
+	return sel.X
+}
+
+// SetEventBuilders sets the event builders whose events carry the taint of
+// the values attached to them
+func (vt *VarTracker) SetEventBuilders(m *EventBuilderMatcher) {
+	vt.checker.builders = m
+}
+
+// CollectEventBuilder taints the event a builder method is called on with
+// the sensitive values it attaches, so that e.Set("token", cfg.Token)
+// taints e even when the returned event is discarded
+func (fc *FactCollector) CollectEventBuilder(call *ast.CallExpr) {
+	info := fc.checker.pass.TypesInfo
+	if !fc.checker.builders.IsBuilder(call, info) {
+		return
+	}
+	event := eventOf(call, info)
+	if event == nil {
+		return
+	}
+	for _, arg := range call.Args {
+		if source := fc.checker.checkSensitiveExpr(arg, fc.sensitiveVars, fc.sensitiveFuncs); source != nil {
+			fc.taintLHS(event, source.withStep(types.ExprString(call.Fun), call.Pos()))
+			return
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package detector
 
 import (
+	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 )
 
@@ -14,6 +16,7 @@ type FactCollector struct {
 	sensitiveVars    map[*types.Var]SensitiveSource
 	sensitiveFuncs   map[types.Object]SensitiveSource
 	sensitiveFuncPos map[sensitiveReturnKey]SensitiveSource // position-aware multi-return tracking
+	sensitiveYields  map[sensitiveReturnKey]SensitiveSource // sensitive yield arguments of iterators, by position
 	sensitiveParams  map[*types.Var]SensitiveSource
 	funcDefs         map[types.Object]*ast.FuncDecl
 	currentFunc      types.Object // Traversal context: only used during collection
@@ -38,42 +41,421 @@ func (fc *FactCollector) SetCurrentFunction(funcObj types.Object) {
 	fc.currentFunc = funcObj
 }
 
-// CollectAssignment analyzes an assignment statement for sensitive data
+// CollectReceiver taints the receiver of a method declared on a struct with
+// sensitive fields, so that inside the method body the receiver is tracked
+// like any other sensitive value, e.g. when it is stored in an interface
+// variable or handed to a helper that logs its parameter.
+func (fc *FactCollector) CollectReceiver(funcDecl *ast.FuncDecl) {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return
+	}
+	for _, name := range funcDecl.Recv.List[0].Names {
+		v, ok := fc.checker.pass.TypesInfo.Defs[name].(*types.Var)
+		if !ok {
+			continue
+		}
+		if source := sensitiveStructSource(v.Type(), "receiver "+name.Name, name.Pos()); source != nil {
+			fc.sensitiveVars[v] = *source
+		}
+	}
+}
+
+// CollectAssignment analyzes an assignment statement for sensitive data.
+// It handles both := and =, including := that redeclares some existing
+// variables, field stores, and tuple forms on the right-hand side.
+// Tracking is flow-insensitive: taint is only ever added, since sinks are
+// checked against the facts of the whole function, so a variable
+// overwritten with a safe value, as by a swap, stays tainted.
 func (fc *FactCollector) CollectAssignment(assign *ast.AssignStmt) {
-	// Multi-value function call: v, err := f()
-	// AST: len(Rhs)==1 with a single CallExpr, len(Lhs)>1
-	if len(assign.Rhs) == 1 && len(assign.Lhs) > 1 {
-		if call, ok := assign.Rhs[0].(*ast.CallExpr); ok {
-			fc.collectMultiValueAssignment(assign.Lhs, call)
+	fc.collectAssign(assign.Lhs, assign.Rhs)
+}
+
+// CollectValueSpec analyzes a var declaration with initializers
+// (var p = u.Password or var v, err = f()) the same way as :=.
+func (fc *FactCollector) CollectValueSpec(spec *ast.ValueSpec) {
+	lhs := make([]ast.Expr, len(spec.Names))
+	for i, name := range spec.Names {
+		lhs[i] = name
+	}
+	fc.collectAssign(lhs, spec.Values)
+}
+
+// CollectInitializer analyzes a package-level var initializer
+// (var token = loadToken()) as listed in types.Info.InitOrder, which is the
+// order in which Go evaluates them.
+func (fc *FactCollector) CollectInitializer(init *types.Initializer) {
+	taint := func(v *types.Var, source SensitiveSource) {
+		if v.Name() != "_" {
+			fc.sensitiveVars[v] = source.withStep(v.Name(), v.Pos())
+		}
+	}
+
+	if len(init.Lhs) > 1 {
+		call, ok := ast.Unparen(init.Rhs).(*ast.CallExpr)
+		if !ok {
 			return
 		}
+		funObj := fc.checker.getFunctionObject(call.Fun)
+		if funObj == nil {
+			return
+		}
+		for i, v := range init.Lhs {
+			if source, found := fc.sensitiveFuncPos[sensitiveReturnKey{funcObj: funObj, index: i}]; found {
+				taint(v, source)
+			}
+		}
+		return
 	}
 
-	// Single-value assignments: variable := expr
-	for i, lhs := range assign.Lhs {
-		if i >= len(assign.Rhs) {
-			continue
+	if source := fc.checker.checkSensitiveExpr(init.Rhs, fc.sensitiveVars, fc.sensitiveFuncs); source != nil {
+		taint(init.Lhs[0], *source)
+	}
+	fc.collectFuncValue(init.Lhs[0], init.Rhs)
+}
+
+// collectAssign records taint for each LHS expression from its RHS.
+func (fc *FactCollector) collectAssign(lhs, rhs []ast.Expr) {
+	// Tuple assignment: v, err := f() or v, ok = m[k]
+	// AST: len(Rhs)==1 with len(Lhs)>1
+	if len(rhs) == 1 && len(lhs) > 1 {
+		if call, ok := ast.Unparen(rhs[0]).(*ast.CallExpr); ok {
+			fc.collectMultiValueAssignment(lhs, call)
+			return
 		}
-		rhs := assign.Rhs[i]
+		// Comma-ok forms (m[k], x.(T), <-ch) yield the value first and a
+		// bool second; only the value can carry taint.
+		if source := fc.checker.checkSensitiveExpr(rhs[0], fc.sensitiveVars, fc.sensitiveFuncs); source != nil {
+			fc.taintLHS(lhs[0], *source)
+		}
+		return
+	}
 
-		// Get the variable being assigned to
-		var varObj *types.Var
-		switch l := lhs.(type) {
-		case *ast.Ident:
-			if obj := fc.checker.pass.TypesInfo.Defs[l]; obj != nil {
-				if v, ok := obj.(*types.Var); ok {
-					varObj = v
-				}
+	// Parallel assignment: a, b := x, y or a, b = b, a
+	// Every RHS is evaluated before any LHS is updated so that swaps see the
+	// pre-assignment taint, matching Go's evaluation order. Clearing the
+	// taint of an LHS receiving a safe value would hide its earlier uses
+	// from the sinks, so after a, b = b, a both stay tainted.
+	sources := make([]*SensitiveSource, len(lhs))
+	for i := range lhs {
+		if i >= len(rhs) {
+			break
+		}
+		sources[i] = fc.checker.checkSensitiveExpr(rhs[i], fc.sensitiveVars, fc.sensitiveFuncs)
+	}
+	for i, l := range lhs {
+		if sources[i] != nil {
+			fc.taintLHS(l, *sources[i])
+		}
+		if ident, ok := ast.Unparen(l).(*ast.Ident); ok && i < len(rhs) {
+			if v := fc.assignedVar(ident); v != nil {
+				fc.collectFuncValue(v, rhs[i])
 			}
 		}
+	}
+}
+
+// collectFuncValue records the results of a function literal bound to v,
+// directly or through sync.OnceValue and sync.OnceValues
+// (var secret = sync.OnceValue(func() string { return cfg.Token })), so that
+// calling v is treated like calling a function returning them.
+func (fc *FactCollector) collectFuncValue(v *types.Var, rhs ast.Expr) {
+	if v == nil || v.Name() == "_" {
+		return
+	}
+	if call, ok := ast.Unparen(rhs).(*ast.CallExpr); ok && isOnceValueCall(call, fc.checker.pass.TypesInfo) {
+		rhs = call.Args[0]
+	}
+	lit, ok := ast.Unparen(rhs).(*ast.FuncLit)
+	if !ok {
+		return
+	}
+	ast.Inspect(lit.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			return false // Returns of nested literals belong to them
+		case *ast.ReturnStmt:
+			fc.collectReturnOf(v, node)
+		}
+		return true
+	})
+}
+
+// isOnceValueCall reports whether call is sync.OnceValue(f) or
+// sync.OnceValues(f), which return a function yielding f's results.
+func isOnceValueCall(call *ast.CallExpr, info *types.Info) bool {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 1 {
+		return false
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "sync" {
+		return false
+	}
+	return fn.Name() == "OnceValue" || fn.Name() == "OnceValues"
+}
+
+// CollectRange analyzes a range statement. Ranging over a tainted collection
+// taints the element variable, and ranging over a collection of structs with
+// sensitive fields taints it with the first sensitive field of the element
+// type, so copies of the element handed to other functions are still tracked.
+func (fc *FactCollector) CollectRange(rs *ast.RangeStmt) {
+	info := fc.checker.pass.TypesInfo
+
+	// The element is the value variable, except for channels where the
+	// single iteration variable is the received element.
+	elem := rs.Value
+	if t := info.TypeOf(rs.X); t != nil {
+		if _, ok := t.Underlying().(*types.Chan); ok {
+			elem = rs.Key
+		}
+	}
+	if fc.collectRangeFunc(rs) || elem == nil {
+		return
+	}
 
-		if varObj == nil {
+	if source := fc.checker.checkSensitiveExpr(rs.X, fc.sensitiveVars, fc.sensitiveFuncs); source != nil {
+		fc.taintLHS(elem, source.withStep("range "+types.ExprString(rs.X), rs.X.Pos()))
+		return
+	}
+
+	if source := sensitiveStructSource(info.TypeOf(elem), "range "+types.ExprString(rs.X), rs.X.Pos()); source != nil {
+		fc.taintLHS(elem, *source)
+	}
+}
+
+// collectRangeFunc taints the iteration variables of a range over an
+// iterator function (for v := range u.Secrets()) with the sensitive values
+// the iterator yields in their position. It reports whether rs ranges over a
+// function.
+func (fc *FactCollector) collectRangeFunc(rs *ast.RangeStmt) bool {
+	t := fc.checker.pass.TypesInfo.TypeOf(rs.X)
+	if t == nil {
+		return false
+	}
+	if _, ok := t.Underlying().(*types.Signature); !ok {
+		return false
+	}
+
+	// The iterator is either the result of a call or a function value
+	// such as the method value u.All
+	iterator := fc.checker.getFunctionObject(ast.Unparen(rs.X))
+	if call, ok := ast.Unparen(rs.X).(*ast.CallExpr); ok {
+		if collection, positions := carriedYields(call, fc.checker.pass.TypesInfo); collection != nil {
+			fc.collectRangeCarried(rs, collection, positions)
+			return true
+		}
+		iterator = fc.checker.getFunctionObject(call.Fun)
+	}
+	if iterator == nil {
+		return true
+	}
+	for i, v := range []ast.Expr{rs.Key, rs.Value} {
+		if v == nil {
 			continue
 		}
+		if source, found := fc.sensitiveYields[sensitiveReturnKey{funcObj: iterator, index: i}]; found {
+			fc.taintLHS(v, source.withStep("range "+types.ExprString(rs.X), rs.X.Pos()))
+		}
+	}
+	return true
+}
 
-		// Check if RHS is a sensitive field access
-		if source := fc.checker.checkSensitiveExpr(rhs, fc.sensitiveVars, fc.sensitiveFuncs); source != nil {
-			fc.sensitiveVars[varObj] = *source
+// collectRangeCarried taints the iteration variables in positions of a range
+// over a standard library iterator with the taint of the collection it
+// iterates over (see carriedYields)
+func (fc *FactCollector) collectRangeCarried(rs *ast.RangeStmt, collection ast.Expr, positions []int) {
+	source := fc.checker.checkSensitiveExpr(collection, fc.sensitiveVars, fc.sensitiveFuncs)
+	if source == nil {
+		return
+	}
+	vars := []ast.Expr{rs.Key, rs.Value}
+	for _, i := range positions {
+		if vars[i] != nil {
+			fc.taintLHS(vars[i], source.withStep("range "+types.ExprString(rs.X), rs.X.Pos()))
+		}
+	}
+}
+
+// CollectYield records the sensitive arguments of a call to the yield
+// function of an iterator, in the iterator itself
+// (func (u User) All(yield func(string) bool)) or in the iterator returned by
+// the current function (func (u User) Secrets() iter.Seq[string]).
+func (fc *FactCollector) CollectYield(call *ast.CallExpr) {
+	if fc.currentFunc == nil {
+		return
+	}
+	yield := producedYield(fc.currentFunc)
+	if yield == nil {
+		return
+	}
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return
+	}
+	v, ok := fc.checker.pass.TypesInfo.Uses[ident].(*types.Var)
+	if !ok || !types.Identical(v.Type().Underlying(), yield) {
+		return
+	}
+	for i, arg := range call.Args {
+		if source := fc.checker.checkSensitiveExpr(arg, fc.sensitiveVars, fc.sensitiveFuncs); source != nil {
+			key := sensitiveReturnKey{funcObj: fc.currentFunc, index: i}
+			fc.sensitiveYields[key] = source.withStep(fc.currentFunc.Name()+" yield", arg.Pos())
+		}
+	}
+}
+
+// producedYield returns the type of the yield function of the iterator
+// funcObj is, or returns, or nil when it is neither
+func producedYield(funcObj types.Object) *types.Signature {
+	sig, ok := funcObj.Type().Underlying().(*types.Signature)
+	if !ok {
+		return nil
+	}
+	if yield := iteratorYield(sig); yield != nil {
+		return yield
+	}
+	if sig.Results().Len() != 1 {
+		return nil
+	}
+	result, ok := sig.Results().At(0).Type().Underlying().(*types.Signature)
+	if !ok {
+		return nil
+	}
+	return iteratorYield(result)
+}
+
+// iteratorYield returns the type of the yield function of a range-over-func
+// iterator signature, func(yield func(K[, V]) bool), or nil for other
+// signatures
+func iteratorYield(sig *types.Signature) *types.Signature {
+	if sig.Params().Len() != 1 || sig.Results().Len() != 0 {
+		return nil
+	}
+	yield, ok := sig.Params().At(0).Type().Underlying().(*types.Signature)
+	if !ok || yield.Params().Len() > 2 || yield.Results().Len() != 1 {
+		return nil
+	}
+	if b, ok := yield.Results().At(0).Type().Underlying().(*types.Basic); !ok || b.Kind() != types.Bool {
+		return nil
+	}
+	return yield
+}
+
+// CollectTypeSwitch analyzes a type switch with a binding
+// (switch v := x.(type)). Each case clause declares its own implicit v; all
+// of them inherit the taint of x, and a clause whose concrete type is a struct
+// with sensitive fields taints its v like a range element of that type.
+func (fc *FactCollector) CollectTypeSwitch(ts *ast.TypeSwitchStmt) {
+	assign, ok := ts.Assign.(*ast.AssignStmt)
+	if !ok || len(assign.Rhs) != 1 {
+		return
+	}
+	assert, ok := ast.Unparen(assign.Rhs[0]).(*ast.TypeAssertExpr)
+	if !ok {
+		return
+	}
+	info := fc.checker.pass.TypesInfo
+	source := fc.checker.checkSensitiveExpr(assert.X, fc.sensitiveVars, fc.sensitiveFuncs)
+	label := "switch " + types.ExprString(assert.X) + ".(type)"
+
+	for _, stmt := range ts.Body.List {
+		clause, ok := stmt.(*ast.CaseClause)
+		if !ok {
+			continue
+		}
+		v, ok := info.Implicits[clause].(*types.Var)
+		if !ok {
+			continue
+		}
+		if source != nil {
+			fc.sensitiveVars[v] = source.withStep(v.Name(), clause.Pos())
+			continue
+		}
+		if caseSource := sensitiveStructSource(v.Type(), label, assert.X.Pos()); caseSource != nil {
+			fc.sensitiveVars[v] = caseSource.withStep(v.Name(), clause.Pos())
+		}
+	}
+}
+
+// sensitiveStructSource returns a source for a value of type typ when typ is,
+// or points to, a named struct with a sensitive field. The source names the
+// first such field; label and pos describe where the value came from.
+func sensitiveStructSource(typ types.Type, label string, pos token.Pos) *SensitiveSource {
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok {
+		return nil
+	}
+	field, owner := findSensitiveField(named, make(map[string]bool))
+	if field == nil {
+		return nil
+	}
+	return &SensitiveSource{
+		FieldName: fmt.Sprintf("%s.%s", owner, field.Name()),
+		FieldPos:  field.Pos(),
+		Position:  pos,
+		FlowPath:  []FlowStep{{Label: label, Pos: pos}},
+		Level:     structLevel(named, make(map[string]bool)),
+	}
+}
+
+// taintLHS records source against the variable written by lhs. Blank
+// identifiers and expressions that do not resolve to a variable are ignored.
+func (fc *FactCollector) taintLHS(lhs ast.Expr, source SensitiveSource) {
+	var varObj *types.Var
+	switch l := ast.Unparen(lhs).(type) {
+	case *ast.Ident:
+		varObj = fc.assignedVar(l)
+	case *ast.SelectorExpr:
+		// Field store: cfg.Token = secrets.APIKey taints cfg as a whole,
+		// so logging or passing cfg on is caught later.
+		varObj = fc.fieldStoreBase(l)
+	case *ast.IndexExpr:
+		// Element store: args[1] = secrets.APIKey taints args as a whole,
+		// so spreading it into a log call (args...) is caught later.
+		if ident, ok := ast.Unparen(l.X).(*ast.Ident); ok {
+			varObj, _ = fc.checker.pass.TypesInfo.Uses[ident].(*types.Var)
+		}
+	}
+	if varObj == nil {
+		return
+	}
+	fc.sensitiveVars[varObj] = source.withStep(varObj.Name(), lhs.Pos())
+}
+
+// assignedVar resolves the variable written by an identifier on the left of
+// an assignment. A := statement defines new variables (Defs) but may also
+// reassign existing ones (Uses), and = only ever uses existing ones.
+func (fc *FactCollector) assignedVar(ident *ast.Ident) *types.Var {
+	info := fc.checker.pass.TypesInfo
+	if obj := info.Defs[ident]; obj != nil {
+		v, _ := obj.(*types.Var)
+		return v
+	}
+	v, _ := info.Uses[ident].(*types.Var)
+	return v
+}
+
+// fieldStoreBase returns the local variable whose field is written by sel
+// (cfg in cfg.Token or cfg.Auth.Token), or nil when sel is not a field
+// selection rooted at a variable.
+func (fc *FactCollector) fieldStoreBase(sel *ast.SelectorExpr) *types.Var {
+	info := fc.checker.pass.TypesInfo
+	for {
+		selection, ok := info.Selections[sel]
+		if !ok || selection.Kind() != types.FieldVal {
+			return nil
+		}
+		switch x := sel.X.(type) {
+		case *ast.Ident:
+			v, _ := info.Uses[x].(*types.Var)
+			return v
+		case *ast.SelectorExpr:
+			sel = x
+		default:
+			return nil
 		}
 	}
 }
@@ -81,22 +463,24 @@ func (fc *FactCollector) CollectAssignment(assign *ast.AssignStmt) {
 // collectMultiValueAssignment handles v, err := f() by mapping each LHS variable
 // to the corresponding return position in sensitiveFuncPos.
 func (fc *FactCollector) collectMultiValueAssignment(lhs []ast.Expr, call *ast.CallExpr) {
+	// JWTs: s, err := tok.SignedString(key)
+	if source := credentialSource(call, fc.checker.pass.TypesInfo); source != nil {
+		fc.taintLHS(lhs[0], *source)
+		return
+	}
+	// Parsers carry their input into the first result: u, err := url.Parse(dsn)
+	if source := fc.checker.carriedSource(call, fc.sensitiveVars, fc.sensitiveFuncs); source != nil {
+		fc.taintLHS(lhs[0], *source)
+		return
+	}
 	funObj := fc.checker.getFunctionObject(call.Fun)
 	if funObj == nil {
 		return
 	}
 	for i, l := range lhs {
-		ident, ok := l.(*ast.Ident)
-		if !ok {
-			continue
-		}
-		varObj, ok := fc.checker.pass.TypesInfo.Defs[ident].(*types.Var)
-		if !ok || varObj == nil {
-			continue
-		}
 		key := sensitiveReturnKey{funcObj: funObj, index: i}
 		if source, found := fc.sensitiveFuncPos[key]; found {
-			fc.sensitiveVars[varObj] = source
+			fc.taintLHS(l, source)
 		}
 	}
 }
@@ -106,11 +490,16 @@ func (fc *FactCollector) CollectReturn(ret *ast.ReturnStmt) {
 	if fc.currentFunc == nil {
 		return
 	}
+	fc.collectReturnOf(fc.currentFunc, ret)
+}
 
+// collectReturnOf records the sensitive results of a return statement of
+// funcObj, which is a function or a variable holding a function value.
+func (fc *FactCollector) collectReturnOf(funcObj types.Object, ret *ast.ReturnStmt) {
 	if len(ret.Results) == 1 {
 		// Single return: mark the function itself as sensitive (existing behavior)
 		if source := fc.checker.checkSensitiveExpr(ret.Results[0], fc.sensitiveVars, fc.sensitiveFuncs); source != nil {
-			fc.sensitiveFuncs[fc.currentFunc] = *source
+			fc.sensitiveFuncs[funcObj] = source.withStep(funcObj.Name()+" return", ret.Results[0].Pos())
 		}
 		return
 	}
@@ -118,8 +507,8 @@ func (fc *FactCollector) CollectReturn(ret *ast.ReturnStmt) {
 	// Multi-value return: record sensitivity per position
 	for i, result := range ret.Results {
 		if source := fc.checker.checkSensitiveExpr(result, fc.sensitiveVars, fc.sensitiveFuncs); source != nil {
-			key := sensitiveReturnKey{funcObj: fc.currentFunc, index: i}
-			fc.sensitiveFuncPos[key] = *source
+			key := sensitiveReturnKey{funcObj: funcObj, index: i}
+			fc.sensitiveFuncPos[key] = source.withStep(fmt.Sprintf("%s return #%d", funcObj.Name(), i), result.Pos())
 		}
 	}
 }
@@ -3,8 +3,10 @@ package detector
 import (
 	"go/ast"
 	"go/types"
+	"regexp"
 	"strings"
 
+	"github.com/nilpoona/leakhound/config"
 	"golang.org/x/tools/go/analysis"
 )
 
@@ -12,6 +14,14 @@ import (
 type FieldCollector struct {
 	pass            *analysis.Pass
 	sensitiveFields map[sensitiveField]bool
+
+	// classifications holds how fields recognized by name rather than by
+	// their tags were classified (see patternClassification)
+	classifications map[sensitiveField]string
+
+	// pii matches personal-data field names in PII mode; nil when PII mode
+	// is disabled
+	pii *config.NameMatcher
 }
 
 // NewFieldCollector creates a new FieldCollector with private state.
@@ -19,48 +29,109 @@ func NewFieldCollector(pass *analysis.Pass) *FieldCollector {
 	return &FieldCollector{
 		pass:            pass,
 		sensitiveFields: make(map[sensitiveField]bool),
+		classifications: make(map[sensitiveField]string),
 	}
 }
 
-// NewFieldCollectorWithFields creates a FieldCollector that writes into a
-// shared sensitive-field map. Used by whole-program mode so multiple packages
-// contribute to the same accumulator.
-func NewFieldCollectorWithFields(pass *analysis.Pass, fields map[sensitiveField]bool) *FieldCollector {
+// NewFieldCollectorWithFields creates a FieldCollector that writes into
+// shared sensitive-field and classification maps. Used by whole-program mode
+// so multiple packages contribute to the same accumulators.
+func NewFieldCollectorWithFields(pass *analysis.Pass, fields map[sensitiveField]bool, classifications map[sensitiveField]string) *FieldCollector {
 	if fields == nil {
 		fields = make(map[sensitiveField]bool)
 	}
+	if classifications == nil {
+		classifications = make(map[sensitiveField]string)
+	}
 	return &FieldCollector{
 		pass:            pass,
 		sensitiveFields: fields,
+		classifications: classifications,
 	}
 }
 
+// SetPIIMatcher enables PII mode: fields tagged pii:"true", and fields
+// without a sensitive or pii tag whose names match m, are collected too
+func (fc *FieldCollector) SetPIIMatcher(m *config.NameMatcher) {
+	fc.pii = m
+}
+
 // CollectFromTypeSpec collects sensitive fields from a TypeSpec node
 func (fc *FieldCollector) CollectFromTypeSpec(typeSpec *ast.TypeSpec) {
+	fc.addFields(fc.fieldsOf(typeSpec))
+}
+
+// collectedField is a sensitive field with its classification, "" for a
+// field recognized by its tags
+type collectedField struct {
+	sensitiveField
+	classification string
+}
+
+// addFields records fields as sensitive
+func (fc *FieldCollector) addFields(fields []collectedField) {
+	for _, cf := range fields {
+		fc.sensitiveFields[cf.sensitiveField] = true
+		if cf.classification != "" {
+			fc.classifications[cf.sensitiveField] = cf.classification
+		}
+	}
+}
+
+// fieldsOf returns the sensitive fields declared by typeSpec. It does not
+// modify the collector, so files may be scanned concurrently.
+func (fc *FieldCollector) fieldsOf(typeSpec *ast.TypeSpec) []collectedField {
 	structType, ok := typeSpec.Type.(*ast.StructType)
 	if !ok {
-		return
+		return nil
 	}
 
 	typeName := typeSpec.Name.Name
 
+	var fields []collectedField
 	for _, field := range structType.Fields.List {
-		if field.Tag == nil {
+		if field.Tag == nil && fc.pii == nil {
 			continue
 		}
 
-		tagValue := strings.Trim(field.Tag.Value, "`")
-		if !HasSensitiveTag(tagValue) {
+		tagValue := ""
+		if field.Tag != nil {
+			tagValue = strings.Trim(field.Tag.Value, "`")
+		}
+
+		// Fields of a redact wrapper type render as "[REDACTED]"
+		if isRedactWrapper(fc.pass.TypesInfo.TypeOf(field.Type)) {
 			continue
 		}
 
 		for _, name := range field.Names {
-			fc.sensitiveFields[sensitiveField{
+			cf := collectedField{sensitiveField: sensitiveField{
 				typeName:  typeName,
 				fieldName: name.Name,
-			}] = true
+			}}
+			switch {
+			case HasSensitiveTag(tagValue), fc.isPIITagged(field.Tag):
+			case fc.isPIIName(field.Tag, name.Name):
+				cf.classification = patternClassification(fc.pii, name.Name)
+			default:
+				continue
+			}
+			fields = append(fields, cf)
 		}
 	}
+	return fields
+}
+
+// isPIITagged reports whether a field is tagged pii:"true" in PII mode
+func (fc *FieldCollector) isPIITagged(tag *ast.BasicLit) bool {
+	return fc.pii != nil && tag != nil && HasPIITag(strings.Trim(tag.Value, "`"))
+}
+
+// isPIIName reports whether a field holds personal data by its name in PII
+// mode: it has neither a sensitive nor a pii tag and its name matches the
+// PII patterns
+func (fc *FieldCollector) isPIIName(tag *ast.BasicLit, name string) bool {
+	return fc.pii != nil && !hasTagKey(tag, "sensitive") && !hasTagKey(tag, "pii") && fc.pii.Match(name)
 }
 
 // GetSensitiveFields returns all collected sensitive fields
@@ -68,11 +139,137 @@ func (fc *FieldCollector) GetSensitiveFields() map[sensitiveField]bool {
 	return fc.sensitiveFields
 }
 
-// HasSensitiveTag checks if the tag string contains sensitive:"true"
+// Classifications returns how the collected fields recognized by name were
+// classified
+func (fc *FieldCollector) Classifications() map[sensitiveField]string {
+	return fc.classifications
+}
+
+// sensitiveTagPattern matches sensitive:"true" and its options,
+// sensitive:"true,level=warning", in both the sensitive:"true" and
+// sensitive:\"true\" formats. The first group holds the options.
+var sensitiveTagPattern = regexp.MustCompile(`sensitive:\\?"true((?:,[^"\\]*)?)\\?"`)
+
+// HasSensitiveTag checks if the tag string contains sensitive:"true",
+// with or without options
 func HasSensitiveTag(tag string) bool {
-	// Support both sensitive:"true" and sensitive:\"true\" formats
-	return strings.Contains(tag, `sensitive:"true"`) ||
-		strings.Contains(tag, `sensitive:\"true\"`)
+	return sensitiveTagPattern.MatchString(tag)
+}
+
+// piiTagPattern matches pii:"true" in both the pii:"true" and pii:\"true\"
+// formats
+var piiTagPattern = regexp.MustCompile(`pii:\\?"true\\?"`)
+
+// HasPIITag checks if the tag string contains pii:"true"
+func HasPIITag(tag string) bool {
+	return piiTagPattern.MatchString(tag)
+}
+
+// isSensitiveTagged reports whether field, with struct tag tag, is tagged
+// sensitive:"true" and not of a redact wrapper type, which is safe to log
+func isSensitiveTagged(field *types.Var, tag string) bool {
+	return HasSensitiveTag(tag) && !isRedactWrapper(field.Type())
+}
+
+// SensitiveTagLevel returns the severity set by the level option of a
+// sensitive tag, e.g. SeverityWarning for sensitive:"true,level=warning",
+// or "" when the tag has no valid level option
+func SensitiveTagLevel(tag string) Severity {
+	m := sensitiveTagPattern.FindStringSubmatch(tag)
+	if m == nil {
+		return ""
+	}
+	for _, opt := range strings.Split(m[1], ",") {
+		value, ok := strings.CutPrefix(strings.TrimSpace(opt), "level=")
+		if !ok {
+			continue
+		}
+		switch level := Severity(value); level {
+		case SeverityError, SeverityWarning, SeverityNote:
+			return level
+		}
+	}
+	return ""
+}
+
+// fieldLevel returns the tag level of the sensitive field fieldName of
+// named, searching embedded structs as well
+func fieldLevel(named *types.Named, fieldName string) Severity {
+	underlying, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return ""
+	}
+	for i := 0; i < underlying.NumFields(); i++ {
+		field := underlying.Field(i)
+		if field.Name() == fieldName {
+			return SensitiveTagLevel(underlying.Tag(i))
+		}
+		if field.Embedded() {
+			fieldType := field.Type()
+			if ptr, ok := fieldType.(*types.Pointer); ok {
+				fieldType = ptr.Elem()
+			}
+			if namedType, ok := fieldType.(*types.Named); ok && checkSensitiveFieldFromTypeInfo(nil, namedType, fieldName) {
+				return fieldLevel(namedType, fieldName)
+			}
+		}
+	}
+	return ""
+}
+
+// structLevel returns the most severe tag level among the sensitive fields
+// of named, including those of embedded structs, since logging the struct
+// reveals all of them. A field without a level counts as SeverityError,
+// which is reported as "". Structs already visited contribute nothing.
+func structLevel(named *types.Named, visited map[string]bool) Severity {
+	underlying, ok := named.Underlying().(*types.Struct)
+	if !ok || named.Obj() == nil || visited[named.Obj().Name()] {
+		return SeverityNote
+	}
+	visited[named.Obj().Name()] = true
+
+	level := SeverityNote
+	raise := func(l Severity) {
+		if l == "" || l == SeverityError {
+			level = ""
+		} else if l == SeverityWarning && level == SeverityNote {
+			level = SeverityWarning
+		}
+	}
+	for i := 0; i < underlying.NumFields() && level != ""; i++ {
+		field := underlying.Field(i)
+		if isSensitiveTagged(field, underlying.Tag(i)) {
+			raise(SensitiveTagLevel(underlying.Tag(i)))
+			continue
+		}
+		if field.Embedded() {
+			fieldType := field.Type()
+			if ptr, ok := fieldType.(*types.Pointer); ok {
+				fieldType = ptr.Elem()
+			}
+			if namedType, ok := fieldType.(*types.Named); ok && checkStructForSensitiveFields(nil, namedType, make(map[string]bool)) {
+				raise(structLevel(namedType, visited))
+			}
+		}
+	}
+	return level
+}
+
+// findPIIField returns the first field of named recorded in fields that is
+// not tagged sensitive:"true", or nil. In PII mode such fields hold personal
+// data (see FieldCollector.SetPIIMatcher).
+func findPIIField(named *types.Named, fields map[sensitiveField]bool) *types.Var {
+	underlying, ok := named.Underlying().(*types.Struct)
+	if !ok || named.Obj() == nil {
+		return nil
+	}
+	for i := 0; i < underlying.NumFields(); i++ {
+		field := underlying.Field(i)
+		if fields[sensitiveField{typeName: named.Obj().Name(), fieldName: field.Name()}] && !HasSensitiveTag(underlying.Tag(i)) {
+			return field
+		}
+	}
+	return nil
 }
 
 // hasAnySensitiveFields checks if a struct type has any fields with sensitive tags
@@ -113,7 +310,7 @@ func checkStructForSensitiveFields(pass *analysis.Pass, named *types.Named, visi
 		tag := underlying.Tag(i)
 
 		// Check if this field has a sensitive tag
-		if HasSensitiveTag(tag) {
+		if isSensitiveTagged(field, tag) {
 			return true
 		}
 
@@ -138,12 +335,51 @@ func checkStructForSensitiveFields(pass *analysis.Pass, named *types.Named, visi
 	return false
 }
 
+// findSensitiveField returns the first field tagged sensitive:"true" in the
+// named struct type, searching embedded structs as well, along with the name
+// of the struct that declares it. It is the witness-producing counterpart of
+// checkStructForSensitiveFields, used to attach field metadata to whole-struct
+// findings.
+func findSensitiveField(named *types.Named, visited map[string]bool) (*types.Var, string) {
+	underlying, ok := named.Underlying().(*types.Struct)
+	if !ok || named.Obj() == nil {
+		return nil, ""
+	}
+
+	typeName := named.Obj().Name()
+	if visited[typeName] {
+		return nil, ""
+	}
+	visited[typeName] = true
+
+	for i := 0; i < underlying.NumFields(); i++ {
+		field := underlying.Field(i)
+		if isSensitiveTagged(field, underlying.Tag(i)) {
+			return field, typeName
+		}
+
+		if field.Embedded() {
+			fieldType := field.Type()
+			if ptr, ok := fieldType.(*types.Pointer); ok {
+				fieldType = ptr.Elem()
+			}
+			if namedType, ok := fieldType.(*types.Named); ok {
+				if v, owner := findSensitiveField(namedType, visited); v != nil {
+					return v, owner
+				}
+			}
+		}
+	}
+
+	return nil, ""
+}
+
 // typeContainsSensitiveStruct unwraps container types (pointer, slice, array,
 // map, chan) and reports whether any element/key/value type is a named struct
-// carrying sensitive fields. It returns the offending struct's type name for
-// use in diagnostics. This is what lets leakhound flag logging an entire
+// carrying sensitive fields. It returns the offending struct type for use in
+// diagnostics. This is what lets leakhound flag logging an entire
 // []User or map[string]User when User has sensitive fields.
-func typeContainsSensitiveStruct(pass *analysis.Pass, typ types.Type, visited map[string]bool) (string, bool) {
+func typeContainsSensitiveStruct(pass *analysis.Pass, typ types.Type, visited map[string]bool) (*types.Named, bool) {
 	switch t := typ.(type) {
 	case *types.Pointer:
 		return typeContainsSensitiveStruct(pass, t.Elem(), visited)
@@ -155,27 +391,26 @@ func typeContainsSensitiveStruct(pass *analysis.Pass, typ types.Type, visited ma
 		return typeContainsSensitiveStruct(pass, t.Elem(), visited)
 	case *types.Map:
 		// A sensitive struct in either the key or the value position leaks.
-		if name, ok := typeContainsSensitiveStruct(pass, t.Key(), visited); ok {
-			return name, true
+		if named, ok := typeContainsSensitiveStruct(pass, t.Key(), visited); ok {
+			return named, true
 		}
 		return typeContainsSensitiveStruct(pass, t.Elem(), visited)
 	case *types.Named:
-		obj := t.Obj()
-		if obj == nil {
-			return "", false
+		if t.Obj() == nil {
+			return nil, false
 		}
 		// A named struct: reuse the embedded-aware struct walk.
 		if _, isStruct := t.Underlying().(*types.Struct); isStruct {
 			if checkStructForSensitiveFields(pass, t, visited) {
-				return obj.Name(), true
+				return t, true
 			}
-			return "", false
+			return nil, false
 		}
 		// A named non-struct (e.g. `type Users []User`): recurse into its
 		// underlying container type.
 		return typeContainsSensitiveStruct(pass, t.Underlying(), visited)
 	}
-	return "", false
+	return nil, false
 }
 
 // checkSensitiveFieldFromTypeInfo checks if a field has sensitive tag using type information
@@ -193,7 +428,7 @@ func checkSensitiveFieldFromTypeInfo(pass *analysis.Pass, named *types.Named, fi
 		if field.Name() == fieldName {
 			// Get the struct tag
 			tag := underlying.Tag(i)
-			return HasSensitiveTag(tag)
+			return isSensitiveTagged(field, tag)
 		}
 
 		// Check embedded structs for the field
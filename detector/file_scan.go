@@ -0,0 +1,143 @@
+package detector
+
+import (
+	"go/ast"
+	"go/types"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// concurrentScanMinFiles is the number of files from which the files of a
+// package are scanned concurrently, given more than one CPU. The scan covers
+// struct tags and sink calls only, while data flow facts are still collected
+// serially, so below it the extra walk costs more than the goroutines save
+// (see BenchmarkCollectFiles).
+const concurrentScanMinFiles = 64
+
+// fileScan is what scanning a file finds without touching the collector's
+// state: the sensitive fields of its struct declarations and the sink calls
+// of its functions. Init functions are left to collectPackageInit.
+type fileScan struct {
+	fields map[*ast.TypeSpec][]collectedField
+	calls  map[*ast.FuncDecl]sinkCalls
+}
+
+// sinkCalls are the log calls, key sink calls and producer sink calls of a
+// function body, in source order
+type sinkCalls struct {
+	caller   types.Object
+	logCalls []*ast.CallExpr
+	keyCalls []keySinkCall
+
+	producerCalls []producerSinkCall
+}
+
+// collectFiles collects the files of the pass. When concurrent is set, the
+// files are first scanned concurrently, one scan per file, and the scans are
+// then merged in file order. Data flow facts depend on the order functions
+// are collected in, so they are not part of the scan and are still
+// collected serially.
+func (c *DataFlowCollector) collectFiles(concurrent bool) {
+	var scans []*fileScan
+	if concurrent {
+		scans = c.scanFiles()
+	}
+	for i, file := range c.pass.Files {
+		var scan *fileScan
+		if scans != nil {
+			scan = scans[i]
+		}
+		c.collectFromFile(file, scan)
+	}
+}
+
+// scanFiles scans the files of the pass concurrently, using up to
+// GOMAXPROCS goroutines. A panic in a worker, out of reach of the recovery
+// of the goroutine collecting the package, is raised again on the calling
+// goroutine once every worker is done, as a workerPanic holding its stack.
+func (c *DataFlowCollector) scanFiles() []*fileScan {
+	files := c.pass.Files
+	scans := make([]*fileScan, len(files))
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	var failure atomic.Pointer[workerPanic]
+	for range min(runtime.GOMAXPROCS(0), len(files)) {
+		wg.Go(func() {
+			defer func() {
+				if r := recover(); r != nil {
+					failure.CompareAndSwap(nil, &workerPanic{value: r, stack: panicStack()})
+				}
+			}()
+			for i := int(next.Add(1) - 1); i < len(files) && failure.Load() == nil; i = int(next.Add(1) - 1) {
+				scans[i] = c.scanFile(files[i])
+			}
+		})
+	}
+	wg.Wait()
+	if p := failure.Load(); p != nil {
+		panic(p)
+	}
+	return scans
+}
+
+// scanFile scans a single file, visiting the same nodes as collectFromFile
+func (c *DataFlowCollector) scanFile(file *ast.File) *fileScan {
+	scan := &fileScan{
+		fields: make(map[*ast.TypeSpec][]collectedField),
+		calls:  make(map[*ast.FuncDecl]sinkCalls),
+	}
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.TypeSpec:
+			scan.fields[node] = c.fieldCollector.fieldsOf(node)
+		case *ast.FuncDecl:
+			if isInitFunc(node) || node.Body == nil {
+				return false
+			}
+			calls := sinkCalls{caller: c.funcObject(node)}
+			ast.Inspect(node.Body, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					c.scanCall(call, &calls)
+				}
+				return true
+			})
+			scan.calls[node] = calls
+			return false
+		}
+		return true
+	})
+	return scan
+}
+
+// scanCall adds call to calls when it is a log call, a cache key or metric
+// name builder, or a message-queue producer
+func (c *DataFlowCollector) scanCall(call *ast.CallExpr, calls *sinkCalls) {
+	if c.logDetector.IsLogCall(call) {
+		calls.logCalls = append(calls.logCalls, call)
+	}
+	if args := c.keySinks.KeyArgs(call, c.pass.TypesInfo); len(args) > 0 {
+		calls.keyCalls = append(calls.keyCalls, keySinkCall{call: call, caller: calls.caller, keyArgs: args})
+	}
+	if args := c.producerSinks.PayloadArgs(call, c.pass.TypesInfo); len(args) > 0 {
+		calls.producerCalls = append(calls.producerCalls, producerSinkCall{call: call, caller: calls.caller, payloadArgs: args})
+	}
+}
+
+// addSinkCalls records the sink calls of a function
+func (c *DataFlowCollector) addSinkCalls(calls sinkCalls) {
+	for _, call := range calls.logCalls {
+		c.logCalls = append(c.logCalls, call)
+		c.logCallFuncs[call] = calls.caller
+	}
+	c.keyCalls = append(c.keyCalls, calls.keyCalls...)
+	c.producerCalls = append(c.producerCalls, calls.producerCalls...)
+}
+
+// funcObject returns the object funcDecl declares, or nil
+func (c *DataFlowCollector) funcObject(funcDecl *ast.FuncDecl) types.Object {
+	if funcDecl.Name == nil {
+		return nil
+	}
+	return c.pass.TypesInfo.Defs[funcDecl.Name]
+}
@@ -0,0 +1,86 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// BenchmarkCollectFiles compares collecting the files of a package serially
+// and concurrently, for the choice of concurrentScanMinFiles
+func BenchmarkCollectFiles(b *testing.B) {
+	for _, n := range []int{1, 4, 16, 64, 256} {
+		pass := benchPass(b, n)
+		for _, concurrent := range []bool{false, true} {
+			name := fmt.Sprintf("files=%d/serial", n)
+			if concurrent {
+				name = fmt.Sprintf("files=%d/concurrent", n)
+			}
+			b.Run(name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					c := NewDataFlowCollector(pass, &config.Config{})
+					c.logDetector.RecordSinkValues(pass.Files, pass.TypesInfo)
+					c.collectFiles(concurrent)
+				}
+			})
+		}
+	}
+}
+
+// benchPass type-checks a generated package of n files, each declaring a
+// struct with a sensitive field and functions logging it
+func benchPass(b *testing.B, n int) *analysis.Pass {
+	b.Helper()
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, n)
+	for i := 0; i < n; i++ {
+		src := fmt.Sprintf(`package bench
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type T%[1]d struct {
+	Name   string
+	Secret string %[2]s
+}
+`, i, sensitiveStructTag())
+		for j := 0; j < 20; j++ {
+			src += fmt.Sprintf(`
+func handle%[1]d_%[2]d(v T%[1]d) string {
+	s := v.Secret
+	slog.Info("msg", "name", v.Name, "secret", s)
+	fmt.Println(v.Name, len(s))
+	return fmt.Sprint(v.Name)
+}
+`, i, j)
+		}
+		file, err := parser.ParseFile(fset, fmt.Sprintf("file%d.go", i), src, 0)
+		if err != nil {
+			b.Fatal(err)
+		}
+		files = append(files, file)
+	}
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf := &types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("bench", fset, files, info)
+	if err != nil {
+		b.Fatal(err)
+	}
+	return &analysis.Pass{Fset: fset, Files: files, Pkg: pkg, TypesInfo: info}
+}
@@ -1,24 +1,102 @@
 package detector
 
-import "go/token"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Severity is the reporting level of a finding. The values mirror SARIF
+// result levels so reporters can pass them through unchanged.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityNote    Severity = "note"
+)
+
+// SARIF rule ID constants, one per detector rule ID.
+const (
+	SARIFRuleIDSensitiveVar            = "LH0001"
+	SARIFRuleIDSensitiveCall           = "LH0002"
+	SARIFRuleIDSensitiveStruct         = "LH0003"
+	SARIFRuleIDSensitiveField          = "LH0004"
+	SARIFRuleIDCrossPkgSensitiveReturn = "LH0005"
+	SARIFRuleIDCrossPkgSensitiveSink   = "LH0006"
+	SARIFRuleIDUntaggedSensitiveField  = "LH0007"
+	SARIFRuleIDSensitiveKey            = "LH0008"
+	SARIFRuleIDPersonalData            = "LH0009"
+	SARIFRuleIDBearerCredential        = "LH0010"
+	SARIFRuleIDExposedSecret           = "LH0011"
+	SARIFRuleIDCommandLineSecret       = "LH0012"
+	SARIFRuleIDConfigDump              = "LH0013"
+	SARIFRuleIDSensitiveStringer       = "LH0014"
+	SARIFRuleIDSensitiveBoundary       = "LH0015"
+	SARIFRuleIDHookForwarding          = "LH0016"
+	SARIFRuleIDSensitivePublished      = "LH0017"
+	SARIFRuleIDHardcodedSecret         = "LH0018"
+	SARIFRuleIDCrashHandlerDump        = "LH0019"
+)
 
 // Finding represents a detected sensitive data leak
 type Finding struct {
 	Pos             token.Pos
+	End             token.Pos // End of the offending expression, token.NoPos if unknown
 	Message         string
 	RuleID          string
-	Suppressed      bool   // true if suppressed by inline comment or config
-	SuppressionKind string // "inSource" (inline comment) or "external" (config file)
+	Severity        Severity                // Reporting level; empty means SeverityError
+	Field           string                  // Sensitive field the value originates from (e.g. "User.Password")
+	FieldPos        token.Pos               // Declaration position of Field, token.NoPos if unknown
+	Sink            string                  // Fully qualified sink function (e.g. "log/slog.Info")
+	CallPos         token.Pos               // Start of the sink call, token.NoPos if unknown
+	CallEnd         token.Pos               // End of the sink call, token.NoPos if unknown
+	Arg             int                     // 1-based sink call argument containing the leak, 0 if unknown
+	FlowPath        []FlowStep              // Data flow path from Field to the sink argument
+	PII             bool                    // Field holds personal data rather than a secret (see config.PIIConfig)
+	Credential      bool                    // Value is an Authorization header or a JWT, whatever the struct tags
+	Exposed         bool                    // Value was unwrapped from a redact wrapper with Expose
+	CommandLine     bool                    // Value was passed on the command line (see config.CommandLineConfig)
+	Fixes           []analysis.SuggestedFix // Suggested edits, e.g. wrapping the value in redact.New
+	Expr            string                  // Normalized source of the offending expression
+	Func            string                  // Enclosing function of the sink call (e.g. "example.com/app.handle")
+	Suppressed      bool                    // true if suppressed by inline comment or config
+	SuppressionKind string                  // "inSource" (inline comment) or "external" (config file)
+	Classification  string                  // How Field was recognized when not by its tags, e.g. "matched default pattern 'e_?mail'"
+	Owners          []string                // Owners of the file from CODEOWNERS, e.g. "@org/payments"
+	BestEffort      bool                    // Matched syntactically in a file that does not type-check (see Config.AllowTypeErrors)
+	ID              string                  // Fingerprint made unique among the findings, see AssignFingerprints
 }
 
 // ruleIDToSARIF maps detector rule IDs to SARIF conventional format.
 var ruleIDToSARIF = map[string]string{
-	RuleIDSensitiveVar:            "LH0001",
-	RuleIDSensitiveCall:           "LH0002",
-	RuleIDSensitiveStruct:         "LH0003",
-	RuleIDSensitiveField:          "LH0004",
-	RuleIDCrossPkgSensitiveReturn: "LH0005",
-	RuleIDCrossPkgSensitiveSink:   "LH0006",
+	RuleIDSensitiveVar:            SARIFRuleIDSensitiveVar,
+	RuleIDSensitiveCall:           SARIFRuleIDSensitiveCall,
+	RuleIDSensitiveStruct:         SARIFRuleIDSensitiveStruct,
+	RuleIDSensitiveField:          SARIFRuleIDSensitiveField,
+	RuleIDCrossPkgSensitiveReturn: SARIFRuleIDCrossPkgSensitiveReturn,
+	RuleIDCrossPkgSensitiveSink:   SARIFRuleIDCrossPkgSensitiveSink,
+	RuleIDUntaggedSensitiveField:  SARIFRuleIDUntaggedSensitiveField,
+	RuleIDSensitiveKey:            SARIFRuleIDSensitiveKey,
+	RuleIDPersonalData:            SARIFRuleIDPersonalData,
+	RuleIDBearerCredential:        SARIFRuleIDBearerCredential,
+	RuleIDExposedSecret:           SARIFRuleIDExposedSecret,
+	RuleIDCommandLineSecret:       SARIFRuleIDCommandLineSecret,
+	RuleIDConfigDump:              SARIFRuleIDConfigDump,
+	RuleIDSensitiveStringer:       SARIFRuleIDSensitiveStringer,
+	RuleIDSensitiveBoundary:       SARIFRuleIDSensitiveBoundary,
+	RuleIDHookForwarding:          SARIFRuleIDHookForwarding,
+	RuleIDSensitivePublished:      SARIFRuleIDSensitivePublished,
+	RuleIDHardcodedSecret:         SARIFRuleIDHardcodedSecret,
+	RuleIDCrashHandlerDump:        SARIFRuleIDCrashHandlerDump,
 }
 
 // ToSARIFRuleID converts a detector rule ID to SARIF format (e.g. "sensitive-var" → "LH0001").
@@ -34,3 +112,183 @@ func ToSARIFRuleID(ruleID string) string {
 func (f Finding) SARIFRuleID() string {
 	return ToSARIFRuleID(f.RuleID)
 }
+
+// Level returns the finding's severity, defaulting to SeverityError when
+// none has been assigned.
+func (f Finding) Level() Severity {
+	if f.Severity == "" {
+		return SeverityError
+	}
+	return f.Severity
+}
+
+// Fingerprint returns a content-based hash that identifies the finding
+// independently of its line number. It covers the rule, the enclosing
+// function, the sink and the normalized offending expression, so it survives
+// unrelated edits that shift lines. The same expression logged twice to the
+// same sink of a function, or in closures of the function, shares a
+// fingerprint; ID tells them apart. Returns "" when the finding carries no
+// expression.
+func (f Finding) Fingerprint() string {
+	if f.Expr == "" {
+		return ""
+	}
+	hash := sha256.Sum256([]byte(f.SARIFRuleID() + "\x00" + f.Func + "\x00" + f.Sink + "\x00" + f.Expr))
+	return hex.EncodeToString(hash[:16])
+}
+
+// AssignFingerprints sets the ID of each finding to its Fingerprint, with
+// ":n" appended to the nth repeat (from 1) among the findings sharing it in
+// source order, so reporters and the triage file key findings uniquely.
+// Suppressed findings are numbered too, so suppressing one keeps the IDs of
+// the others. Findings without a fingerprint get no ID.
+func AssignFingerprints(findings []Finding, _ *config.Config) []Finding {
+	groups := make(map[string][]int)
+	for i := range findings {
+		findings[i].ID = ""
+		if fp := findings[i].Fingerprint(); fp != "" {
+			groups[fp] = append(groups[fp], i)
+		}
+	}
+	for fp, group := range groups {
+		sort.SliceStable(group, func(a, b int) bool {
+			return findings[group[a]].Pos < findings[group[b]].Pos
+		})
+		for n, i := range group {
+			findings[i].ID = fp
+			if n > 0 {
+				findings[i].ID = fmt.Sprintf("%s:%d", fp, n)
+			}
+		}
+	}
+	return findings
+}
+
+// annotateSink stamps the sink call, sink name, argument position,
+// enclosing function and default severity onto the findings produced for
+// argument arg (1-based) of a single sink call. The argument position is also
+// appended to the message, since nested values such as slog.Group members
+// are otherwise hard to tell apart.
+func annotateSink(findings []Finding, call *ast.CallExpr, sink, fn string, arg int) {
+	for i := range findings {
+		if !findings[i].CallPos.IsValid() {
+			findings[i].CallPos, findings[i].CallEnd = call.Pos(), call.End()
+		}
+		if findings[i].Sink == "" {
+			findings[i].Sink = sink
+		}
+		if findings[i].Arg == 0 && arg > 0 {
+			findings[i].Arg = arg
+			findings[i].Message += argSuffix(arg, sink)
+		}
+		if findings[i].Func == "" {
+			findings[i].Func = fn
+		}
+		if findings[i].Severity == "" {
+			findings[i].Severity = SeverityError
+		}
+	}
+}
+
+// reclassify reports findings for particular kinds of data under their own
+// rule, the first that applies of:
+//
+//   - LH0012: flags and command-line arguments declared sensitive
+//   - LH0011: values unwrapped from redact wrappers
+//   - LH0010: Authorization headers and JWTs
+//   - LH0009: personal data (PII mode), at warning severity so it is tracked
+//     apart from leaked secrets
+func reclassify(findings []Finding) {
+	for i := range findings {
+		switch {
+		case findings[i].CommandLine:
+			findings[i].RuleID = RuleIDCommandLineSecret
+			findings[i].Message = fmt.Sprintf("%s is declared sensitive and should not be logged", findings[i].Field)
+		case findings[i].Exposed:
+			findings[i].RuleID = RuleIDExposedSecret
+			findings[i].Message = fmt.Sprintf("secret %q is unwrapped with Expose and should not be logged", findings[i].Field)
+		case findings[i].Credential:
+			findings[i].RuleID = RuleIDBearerCredential
+			findings[i].Message = credentialMessage(findings[i].Field)
+		case findings[i].PII:
+			findings[i].RuleID = RuleIDPersonalData
+			findings[i].Severity = SeverityWarning
+			findings[i].Message = fmt.Sprintf("personal data %q should not be logged", findings[i].Field)
+		}
+	}
+}
+
+// argSuffix describes a sink argument for finding messages, e.g.
+// " in argument 3 of slog.Info".
+func argSuffix(arg int, sink string) string {
+	if sink == "" {
+		return fmt.Sprintf(" in argument %d", arg)
+	}
+	return fmt.Sprintf(" in argument %d of %s", arg, ShortFuncName(sink))
+}
+
+// importPathPrefix matches the leading import-path segments of a qualified
+// function name ("log/", "example.com/app/").
+var importPathPrefix = regexp.MustCompile(`(?:[\w.\-~]+/)+`)
+
+// ShortFuncName trims import paths from a fully qualified function name so it
+// reads like source code: "log/slog.Info" → "slog.Info",
+// "(*log/slog.Logger).Warn" → "(*slog.Logger).Warn".
+func ShortFuncName(name string) string {
+	return importPathPrefix.ReplaceAllString(name, "")
+}
+
+// SinkPackage returns the import path of the package declaring the sink
+// function, e.g. "log/slog" for "log/slog.Info" and "go.uber.org/zap" for
+// "(*go.uber.org/zap.Logger).Info", or "" when the sink is unknown
+func (f Finding) SinkPackage() string {
+	name := f.Sink
+	if strings.HasPrefix(name, "(") {
+		// Method: the receiver type is between the parentheses
+		name, _, _ = strings.Cut(strings.TrimLeft(name, "(*"), ")")
+		name, _, _ = strings.Cut(name, "[")
+	}
+	if dot := strings.LastIndex(name, "."); dot > 0 {
+		return name[:dot]
+	}
+	return ""
+}
+
+// ApplySeverities sets each finding's Severity from the sink-severities of
+// cfg, the default for the sink family: a finding still at the default error
+// severity whose sink is declared in a listed package takes that entry's
+// severity (see config.Config.SinkSeverity). A level already lowered by a
+// field's level tag or by PII mode is kept. The rules section of cfg,
+// including any command-line overrides merged into it, then wins over all of
+// them. Findings matched by neither keep their severity.
+func ApplySeverities(findings []Finding, cfg *config.Config) []Finding {
+	for i := range findings {
+		// A lower level was set by a level tag or PII mode, which win over
+		// the sink family
+		if s := cfg.SinkSeverity(findings[i].SinkPackage()); s != "" && findings[i].Level() == SeverityError {
+			findings[i].Severity = Severity(s)
+		}
+		if s := cfg.RuleSeverity(findings[i].SARIFRuleID()); s != "" {
+			findings[i].Severity = Severity(s)
+		}
+	}
+	return findings
+}
+
+// FailsRun reports whether any unsuppressed finding is at the fail-on
+// severity of cfg or above, so the run should exit with a failure status
+func FailsRun(findings []Finding, cfg *config.Config) bool {
+	if cfg == nil {
+		return false
+	}
+	threshold := severityRank[Severity(cfg.FailOn)]
+	if threshold == 0 {
+		return false // Unset or never
+	}
+	for _, f := range findings {
+		if !f.Suppressed && severityRank[f.Level()] >= threshold {
+			return true
+		}
+	}
+	return false
+}
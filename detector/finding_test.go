@@ -1,6 +1,10 @@
 package detector
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+)
 
 func TestToSARIFRuleID(t *testing.T) {
 	t.Parallel()
@@ -56,3 +60,215 @@ func TestFinding_SARIFRuleID(t *testing.T) {
 		})
 	}
 }
+
+func TestFinding_Fingerprint(t *testing.T) {
+	t.Parallel()
+
+	base := Finding{Pos: 10, RuleID: RuleIDSensitiveField, Func: "example.com/app.handle", Sink: "log/slog.Info", Expr: "u.Password"}
+
+	moved := base
+	moved.Pos = 500
+	if base.Fingerprint() != moved.Fingerprint() {
+		t.Errorf("fingerprint changed when only the position moved")
+	}
+	if got := len(base.Fingerprint()); got != 32 {
+		t.Errorf("fingerprint length = %d, want 32", got)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(f *Finding)
+	}{
+		{"different rule", func(f *Finding) { f.RuleID = RuleIDSensitiveVar }},
+		{"different function", func(f *Finding) { f.Func = "example.com/app.other" }},
+		{"different expression", func(f *Finding) { f.Expr = "u.APIKey" }},
+		{"different sink", func(f *Finding) { f.Sink = "fmt.Println" }},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			changed := base
+			tt.mutate(&changed)
+			if base.Fingerprint() == changed.Fingerprint() {
+				t.Errorf("fingerprint did not change for %s", tt.name)
+			}
+		})
+	}
+
+	if got := (Finding{RuleID: RuleIDSensitiveField}).Fingerprint(); got != "" {
+		t.Errorf("Fingerprint() without Expr = %q, want empty", got)
+	}
+}
+
+func TestAssignFingerprints(t *testing.T) {
+	t.Parallel()
+
+	// Out of source order: repeats are numbered by position
+	findings := AssignFingerprints([]Finding{
+		{Pos: 50, RuleID: RuleIDSensitiveField, Func: "app.handle", Sink: "log/slog.Info", Expr: "u.Email"},
+		{Pos: 10, RuleID: RuleIDSensitiveField, Func: "app.handle", Sink: "log/slog.Info", Expr: "u.Email", Suppressed: true},
+		{Pos: 30, RuleID: RuleIDSensitiveField, Func: "app.handle", Sink: "fmt.Println", Expr: "u.Email"},
+		{Pos: 20, RuleID: RuleIDSensitiveField, Func: "app.handle", Sink: "log/slog.Info", Expr: "u.Email"},
+		{Pos: 40, RuleID: RuleIDSensitiveField, ID: "stale"},
+	}, nil)
+
+	fp := findings[0].Fingerprint()
+	want := []string{fp + ":2", fp, findings[2].Fingerprint(), fp + ":1", ""}
+	for i, f := range findings {
+		if f.ID != want[i] {
+			t.Errorf("findings[%d] (pos %d) ID = %q, want %q", i, f.Pos, f.ID, want[i])
+		}
+	}
+	if findings[2].ID == fp {
+		t.Errorf("findings of different sinks share the ID %q", fp)
+	}
+}
+
+func TestFinding_SinkPackage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		sink string
+		want string
+	}{
+		{"fmt.Println", "fmt"},
+		{"log/slog.Info", "log/slog"},
+		{"(*log.Logger).Printf", "log"},
+		{"(*go.uber.org/zap.Logger).Info", "go.uber.org/zap"},
+		{"(*github.com/sirupsen/logrus.Entry).Infof", "github.com/sirupsen/logrus"},
+		{"gopkg.in/yaml.v3.Marshal", "gopkg.in/yaml.v3"},
+		{"(example.com/app.Store[K]).Put", "example.com/app"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.sink, func(t *testing.T) {
+			t.Parallel()
+			if got := (Finding{Sink: tt.sink}).SinkPackage(); got != tt.want {
+				t.Errorf("SinkPackage() for %q = %q, want %q", tt.sink, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplySeverities(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Rules: map[string]config.RuleConfig{
+		"LH0003": {Severity: "warning"},
+	}}
+	cfg.ApplySeverityOverrides(map[string]string{"LH0004": "note"})
+
+	findings := ApplySeverities([]Finding{
+		{RuleID: RuleIDSensitiveVar, Severity: SeverityError},
+		{RuleID: RuleIDSensitiveStruct, Severity: SeverityError},
+		{RuleID: RuleIDSensitiveField},
+	}, cfg)
+
+	want := []Severity{SeverityError, SeverityWarning, SeverityNote}
+	for i, f := range findings {
+		if f.Severity != want[i] {
+			t.Errorf("findings[%d] (%s) severity = %q, want %q", i, f.SARIFRuleID(), f.Severity, want[i])
+		}
+	}
+}
+
+func TestApplySeverities_SinkSeverities(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		Rules: map[string]config.RuleConfig{"LH0003": {Severity: "note"}},
+		SinkSeverities: []config.SinkSeverityConfig{
+			{Packages: []string{"fmt"}, Severity: "warning"},
+			{Packages: []string{"log/slog", "go.uber.org/zap"}, Severity: "error"},
+			{Packages: []string{"testing"}, Severity: "note"},
+		},
+	}
+
+	findings := ApplySeverities([]Finding{
+		{RuleID: RuleIDSensitiveField, Sink: "fmt.Println"},
+		{RuleID: RuleIDSensitiveField, Sink: "log/slog.Info", Severity: SeverityWarning}, // The level tag wins
+		{RuleID: RuleIDSensitiveStruct, Sink: "(*go.uber.org/zap.Logger).Info"},          // The rule wins
+		{RuleID: RuleIDSensitiveVar, Sink: "(*testing.common).Logf"},
+		{RuleID: RuleIDSensitiveField, Sink: "(*log.Logger).Printf", Severity: SeverityError},
+		{RuleID: RuleIDHardcodedSecret, Severity: SeverityWarning},
+	}, cfg)
+
+	want := []Severity{SeverityWarning, SeverityWarning, SeverityNote, SeverityNote, SeverityError, SeverityWarning}
+	for i, f := range findings {
+		if f.Severity != want[i] {
+			t.Errorf("findings[%d] (%s) severity = %q, want %q", i, f.Sink, f.Severity, want[i])
+		}
+	}
+}
+
+func TestApplySeverities_SinkSeveritiesAndOverrides(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{
+		SinkSeverities: []config.SinkSeverityConfig{
+			{Packages: []string{"log/slog"}, Severity: "error"},
+		},
+	}
+	cfg.ApplySeverityOverrides(map[string]string{config.AllRules: "note"})
+
+	findings := ApplySeverities([]Finding{
+		{RuleID: RuleIDSensitiveField, Sink: "log/slog.Info", Severity: SeverityError},
+		{RuleID: RuleIDPersonalData, Sink: "log/slog.Info", Severity: SeverityWarning},
+		{RuleID: RuleIDSensitiveVar, Sink: "fmt.Println", Severity: SeverityError},
+	}, cfg)
+
+	for i, f := range findings {
+		if f.Severity != SeverityNote {
+			t.Errorf("findings[%d] (%s) severity = %q, want the overridden %q", i, f.SARIFRuleID(), f.Severity, SeverityNote)
+		}
+	}
+}
+
+func TestFailsRun(t *testing.T) {
+	t.Parallel()
+
+	findings := []Finding{
+		{RuleID: RuleIDSensitiveField, Severity: SeverityError, Suppressed: true},
+		{RuleID: RuleIDSensitiveField, Severity: SeverityWarning},
+		{RuleID: RuleIDPersonalData, Severity: SeverityNote},
+	}
+
+	tests := []struct {
+		failOn string
+		want   bool
+	}{
+		{"", false},
+		{config.FailOnNever, false},
+		{"error", false}, // The error is suppressed
+		{"warning", true},
+		{"note", true},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.failOn, func(t *testing.T) {
+			t.Parallel()
+			if got := FailsRun(findings, &config.Config{FailOn: tt.failOn}); got != tt.want {
+				t.Errorf("FailsRun() with fail-on %q = %v, want %v", tt.failOn, got, tt.want)
+			}
+		})
+	}
+
+	if FailsRun(findings[1:], nil) {
+		t.Errorf("FailsRun() with nil config = true, want false")
+	}
+}
+
+func TestFinding_Remediation(t *testing.T) {
+	t.Parallel()
+
+	for _, doc := range RuleDocs() {
+		if (Finding{RuleID: doc.RuleID}).Remediation() == "" {
+			t.Errorf("%s (%s) has no remediation estimate", doc.ID, doc.RuleID)
+		}
+	}
+	if got := (Finding{RuleID: RuleIDSensitiveStruct}).Remediation(); got != RemediationAddLogValuer {
+		t.Errorf("LH0003 Remediation() = %q, want %q", got, RemediationAddLogValuer)
+	}
+}
@@ -0,0 +1,77 @@
+package detector
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// flagHelpArgs returns the indexes in call.Args of the values the help text
+// of a program prints, which -h and any flag parsing error write out: the
+// default value and usage of a flag definition, which flag.PrintDefaults
+// prints, or the data written to the output of a flag set by a custom Usage
+// function. It returns nil for any other call.
+//
+//	flag.StringVar(&cfg.Password, "db-password", cfg.Password, "")  // default printed by -h
+//	flag.String("token", "", "API token, e.g. "+cfg.Token)          // usage printed by -h
+//	io.WriteString(flag.CommandLine.Output(), cfg.DSN)             // in flag.Usage
+//
+// fmt.Fprint* calls writing to the output are sinks whatever the writer.
+func flagHelpArgs(call *ast.CallExpr, info *types.Info) []int {
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil
+	}
+
+	var args []int
+	switch path, name := fn.Pkg().Path(), fn.Name(); {
+	case path == "io" && name == "WriteString":
+		if len(call.Args) == 2 && isFlagOutput(call.Args[0], info) {
+			return []int{1}
+		}
+		return nil
+	case name == "Write":
+		// flag.CommandLine.Output().Write(data)
+		if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && len(call.Args) == 1 && isFlagOutput(sel.X, info) {
+			return []int{0}
+		}
+		return nil
+	case path != "flag":
+		return nil
+	case flagDefiners[name]:
+		args = []int{1, 2} // String(name, value, usage)
+	case name == "Var":
+		args = []int{2} // Var(value, name, usage): the value is a flag.Value
+	case flagBinders[name]:
+		args = []int{2, 3} // StringVar(p, name, value, usage)
+	case name == "Func" || name == "BoolFunc":
+		args = []int{1} // Func(name, usage, fn)
+	default:
+		return nil
+	}
+
+	// A method expression takes the flag set as its first argument
+	if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok {
+		if selection, ok := info.Selections[sel]; ok && selection.Kind() == types.MethodExpr {
+			for i := range args {
+				args[i]++
+			}
+		}
+	}
+	for _, i := range args {
+		if i >= len(call.Args) {
+			return nil
+		}
+	}
+	return args
+}
+
+// isFlagOutput reports whether expr is a call to the Output method of a
+// *flag.FlagSet, the writer of its help text
+func isFlagOutput(expr ast.Expr, info *types.Info) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	return ok && fn.Pkg() != nil && fn.Pkg().Path() == "flag" && fn.Name() == "Output"
+}
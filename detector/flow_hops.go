@@ -0,0 +1,47 @@
+package detector
+
+import (
+	"fmt"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// FlowHops returns the number of data flow steps the value took after its
+// source before reaching the sink, such as variables, parameters and return
+// values, 0 for a direct access
+func (f Finding) FlowHops() int {
+	if len(f.FlowPath) == 0 {
+		return 0
+	}
+	return len(f.FlowPath) - 1
+}
+
+// ApplyFlowHops applies the long-flows setting of cfg to the findings whose
+// data flow takes more hops than max-flow-hops (see
+// config.Config.FlowHopLimit): they are reported at warning level, or
+// suppressed like config-level suppressions. Long flows through helpers are
+// where propagation is most conservative, so this trades recall for
+// precision without disabling data flow tracking.
+func ApplyFlowHops(findings []Finding, cfg *config.Config) []Finding {
+	max, action := cfg.FlowHopLimit()
+	if max == 0 {
+		return findings
+	}
+	for i := range findings {
+		hops := findings[i].FlowHops()
+		if hops <= max || findings[i].Suppressed {
+			continue
+		}
+		switch action {
+		case config.LongFlowsSuppress:
+			findings[i].Suppressed = true
+			findings[i].SuppressionKind = "external"
+		default:
+			if findings[i].Level() == SeverityError {
+				findings[i].Severity = SeverityWarning
+			}
+		}
+		findings[i].Message += fmt.Sprintf(" (data flow of %d hops, over max-flow-hops %d)", hops, max)
+	}
+	return findings
+}
@@ -0,0 +1,70 @@
+package detector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+func TestApplyFlowHops(t *testing.T) {
+	t.Parallel()
+
+	steps := func(n int) []FlowStep { return make([]FlowStep, n) }
+	findings := func() []Finding {
+		return []Finding{
+			{RuleID: RuleIDSensitiveField, Message: "direct", FlowPath: steps(1)},
+			{RuleID: RuleIDSensitiveVar, Message: "short", FlowPath: steps(3)},
+			{RuleID: RuleIDSensitiveCall, Message: "long", FlowPath: steps(4)},
+			{RuleID: RuleIDSensitiveCall, Message: "long note", FlowPath: steps(5), Severity: SeverityNote},
+		}
+	}
+	tests := []struct {
+		name           string
+		cfg            *config.Config
+		wantLevels     []Severity
+		wantSuppressed []bool
+	}{
+		{
+			name:           "no limit",
+			cfg:            &config.Config{},
+			wantLevels:     []Severity{SeverityError, SeverityError, SeverityError, SeverityNote},
+			wantSuppressed: []bool{false, false, false, false},
+		},
+		{
+			name:           "warning",
+			cfg:            &config.Config{MaxFlowHops: 2},
+			wantLevels:     []Severity{SeverityError, SeverityError, SeverityWarning, SeverityNote},
+			wantSuppressed: []bool{false, false, false, false},
+		},
+		{
+			name:           "suppress",
+			cfg:            &config.Config{MaxFlowHops: 2, LongFlows: config.LongFlowsSuppress},
+			wantLevels:     []Severity{SeverityError, SeverityError, SeverityError, SeverityNote},
+			wantSuppressed: []bool{false, false, true, true},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := ApplyFlowHops(findings(), tt.cfg)
+			for i, f := range got {
+				if f.Level() != tt.wantLevels[i] {
+					t.Errorf("%s: Level() = %q, want %q", f.Message, f.Level(), tt.wantLevels[i])
+				}
+				if f.Suppressed != tt.wantSuppressed[i] {
+					t.Errorf("%s: Suppressed = %v, want %v", f.Message, f.Suppressed, tt.wantSuppressed[i])
+				}
+				if f.Suppressed && f.SuppressionKind != "external" {
+					t.Errorf("%s: SuppressionKind = %q, want external", f.Message, f.SuppressionKind)
+				}
+				over := f.FlowHops() > tt.cfg.MaxFlowHops && tt.cfg.MaxFlowHops > 0
+				if noted := strings.Contains(f.Message, "over max-flow-hops"); noted != over {
+					t.Errorf("%s: message %q notes the hop limit = %v, want %v", f.Message, f.Message, noted, over)
+				}
+			}
+		})
+	}
+}
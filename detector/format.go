@@ -0,0 +1,200 @@
+package detector
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// LoggedArg identifies an argument whose value a log call writes
+type LoggedArg struct {
+	Call  *ast.CallExpr // The log call, or a chained call whose arguments it logs
+	Index int           // Index into Call.Args
+}
+
+// Expr returns the argument expression
+func (a LoggedArg) Expr() ast.Expr { return a.Call.Args[a.Index] }
+
+// LoggedArgs returns the arguments written by the log call: its own
+// arguments and those of chained calls (see LoggedCalls). For printf-style
+// sinks with a constant format string, arguments formatted only with verbs
+// that do not reveal the value (%T, %p) are left out, and so are the context
+// and level arguments and the constant messages, keys and values of log/slog
+// calls (see slogArgRoles). A template execution writes only its data
+// argument, and a flag definition its default value and usage (see
+// flagHelpArgs).
+func (ld *LogDetector) LoggedArgs(call *ast.CallExpr, info *types.Info) []LoggedArg {
+	if i := ld.templateDataArg(call, info); i >= 0 {
+		return []LoggedArg{{Call: call, Index: i}}
+	}
+	if indexes := flagHelpArgs(call, info); indexes != nil {
+		args := make([]LoggedArg, len(indexes))
+		for i, index := range indexes {
+			args[i] = LoggedArg{Call: call, Index: index}
+		}
+		return args
+	}
+	opaque := ld.opaqueFormatArgs(call, info)
+	var args []LoggedArg
+	for _, c := range ld.LoggedCalls(call, info) {
+		roles := ld.slogArgRoles(c, info)
+		for i := range c.Args {
+			if c == call && opaque[i] {
+				continue
+			}
+			if roles != nil && (roles[i] == slogArgContext || roles[i] == slogArgLevel || isConstantArg(c.Args[i], info)) {
+				continue
+			}
+			args = append(args, LoggedArg{Call: c, Index: i})
+		}
+	}
+	return args
+}
+
+// FormatArg returns the index of the printf-style format string in
+// call.Args, or -1 if the sink does not take one. Built-in sinks follow fmt,
+// log, the f methods of *zap.SugaredLogger (fmt.Fprintf has the writer
+// first, and SugaredLogger.Logf the level) and (*zerolog.Event).Msgf;
+// configured targets use their format-arg setting.
+func (ld *LogDetector) FormatArg(call *ast.CallExpr, info *types.Info) int {
+	if info == nil {
+		return -1
+	}
+	fn := ld.heldSink(call.Fun, info)
+	if fn == nil {
+		fn, _ = resolveCallee(call.Fun, info).(*types.Func)
+	}
+	if fn == nil || fn.Pkg() == nil {
+		return -1
+	}
+
+	index := -1
+	switch pkgPath := fn.Pkg().Path(); {
+	case (pkgPath == "fmt" || pkgPath == "log") && strings.HasSuffix(fn.Name(), "f"):
+		index = 0
+		if fn.Name() == "Fprintf" {
+			index = 1
+		}
+	case isZapLoggerFunc(fn, "SugaredLogger") && strings.HasSuffix(fn.Name(), "f"):
+		index = 0
+		if fn.Name() == "Logf" {
+			index = 1 // After the level
+		}
+	case fn.Name() == "Msgf" && isZerologEventMethod(fn):
+		index = 0
+	case ld.config != nil:
+		entry := ld.CustomTarget(call, info)
+		if entry == "" {
+			entry = ld.matchCustomTarget(pkgPath, fn.Name(), fn)
+		}
+		index = ld.customFormatArg(entry)
+	}
+
+	// A method expression takes the receiver as its first argument
+	if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && index >= 0 {
+		if selection, ok := info.Selections[sel]; ok && selection.Kind() == types.MethodExpr {
+			index++
+		}
+	}
+	return index
+}
+
+// customFormatArg returns the format-arg of the first target configuring
+// entry, or -1
+func (ld *LogDetector) customFormatArg(entry string) int {
+	if entry == "" {
+		return -1
+	}
+	for _, target := range ld.config.Targets {
+		if target.FormatArg == nil {
+			continue
+		}
+		for _, e := range TargetEntries(&config.Config{Targets: []config.TargetConfig{target}}) {
+			if e == entry {
+				return *target.FormatArg
+			}
+		}
+	}
+	return -1
+}
+
+// opaqueFormatArgs returns the indexes of call.Args that a constant format
+// string only formats with %T or %p. Arguments without a verb are printed as
+// %!(EXTRA ...) and so are never opaque.
+func (ld *LogDetector) opaqueFormatArgs(call *ast.CallExpr, info *types.Info) map[int]bool {
+	index := ld.FormatArg(call, info)
+	if index < 0 || index >= len(call.Args) {
+		return nil
+	}
+	tv, ok := info.Types[call.Args[index]]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		return nil
+	}
+
+	verbs := formatVerbs(constant.StringVal(tv.Value))
+	opaque := make(map[int]bool)
+	for arg, vs := range verbs {
+		i := index + 1 + arg
+		if i >= len(call.Args) {
+			continue
+		}
+		if !strings.ContainsFunc(vs, func(r rune) bool { return r != 'T' && r != 'p' }) {
+			opaque[i] = true
+		}
+	}
+	return opaque
+}
+
+// formatVerbs maps each operand index (0-based, after the format string) to
+// the verbs applied to it by format. Operands consumed by a '*' width or
+// precision are recorded with the verb '*'.
+func formatVerbs(format string) map[int]string {
+	verbs := make(map[int]string)
+	arg := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+		i++
+		// Flags
+		for i < len(format) && strings.IndexByte("+-# 0", format[i]) >= 0 {
+			i++
+		}
+		// Width, precision and explicit argument indexes, e.g. %[2]*.[1]d
+		for i < len(format) {
+			switch c := format[i]; {
+			case c == '[':
+				end := strings.IndexByte(format[i:], ']')
+				if end < 0 {
+					return verbs
+				}
+				if n, err := strconv.Atoi(format[i+1 : i+end]); err == nil && n > 0 {
+					arg = n - 1
+				}
+				i += end + 1
+				continue
+			case c == '*':
+				verbs[arg] += "*"
+				arg++
+			case c == '.' || (c >= '0' && c <= '9'):
+			default:
+				goto verb
+			}
+			i++
+		}
+	verb:
+		if i >= len(format) {
+			break
+		}
+		if format[i] == '%' {
+			continue
+		}
+		verbs[arg] += string(format[i])
+		arg++
+	}
+	return verbs
+}
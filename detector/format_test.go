@@ -0,0 +1,36 @@
+package detector
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestFormatVerbs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		format string
+		want   map[int]string
+	}{
+		{"no verbs", "plain text", map[int]string{}},
+		{"escaped percent", "100%% done %s", map[int]string{0: "s"}},
+		{"flags and width", "%-8s %+.2f %#x", map[int]string{0: "s", 1: "f", 2: "x"}},
+		{"type and pointer", "%T %p %v", map[int]string{0: "T", 1: "p", 2: "v"}},
+		{"explicit index", "%[2]s %[1]T %[1]v", map[int]string{0: "Tv", 1: "s"}},
+		{"star width", "%*s %T", map[int]string{0: "*", 1: "s", 2: "T"}},
+		{"star precision", "%.*f", map[int]string{0: "*", 1: "f"}},
+		{"trailing percent", "done %", map[int]string{}},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := formatVerbs(tt.format)
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("formatVerbs(%q) = %v, want %v", tt.format, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package detector
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// severityRank orders severities from least to most severe
+var severityRank = map[Severity]int{
+	SeverityNote:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+// AggregateByCall merges the findings of each sink call into a single
+// finding at the call expression when cfg sets report-granularity to "call".
+// The merged finding takes the rule, severity and flow of its most severe
+// member and lists every tainted argument in its message:
+//
+//	slog.Info logs sensitive data in 2 arguments: "User.Password" (argument 2), "User.Token" (argument 3)
+//
+// Suppressed findings and findings without a sink call are kept as they are.
+// Otherwise findings are returned unchanged.
+func AggregateByCall(findings []Finding, cfg *config.Config) []Finding {
+	if !cfg.ReportsPerCall() {
+		return findings
+	}
+
+	// Merged findings take the place of the first finding of their call
+	groups := make(map[token.Pos][]Finding)
+	slots := make(map[token.Pos]int)
+	result := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		if f.Suppressed || !f.CallPos.IsValid() {
+			result = append(result, f)
+			continue
+		}
+		if _, seen := slots[f.CallPos]; !seen {
+			slots[f.CallPos] = len(result)
+			result = append(result, Finding{})
+		}
+		groups[f.CallPos] = append(groups[f.CallPos], f)
+	}
+	for pos, i := range slots {
+		result[i] = mergeCallFindings(groups[pos])
+	}
+	return result
+}
+
+// mergeCallFindings merges the findings of one sink call, which are in
+// argument order
+func mergeCallFindings(group []Finding) Finding {
+	lead := group[0]
+	for _, f := range group[1:] {
+		if severityRank[f.Level()] > severityRank[lead.Level()] {
+			lead = f
+		}
+	}
+
+	merged := lead
+	merged.Pos, merged.End = lead.CallPos, lead.CallEnd
+	merged.Arg = 0
+	merged.Fixes = mergeFixes(group)
+
+	var parts []string
+	seen := make(map[string]bool)
+	for _, f := range group {
+		name := f.Field
+		if name == "" {
+			name = f.Expr
+		}
+		part := fmt.Sprintf("%q (argument %d)", name, f.Arg)
+		if !seen[part] {
+			seen[part] = true
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) > 1 {
+		merged.Message = fmt.Sprintf("%s logs sensitive data in %d arguments: %s",
+			ShortFuncName(lead.Sink), len(parts), strings.Join(parts, ", "))
+	}
+	return merged
+}
+
+// mergeFixes combines the suggested fixes of a call's findings into one,
+// dropping duplicate edits such as the redact import each of them adds
+func mergeFixes(group []Finding) []analysis.SuggestedFix {
+	var fixes []analysis.SuggestedFix
+	for _, f := range group {
+		fixes = append(fixes, f.Fixes...)
+	}
+	if len(fixes) <= 1 {
+		return fixes
+	}
+
+	type editKey struct {
+		pos, end token.Pos
+		text     string
+	}
+	seen := make(map[editKey]bool)
+	merged := analysis.SuggestedFix{Message: fixes[0].Message}
+	for _, fix := range fixes {
+		for _, edit := range fix.TextEdits {
+			key := editKey{edit.Pos, edit.End, string(edit.NewText)}
+			if !seen[key] {
+				seen[key] = true
+				merged.TextEdits = append(merged.TextEdits, edit)
+			}
+		}
+	}
+	return []analysis.SuggestedFix{merged}
+}
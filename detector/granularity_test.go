@@ -0,0 +1,77 @@
+package detector
+
+import (
+	"go/token"
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestAggregateByCall(t *testing.T) {
+	t.Parallel()
+
+	redactImport := analysis.TextEdit{Pos: 5, End: 5, NewText: []byte("\n\t\"github.com/nilpoona/leakhound/redact\"")}
+	findings := []Finding{
+		{Pos: 110, RuleID: RuleIDSensitiveField, Severity: SeverityWarning, Field: "User.Hint", Sink: "log/slog.Info", CallPos: 100, CallEnd: 200, Arg: 3,
+			Fixes: []analysis.SuggestedFix{{Message: "Wrap the value in redact.New", TextEdits: []analysis.TextEdit{redactImport, {Pos: 110, End: 110}}}}},
+		{Pos: 150, RuleID: RuleIDSensitiveVar, Severity: SeverityError, Field: "User.Password", Sink: "log/slog.Info", CallPos: 100, CallEnd: 200, Arg: 5,
+			Fixes: []analysis.SuggestedFix{{Message: "Wrap the value in redact.New", TextEdits: []analysis.TextEdit{redactImport, {Pos: 150, End: 150}}}}},
+		{Pos: 310, RuleID: RuleIDSensitiveField, Severity: SeverityError, Field: "User.Token", Sink: "log/slog.Info", CallPos: 300, CallEnd: 400, Arg: 3, Message: "token"},
+		{Pos: 320, RuleID: RuleIDSensitiveField, Field: "User.Password", CallPos: 300, CallEnd: 400, Arg: 5, Suppressed: true},
+		{Pos: 500, RuleID: RuleIDSensitiveStruct, Message: "no call"},
+	}
+
+	t.Run("arg granularity", func(t *testing.T) {
+		t.Parallel()
+		got := AggregateByCall(append([]Finding(nil), findings...), &config.Config{})
+		if len(got) != len(findings) {
+			t.Errorf("len(findings) = %d, want %d unchanged", len(got), len(findings))
+		}
+	})
+
+	t.Run("call granularity", func(t *testing.T) {
+		t.Parallel()
+		got := AggregateByCall(append([]Finding(nil), findings...), &config.Config{ReportGranularity: config.GranularityCall})
+		if len(got) != 4 {
+			t.Fatalf("len(findings) = %d, want 4: %+v", len(got), got)
+		}
+
+		merged := got[0]
+		if merged.Pos != 100 || merged.End != 200 || merged.Arg != 0 {
+			t.Errorf("merged Pos, End, Arg = %v, %v, %d, want 100, 200, 0", merged.Pos, merged.End, merged.Arg)
+		}
+		if merged.RuleID != RuleIDSensitiveVar || merged.Severity != SeverityError {
+			t.Errorf("merged rule, severity = %s, %s, want those of the most severe finding", merged.RuleID, merged.Severity)
+		}
+		wantMsg := `slog.Info logs sensitive data in 2 arguments: "User.Hint" (argument 3), "User.Password" (argument 5)`
+		if merged.Message != wantMsg {
+			t.Errorf("merged Message = %q, want %q", merged.Message, wantMsg)
+		}
+		if len(merged.Fixes) != 1 || len(merged.Fixes[0].TextEdits) != 3 {
+			t.Errorf("merged Fixes = %+v, want one fix with the import edit once", merged.Fixes)
+		}
+
+		// Kept as they are
+		if got[2].Pos != 320 || !got[2].Suppressed {
+			t.Errorf("findings[2] = %+v, want the suppressed finding", got[2])
+		}
+		if got[3].Pos != 500 {
+			t.Errorf("findings[3] = %+v, want the finding without a call", got[3])
+		}
+
+		single := got[1]
+		if single.Pos != 300 || single.Message != "token" || single.Arg != 0 {
+			t.Errorf("single = %+v, want Pos 300 and the original message", single)
+		}
+	})
+}
+
+func TestAggregateByCall_NilConfig(t *testing.T) {
+	t.Parallel()
+
+	findings := []Finding{{Pos: token.Pos(1), CallPos: 1}, {Pos: token.Pos(2), CallPos: 1}}
+	if got := AggregateByCall(findings, nil); len(got) != 2 {
+		t.Errorf("len(findings) = %d, want 2 unchanged", len(got))
+	}
+}
@@ -0,0 +1,247 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// defaultTagKeys are the struct tag keys holding a field's default or
+// example value, as read by caarlos0/env, cleanenv, go-arg, kong and swag
+var defaultTagKeys = map[string]bool{
+	"default":     true,
+	"envDefault":  true,
+	"env-default": true,
+	"example":     true,
+}
+
+// dsnPassword matches the password of a connection string, either in the
+// userinfo of a URL, as in postgres://app:hunter2@db/app, or as a keyword,
+// as in host=db password=hunter2 or ?password=hunter2
+var dsnPassword = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9+.\-]*://[^/@\s:]*:([^@\s/]+)@|(?:^|[\s?&;])(?i:password|passwd|pwd)=([^\s&;]+)`)
+
+// placeholderValue matches values standing for a secret rather than being
+// one: environment references, format verbs, <password> and {{.Password}}
+var placeholderValue = regexp.MustCompile(`^\$|%[sv]|^<.*>$|^\{\{.*\}\}$`)
+
+// envVarName matches the names of environment variables, which tags and
+// constants of sensitive-looking names often hold, as in env:"DB_PASSWORD"
+var envVarName = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// AuditHardcodedSecrets reports secrets written into the code (LH0018): the
+// default values in the struct tags of fields tagged sensitive or whose names
+// match the sensitive-name heuristics, the string constants with such names,
+// and connection strings with a password in any struct tag or string
+// constant. A sensitive:"false" tag marks a field as reviewed, as for LH0007.
+// The findings are reported at the declaration, and their messages never
+// include the secret.
+//
+//	Password string `default:"hunter2"`
+//	const dsn = "postgres://app:hunter2@db/app"
+//
+// pkgPath qualifies the findings so identical names in different packages
+// fingerprint differently.
+func AuditHardcodedSecrets(files []*ast.File, info *types.Info, pkgPath string, matcher *config.NameMatcher) []Finding {
+	if matcher == nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				if structType, ok := n.Type.(*ast.StructType); ok {
+					findings = append(findings, auditStructTags(n.Name.Name, structType, pkgPath, matcher)...)
+				}
+			case *ast.GenDecl:
+				if n.Tok == token.CONST {
+					findings = append(findings, auditConstants(n, info, pkgPath, matcher)...)
+				}
+			}
+			return true
+		})
+	}
+	return findings
+}
+
+// auditStructTags checks the tags of the named fields of a single struct type
+func auditStructTags(typeName string, structType *ast.StructType, pkgPath string, matcher *config.NameMatcher) []Finding {
+	var findings []Finding
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil || len(field.Names) == 0 {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		tagged := HasSensitiveTag(tag)
+		reviewed := reflect.StructTag(tag).Get("sensitive") == "false"
+		for _, name := range field.Names {
+			qualified := typeName + "." + name.Name
+			for _, pair := range structTagPairs(tag) {
+				var what, classification string
+				switch {
+				case hasDSNPassword(pair.value):
+					what = "a connection string with a hardcoded password"
+				case defaultTagKeys[pair.key] && (tagged || !reviewed && matcher.Match(name.Name)) && isSecretValue(pair.value, matcher):
+					what = "a hardcoded secret"
+					if !tagged {
+						classification = patternClassification(matcher, name.Name)
+					}
+				default:
+					continue
+				}
+				finding := hardcodedSecretFinding(field.Tag, qualified, pkgPath,
+					fmt.Sprintf("struct tag '%s' of field '%s' holds %s", pair.key, qualified, what))
+				finding.classify(classification)
+				findings = append(findings, finding)
+				break // One finding per field
+			}
+		}
+	}
+	return findings
+}
+
+// auditConstants checks the string constants declared by decl
+func auditConstants(decl *ast.GenDecl, info *types.Info, pkgPath string, matcher *config.NameMatcher) []Finding {
+	var findings []Finding
+	for _, spec := range decl.Specs {
+		for _, name := range spec.(*ast.ValueSpec).Names {
+			obj, ok := info.Defs[name].(*types.Const)
+			if !ok || obj.Val().Kind() != constant.String {
+				continue
+			}
+			value := constant.StringVal(obj.Val())
+			var what, classification string
+			switch {
+			case hasDSNPassword(value):
+				what = "a connection string with a hardcoded password"
+			case matcher.Match(name.Name) && isSecretValue(value, matcher):
+				what = "a hardcoded secret"
+				classification = patternClassification(matcher, name.Name)
+			default:
+				continue
+			}
+			qualified := name.Name
+			if obj.Parent() == obj.Pkg().Scope() {
+				qualified = obj.Pkg().Name() + "." + name.Name
+			}
+			finding := hardcodedSecretFinding(name, qualified, pkgPath,
+				fmt.Sprintf("constant '%s' holds %s", qualified, what))
+			finding.classify(classification)
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// hardcodedSecretFinding returns an LH0018 finding for the declaration node
+// of name
+func hardcodedSecretFinding(node ast.Node, name, pkgPath, message string) Finding {
+	return Finding{
+		Pos:      node.Pos(),
+		End:      node.End(),
+		Message:  message,
+		RuleID:   RuleIDHardcodedSecret,
+		Severity: SeverityWarning,
+		Field:    name,
+		FieldPos: node.Pos(),
+		Expr:     name,
+		Func:     pkgPath,
+	}
+}
+
+// hasDSNPassword reports whether value is a connection string holding a
+// password other than a placeholder
+func hasDSNPassword(value string) bool {
+	for _, m := range dsnPassword.FindAllStringSubmatch(value, -1) {
+		password := m[1] + m[2]
+		if !isPlaceholder(password) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecretValue reports whether value, given to a field or constant with a
+// sensitive-looking name, looks like the secret itself rather than a
+// placeholder, the name of an environment variable or a header, or a
+// description
+func isSecretValue(value string, matcher *config.NameMatcher) bool {
+	return value != "" &&
+		!strings.ContainsAny(value, " \t\n") &&
+		!isPlaceholder(value) &&
+		!envVarName.MatchString(value) &&
+		!matcher.Match(value)
+}
+
+// isPlaceholder reports whether value stands for a secret rather than being
+// one: a placeholder matching placeholderValue, or a mask such as ****
+func isPlaceholder(value string) bool {
+	if placeholderValue.MatchString(value) {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(value)
+	return strings.Trim(value, string(first)) == ""
+}
+
+// tagPair is a key:"value" pair of a struct tag
+type tagPair struct {
+	key, value string
+}
+
+// structTagPairs returns the key:"value" pairs of a struct tag in order,
+// parsed like reflect.StructTag.Lookup. Parsing stops at the first
+// malformed pair.
+func structTagPairs(tag string) []tagPair {
+	var pairs []tagPair
+	for tag != "" {
+		i := 0
+		for i < len(tag) && tag[i] == ' ' {
+			i++
+		}
+		tag = tag[i:]
+		if tag == "" {
+			break
+		}
+
+		i = 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			break
+		}
+		key := tag[:i]
+		tag = tag[i+1:]
+
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			break
+		}
+		value, err := strconv.Unquote(tag[:i+1])
+		if err != nil {
+			break
+		}
+		tag = tag[i+1:]
+		pairs = append(pairs, tagPair{key: key, value: value})
+	}
+	return pairs
+}
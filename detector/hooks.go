@@ -0,0 +1,322 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// HookMatcher matches the registrations of logger hooks, such as
+// logrus.AddHook, and the external sinks a hook may forward log entries to,
+// such as the net/http client (LH0016). Calls are matched like custom
+// logging targets.
+type HookMatcher struct {
+	registrations *LogDetector
+	forwarders    *LogDetector
+}
+
+// NewHookMatcher creates a matcher for cfg's hooks, or returns nil when no
+// registration is configured
+func NewHookMatcher(pass *analysis.Pass, cfg *config.Config) *HookMatcher {
+	if cfg == nil || len(cfg.Hooks.Registrations) == 0 {
+		return nil
+	}
+	return &HookMatcher{
+		registrations: NewLogDetectorWithConfig(pass, &config.Config{Targets: cfg.Hooks.Registrations}),
+		forwarders:    NewLogDetectorWithConfig(pass, &config.Config{Targets: cfg.HookForwarders()}),
+	}
+}
+
+// hookFunc is a function receiving the entries of a registered hook: a
+// function literal, a function, or a method of the hook's type
+type hookFunc struct {
+	name   string // e.g. "SlackHook.Fire", or "func literal"
+	params []types.Object
+	body   *ast.BlockStmt
+	info   *types.Info // Type information of the package declaring the function
+}
+
+// HookFindings returns the findings (LH0016) for the logger hooks registered
+// in the package whose implementation forwards the entries it receives, or
+// values derived from them, to an external sink. The finding is reported at
+// the registration, once per hook function, naming the forwarding call.
+// Hooks declared in another package are only checked in whole-program mode,
+// where their bodies are known.
+func (c *DataFlowCollector) HookFindings() []Finding {
+	if c.hooks == nil {
+		return nil
+	}
+
+	var findings []Finding
+	for _, file := range c.pass.Files {
+		for _, decl := range file.Decls {
+			caller := ""
+			if fn, ok := decl.(*ast.FuncDecl); ok {
+				caller = funcName(c.pass.TypesInfo.Defs[fn.Name])
+			}
+			ast.Inspect(decl, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || c.hooks.registrations.CustomTarget(call, c.pass.TypesInfo) == "" {
+					return true
+				}
+				sink := SinkName(call, c.pass.TypesInfo)
+				for i, arg := range call.Args {
+					for _, hook := range c.hookFuncs(call, i, arg) {
+						forward := c.hooks.forwardingCall(hook)
+						if forward == nil {
+							continue
+						}
+						argFindings := []Finding{{
+							Pos:  arg.Pos(),
+							End:  arg.End(),
+							Expr: types.ExprString(arg),
+							Message: fmt.Sprintf("hook '%s' forwards log entries to %s, sending any sensitive data logged out of the process",
+								hook.name, ShortFuncName(SinkName(forward, hook.info))),
+							RuleID:   RuleIDHookForwarding,
+							Severity: SeverityWarning,
+						}}
+						annotateSink(argFindings, call, sink, caller, i+1)
+						findings = append(findings, argFindings...)
+					}
+				}
+				return true
+			})
+		}
+	}
+	return findings
+}
+
+// hookFuncs returns the functions receiving the entries of arg, argument i
+// of the registration call: arg itself when it is a function literal or a
+// function, or else the methods of arg's type implementing the interface the
+// registration accepts, such as logrus.Hook's Levels and Fire. Functions
+// whose body is unknown are left out.
+func (c *DataFlowCollector) hookFuncs(call *ast.CallExpr, i int, arg ast.Expr) []hookFunc {
+	info := c.pass.TypesInfo
+	switch arg := ast.Unparen(arg).(type) {
+	case *ast.FuncLit:
+		return []hookFunc{{name: "func literal", params: hookParams(arg.Type, info), body: arg.Body, info: info}}
+	case *ast.Ident, *ast.SelectorExpr:
+		if fn, ok := resolveCallee(arg, info).(*types.Func); ok {
+			if hook, ok := c.hookMethod(fn); ok {
+				return []hookFunc{hook}
+			}
+			return nil
+		}
+	}
+
+	sig, ok := info.TypeOf(call.Fun).(*types.Signature)
+	if !ok {
+		return nil
+	}
+	param := paramType(sig, i)
+	iface, ok := param.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	var hooks []hookFunc
+	for m := range iface.Methods() {
+		obj, _, _ := types.LookupFieldOrMethod(info.TypeOf(arg), true, m.Pkg(), m.Name())
+		if fn, ok := obj.(*types.Func); ok {
+			if hook, ok := c.hookMethod(fn); ok {
+				hooks = append(hooks, hook)
+			}
+		}
+	}
+	return hooks
+}
+
+// hookMethod returns the hook function for fn, or false when its body is
+// not in the analyzed code
+func (c *DataFlowCollector) hookMethod(fn *types.Func) (hookFunc, bool) {
+	fn = fn.Origin()
+	name := fn.Name()
+	if recv := fn.Signature().Recv(); recv != nil {
+		recvType := recv.Type()
+		if ptr, ok := recvType.(*types.Pointer); ok {
+			recvType = ptr.Elem()
+		}
+		name = types.TypeString(recvType, func(*types.Package) string { return "" }) + "." + name
+	}
+
+	if c.world != nil {
+		decl, pkg := c.world.funcDefs[fn], c.world.PackageOf(fn)
+		if decl == nil || decl.Body == nil || pkg == nil {
+			return hookFunc{}, false
+		}
+		return hookFunc{name: name, params: hookParams(decl.Type, pkg.TypesInfo), body: decl.Body, info: pkg.TypesInfo}, true
+	}
+	for _, file := range c.pass.Files {
+		for _, decl := range file.Decls {
+			if decl, ok := decl.(*ast.FuncDecl); ok && decl.Body != nil && c.pass.TypesInfo.Defs[decl.Name] == fn {
+				return hookFunc{name: name, params: hookParams(decl.Type, c.pass.TypesInfo), body: decl.Body, info: c.pass.TypesInfo}, true
+			}
+		}
+	}
+	return hookFunc{}, false
+}
+
+// forwardingCall returns the first call in the body of hook passing one of
+// its parameters, the entry, or a value derived from it to an external
+// sink, or nil. Values are derived through assignments, range loops and
+// calls writing into a variable, as in json.NewEncoder(&buf).Encode(entry)
+// or buf.WriteString(entry.Message).
+func (m *HookMatcher) forwardingCall(hook hookFunc) *ast.CallExpr {
+	if len(hook.params) == 0 {
+		return nil
+	}
+	tainted := make(map[types.Object]bool, len(hook.params))
+	for _, p := range hook.params {
+		tainted[p] = true
+	}
+	for changed := true; changed; {
+		changed = false
+		taint := func(expr ast.Expr) {
+			if obj := rootObject(expr, hook.info); obj != nil && !tainted[obj] {
+				tainted[obj] = true
+				changed = true
+			}
+		}
+		// taintWritten taints the variables call writes into: its receiver,
+		// or the variables passed by address to the call returning it, and
+		// the variables it is passed by address
+		var taintWritten func(call *ast.CallExpr)
+		taintWritten = func(call *ast.CallExpr) {
+			if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok {
+				if _, isMethod := hook.info.Selections[sel]; isMethod {
+					if inner, ok := ast.Unparen(sel.X).(*ast.CallExpr); ok {
+						taintWritten(inner) // json.NewEncoder(&buf).Encode(entry)
+					} else {
+						taint(sel.X) // buf.WriteString(entry.Message)
+					}
+				}
+			}
+			for _, arg := range call.Args {
+				if addr, ok := ast.Unparen(arg).(*ast.UnaryExpr); ok && addr.Op == token.AND {
+					taint(addr.X) // json.Unmarshal(data, &out)
+				}
+			}
+		}
+		ast.Inspect(hook.body, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if mentionsAny(n.Rhs, tainted, hook.info) {
+					for _, lhs := range n.Lhs {
+						taint(lhs)
+					}
+				}
+			case *ast.ValueSpec:
+				if mentionsAny(n.Values, tainted, hook.info) {
+					for _, name := range n.Names {
+						taint(name)
+					}
+				}
+			case *ast.RangeStmt:
+				if mentions(n.X, tainted, hook.info) {
+					if n.Key != nil {
+						taint(n.Key)
+					}
+					if n.Value != nil {
+						taint(n.Value)
+					}
+				}
+			case *ast.CallExpr:
+				if mentionsAny(n.Args, tainted, hook.info) {
+					taintWritten(n)
+				}
+			}
+			return true
+		})
+	}
+
+	var forward *ast.CallExpr
+	ast.Inspect(hook.body, func(n ast.Node) bool {
+		if forward != nil {
+			return false
+		}
+		call, ok := n.(*ast.CallExpr)
+		if ok && m.forwarders.CustomTarget(call, hook.info) != "" && mentionsAny(call.Args, tainted, hook.info) {
+			forward = call
+		}
+		return true
+	})
+	return forward
+}
+
+// mentionsAny reports whether any of exprs mentions a tainted variable
+func mentionsAny(exprs []ast.Expr, tainted map[types.Object]bool, info *types.Info) bool {
+	for _, expr := range exprs {
+		if mentions(expr, tainted, info) {
+			return true
+		}
+	}
+	return false
+}
+
+// mentions reports whether expr refers to a tainted variable
+func mentions(expr ast.Expr, tainted map[types.Object]bool, info *types.Info) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && tainted[info.Uses[ident]] {
+			found = true
+		}
+		return !found
+	})
+	return found
+}
+
+// rootObject returns the variable expr is or selects from, as buf for
+// buf.data[0], or nil
+func rootObject(expr ast.Expr, info *types.Info) types.Object {
+	for {
+		switch e := ast.Unparen(expr).(type) {
+		case *ast.Ident:
+			if obj := info.Defs[e]; obj != nil {
+				return obj
+			}
+			if obj, ok := info.Uses[e].(*types.Var); ok {
+				return obj
+			}
+			return nil
+		case *ast.SelectorExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.StarExpr:
+			expr = e.X
+		default:
+			return nil
+		}
+	}
+}
+
+// hookParams returns the objects of the named parameters of fn, the entries
+// a hook function receives
+func hookParams(fn *ast.FuncType, info *types.Info) []types.Object {
+	var params []types.Object
+	for _, field := range fn.Params.List {
+		for _, name := range field.Names {
+			if obj := info.Defs[name]; obj != nil {
+				params = append(params, obj)
+			}
+		}
+	}
+	return params
+}
+
+// paramType returns the type of parameter i of sig, the element type for
+// the arguments of a variadic parameter
+func paramType(sig *types.Signature, i int) types.Type {
+	params := sig.Params()
+	if sig.Variadic() && i >= params.Len()-1 {
+		return params.At(params.Len() - 1).Type().(*types.Slice).Elem()
+	}
+	if i >= params.Len() {
+		return types.Typ[types.Invalid]
+	}
+	return params.At(i).Type()
+}
@@ -0,0 +1,85 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// KeySinkMatcher matches calls to the key sinks of a configuration:
+// functions whose arguments become cache keys or metric names (LH0008).
+// Calls are matched like custom logging targets.
+type KeySinkMatcher struct {
+	targets *LogDetector
+	sinks   []config.KeySinkConfig
+}
+
+// NewKeySinkMatcher creates a matcher for cfg's key sinks, or returns nil
+// when none are configured
+func NewKeySinkMatcher(pass *analysis.Pass, cfg *config.Config) *KeySinkMatcher {
+	if cfg == nil || len(cfg.KeySinks) == 0 {
+		return nil
+	}
+	targets := &config.Config{}
+	for _, sink := range cfg.KeySinks {
+		targets.Targets = append(targets.Targets, sink.TargetConfig)
+	}
+	return &KeySinkMatcher{
+		targets: NewLogDetectorWithConfig(pass, targets),
+		sinks:   cfg.KeySinks,
+	}
+}
+
+// KeyArgs returns the indexes of the key arguments of call, or nil if call
+// is not a key sink
+func (m *KeySinkMatcher) KeyArgs(call *ast.CallExpr, info *types.Info) []int {
+	if m == nil {
+		return nil
+	}
+	entry := m.targets.CustomTarget(call, info)
+	if entry == "" {
+		return nil
+	}
+	for _, sink := range m.sinks {
+		for _, e := range TargetEntries(&config.Config{Targets: []config.TargetConfig{sink.TargetConfig}}) {
+			if e != entry {
+				continue
+			}
+			var args []int
+			for i := range call.Args {
+				if sink.IsKeyArg(i) {
+					args = append(args, i)
+				}
+			}
+			return args
+		}
+	}
+	return nil
+}
+
+// keySinkCall is a key sink call collected during traversal
+type keySinkCall struct {
+	call    *ast.CallExpr
+	caller  types.Object // Enclosing function
+	keyArgs []int
+}
+
+// asKeyFindings turns the findings for a key argument into LH0008 findings,
+// or LH0009 findings for personal data
+func asKeyFindings(findings []Finding) {
+	for i := range findings {
+		findings[i].Fixes = nil // Wrapping the value would change the key
+
+		if findings[i].PII {
+			findings[i].RuleID = RuleIDPersonalData
+			findings[i].Severity = SeverityWarning
+			findings[i].Message = fmt.Sprintf("personal data %q is used in a cache key or metric name", findings[i].Field)
+			continue
+		}
+		findings[i].RuleID = RuleIDSensitiveKey
+		findings[i].Message = fmt.Sprintf("sensitive field %q is used in a cache key or metric name", findings[i].Field)
+	}
+}
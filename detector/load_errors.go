@@ -0,0 +1,50 @@
+package detector
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ErrPackageLoad is matched, with errors.Is, by PackageLoadErrors: the
+// analyzed code does not load, e.g. a pattern matches no package or a package
+// does not parse, which is fixed in the code or its build rather than in
+// leakhound
+var ErrPackageLoad = errors.New("package load failed")
+
+// PackageLoadError reports that a package, or the whole set of packages,
+// failed to load
+type PackageLoadError struct {
+	Package string // Import path of the package, or "" if loading failed as a whole
+	Err     error  // e.g. a packages.Error, with its position
+}
+
+func (e *PackageLoadError) Error() string {
+	if e.Package == "" {
+		return fmt.Sprintf("failed to load packages: %v", e.Err)
+	}
+	return fmt.Sprintf("package %s: %v", e.Package, e.Err)
+}
+
+func (e *PackageLoadError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrPackageLoad
+func (e *PackageLoadError) Is(target error) bool {
+	return target == ErrPackageLoad
+}
+
+// PackageLoadErrors returns the errors of pkgs, as returned by packages.Load,
+// one per package error. Analysis can go on with the packages that loaded,
+// as the driver does.
+func PackageLoadErrors(pkgs []*packages.Package) []*PackageLoadError {
+	var errs []*PackageLoadError
+	for _, pkg := range pkgs {
+		for _, err := range pkg.Errors {
+			errs = append(errs, &PackageLoadError{Package: pkg.PkgPath, Err: err})
+		}
+	}
+	return errs
+}
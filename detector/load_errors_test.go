@@ -0,0 +1,37 @@
+package detector
+
+import (
+	"errors"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestPackageLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	parseErr := packages.Error{Pos: "/src/app/main.go:3:1", Msg: "expected declaration, found '}'", Kind: packages.ParseError}
+	pkgs := []*packages.Package{
+		{PkgPath: "example.com/app", Errors: []packages.Error{parseErr}},
+		{PkgPath: "example.com/app/ok"},
+	}
+
+	errs := PackageLoadErrors(pkgs)
+	if len(errs) != 1 {
+		t.Fatalf("len(PackageLoadErrors()) = %d, want 1", len(errs))
+	}
+	err := error(errs[0])
+	if want := "package example.com/app: /src/app/main.go:3:1: expected declaration, found '}'"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err, want)
+	}
+	if !errors.Is(err, ErrPackageLoad) {
+		t.Errorf("errors.Is(%v, ErrPackageLoad) = false, want true", err)
+	}
+	if errors.Is(err, ErrAnalysisPanic) {
+		t.Errorf("errors.Is(%v, ErrAnalysisPanic) = true, want false", err)
+	}
+	var pkgErr packages.Error
+	if !errors.As(err, &pkgErr) || pkgErr.Kind != packages.ParseError {
+		t.Errorf("errors.As(%v, packages.Error) = %+v, want the parse error", err, pkgErr)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"go/ast"
 	"go/types"
 	"slices"
+	"strings"
 
 	"github.com/nilpoona/leakhound/config"
 	"golang.org/x/tools/go/analysis"
@@ -13,21 +14,27 @@ import (
 type LogDetector struct {
 	pass   *analysis.Pass
 	config *config.Config
+
+	// sinkValues maps variables, parameters and struct fields that hold a
+	// sink function value to that function (see RecordSinkValues)
+	sinkValues map[*types.Var]*types.Func
 }
 
 // NewLogDetector creates a new LogDetector
 func NewLogDetector(pass *analysis.Pass) *LogDetector {
 	return &LogDetector{
-		pass:   pass,
-		config: nil,
+		pass:       pass,
+		config:     nil,
+		sinkValues: make(map[*types.Var]*types.Func),
 	}
 }
 
 // NewLogDetectorWithConfig creates a new LogDetector with custom configuration
 func NewLogDetectorWithConfig(pass *analysis.Pass, cfg *config.Config) *LogDetector {
 	return &LogDetector{
-		pass:   pass,
-		config: cfg,
+		pass:       pass,
+		config:     cfg,
+		sinkValues: make(map[*types.Var]*types.Func),
 	}
 }
 
@@ -44,22 +51,70 @@ func (ld *LogDetector) IsLogCallWithInfo(call *ast.CallExpr, info *types.Info) b
 	if info == nil {
 		return false
 	}
+
+	// Function values holding a sink: logFn := slog.Info; logFn(...)
+	if ld.heldSink(call.Fun, info) != nil {
+		return true
+	}
+
 	sel, ok := call.Fun.(*ast.SelectorExpr)
 	if !ok {
 		return false
 	}
 
 	// Use type information to accurately verify the call
-	obj := info.Uses[sel.Sel]
-	if obj == nil {
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok {
 		return false
 	}
+	if ld.isSinkFunc(fn) {
+		return true
+	}
 
-	fn, ok := obj.(*types.Func)
-	if !ok {
-		return false
+	// Template execution into a log or response writer:
+	// tmpl.Execute(os.Stdout, data)
+	if ld.templateDataArg(call, info) >= 0 {
+		return true
+	}
+
+	// Flag definitions and writes to a flag set's output print the help
+	// text: flag.StringVar(&p, "password", cfg.Password, "")
+	if flagHelpArgs(call, info) != nil {
+		return true
+	}
+
+	// Check the static type of the receiver expression, so a configured
+	// wrapper type matches even when the method is promoted from an
+	// embedded logger, and fluent chains such as
+	// logger.WithField("k", v).Info(msg) rooted at a configured receiver
+	if ld.config != nil {
+		if ld.matchStaticReceiver(sel, info) != "" {
+			return true
+		}
+		entry, _ := ld.matchChainedTarget(sel, info)
+		return entry != ""
+	}
+
+	return false
+}
+
+// matchStaticReceiver returns the target entry matching a method call by
+// the static type of its receiver expression, which may be any expression
+// (s.log, loggers[i], newLogger()), or "". This differs from the declared
+// receiver of the method when the method is promoted from an embedded field.
+func (ld *LogDetector) matchStaticReceiver(sel *ast.SelectorExpr, info *types.Info) string {
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return ""
 	}
+	return ld.matchReceiverType(selection.Recv(), sel.Sel.Name)
+}
 
+// isSinkFunc reports whether calling fn writes its arguments to a log: a
+// print function of slog, log or fmt, a logging method of *slog.Logger,
+// *log.Logger, *zap.Logger or *zap.SugaredLogger, a method of
+// *zerolog.Event sending the event, or a configured target.
+func (ld *LogDetector) isSinkFunc(fn *types.Func) bool {
 	pkg := fn.Pkg()
 	// Add nil check for package to handle build constraint issues
 	if pkg == nil {
@@ -67,7 +122,7 @@ func (ld *LogDetector) IsLogCallWithInfo(call *ast.CallExpr, info *types.Info) b
 	}
 
 	pkgPath := pkg.Path()
-	funcName := sel.Sel.Name
+	funcName := fn.Name()
 
 	// Check for slog package calls
 	if pkgPath == "log/slog" {
@@ -97,6 +152,16 @@ func (ld *LogDetector) IsLogCallWithInfo(call *ast.CallExpr, info *types.Info) b
 		}
 	}
 
+	// Check for methods of *zap.Logger and *zap.SugaredLogger
+	if isZapLoggerFunc(fn, "Logger") || isZapLoggerFunc(fn, "SugaredLogger") {
+		return true
+	}
+
+	// Check for the methods sending a *zerolog.Event: log.Info().Msg(msg)
+	if isZerologSendFunc(fn) {
+		return true
+	}
+
 	// Check custom targets from configuration
 	if ld.config != nil {
 		return ld.isCustomLogCall(pkgPath, funcName, fn)
@@ -105,6 +170,126 @@ func (ld *LogDetector) IsLogCallWithInfo(call *ast.CallExpr, info *types.Info) b
 	return false
 }
 
+// LoggedCalls returns call followed by the calls whose arguments it also
+// writes to the log: the With calls of a slog chain,
+// slog.Default().With("k", v).Info(msg), the field methods of a zerolog
+// event, log.Info().Str("k", v).Msg(msg), and for a fluent chain matched
+// through a configured receiver, logger.WithField("k", v).Info(msg), every
+// call in the chain after the receiver. For any other call only call itself
+// is returned.
+func (ld *LogDetector) LoggedCalls(call *ast.CallExpr, info *types.Info) []*ast.CallExpr {
+	calls := []*ast.CallExpr{call}
+	if info == nil {
+		return calls
+	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return calls
+	}
+	if hops := slogWithHops(sel, info); len(hops) > 0 {
+		return append(calls, hops...)
+	}
+	if hops := zerologEventHops(sel, info); len(hops) > 0 {
+		return append(calls, hops...)
+	}
+	if ld.config == nil {
+		return calls
+	}
+	_, hops := ld.matchChainedTarget(sel, info)
+	return append(calls, hops...)
+}
+
+// slogWithHops returns the With calls, in source order, in the receiver
+// chain of a *slog.Logger method call: the attributes they add are written
+// by every record the resulting logger emits. WithGroup calls are walked
+// through but not returned, since their only argument is a group name.
+func slogWithHops(sel *ast.SelectorExpr, info *types.Info) []*ast.CallExpr {
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok {
+		return nil
+	}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() == nil || !isSlogLoggerType(sig.Recv().Type()) {
+		return nil
+	}
+
+	var hops []*ast.CallExpr
+	x := sel.X
+	for {
+		call, ok := ast.Unparen(x).(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		inner, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		fn, ok := info.Uses[inner.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "log/slog" {
+			break
+		}
+		if fn.Name() == "With" {
+			hops = append(hops, call)
+		} else if fn.Name() != "WithGroup" {
+			break
+		}
+		x = inner.X
+	}
+	slices.Reverse(hops)
+	return hops
+}
+
+// zerologEventHops returns the *zerolog.Event method calls, in source order,
+// in the receiver chain of a method call sending the event: every field they
+// add, log.Info().Str("k", v).Int("n", n).Msg(msg), is written with the
+// message. The walk stops at the call creating the event, such as log.Info()
+// or logger.WithLevel(level), whose arguments are not logged.
+func zerologEventHops(sel *ast.SelectorExpr, info *types.Info) []*ast.CallExpr {
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || !isZerologSendFunc(fn) {
+		return nil
+	}
+
+	var hops []*ast.CallExpr
+	x := sel.X
+	for {
+		call, ok := ast.Unparen(x).(*ast.CallExpr)
+		if !ok {
+			break
+		}
+		inner, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			break
+		}
+		fn, ok := info.Uses[inner.Sel].(*types.Func)
+		if !ok || !isZerologEventMethod(fn) {
+			break
+		}
+		hops = append(hops, call)
+		x = inner.X
+	}
+	slices.Reverse(hops)
+	return hops
+}
+
+// SinkName returns the fully qualified name of the function invoked by call,
+// e.g. "log/slog.Info" or "(*log/slog.Logger).Info". Returns "" when the
+// callee cannot be resolved through info.
+func SinkName(call *ast.CallExpr, info *types.Info) string {
+	if fn, ok := resolveCallee(call.Fun, info).(*types.Func); ok {
+		return fn.FullName()
+	}
+	return ""
+}
+
+// funcName returns the qualified name of a function object for use in
+// finding metadata, or "" for nil (package-level expressions).
+func funcName(obj types.Object) string {
+	if fn, ok := obj.(*types.Func); ok {
+		return fn.FullName()
+	}
+	return ""
+}
+
 // Helper functions for method name checking
 
 func isSlogStyleMethod(name string) bool {
@@ -128,6 +313,27 @@ func isFmtStyleMethod(name string) bool {
 		name == "Printf" || name == "Println"
 }
 
+// isZapStyleMethod reports whether name is a logging method of *zap.Logger:
+// a level (Debug, Info, ..., Fatal) or Log, which takes the level first
+func isZapStyleMethod(name string) bool {
+	return name == "Debug" || name == "Info" ||
+		name == "Warn" || name == "Error" ||
+		name == "DPanic" || name == "Panic" ||
+		name == "Fatal" || name == "Log"
+}
+
+// isZapSugarStyleMethod reports whether name is a logging method of
+// *zap.SugaredLogger: a *zap.Logger method name, alone or with the f
+// (printf-style), w (key-value pairs) or ln suffix, e.g. Infow
+func isZapSugarStyleMethod(name string) bool {
+	for _, suffix := range []string{"f", "w", "ln"} {
+		if base, ok := strings.CutSuffix(name, suffix); ok && isZapStyleMethod(base) {
+			return true
+		}
+	}
+	return isZapStyleMethod(name)
+}
+
 func isSlogLoggerType(t types.Type) bool {
 	// Handle pointer type
 	ptr, ok := t.(*types.Pointer)
@@ -182,16 +388,186 @@ func isLogLoggerType(t types.Type) bool {
 	return pkg.Path() == "log"
 }
 
+// isZapLoggerType reports whether t is a pointer to the named type of
+// go.uber.org/zap, "Logger" or "SugaredLogger"
+func isZapLoggerType(t types.Type, name string) bool {
+	ptr, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj != nil && obj.Name() == name && obj.Pkg() != nil && obj.Pkg().Path() == "go.uber.org/zap"
+}
+
+// isZapLoggerFunc reports whether fn is a logging method of the go.uber.org/zap
+// type name, "Logger" or "SugaredLogger"
+func isZapLoggerFunc(fn *types.Func, name string) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil || !isZapLoggerType(sig.Recv().Type(), name) {
+		return false
+	}
+	if name == "SugaredLogger" {
+		return isZapSugarStyleMethod(fn.Name())
+	}
+	return isZapStyleMethod(fn.Name())
+}
+
+// isZerologEventMethod reports whether fn is a method of *zerolog.Event
+func isZerologEventMethod(fn *types.Func) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return false
+	}
+	ptr, ok := sig.Recv().Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	named, ok := ptr.Elem().(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj != nil && obj.Name() == "Event" && obj.Pkg() != nil && obj.Pkg().Path() == "github.com/rs/zerolog"
+}
+
+// isZerologSendFunc reports whether fn is a method of *zerolog.Event writing
+// the event: Msg, Msgf or Send
+func isZerologSendFunc(fn *types.Func) bool {
+	switch fn.Name() {
+	case "Msg", "Msgf", "Send":
+		return isZerologEventMethod(fn)
+	}
+	return false
+}
+
 // isCustomLogCall checks if the call matches any custom target configuration
 func (ld *LogDetector) isCustomLogCall(pkgPath, funcName string, fn *types.Func) bool {
+	return ld.matchCustomTarget(pkgPath, funcName, fn) != ""
+}
+
+// CustomTarget returns the configured target entry (see TargetEntries) that
+// makes call a sink, or "" if call is not a custom logging call. Built-in
+// sinks such as slog and fmt are not reported.
+func (ld *LogDetector) CustomTarget(call *ast.CallExpr, info *types.Info) string {
+	if ld.config == nil || info == nil {
+		return ""
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return ""
+	}
+	if entry := ld.matchCustomTarget(fn.Pkg().Path(), sel.Sel.Name, fn); entry != "" {
+		return entry
+	}
+	if entry := ld.matchStaticReceiver(sel, info); entry != "" {
+		return entry
+	}
+	entry, _ := ld.matchChainedTarget(sel, info)
+	return entry
+}
+
+// matchChainedTarget matches the final method of a fluent chain, sel.Sel in
+// logger.WithField("k", v).Info(msg), against the targets by walking the
+// chain towards its root. At each hop the static type of the receiver is
+// checked against the configured receivers; a chain rooted at a
+// package-level function, logrus.WithField("k", v).Info(msg), is checked
+// against the functions configured for that package. It returns the matched
+// target entry and the chained calls between the matched receiver and sel,
+// in source order.
+func (ld *LogDetector) matchChainedTarget(sel *ast.SelectorExpr, info *types.Info) (string, []*ast.CallExpr) {
+	name := sel.Sel.Name
+	var hops []*ast.CallExpr
+	x := sel.X
+	for {
+		call, ok := ast.Unparen(x).(*ast.CallExpr)
+		if !ok {
+			return "", nil
+		}
+		hops = append(hops, call)
+
+		var entry string
+		switch fun := ast.Unparen(call.Fun).(type) {
+		case *ast.SelectorExpr:
+			if fn, ok := info.Uses[fun.Sel].(*types.Func); ok && isPackageFunc(fn) {
+				entry = ld.matchChainFunc(fn, name)
+				if entry == "" {
+					return "", nil
+				}
+				break
+			}
+			x = fun.X
+			entry = ld.matchReceiverType(info.TypeOf(x), name)
+		case *ast.Ident:
+			fn, ok := info.Uses[fun].(*types.Func)
+			if !ok || !isPackageFunc(fn) {
+				return "", nil
+			}
+			if entry = ld.matchChainFunc(fn, name); entry == "" {
+				return "", nil
+			}
+		default:
+			return "", nil
+		}
+
+		if entry != "" {
+			slices.Reverse(hops)
+			return entry, hops
+		}
+	}
+}
+
+// isPackageFunc reports whether fn is a package-level function
+func isPackageFunc(fn *types.Func) bool {
+	sig, ok := fn.Type().(*types.Signature)
+	return ok && sig.Recv() == nil && fn.Pkg() != nil
+}
+
+// matchChainFunc returns the target entry for method name called on the
+// result of the package-level function fn, or ""
+func (ld *LogDetector) matchChainFunc(fn *types.Func, name string) string {
+	pkgPath := fn.Pkg().Path()
+	for _, target := range ld.config.Targets {
+		if target.MatchesPackage(pkgPath) && slices.Contains(target.Functions, name) {
+			return targetEntry(target.Package, "", name)
+		}
+	}
+	return ""
+}
+
+// matchReceiverType returns the target entry for method name called on a
+// receiver of static type t, or ""
+func (ld *LogDetector) matchReceiverType(t types.Type, name string) string {
+	if t == nil {
+		return ""
+	}
 	for _, target := range ld.config.Targets {
-		if target.Package != pkgPath {
+		for _, method := range target.Methods {
+			if slices.Contains(method.Names, name) && ld.isMatchingReceiverType(t, target.Package, method.Receiver) {
+				return targetEntry(target.Package, method.Receiver, name)
+			}
+		}
+	}
+	return ""
+}
+
+// matchCustomTarget returns the target entry matching the function, or ""
+func (ld *LogDetector) matchCustomTarget(pkgPath, funcName string, fn *types.Func) string {
+	for _, target := range ld.config.Targets {
+		if !target.MatchesPackage(pkgPath) {
 			continue
 		}
 
 		// Check if it's a package-level function
 		if slices.Contains(target.Functions, funcName) {
-			return true
+			return targetEntry(target.Package, "", funcName)
 		}
 
 		// Check if it's a method on a configured receiver type
@@ -206,29 +582,59 @@ func (ld *LogDetector) isCustomLogCall(pkgPath, funcName string, fn *types.Func)
 		}
 
 		for _, method := range target.Methods {
-			if ld.isMatchingReceiverType(recv.Type(), pkgPath, method.Receiver) {
+			if ld.isMatchingReceiverType(recv.Type(), target.Package, method.Receiver) {
 				if slices.Contains(method.Names, funcName) {
-					return true
+					return targetEntry(target.Package, method.Receiver, funcName)
 				}
 			}
 		}
 	}
 
-	return false
+	return ""
 }
 
-// isMatchingReceiverType checks if the receiver type matches the configured receiver
-func (ld *LogDetector) isMatchingReceiverType(t types.Type, pkgPath, configReceiver string) bool {
-	// configReceiver can be "*Logger" or "Logger"
-	isPointer := false
-	typeName := configReceiver
-	if len(configReceiver) > 0 && configReceiver[0] == '*' {
-		isPointer = true
-		typeName = configReceiver[1:]
+// TargetEntries lists every function and method configured in cfg's targets
+// section, formatted like "go.uber.org/zap.Info" or
+// "go.uber.org/zap.(*Logger).Info". Targets with a package glob keep the
+// glob, e.g. "github.com/org/zap*.(*Logger).Info", and so do the entries
+// matched for their calls.
+func TargetEntries(cfg *config.Config) []string {
+	if cfg == nil {
+		return nil
+	}
+	var entries []string
+	for _, target := range cfg.Targets {
+		for _, fn := range target.Functions {
+			entries = append(entries, targetEntry(target.Package, "", fn))
+		}
+		for _, method := range target.Methods {
+			for _, name := range method.Names {
+				entries = append(entries, targetEntry(target.Package, method.Receiver, name))
+			}
+		}
+	}
+	return entries
+}
+
+// targetEntry formats a configured target function or method
+func targetEntry(pkgPath, receiver, name string) string {
+	if receiver == "" {
+		return pkgPath + "." + name
+	}
+	return pkgPath + ".(" + receiver + ")." + name
+}
+
+// isMatchingReceiverType checks if the receiver type matches the configured
+// receiver, declared in a package matching pattern
+func (ld *LogDetector) isMatchingReceiverType(t types.Type, pattern, configReceiver string) bool {
+	// configReceiver can be "*Logger", "Logger" or generic, "*Logger[T]"
+	receiver, err := config.ParseReceiver(configReceiver)
+	if err != nil {
+		return false
 	}
 
 	// Check pointer type
-	if isPointer {
+	if receiver.Pointer {
 		ptr, ok := t.(*types.Pointer)
 		if !ok {
 			return false
@@ -242,9 +648,16 @@ func (ld *LogDetector) isMatchingReceiverType(t types.Type, pkgPath, configRecei
 		return false
 	}
 
-	// Check if the type name matches
+	// Check if the type name matches; instantiations of a generic type share
+	// the origin's name
 	obj := named.Obj()
-	if obj == nil || obj.Name() != typeName {
+	if obj == nil || obj.Name() != receiver.Name {
+		return false
+	}
+
+	// A generic receiver matches every instantiation of a type with as many
+	// type parameters
+	if receiver.Generic() && named.Origin().TypeParams().Len() != len(receiver.TypeParams) {
 		return false
 	}
 
@@ -254,5 +667,5 @@ func (ld *LogDetector) isMatchingReceiverType(t types.Type, pkgPath, configRecei
 		return false
 	}
 
-	return pkg.Path() == pkgPath
+	return config.MatchPackage(pattern, pkg.Path())
 }
@@ -0,0 +1,171 @@
+package detector
+
+import (
+	"fmt"
+	"go/types"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// Estimated bytes held by each tracked fact on 64-bit platforms, including
+// map overhead. The estimates only need to be proportionate: the budget is a
+// guard against packages whose data flow facts run away, not an exact
+// accounting.
+const (
+	sensitiveVarBytes = 160 // SensitiveSource with a short flow path
+	funcDefBytes      = 32  // The declaration itself belongs to the package syntax
+	logCallBytes      = 32  // The call and its enclosing function
+)
+
+// trackedFacts counts the data flow facts tracked for a package
+type trackedFacts struct {
+	vars     int // sensitiveVars and sensitiveParams entries
+	funcDefs int
+	logCalls int
+}
+
+// bytes estimates the memory held by the facts
+func (t trackedFacts) bytes() int64 {
+	return int64(t.vars)*sensitiveVarBytes + int64(t.funcDefs)*funcDefBytes + int64(t.logCalls)*logCallBytes
+}
+
+// budgetNotification reports that the facts of package pkg, an estimated used
+// bytes, went over budget
+func budgetNotification(pkg string, used, budget int64) Notification {
+	return Notification{
+		Level:   SeverityWarning,
+		Package: pkg,
+		Message: fmt.Sprintf(
+			"package %s tracks about %s of data flow facts, over the max-memory budget of %s; only direct accesses to sensitive fields are checked in it",
+			pkg, config.FormatMemorySize(used), config.FormatMemorySize(budget)),
+	}
+}
+
+// enforceBudget degrades the collector to direct-access-only detection when
+// facts exceed its memory budget, and reports whether it is degraded
+func (c *DataFlowCollector) enforceBudget(facts trackedFacts) bool {
+	if c.notification != nil {
+		return true
+	}
+	if c.budget <= 0 || facts.bytes() <= c.budget {
+		return false
+	}
+	pkgPath := ""
+	if c.pass.Pkg != nil {
+		pkgPath = c.pass.Pkg.Path()
+	}
+	note := budgetNotification(pkgPath, facts.bytes(), c.budget)
+	c.notification = &note
+
+	// A fresh tracker knows no variables or functions, so only field
+	// accesses, whole structs and builtin sources are reported, and the
+	// dropped facts can be freed
+	builders, commandLine := c.varTracker.checker.builders, c.varTracker.checker.commandLine
+	c.varTracker = NewVarTracker(c.pass, c.fieldCollector.GetSensitiveFields())
+	c.varTracker.SetEventBuilders(builders)
+	c.varTracker.SetCommandLineSources(commandLine)
+	c.detector.varTracker = c.varTracker
+	c.detector.SetSanitizers(c.detector.sanitizers)
+	if c.world != nil {
+		c.world.forgetPackage(c.pass.Pkg)
+	}
+	return true
+}
+
+// trackedFacts counts the facts tracked by a single-package collector, whose
+// tracking maps are private
+func (c *DataFlowCollector) trackedFacts() trackedFacts {
+	return trackedFacts{
+		vars:     len(c.varTracker.sensitiveVars) + len(c.varTracker.facts.sensitiveParams),
+		funcDefs: len(c.varTracker.facts.funcDefs),
+		logCalls: len(c.logCalls),
+	}
+}
+
+// trackedFacts counts the facts in the world's tracking maps. The log calls
+// are held by the package collectors and are not counted.
+func (w *WorldView) trackedFacts() trackedFacts {
+	return trackedFacts{
+		vars:     len(w.sensitiveVars) + len(w.sensitiveParams),
+		funcDefs: len(w.funcDefs),
+	}
+}
+
+// trackedFactsByPackage counts the facts in the world's tracking maps per
+// package
+func (w *WorldView) trackedFactsByPackage() map[*types.Package]trackedFacts {
+	counts := make(map[*types.Package]trackedFacts)
+	for v := range w.sensitiveVars {
+		t := counts[v.Pkg()]
+		t.vars++
+		counts[v.Pkg()] = t
+	}
+	for v := range w.sensitiveParams {
+		t := counts[v.Pkg()]
+		t.vars++
+		counts[v.Pkg()] = t
+	}
+	for obj := range w.funcDefs {
+		t := counts[obj.Pkg()]
+		t.funcDefs++
+		counts[obj.Pkg()] = t
+	}
+	return counts
+}
+
+// forgetPackage drops the facts of pkg from the tracking maps: its
+// variables and function declarations, so data no longer flows through the
+// package's functions, and the sensitive returns, yields and sink parameters
+// of its functions, so other packages are not checked against them
+func (w *WorldView) forgetPackage(pkg *types.Package) {
+	for v := range w.sensitiveVars {
+		if v.Pkg() == pkg {
+			delete(w.sensitiveVars, v)
+		}
+	}
+	for v := range w.sensitiveParams {
+		if v.Pkg() == pkg {
+			delete(w.sensitiveParams, v)
+		}
+	}
+	for obj := range w.funcDefs {
+		if obj.Pkg() == pkg {
+			delete(w.funcDefs, obj)
+		}
+	}
+	for obj := range w.sensitiveFuncs {
+		if obj.Pkg() == pkg {
+			delete(w.sensitiveFuncs, obj)
+		}
+	}
+	for key := range w.sensitiveFuncPos {
+		if key.funcObj.Pkg() == pkg {
+			delete(w.sensitiveFuncPos, key)
+		}
+	}
+	for key := range w.sensitiveYields {
+		if key.funcObj.Pkg() == pkg {
+			delete(w.sensitiveYields, key)
+		}
+	}
+	for v := range w.sinkParams {
+		if v.Pkg() == pkg {
+			delete(w.sinkParams, v)
+			delete(w.paramSinks, v)
+		}
+	}
+}
+
+// enforceBudgets checks every package collector against its memory budget
+// once cross-package propagation has settled the tracking maps
+func (wp *WholeProgramCollector) enforceBudgets() {
+	if wp.cfg.MemoryBudget() <= 0 {
+		return
+	}
+	counts := wp.world.trackedFactsByPackage()
+	for _, c := range wp.orderedCollectors() {
+		facts := counts[c.pass.Pkg]
+		facts.logCalls = len(c.logCalls)
+		c.enforceBudget(facts)
+	}
+}
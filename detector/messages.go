@@ -0,0 +1,72 @@
+package detector
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// ApplyMessages replaces each finding's message with the rules section's
+// message template for its rule, if any, executed with the finding's values
+// (see config.MessageTemplateFields), e.g.
+//
+//	{{.Message}} (runbook: https://wiki.example.com/leaks#{{.Rule}})
+//
+// Templates are validated by LoadConfig; a finding whose template fails to
+// execute keeps its message.
+func ApplyMessages(findings []Finding, cfg *config.Config) []Finding {
+	templates := make(map[string]*template.Template) // By SARIF rule ID, nil for none
+	for i := range findings {
+		ruleID := findings[i].SARIFRuleID()
+		tmpl, ok := templates[ruleID]
+		if !ok {
+			if text := cfg.RuleMessage(ruleID); text != "" {
+				tmpl, _ = config.ParseMessageTemplate(ruleID, text)
+			}
+			templates[ruleID] = tmpl
+		}
+		if tmpl == nil {
+			continue
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, messageData(findings[i])); err == nil {
+			findings[i].Message = b.String()
+		}
+	}
+	return findings
+}
+
+// messageData returns the values of config.MessageTemplateFields for f
+func messageData(f Finding) map[string]any {
+	steps := make([]string, 0, len(f.FlowPath))
+	for _, step := range f.FlowPath {
+		steps = append(steps, step.Label)
+	}
+	flow := steps
+	if f.Sink != "" {
+		flow = append(flow[:len(flow):len(flow)], ShortFuncName(f.Sink))
+	}
+	typeName := ""
+	if t, _, ok := strings.Cut(f.Field, "."); ok && !f.CommandLine {
+		typeName = t
+	}
+	name := ""
+	if doc, ok := LookupRuleDoc(f.SARIFRuleID()); ok {
+		name = doc.Name
+	}
+	return map[string]any{
+		"Message":  f.Message,
+		"Rule":     f.SARIFRuleID(),
+		"RuleName": name,
+		"Severity": string(f.Level()),
+		"Field":    f.Field,
+		"Type":     typeName,
+		"Sink":     ShortFuncName(f.Sink),
+		"Arg":      f.Arg,
+		"Expr":     f.Expr,
+		"Func":     f.Func,
+		"Flow":     strings.Join(flow, " → "),
+		"FlowPath": steps,
+	}
+}
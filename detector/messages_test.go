@@ -0,0 +1,44 @@
+package detector
+
+import (
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+func TestApplyMessages(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Rules: map[string]config.RuleConfig{
+		"LH0004": {Message: "{{.Message}} (runbook: https://wiki.example.com/leaks#{{.Rule}})"},
+		"LH0001": {Message: "{{.Type}}.{{.Field}} reaches {{.Sink}} argument {{.Arg}} via {{.Flow}} in {{.Func}} [{{.RuleName}}, {{.Severity}}]"},
+		"LH0003": {Message: "{{.Owner}}"}, // Fails to execute
+	}}
+
+	findings := ApplyMessages([]Finding{
+		{RuleID: RuleIDSensitiveField, Message: "sensitive field 'User.Password' should not be logged"},
+		{
+			RuleID:   RuleIDSensitiveVar,
+			Message:  "variable 'pw' contains sensitive data",
+			Field:    "User.Password",
+			Sink:     "log/slog.Info",
+			Arg:      2,
+			Func:     "example.com/app.login",
+			FlowPath: []FlowStep{{Label: "User.Password"}, {Label: "pw"}},
+		},
+		{RuleID: RuleIDSensitiveStruct, Message: "struct 'User' contains sensitive fields"},
+		{RuleID: RuleIDSensitiveCall, Message: "unchanged"},
+	}, cfg)
+
+	want := []string{
+		"sensitive field 'User.Password' should not be logged (runbook: https://wiki.example.com/leaks#LH0004)",
+		"User.User.Password reaches slog.Info argument 2 via User.Password → pw → slog.Info in example.com/app.login [SensitiveVariableLogged, error]",
+		"struct 'User' contains sensitive fields",
+		"unchanged",
+	}
+	for i, f := range findings {
+		if f.Message != want[i] {
+			t.Errorf("findings[%d] (%s) message = %q, want %q", i, f.SARIFRuleID(), f.Message, want[i])
+		}
+	}
+}
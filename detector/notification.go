@@ -0,0 +1,50 @@
+package detector
+
+import "fmt"
+
+// Notification is a message about the analysis itself rather than the
+// analyzed code, such as a package analyzed with reduced precision. Reporters
+// surface it apart from findings, e.g. as a SARIF tool execution notification.
+type Notification struct {
+	Level   Severity
+	Package string // Import path of the package concerned
+	Message string
+
+	// PanicType and Stack describe a panic recovered while analyzing the
+	// package: the Go type of the panic value, e.g. "runtime.boundsError",
+	// and the stack, innermost frame first. Both are empty otherwise.
+	PanicType string
+	Stack     []StackFrame
+}
+
+// String renders the notification for the command line, e.g.
+// "warning: package example.com/app tracks ..."
+func (n Notification) String() string {
+	return fmt.Sprintf("%s: %s", n.Level, n.Message)
+}
+
+// Notifications returns the notifications raised while collecting, such as
+// files skipped for type errors or the package going over its memory budget
+func (c *DataFlowCollector) Notifications() []Notification {
+	var notes []Notification
+	for _, note := range []*Notification{c.skipped, c.notification} {
+		if note != nil {
+			notes = append(notes, *note)
+		}
+	}
+	return notes
+}
+
+// Notifications returns the notifications raised by the package collectors,
+// in package order, followed by the panics recovered during the analysis
+// (see guard)
+func (wp *WholeProgramCollector) Notifications() []Notification {
+	var notes []Notification
+	for _, c := range wp.orderedCollectors() {
+		notes = append(notes, c.Notifications()...)
+	}
+	for _, p := range wp.panics {
+		notes = append(notes, p.Notification())
+	}
+	return notes
+}
@@ -0,0 +1,100 @@
+package detector
+
+import (
+	"go/token"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// Stage is a step of the post-processing of findings before they are
+// reported. It returns the findings it is given marked, filtered, merged or
+// rewritten under cfg, the config of their package. AssignFingerprints,
+// ApplySeverities, ApplyFlowHops, FilterByConfidence, AggregateByCall and
+// ApplyMessages are stages.
+type Stage func(findings []Finding, cfg *config.Config) []Finding
+
+// Pipeline runs stages in order, each on the findings the previous returned.
+// The analyzer and the whole-program driver run the pipeline of NewPipeline,
+// so suppressions, baselines and severities behave the same whatever the
+// driver and output format.
+type Pipeline []Stage
+
+// NewPipeline returns the post-processing run before reporting, in order:
+//
+//   - Deduplicate
+//   - suppression by //noleak comments indexed by filter and by the suppress
+//     section of the config
+//   - AssignFingerprints
+//   - baseline, such as the statuses of a triage file
+//   - ApplySeverities, ApplyFlowHops, FilterByConfidence, AggregateByCall and
+//     ApplyMessages
+//
+// Suppression and the baseline come first so the other stages see which
+// findings are suppressed, e.g. AggregateByCall keeps them out of merges.
+// IDs are assigned before the baseline, which keys findings on them, and
+// before stages that drop findings, so the IDs of the others do not shift.
+func NewPipeline(filter *SuppressionFilter, fset *token.FileSet, baseline ...Stage) Pipeline {
+	p := NewSuppressionPipeline(filter, fset)
+	p = append(p, baseline...)
+	return append(p, ApplySeverities, ApplyFlowHops, FilterByConfidence, AggregateByCall, ApplyMessages)
+}
+
+// NewSuppressionPipeline returns the stages of NewPipeline before the
+// baseline: Deduplicate, suppression by filter and the config, and
+// AssignFingerprints. Drivers that only need the findings suppressed and
+// identified, such as the one writing a triage file, run it so the IDs they
+// record match those the baseline of a later run looks up.
+func NewSuppressionPipeline(filter *SuppressionFilter, fset *token.FileSet) Pipeline {
+	return Pipeline{Deduplicate, filter.Stage(fset), AssignFingerprints}
+}
+
+// With returns a copy of p with stages appended, such as filters of an
+// embedding tool run after the built-in stages
+func (p Pipeline) With(stages ...Stage) Pipeline {
+	out := make(Pipeline, 0, len(p)+len(stages))
+	out = append(out, p...)
+	return append(out, stages...)
+}
+
+// Run runs the stages of p on findings under cfg; a nil cfg is the empty
+// config
+func (p Pipeline) Run(findings []Finding, cfg *config.Config) []Finding {
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	for _, stage := range p {
+		findings = stage(findings, cfg)
+	}
+	return findings
+}
+
+// Stage returns the stage applying the suppressions of sf to findings in
+// files of fset (see Apply)
+func (sf *SuppressionFilter) Stage(fset *token.FileSet) Stage {
+	return func(findings []Finding, cfg *config.Config) []Finding {
+		return sf.Apply(findings, fset, cfg)
+	}
+}
+
+// Deduplicate drops the findings reported more than once, with the same rule
+// and message for the same range, keeping the first. A file analyzed in
+// several packages, or a flow reached through several paths, reports the
+// same leak once.
+func Deduplicate(findings []Finding, _ *config.Config) []Finding {
+	type key struct {
+		pos, end token.Pos
+		ruleID   string
+		message  string
+	}
+	seen := make(map[key]bool, len(findings))
+	result := findings[:0]
+	for _, f := range findings {
+		k := key{f.Pos, f.End, f.RuleID, f.Message}
+		if seen[k] {
+			continue
+		}
+		seen[k] = true
+		result = append(result, f)
+	}
+	return result
+}
@@ -0,0 +1,155 @@
+package detector
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+func TestDeduplicate(t *testing.T) {
+	t.Parallel()
+
+	findings := Deduplicate([]Finding{
+		{Pos: 10, End: 15, RuleID: RuleIDSensitiveVar, Message: "a"},
+		{Pos: 10, End: 15, RuleID: RuleIDSensitiveVar, Message: "a", Sink: "fmt.Println"},
+		{Pos: 10, End: 15, RuleID: RuleIDSensitiveVar, Message: "b"},
+		{Pos: 10, End: 15, RuleID: RuleIDSensitiveField, Message: "a"},
+		{Pos: 10, End: 20, RuleID: RuleIDSensitiveVar, Message: "a"},
+		{Pos: 10, End: 15, RuleID: RuleIDSensitiveVar, Message: "a"},
+	}, nil)
+
+	if len(findings) != 4 {
+		t.Fatalf("Deduplicate() kept %d findings, want 4: %+v", len(findings), findings)
+	}
+	if findings[0].Sink != "" {
+		t.Errorf("Deduplicate() kept %+v, want the first of the duplicates", findings[0])
+	}
+}
+
+func TestNewPipeline(t *testing.T) {
+	t.Parallel()
+
+	const src = `package p
+
+func f() {
+	//noleak:LH0001
+	a()
+	b()
+}
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+	filter := &SuppressionFilter{}
+	filter.Build([]*ast.File{file}, fset)
+	tf := fset.File(file.Pos())
+	line := func(n int) token.Pos { return tf.LineStart(n) + 1 }
+
+	var baselineSaw []bool // Whether the findings were suppressed when the baseline ran
+	baseline := func(findings []Finding, _ *config.Config) []Finding {
+		for _, f := range findings {
+			baselineSaw = append(baselineSaw, f.Suppressed)
+		}
+		return findings
+	}
+	cfg := &config.Config{Rules: map[string]config.RuleConfig{
+		"LH0001": {Severity: "warning", Message: "{{.Message}} (see runbook)"},
+	}}
+
+	findings := NewPipeline(filter, fset, baseline).Run([]Finding{
+		{Pos: line(5), RuleID: RuleIDSensitiveVar, Message: "leak in a"},
+		{Pos: line(6), RuleID: RuleIDSensitiveVar, Message: "leak in b"},
+		{Pos: line(6), RuleID: RuleIDSensitiveVar, Message: "leak in b"},
+	}, cfg)
+
+	if len(findings) != 2 {
+		t.Fatalf("Run() returned %d findings, want 2 once deduplicated", len(findings))
+	}
+	if !findings[0].Suppressed || findings[1].Suppressed {
+		t.Errorf("Suppressed = %v, %v, want true, false", findings[0].Suppressed, findings[1].Suppressed)
+	}
+	if len(baselineSaw) != 2 || !baselineSaw[0] || baselineSaw[1] {
+		t.Errorf("baseline saw suppressions %v, want [true false]: it runs after suppression", baselineSaw)
+	}
+	if findings[1].Severity != SeverityWarning {
+		t.Errorf("Severity = %q, want %q", findings[1].Severity, SeverityWarning)
+	}
+	if want := "leak in b (see runbook)"; findings[1].Message != want {
+		t.Errorf("Message = %q, want %q", findings[1].Message, want)
+	}
+}
+
+func TestNewSuppressionPipeline(t *testing.T) {
+	t.Parallel()
+
+	// A duplicate must not shift the IDs of repeats: the IDs written to a
+	// triage file are those the baseline of a later run looks up
+	findings := func() []Finding {
+		leak := Finding{RuleID: RuleIDSensitiveVar, Message: "leak", Func: "p.f", Sink: "fmt.Println", Expr: "password"}
+		first, duplicate, repeat := leak, leak, leak
+		first.Pos, duplicate.Pos, repeat.Pos = 10, 10, 20
+		return []Finding{first, duplicate, repeat}
+	}
+	fset := token.NewFileSet()
+	filter := &SuppressionFilter{}
+
+	var baselineIDs []string
+	baseline := func(findings []Finding, _ *config.Config) []Finding {
+		for _, f := range findings {
+			baselineIDs = append(baselineIDs, f.ID)
+		}
+		return findings
+	}
+	NewPipeline(filter, fset, baseline).Run(findings(), nil)
+
+	got := NewSuppressionPipeline(filter, fset).Run(findings(), nil)
+	if len(got) != 2 || len(baselineIDs) != 2 {
+		t.Fatalf("Run() returned %d findings, baseline saw %d, want 2 once deduplicated", len(got), len(baselineIDs))
+	}
+	for i, f := range got {
+		if f.ID != baselineIDs[i] {
+			t.Errorf("findings[%d].ID = %q, want %q, the ID seen by the baseline", i, f.ID, baselineIDs[i])
+		}
+	}
+	if fp := got[0].Fingerprint(); got[1].ID != fp+":1" {
+		t.Errorf("repeat ID = %q, want %q", got[1].ID, fp+":1")
+	}
+}
+
+func TestPipeline_With(t *testing.T) {
+	t.Parallel()
+
+	var order []string
+	stage := func(name string) Stage {
+		return func(findings []Finding, cfg *config.Config) []Finding {
+			if cfg == nil {
+				t.Errorf("stage %s got a nil config", name)
+			}
+			order = append(order, name)
+			return findings
+		}
+	}
+
+	base := make(Pipeline, 0, 4)
+	base = append(base, stage("a"))
+	extended := base.With(stage("b"))
+	other := base.With(stage("c"))
+
+	extended.Run(nil, nil)
+	if got := len(order); got != 2 || order[0] != "a" || order[1] != "b" {
+		t.Errorf("With() ran %v, want [a b]", order)
+	}
+	order = nil
+	other.Run(nil, nil)
+	if got := len(order); got != 2 || order[1] != "c" {
+		t.Errorf("With() ran %v, want [a c]", order)
+	}
+	if len(base) != 1 {
+		t.Errorf("With() changed the pipeline to %d stages", len(base))
+	}
+}
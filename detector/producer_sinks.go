@@ -0,0 +1,93 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// ProducerSinkMatcher matches calls to the producer sinks of a
+// configuration: message-queue producers whose messages feed a logging
+// pipeline (LH0017). Calls are matched like custom logging targets.
+type ProducerSinkMatcher struct {
+	targets *LogDetector
+	sinks   []config.ProducerSinkConfig
+}
+
+// NewProducerSinkMatcher creates a matcher for cfg's producer sinks, or
+// returns nil when none are configured
+func NewProducerSinkMatcher(pass *analysis.Pass, cfg *config.Config) *ProducerSinkMatcher {
+	if cfg == nil || len(cfg.ProducerSinks) == 0 {
+		return nil
+	}
+	targets := &config.Config{}
+	for _, sink := range cfg.ProducerSinks {
+		targets.Targets = append(targets.Targets, sink.TargetConfig)
+	}
+	return &ProducerSinkMatcher{
+		targets: NewLogDetectorWithConfig(pass, targets),
+		sinks:   cfg.ProducerSinks,
+	}
+}
+
+// PayloadArgs returns the indexes of the payload arguments of call, or nil
+// if call is not a producer sink
+func (m *ProducerSinkMatcher) PayloadArgs(call *ast.CallExpr, info *types.Info) []int {
+	if m == nil {
+		return nil
+	}
+	entry := m.targets.CustomTarget(call, info)
+	if entry == "" {
+		return nil
+	}
+	variadic := -1
+	if sig, ok := info.TypeOf(call.Fun).(*types.Signature); ok && sig.Variadic() && !call.Ellipsis.IsValid() {
+		variadic = sig.Params().Len() - 1
+	}
+	for _, sink := range m.sinks {
+		for _, e := range TargetEntries(&config.Config{Targets: []config.TargetConfig{sink.TargetConfig}}) {
+			if e != entry {
+				continue
+			}
+			var args []int
+			for i := range call.Args {
+				if sink.IsPayloadArg(i, variadic) {
+					args = append(args, i)
+				}
+			}
+			return args
+		}
+	}
+	return nil
+}
+
+// producerSinkCall is a producer sink call collected during traversal
+type producerSinkCall struct {
+	call        *ast.CallExpr
+	caller      types.Object // Enclosing function
+	payloadArgs []int
+}
+
+// asProducerFindings turns the findings for a payload argument into LH0017
+// findings, or LH0009 findings for personal data
+func asProducerFindings(findings []Finding) {
+	for i := range findings {
+		findings[i].Fixes = nil // The consumers may need the value as is
+
+		what := findings[i].Field
+		if what == "" {
+			what = findings[i].Expr
+		}
+		if findings[i].PII {
+			findings[i].RuleID = RuleIDPersonalData
+			findings[i].Severity = SeverityWarning
+			findings[i].Message = fmt.Sprintf("personal data %q is published to a message queue feeding a logging pipeline", what)
+			continue
+		}
+		findings[i].RuleID = RuleIDSensitivePublished
+		findings[i].Message = fmt.Sprintf("sensitive data %q is published to a message queue feeding a logging pipeline", what)
+	}
+}
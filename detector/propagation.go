@@ -0,0 +1,193 @@
+package detector
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+)
+
+// carriedArgs returns the arguments of call whose data ends up in its
+// result: the operands of fmt.Sprint, fmt.Sprintf and fmt.Sprintln, the
+// user name and password of url.User and url.UserPassword, the input of
+// url.Parse and url.ParseRequestURI, and the string trimmed by the
+// strings.Trim* and strings.Cut* prefix and suffix helpers. These are how
+// DSNs and URLs embedding credentials are usually assembled, and bearer
+// tokens extracted. The slice and elements passed to append are carried too,
+// so slices of slog.Attrs or key-value pairs built up for a log call keep the
+// taint of their elements. Errors carry the errors and values they are built
+// from along the error chain: the operands of fmt.Errorf, including those
+// wrapped with %w, the errors of errors.Join and the error of errors.Unwrap,
+// and the arguments of the github.com/pkg/errors constructors and wrappers,
+// such as Wrap, Wrapf and Cause:
+//
+//	dsn := fmt.Sprintf("postgres://%s:%s@%s/app", cfg.User, cfg.Password, host)
+//	u.User = url.UserPassword(cfg.User, cfg.Password)
+//	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+//	attrs = append(attrs, slog.String("token", cfg.Token))
+//	err = errors.Join(err, fmt.Errorf("key=%s", cfg.APIKey))
+func carriedArgs(call *ast.CallExpr, info *types.Info) []ast.Expr {
+	if isBuiltinAppend(call, info) {
+		return call.Args
+	}
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	if !ok || fn.Pkg() == nil || !isPackageFunc(fn) {
+		return nil
+	}
+	switch fn.Pkg().Path() + "." + fn.Name() {
+	case "fmt.Sprint", "fmt.Sprintf", "fmt.Sprintln", "net/url.User", "net/url.UserPassword":
+		return call.Args
+	case "fmt.Errorf", "errors.New", "errors.Join", "errors.Unwrap":
+		return call.Args
+	case "net/url.Parse", "net/url.ParseRequestURI":
+		if len(call.Args) == 1 {
+			return call.Args
+		}
+	case "strings.TrimPrefix", "strings.TrimSuffix", "strings.TrimSpace", "strings.CutPrefix", "strings.CutSuffix":
+		return call.Args[:1]
+	}
+	if fn.Pkg().Path() == "github.com/pkg/errors" {
+		switch fn.Name() {
+		case "New", "Errorf", "Wrap", "Wrapf", "WithMessage", "WithMessagef", "WithStack", "Cause", "Unwrap":
+			return call.Args
+		}
+	}
+	return nil
+}
+
+// carriedYields returns the collection passed to a standard library
+// function returning an iterator over it, and the positions of the yielded
+// values taken from the collection: the elements of slices.All,
+// slices.Backward and slices.Values, the keys and values of maps.All,
+// maps.Keys and maps.Values, and the substrings of the strings and bytes
+// Lines, SplitSeq, SplitAfterSeq, FieldsSeq and FieldsFuncSeq functions.
+//
+//	for _, token := range maps.All(cfg.Tokens) {
+func carriedYields(call *ast.CallExpr, info *types.Info) (ast.Expr, []int) {
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	if !ok || fn.Pkg() == nil || !isPackageFunc(fn) || len(call.Args) == 0 {
+		return nil, nil
+	}
+	switch fn.Pkg().Path() {
+	case "slices":
+		switch fn.Name() {
+		case "All", "Backward":
+			return call.Args[0], []int{1}
+		case "Values":
+			return call.Args[0], []int{0}
+		}
+	case "maps":
+		switch fn.Name() {
+		case "All":
+			return call.Args[0], []int{0, 1}
+		case "Keys", "Values":
+			return call.Args[0], []int{0}
+		}
+	case "strings", "bytes":
+		switch fn.Name() {
+		case "Lines", "SplitSeq", "SplitAfterSeq", "FieldsSeq", "FieldsFuncSeq":
+			return call.Args[0], []int{0}
+		}
+	}
+	return nil, nil
+}
+
+// isBuiltinAppend reports whether call calls the append builtin
+func isBuiltinAppend(call *ast.CallExpr, info *types.Info) bool {
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	builtin, ok := info.Uses[ident].(*types.Builtin)
+	return ok && builtin.Name() == "append"
+}
+
+// carriedReceiver returns the URL whose credentials a method call renders
+// or returns — u in u.String() and u.User.Password() — or the error whose
+// message err.Error() returns, or nil. The credentials come from the URL as a
+// whole, since storing them in its User field taints the URL variable.
+// (*url.URL).Redacted masks the password and is not a carrier.
+func carriedReceiver(call *ast.CallExpr, info *types.Info) ast.Expr {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.MethodVal {
+		return nil
+	}
+	switch name := sel.Sel.Name; {
+	case isURLType(selection.Recv(), "URL") && name == "String":
+		return sel.X
+	case isURLType(selection.Recv(), "Userinfo") && (name == "String" || name == "Username" || name == "Password"):
+		// u.User.Password(): the Userinfo read from a URL carries the URL's taint
+		if field, ok := ast.Unparen(sel.X).(*ast.SelectorExpr); ok && field.Sel.Name == "User" && isURLType(info.TypeOf(field.X), "URL") {
+			return field.X
+		}
+		return sel.X
+	case name == "Error" && isErrorMethod(selection):
+		return sel.X
+	}
+	return nil
+}
+
+// isErrorMethod reports whether selection is the Error method of an error
+func isErrorMethod(selection *types.Selection) bool {
+	errorType := types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+	return types.Implements(selection.Recv(), errorType)
+}
+
+// isURLType reports whether t is net/url.name or a pointer to it
+func isURLType(t types.Type, name string) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == "net/url" && named.Obj().Name() == name
+}
+
+// isStringConcat reports whether expr is a string concatenation a + b
+func isStringConcat(expr *ast.BinaryExpr, info *types.Info) bool {
+	if expr.Op != token.ADD {
+		return false
+	}
+	t := info.TypeOf(expr)
+	if t == nil {
+		return false
+	}
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// carriedSource returns the source of the sensitive data carried into the
+// result of call by its arguments or receiver (see carriedArgs and
+// carriedReceiver, and EventBuilderMatcher for configured event builders),
+// or nil
+func (sc *SensitivityChecker) carriedSource(
+	call *ast.CallExpr,
+	vars map[*types.Var]SensitiveSource,
+	funcs map[types.Object]SensitiveSource,
+) *SensitiveSource {
+	info := sc.pass.TypesInfo
+	args := carriedArgs(call, info)
+	if recv := carriedReceiver(call, info); recv != nil {
+		args = []ast.Expr{recv}
+	}
+	// Events returned by builders carry the values attached to them and
+	// those of the event they are called on
+	if sc.builders.IsBuilder(call, info) {
+		args = call.Args
+		if event := eventOf(call, info); event != nil {
+			args = append([]ast.Expr{event}, args...)
+		}
+	}
+	for _, arg := range args {
+		if source := sc.checkSensitiveExpr(arg, vars, funcs); source != nil {
+			carried := source.withStep(types.ExprString(call.Fun), call.Pos())
+			return &carried
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package detector
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// ErrAnalysisPanic is matched, with errors.Is, by the PanicErrors of
+// WholeProgramCollector.Err: a panic in leakhound is a bug in the tool rather
+// than in the analyzed code or the configuration
+var ErrAnalysisPanic = errors.New("analysis panicked")
+
+// PanicError reports a panic recovered while analyzing a package, which was
+// then left out of the rest of the analysis
+type PanicError struct {
+	Package string // Import path of the package, or "" for a whole-program step
+	Step    string // e.g. "collecting facts"
+	Value   any    // The value passed to panic
+	Stack   []StackFrame
+}
+
+func (e *PanicError) Error() string {
+	if e.Package == "" {
+		return fmt.Sprintf("analysis panicked while %s, which was stopped: %v", e.Step, e.Value)
+	}
+	return fmt.Sprintf("package %s: analysis panicked while %s, the package was skipped: %v", e.Package, e.Step, e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, such as a runtime.Error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Is reports whether target is ErrAnalysisPanic
+func (e *PanicError) Is(target error) bool {
+	return target == ErrAnalysisPanic
+}
+
+// Notification reports the panic as an error notification holding its value
+// and stack
+func (e *PanicError) Notification() Notification {
+	return Notification{
+		Level:     SeverityError,
+		Package:   e.Package,
+		Message:   e.Error(),
+		PanicType: fmt.Sprintf("%T", e.Value),
+		Stack:     e.Stack,
+	}
+}
+
+// StackFrame is a frame of the stack of a recovered panic
+type StackFrame struct {
+	Function string // e.g. "github.com/nilpoona/leakhound/detector.(*Detector).checkArg"
+	File     string
+	Line     int
+}
+
+// Err returns the panics recovered during the analysis, joined, or nil if
+// there were none. Each is a PanicError naming its package; the findings of
+// the other packages are still reported.
+func (wp *WholeProgramCollector) Err() error {
+	errs := make([]error, len(wp.panics))
+	for i, p := range wp.panics {
+		errs[i] = p
+	}
+	return errors.Join(errs...)
+}
+
+// guard runs fn, a step of the analysis of the package with import path
+// pkgPath, recovering from a panic in it so one unusual package does not end
+// the whole run: the panic is recorded as a PanicError holding its value and
+// stack, see Err and Notifications. It reports whether fn completed; the
+// caller leaves a failed package out of the rest of the analysis.
+func (wp *WholeProgramCollector) guard(pkgPath, step string, fn func()) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			err := &PanicError{Package: pkgPath, Step: step, Value: r, Stack: panicStack()}
+			if p, isWorker := r.(*workerPanic); isWorker {
+				err.Value, err.Stack = p.value, p.stack
+			}
+			wp.panics = append(wp.panics, err)
+			ok = false
+		}
+	}()
+	fn()
+	return true
+}
+
+// workerPanic is a panic recovered in a goroutine started by the guarded
+// function, such as a worker of scanFiles, and raised again on the guarded
+// goroutine; guard reports its value and the stack of the worker
+type workerPanic struct {
+	value any
+	stack []StackFrame
+}
+
+// panicStack returns the stack of the panic being recovered, innermost frame
+// first, from the function that panicked up to the guarded function, or to
+// the start of the goroutine in a worker. It must be called by the deferred
+// function that recovers.
+func panicStack() []StackFrame {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(1, pcs)])
+	var stack []StackFrame
+	panicking := false
+	for {
+		frame, more := frames.Next()
+		switch {
+		case frame.Function == "runtime.gopanic":
+			panicking = true // The frames that follow raised the panic
+		case strings.HasSuffix(frame.Function, ".(*WholeProgramCollector).guard"):
+			return stack
+		case panicking && !strings.HasPrefix(frame.Function, "runtime."):
+			stack = append(stack, StackFrame{Function: frame.Function, File: frame.File, Line: frame.Line})
+		}
+		if !more {
+			return stack
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package detector
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"runtime"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// explode panics with a nil map write, a runtime error raised two frames
+// below the guarded function
+func explode() {
+	var m map[string]int
+	m["boom"]++
+}
+
+func TestWholeProgramCollector_Guard(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		pkgPath   string
+		fn        func()
+		wantOK    bool
+		wantMsg   string
+		wantType  string
+		wantFrame string
+	}{
+		{
+			name:    "completes",
+			pkgPath: "example.com/app",
+			fn:      func() {},
+			wantOK:  true,
+		},
+		{
+			name:      "runtime error",
+			pkgPath:   "example.com/app",
+			fn:        func() { explode() },
+			wantMsg:   "package example.com/app: analysis panicked while collecting facts, the package was skipped: assignment to entry in nil map",
+			wantType:  "runtime.plainError",
+			wantFrame: "detector.explode",
+		},
+		{
+			name:      "whole program",
+			fn:        func() { panic("unexpected node") },
+			wantMsg:   "analysis panicked while collecting facts, which was stopped: unexpected node",
+			wantType:  "string",
+			wantFrame: "detector.TestWholeProgramCollector_Guard",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wp := &WholeProgramCollector{}
+			if ok := wp.guard(tt.pkgPath, "collecting facts", tt.fn); ok != tt.wantOK {
+				t.Fatalf("guard() = %v, want %v", ok, tt.wantOK)
+			}
+			notes := wp.Notifications()
+			if tt.wantOK {
+				if err := wp.Err(); err != nil || len(notes) != 0 {
+					t.Errorf("Err(), Notifications() = %v, %v, want none", err, notes)
+				}
+				return
+			}
+
+			err := wp.Err()
+			if !errors.Is(err, ErrAnalysisPanic) {
+				t.Errorf("Err() = %v, want an ErrAnalysisPanic", err)
+			}
+			var panicErr *PanicError
+			if !errors.As(err, &panicErr) || panicErr.Package != tt.pkgPath {
+				t.Fatalf("Err() = %#v, want a PanicError for package %q", err, tt.pkgPath)
+			}
+			if len(notes) != 1 {
+				t.Fatalf("len(Notifications()) = %d, want 1", len(notes))
+			}
+			got := notes[0]
+			if got.Level != SeverityError || got.Package != tt.pkgPath {
+				t.Errorf("level, package = %s, %q, want %s, %q", got.Level, got.Package, SeverityError, tt.pkgPath)
+			}
+			if got.Message != tt.wantMsg || err.Error() != tt.wantMsg {
+				t.Errorf("message = %q, error = %q, want %q", got.Message, err, tt.wantMsg)
+			}
+			var runtimeErr runtime.Error
+			if isRuntime := errors.As(err, &runtimeErr); isRuntime != (tt.wantType == "runtime.plainError") {
+				t.Errorf("errors.As(Err(), runtime.Error) = %v, want the panic value unwrapped", isRuntime)
+			}
+			if got.PanicType != tt.wantType {
+				t.Errorf("panic type = %q, want %q", got.PanicType, tt.wantType)
+			}
+			if len(got.Stack) == 0 {
+				t.Fatal("stack is empty")
+			}
+			if !strings.Contains(got.Stack[0].Function, tt.wantFrame) {
+				t.Errorf("innermost frame = %s, want %s", got.Stack[0].Function, tt.wantFrame)
+			}
+			for _, frame := range got.Stack {
+				if strings.HasPrefix(frame.Function, "runtime.") || strings.HasSuffix(frame.Function, ".guard") {
+					t.Errorf("stack holds frame %s outside the guarded function", frame.Function)
+				}
+				if frame.File == "" || frame.Line == 0 {
+					t.Errorf("frame %s has no position", frame.Function)
+				}
+			}
+		})
+	}
+}
+
+func TestWholeProgramCollector_GuardConcurrentScan(t *testing.T) {
+	t.Parallel()
+
+	// Without a field collector, scanning a tagged struct panics in the
+	// goroutines of scanFiles rather than in the guarded one
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range []string{"a.go", "b.go", "c.go"} {
+		file, err := parser.ParseFile(fset, name, "package app\n\ntype T struct {\n\tSecret string `sensitive:\"true\"`\n}\n", 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	c := &DataFlowCollector{pass: &analysis.Pass{Fset: fset, Files: files}}
+
+	wp := &WholeProgramCollector{}
+	if ok := wp.guard("example.com/app", "collecting facts", func() { c.scanFiles() }); ok {
+		t.Fatal("guard() = true, want the worker panic recovered")
+	}
+	var panicErr *PanicError
+	if err := wp.Err(); !errors.As(err, &panicErr) || panicErr.Package != "example.com/app" {
+		t.Fatalf("Err() = %#v, want a PanicError for the package", err)
+	}
+	if _, wrapped := panicErr.Value.(*workerPanic); wrapped {
+		t.Errorf("panic value = %#v, want the value raised by the worker", panicErr.Value)
+	}
+	var runtimeErr runtime.Error
+	if !errors.As(panicErr, &runtimeErr) {
+		t.Errorf("Err() = %v, want the runtime error of the worker unwrapped", panicErr)
+	}
+	if len(panicErr.Stack) == 0 || !strings.Contains(panicErr.Stack[0].Function, "(*FieldCollector).fieldsOf") {
+		t.Errorf("stack = %+v, want the worker's, innermost in fieldsOf", panicErr.Stack)
+	}
+}
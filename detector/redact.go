@@ -0,0 +1,147 @@
+package detector
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// RedactPackage is the import path of the companion package whose wrapper
+// types, such as redact.Secret[string], render as "[REDACTED]"
+const RedactPackage = "github.com/nilpoona/leakhound/redact"
+
+// isRedactWrapper reports whether t is a type declared in RedactPackage, or a
+// pointer to one. Wrapped values are safe to log, and so are fields of a
+// wrapper type whatever their tags say.
+func isRedactWrapper(t types.Type) bool {
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return named.Obj().Pkg().Path() == RedactPackage
+}
+
+// exposedSource returns a source for the value a redact wrapper's Expose
+// method unwraps, which is reported under LH0011 when it reaches a sink, or
+// nil:
+//
+//	slog.Info("login", "password", account.Password.Expose())
+func exposedSource(expr ast.Expr, info *types.Info) *SensitiveSource {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	if !ok {
+		return nil
+	}
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != "Expose" || !isRedactWrapper(info.TypeOf(sel.X)) {
+		return nil
+	}
+
+	// Name the wrapper after its field, Account.Password, when it is one
+	label, fieldPos := types.ExprString(sel.X), token.NoPos
+	if field, ok := ast.Unparen(sel.X).(*ast.SelectorExpr); ok {
+		if selection, ok := info.Selections[field]; ok && selection.Kind() == types.FieldVal {
+			recv := selection.Recv()
+			if ptr, ok := recv.(*types.Pointer); ok {
+				recv = ptr.Elem()
+			}
+			if named, ok := recv.(*types.Named); ok {
+				label = named.Obj().Name() + "." + field.Sel.Name
+			}
+			fieldPos = selection.Obj().Pos()
+		}
+	}
+	return &SensitiveSource{
+		FieldName: label,
+		FieldPos:  fieldPos,
+		Position:  expr.Pos(),
+		FlowPath:  []FlowStep{{Label: label + ".Expose()", Pos: expr.Pos()}},
+		Exposed:   true,
+	}
+}
+
+// redactFixes returns a suggested fix wrapping expr in redact.New, importing
+// RedactPackage when the file does not already:
+//
+//	slog.Info("login", "password", u.Password)
+//	slog.Info("login", "password", redact.New(u.Password))
+//
+// There is none unless expr is the sink argument itself and its parameter
+// accepts the wrapper (see CheckLoggedArg): slog.String("password",
+// redact.New(u.Password)) or "password: "+redact.New(u.Password) would not
+// compile.
+func (d *Detector) redactFixes(expr ast.Expr) []analysis.SuggestedFix {
+	if d.fixArg == nil || ast.Unparen(expr) != d.fixArg {
+		return nil
+	}
+	file := d.fileOf(expr.Pos())
+	if file == nil {
+		return nil
+	}
+
+	name, edits := "redact", []analysis.TextEdit(nil)
+	if local, ok := importName(file, RedactPackage); ok {
+		if local == "_" || local == "." {
+			return nil
+		}
+		name = local
+	} else {
+		edits = append(edits, addImport(file, RedactPackage))
+	}
+
+	edits = append(edits,
+		analysis.TextEdit{Pos: expr.Pos(), End: expr.Pos(), NewText: []byte(name + ".New(")},
+		analysis.TextEdit{Pos: expr.End(), End: expr.End(), NewText: []byte(")")},
+	)
+	return []analysis.SuggestedFix{{
+		Message:   "Wrap the value in " + name + ".New",
+		TextEdits: edits,
+	}}
+}
+
+// fileOf returns the file of the pass containing pos, or nil
+func (d *Detector) fileOf(pos token.Pos) *ast.File {
+	for _, file := range d.pass.Files {
+		if file.FileStart <= pos && pos <= file.FileEnd {
+			return file
+		}
+	}
+	return nil
+}
+
+// importName returns the name under which file imports importPath
+func importName(file *ast.File, importPath string) (string, bool) {
+	for _, imp := range file.Imports {
+		if p, err := strconv.Unquote(imp.Path.Value); err != nil || p != importPath {
+			continue
+		}
+		if imp.Name != nil {
+			return imp.Name.Name, true
+		}
+		return path.Base(importPath), true
+	}
+	return "", false
+}
+
+// addImport returns an edit importing importPath: into the first import
+// declaration of file, or after the package clause when there is none
+func addImport(file *ast.File, importPath string) analysis.TextEdit {
+	spec := strconv.Quote(importPath)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		if gen.Lparen.IsValid() {
+			return analysis.TextEdit{Pos: gen.Lparen + 1, End: gen.Lparen + 1, NewText: []byte("\n\t" + spec)}
+		}
+		return analysis.TextEdit{Pos: gen.End(), End: gen.End(), NewText: []byte("\nimport " + spec)}
+	}
+	return analysis.TextEdit{Pos: file.Name.End(), End: file.Name.End(), NewText: []byte("\n\nimport " + spec)}
+}
@@ -0,0 +1,176 @@
+package detector
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// SetMarshalers sets the redaction interfaces honored for whole-struct
+// findings. A struct value passed to a call into one of a marshaler's
+// packages is rendered through the interface, e.g. zap.Object calls
+// MarshalLogObject, so it is not reported when its type implements the
+// interface and the implementation reads no sensitive field.
+func (d *Detector) SetMarshalers(marshalers []config.MarshalerConfig) {
+	d.marshalers = marshalers
+}
+
+// CheckLoggedArg is CheckArgForSensitiveData for argument index of a sink
+// call, so redaction interfaces the sink renders values through are honored
+// for the argument itself, not just inside attribute constructors.
+func (d *Detector) CheckLoggedArg(call *ast.CallExpr, index int) []Finding {
+	arg := call.Args[index]
+	if sig, ok := d.pass.TypesInfo.TypeOf(call.Fun).(*types.Signature); ok && !call.Ellipsis.IsValid() && acceptsWrapped(paramType(sig, index)) {
+		d.fixArg = ast.Unparen(arg)
+		defer func() { d.fixArg = nil }()
+	}
+	return d.checkArg(arg, calleePackage(call, d.pass.TypesInfo))
+}
+
+// acceptsWrapped reports whether a parameter of type t accepts the
+// redact.Secret that redact.New returns in place of its argument, which only
+// an interface such as slog.Info's ...any does
+func acceptsWrapped(t types.Type) bool {
+	if _, ok := t.(*types.TypeParam); ok {
+		return false
+	}
+	return types.IsInterface(t)
+}
+
+// calleePackage returns the package path of the function called by call, or
+// "" when it cannot be resolved
+func calleePackage(call *ast.CallExpr, info *types.Info) string {
+	obj := resolveCallee(ast.Unparen(call.Fun), info)
+	if obj == nil || obj.Pkg() == nil {
+		return ""
+	}
+	return obj.Pkg().Path()
+}
+
+// isRedacted reports whether a value of type t (named, once dereferenced)
+// passed to a call into package consumer is rendered through a redaction
+// interface whose implementation reads none of named's sensitive fields.
+// Implementations declared outside the analyzed packages are trusted.
+func (d *Detector) isRedacted(t types.Type, named *types.Named, consumer string) bool {
+	if consumer == "" {
+		return false
+	}
+	for _, m := range d.marshalers {
+		if !consumesMarshaler(m, consumer) {
+			continue
+		}
+		iface := d.lookupInterface(m)
+		if iface == nil || !types.Implements(t, iface) {
+			continue
+		}
+		if !d.marshalReadsSensitiveField(t, named, iface) {
+			return true
+		}
+	}
+	return false
+}
+
+// consumesMarshaler reports whether calls into pkgPath render values
+// through m's interface
+func consumesMarshaler(m config.MarshalerConfig, pkgPath string) bool {
+	for _, pkg := range m.Packages {
+		if pkgPath == pkg || strings.HasPrefix(pkgPath, pkg+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupInterface resolves m's interface among the packages imported,
+// directly or not, by the analyzed package. Returns nil when the package is
+// not imported, in which case no value can implement it.
+func (d *Detector) lookupInterface(m config.MarshalerConfig) *types.Interface {
+	if iface, ok := d.ifaces[m.Interface]; ok {
+		return iface
+	}
+	if d.ifaces == nil {
+		d.ifaces = make(map[string]*types.Interface)
+	}
+	pkgPath, name := m.InterfacePath()
+	var iface *types.Interface
+	if pkg := findImport(d.pass.Pkg, pkgPath, make(map[*types.Package]bool)); pkg != nil {
+		if obj, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok {
+			iface, _ = obj.Type().Underlying().(*types.Interface)
+		}
+	}
+	d.ifaces[m.Interface] = iface
+	return iface
+}
+
+// findImport returns the package with the given path among pkg and its
+// transitive imports
+func findImport(pkg *types.Package, path string, visited map[*types.Package]bool) *types.Package {
+	if pkg == nil || visited[pkg] {
+		return nil
+	}
+	visited[pkg] = true
+	if pkg.Path() == path {
+		return pkg
+	}
+	for _, imp := range pkg.Imports() {
+		if found := findImport(imp, path, visited); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// marshalReadsSensitiveField reports whether a method of iface implemented
+// by t reads a sensitive field of named through its receiver, e.g. a
+// MarshalLogObject that still writes u.Password. Any other use of the
+// receiver than selecting a field that is neither sensitive nor holds a
+// struct with sensitive fields, such as copying, converting or returning it,
+// counts as reading every field.
+func (d *Detector) marshalReadsSensitiveField(t types.Type, named *types.Named, iface *types.Interface) bool {
+	for i := 0; i < iface.NumMethods(); i++ {
+		obj, _, _ := types.LookupFieldOrMethod(t, true, iface.Method(i).Pkg(), iface.Method(i).Name())
+		fn, ok := obj.(*types.Func)
+		if !ok {
+			continue
+		}
+		funcDecl := d.varTracker.FuncDecl(fn.Origin())
+		if funcDecl == nil || funcDecl.Body == nil || funcDecl.Recv == nil || len(funcDecl.Recv.List[0].Names) == 0 {
+			continue
+		}
+		recv := d.pass.TypesInfo.Defs[funcDecl.Recv.List[0].Names[0]]
+		if recv == nil {
+			continue
+		}
+
+		reads := false
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			if reads {
+				return false
+			}
+			switch node := n.(type) {
+			case *ast.SelectorExpr:
+				x, ok := ast.Unparen(node.X).(*ast.Ident)
+				if !ok || d.pass.TypesInfo.Uses[x] != recv {
+					return true
+				}
+				// u.Name: the receiver itself is not visited
+				if selection, ok := d.pass.TypesInfo.Selections[node]; ok && selection.Kind() == types.FieldVal &&
+					!checkSensitiveFieldFromTypeInfo(d.pass, named, node.Sel.Name) && !d.isSensitiveStructValue(node) {
+					return false
+				}
+				reads = true
+			case *ast.Ident:
+				if d.pass.TypesInfo.Uses[node] == recv {
+					reads = true
+				}
+			}
+			return !reads
+		})
+		if reads {
+			return true
+		}
+	}
+	return false
+}
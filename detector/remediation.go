@@ -0,0 +1,39 @@
+package detector
+
+// Remediation estimates, the kind of change expected to fix a finding
+const (
+	RemediationRemoveArg    = "remove-arg"    // Stop logging the value
+	RemediationAddLogValuer = "add-logvaluer" // Make the type render a redacted view of itself
+	RemediationAddSanitizer = "add-sanitizer" // Redact or mask the value before it reaches the sink
+	RemediationAddTag       = "add-tag"       // Tag the field (audit findings)
+	RemediationLoadSecret   = "load-secret"   // Load the secret at run time instead of writing it in the code
+)
+
+// remediations maps detector rule IDs to the usual fix of their findings
+var remediations = map[string]string{
+	RuleIDSensitiveVar:            RemediationAddSanitizer,
+	RuleIDSensitiveCall:           RemediationAddSanitizer,
+	RuleIDSensitiveStruct:         RemediationAddLogValuer,
+	RuleIDSensitiveField:          RemediationRemoveArg,
+	RuleIDCrossPkgSensitiveReturn: RemediationAddSanitizer,
+	RuleIDCrossPkgSensitiveSink:   RemediationAddSanitizer,
+	RuleIDUntaggedSensitiveField:  RemediationAddTag,
+	RuleIDSensitiveKey:            RemediationAddSanitizer,
+	RuleIDPersonalData:            RemediationAddSanitizer,
+	RuleIDBearerCredential:        RemediationRemoveArg,
+	RuleIDExposedSecret:           RemediationRemoveArg,
+	RuleIDCommandLineSecret:       RemediationRemoveArg,
+	RuleIDConfigDump:              RemediationRemoveArg,
+	RuleIDSensitiveStringer:       RemediationAddSanitizer,
+	RuleIDSensitiveBoundary:       RemediationRemoveArg,
+	RuleIDHookForwarding:          RemediationAddSanitizer,
+	RuleIDSensitivePublished:      RemediationAddSanitizer,
+	RuleIDHardcodedSecret:         RemediationLoadSecret,
+	RuleIDCrashHandlerDump:        RemediationRemoveArg,
+}
+
+// Remediation returns the estimated kind of fix for the finding, e.g.
+// "add-logvaluer" for a struct logged whole, or "" for an unknown rule
+func (f Finding) Remediation() string {
+	return remediations[f.RuleID]
+}
@@ -0,0 +1,386 @@
+package detector
+
+import "strings"
+
+// RuleDoc documents a single rule. The SARIF reporter and the
+// `leakhound explain` command both render from these definitions, so the
+// binary carries the same documentation that HelpURI points to.
+type RuleDoc struct {
+	ID          string // SARIF rule ID (e.g. "LH0001")
+	RuleID      string // Detector rule ID (e.g. "sensitive-var")
+	Name        string // PascalCase rule name (e.g. "SensitiveVariableLogged")
+	Short       string // One-line summary
+	Full        string // Full description
+	Help        string // Short remediation hint used as SARIF help text
+	Bad         string // Code example that triggers the rule
+	Good        string // Corrected version of Bad
+	Remediation string // Detailed remediation guidance
+
+	// SecuritySeverity is the default CVSS-like score (0.0-10.0) reported
+	// to code scanning tools; it can be overridden per rule in the config.
+	SecuritySeverity float64
+
+	// CWE and OWASP classify the rule for compliance tooling, e.g.
+	// "CWE-532" and "A09:2021".
+	CWE   []string
+	OWASP []string
+
+	// Severity is the default level reported for the rule; empty means
+	// SeverityError.
+	Severity Severity
+}
+
+// Level returns the rule's default severity level.
+func (d RuleDoc) Level() Severity {
+	if d.Severity == "" {
+		return SeverityError
+	}
+	return d.Severity
+}
+
+// HelpURI returns the documentation anchor for the rule.
+func (d RuleDoc) HelpURI() string {
+	return "https://github.com/nilpoona/leakhound#" + d.ID
+}
+
+// ruleDocs lists every rule in SARIF rule ID order.
+var ruleDocs = []RuleDoc{
+	{
+		ID:     SARIFRuleIDSensitiveVar,
+		RuleID: RuleIDSensitiveVar,
+		Name:   "SensitiveVariableLogged",
+		Short:  "Variable containing sensitive data is logged",
+		Full:   "A variable that contains data from a field tagged with sensitive:\"true\" is passed to a logging function.",
+		Help:   "Avoid logging variables that contain sensitive information. Consider redacting or removing the sensitive data before logging.",
+		Bad: `password := user.Password
+slog.Info("login", "password", password)`,
+		Good: `slog.Info("login", "user", user.Name)`,
+		Remediation: "Trace the variable back to the sensitive field it was assigned from and stop passing it to the logger. " +
+			"If the value is needed for debugging, log a redacted form (for example a fixed mask or a length) instead.",
+		SecuritySeverity: 7.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDSensitiveCall,
+		RuleID: RuleIDSensitiveCall,
+		Name:   "SensitiveFunctionCallLogged",
+		Short:  "Function call returning sensitive data is logged",
+		Full:   "A function call that returns sensitive data (from a field tagged with sensitive:\"true\") is passed to a logging function.",
+		Help:   "Avoid logging function return values that contain sensitive information. Store the result in a variable and redact sensitive fields before logging.",
+		Bad: `func token(c Config) string { return c.Token }
+
+log.Printf("token: %s", token(cfg))`,
+		Good: `log.Printf("token configured: %t", token(cfg) != "")`,
+		Remediation: "The called function returns a value derived from a sensitive field. " +
+			"Log a property of the value (presence, length) rather than the value itself, or stop logging the call result.",
+		SecuritySeverity: 7.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDSensitiveStruct,
+		RuleID: RuleIDSensitiveStruct,
+		Name:   "SensitiveStructLogged",
+		Short:  "Struct containing sensitive fields is logged",
+		Full:   "An entire struct that contains fields tagged with sensitive:\"true\" is passed to a logging function.",
+		Help:   "Avoid logging entire structs that contain sensitive fields. Log only the non-sensitive fields individually.",
+		Bad:    `slog.Info("user loaded", "user", user)`,
+		Good:   `slog.Info("user loaded", "id", user.ID, "name", user.Name)`,
+		Remediation: "Loggers print every exported field of a struct, including the sensitive ones. " +
+			"Log the individual non-sensitive fields, or implement slog.LogValuer / fmt.Stringer on the type so it renders a redacted view.",
+		SecuritySeverity: 6.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDSensitiveField,
+		RuleID: RuleIDSensitiveField,
+		Name:   "SensitiveFieldLogged",
+		Short:  "Sensitive struct field is logged",
+		Full:   "A struct field tagged with sensitive:\"true\" is directly accessed and passed to a logging function.",
+		Help:   "Avoid logging fields marked as sensitive. Remove the field from the log call or redact its value.",
+		Bad:    `fmt.Println("password:", user.Password)`,
+		Good:   `fmt.Println("password set:", user.Password != "")`,
+		Remediation: "Remove the sensitive field from the log call. " +
+			"If the field was tagged by mistake, remove the sensitive:\"true\" tag instead of suppressing the finding.",
+		SecuritySeverity: 8.0,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDCrossPkgSensitiveReturn,
+		RuleID: RuleIDCrossPkgSensitiveReturn,
+		Name:   "CrossPackageSensitiveReturnLogged",
+		Short:  "Cross-package function returning sensitive data is logged",
+		Full:   "A function defined in a different package returns data derived from a field tagged with sensitive:\"true\", and the result is passed to a logging function.",
+		Help:   "Avoid logging the return value of cross-package functions that surface sensitive data. Redact or transform the value before logging.",
+		Bad: `// package secret
+func GetPassword(u User) string { return u.Password }
+
+// package app
+slog.Info("pw", "v", secret.GetPassword(u))`,
+		Good: `slog.Info("pw", "set", secret.GetPassword(u) != "")`,
+		Remediation: "The callee lives in another package, so the leak is easy to miss in review. " +
+			"Either stop logging the result, or change the callee's API to return a redacted value for callers that only need it for diagnostics.",
+		SecuritySeverity: 7.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDCrossPkgSensitiveSink,
+		RuleID: RuleIDCrossPkgSensitiveSink,
+		Name:   "CrossPackageSensitiveSink",
+		Short:  "Sensitive data flows into a logging sink in another package",
+		Full:   "Sensitive data (from a field tagged with sensitive:\"true\") is passed as an argument to a function in a different package whose body forwards that parameter to a logging function.",
+		Help:   "Avoid passing sensitive values to cross-package functions that log their parameters. Redact upstream or switch to a non-logging API.",
+		Bad: `// package audit
+func Record(payload string) { slog.Info("audit", "payload", payload) }
+
+// package app
+audit.Record(u.Password)`,
+		Good: `audit.Record("password changed for " + u.Name)`,
+		Remediation: "The parameter is logged somewhere inside the callee (possibly several calls deep). " +
+			"Pass a redacted value, or change the callee so it does not log the parameter verbatim.",
+		SecuritySeverity: 7.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDUntaggedSensitiveField,
+		RuleID: RuleIDUntaggedSensitiveField,
+		Name:   "UntaggedSensitiveField",
+		Short:  "Field that looks sensitive has no sensitive tag",
+		Full:   "A field of an exported struct has a name matching the sensitive-name patterns (password, token, secret, ...) but is not tagged with sensitive:\"true\", so leaks of it are not detected. This audit rule is disabled by default.",
+		Help:   "Tag the field with sensitive:\"true\" so leakhound tracks it, or tag it sensitive:\"false\" if it does not hold sensitive data.",
+		Bad: `type Account struct {
+	APIKey string
+}`,
+		Good: `type Account struct {
+	APIKey string ` + "`sensitive:\"true\"`" + `
+}`,
+		Remediation: "Review each reported field. Add sensitive:\"true\" to fields that hold secrets or personal data; " +
+			"add sensitive:\"false\" to record that a matching field (for example a pagination token) was reviewed and is safe to log. " +
+			"Enable the audit with audit.untagged-fields.enabled in the config file and tune audit.untagged-fields.patterns to your naming conventions.",
+		SecuritySeverity: 3.0,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+		Severity:         SeverityWarning,
+	},
+	{
+		ID:     SARIFRuleIDSensitiveKey,
+		RuleID: RuleIDSensitiveKey,
+		Name:   "SensitiveDataInKey",
+		Short:  "Sensitive data is used in a cache key or metric name",
+		Full:   "Data from a field tagged with sensitive:\"true\" is used to build a key passed to a configured key sink, such as a Redis or Memcached key or a metric name or label. Keys are routinely written to infrastructure logs, slow-query logs and dashboards.",
+		Help:   "Build keys from non-sensitive identifiers, or hash the sensitive value with a keyed hash before using it in a key.",
+		Bad:    `rdb.Set(ctx, fmt.Sprintf("sess:%s", session.Token), userID, time.Hour)`,
+		Good:   `rdb.Set(ctx, fmt.Sprintf("sess:%s", session.ID), userID, time.Hour)`,
+		Remediation: "Key the entry by an opaque identifier instead of the secret itself. " +
+			"If the sensitive value must be the lookup key, derive it with an HMAC so the key reveals nothing. " +
+			"Key sinks are configured per client library under key-sinks in the config file.",
+		SecuritySeverity: 6.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDPersonalData,
+		RuleID: RuleIDPersonalData,
+		Name:   "PersonalDataLogged",
+		Short:  "Personal data is logged",
+		Full:   "Personally identifiable information, from a field tagged with pii:\"true\" or one whose name matches the PII patterns (email, SSN, phone, date of birth), is passed to a logging function. This rule is reported only in PII mode, which is disabled by default, so privacy findings can be tracked apart from leaked secrets.",
+		Help:   "Avoid logging personal data. Log an opaque identifier such as a user ID, or a masked form of the value.",
+		Bad:    `slog.Info("signup", "email", user.Email)`,
+		Good:   `slog.Info("signup", "user_id", user.ID)`,
+		Remediation: "Replace the personal data with an identifier that lets you correlate log lines without revealing who the user is. " +
+			"If the value is needed for support, log a masked form (for example the email domain) and keep the full value in access-controlled storage. " +
+			"Enable the rule with pii.enabled in the config file, tag fields with pii:\"true\", and tune pii.patterns to your naming conventions; tag a field pii:\"false\" if it matches a pattern but holds no personal data.",
+		SecuritySeverity: 4.0,
+		CWE:              []string{"CWE-359"},
+		OWASP:            []string{"A09:2021"},
+		Severity:         SeverityWarning,
+	},
+	{
+		ID:     SARIFRuleIDBearerCredential,
+		RuleID: RuleIDBearerCredential,
+		Name:   "BearerCredentialLogged",
+		Short:  "Authorization header value or JWT is logged",
+		Full:   "The value of an Authorization or Proxy-Authorization request header, or an encoded JWT, is passed to a logging function. These are recognized without struct tags, including after the token is extracted with helpers such as strings.TrimPrefix(auth, \"Bearer \").",
+		Help:   "Never log bearer tokens or JWTs. Log the token's subject or ID claim, or whether a token was present, instead.",
+		Bad:    `slog.Info("request", "auth", r.Header.Get("Authorization"))`,
+		Good:   `slog.Info("request", "authenticated", r.Header.Get("Authorization") != "")`,
+		Remediation: "A logged bearer token can be replayed by anyone who reads the logs until it expires. " +
+			"Log non-secret claims such as sub or jti from the parsed token instead of the token itself. " +
+			"Credentials are recognized from net/http.Header and from the Token type of packages named jwt, such as github.com/golang-jwt/jwt.",
+		SecuritySeverity: 8.0,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDExposedSecret,
+		RuleID: RuleIDExposedSecret,
+		Name:   "ExposedSecretLogged",
+		Short:  "Secret unwrapped from a redact wrapper is logged",
+		Full:   "A value unwrapped from a redact.Secret with its Expose method is passed to a logging function. The wrapper renders as [REDACTED], but the unwrapped value does not.",
+		Help:   "Log the redact.Secret itself rather than the result of Expose, and call Expose only where the raw value is consumed.",
+		Bad:    `slog.Info("connect", "password", cfg.Password.Expose())`,
+		Good:   `slog.Info("connect", "password", cfg.Password)`,
+		Remediation: "Keep secrets wrapped until the point of use, such as the call that opens a connection, and pass the wrapper to loggers. " +
+			"If a helper needs the raw value, have it take the redact.Secret and call Expose itself.",
+		SecuritySeverity: 7.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDCommandLineSecret,
+		RuleID: RuleIDCommandLineSecret,
+		Name:   "CommandLineSecretLogged",
+		Short:  "Flag or command-line argument declared sensitive is logged",
+		Full:   "The value of a flag or an element of os.Args declared sensitive in the command-line section of the config file is passed to a logging function, directly or after flowing through variables and functions.",
+		Help:   "Do not log secrets passed on the command line. Log whether the value was set instead.",
+		Bad:    `slog.Info("starting", "db-password", *dbPassword)`,
+		Good:   `slog.Info("starting", "db-password-set", *dbPassword != "")`,
+		Remediation: "Secrets passed as flags or arguments end up in logs whenever the startup configuration is dumped for debugging. " +
+			"Log that the value is set rather than the value itself, and prefer reading secrets from the environment or a file, since command lines are also visible to other users of the host. " +
+			"Declare the flags by name in command-line.flags and the positional arguments by index in command-line.args.",
+		SecuritySeverity: 7.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDConfigDump,
+		RuleID: RuleIDConfigDump,
+		Name:   "ConfigStructPrinted",
+		Short:  "Configuration struct is printed whole",
+		Full:   "A struct declared in a configuration package (config, settings, ...) with more than the configured number of fields is passed to fmt or log Print, Printf or Println. Such debugging dumps print every field the configuration holds, tagged or not, including the secrets it grows later. This audit rule is disabled by default.",
+		Help:   "Remove the debugging print, or log the few fields needed by name.",
+		Bad:    `fmt.Println(cfg)`,
+		Good:   `slog.Info("config loaded", "addr", cfg.Addr, "env", cfg.Env)`,
+		Remediation: "Printing a whole configuration is a common leftover from debugging that leaks credentials once it reaches production logs. " +
+			"Delete the print, or log the fields you need explicitly; if the type must be printable, implement fmt.Stringer with a redacted view. " +
+			"Enable the audit with audit.config-dumps.enabled in the config file, and tune audit.config-dumps.max-fields and audit.config-dumps.packages to your layout.",
+		SecuritySeverity: 3.0,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+		Severity:         SeverityWarning,
+	},
+	{
+		ID:     SARIFRuleIDSensitiveStringer,
+		RuleID: RuleIDSensitiveStringer,
+		Name:   "SensitiveStringer",
+		Short:  "String, Format or MarshalText method renders sensitive data",
+		Full:   "A String, GoString or MarshalText method returns sensitive data, or a Format method writes it to its fmt.State. fmt calls these methods whenever a value of the type is printed with %v, %s or %+v, and log/slog handlers call MarshalText, so every log of the type leaks the data, wherever it happens.",
+		Help:   "Render a redacted view of the sensitive data in the method.",
+		Bad:    `func (u User) String() string { return u.Name + ":" + u.Password }`,
+		Good:   `func (u User) String() string { return u.Name + ":[REDACTED]" }`,
+		Remediation: "Formatting methods are called implicitly by fmt and by loggers that format their arguments, so a leak in one reaches every log of the type. " +
+			"Leave the sensitive fields out of the method's output, or mask them (e.g. show the last four characters only). " +
+			"For log/slog, also implement slog.LogValuer so structured logs get the same redacted view.",
+		SecuritySeverity: 7.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+		Severity:         SeverityError,
+	},
+	{
+		ID:     SARIFRuleIDSensitiveBoundary,
+		RuleID: RuleIDSensitiveBoundary,
+		Name:   "SensitiveTypeAtBoundary",
+		Short:  "Boundary package accepts a sensitive type",
+		Full:   "A function or method declared in a boundary package, one the config declares sensitive types must never cross into such as a logging or telemetry package, accepts a struct with sensitive fields, directly or in a pointer, slice, array, map or channel. Whatever such a package is given ends up logged or exported, so the signature invites leaks at the API design level. This rule only applies to the packages listed in boundaries.",
+		Help:   "Accept a redacted view or the non-sensitive values instead of the sensitive type.",
+		Bad:    `func Track(event string, u *models.User)`,
+		Good:   `func Track(event string, userID string)`,
+		Remediation: "Boundary packages should define the data they accept rather than take domain types carrying secrets. " +
+			"Change the signature to accept the few non-sensitive values needed, or a dedicated type without sensitive fields. " +
+			"Declare boundary packages, with an optional reason shown in the findings, in the boundaries section of the config file.",
+		SecuritySeverity: 5.0,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+		Severity:         SeverityWarning,
+	},
+	{
+		ID:     SARIFRuleIDHookForwarding,
+		RuleID: RuleIDHookForwarding,
+		Name:   "LogHookForwarding",
+		Short:  "Logger hook forwards log entries to an external sink",
+		Full:   "A logger hook registered with a configured registration point, such as logrus.AddHook or zap.Hooks, passes the entries it receives, or values derived from them, to an external sink such as an HTTP client or a Kafka producer. Hooks see every entry logged, including the sensitive data other rules report, and ship it out of the process past the redaction of the log pipeline. This rule only applies to the registrations listed in hooks.registrations.",
+		Help:   "Forward a redacted view of the entry, or only the fields the external system needs.",
+		Bad:    `http.Post(h.url, "application/json", bytes.NewReader(entry.Message))`,
+		Good:   `http.Post(h.url, "application/json", bytes.NewReader(redactEntry(entry)))`,
+		Remediation: "An entry forwarded by a hook reaches systems outside the log pipeline's retention and access controls, such as chat channels or vendor APIs. " +
+			"Send only the fields the receiving system needs, e.g. the level and message, and mask the entry's data fields before forwarding them. " +
+			"Declare hook registrations in hooks.registrations, and external sinks other than the net/http client in hooks.forwarders.",
+		SecuritySeverity: 6.5,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+		Severity:         SeverityWarning,
+	},
+	{
+		ID:     SARIFRuleIDSensitivePublished,
+		RuleID: RuleIDSensitivePublished,
+		Name:   "SensitiveDataPublished",
+		Short:  "Sensitive data is published to a message queue feeding logs",
+		Full:   "Data from a field tagged with sensitive:\"true\" is passed in the payload of a configured producer sink, such as a Kafka writer, an SQS queue or a Pub/Sub topic whose messages feed a logging pipeline. The message is indexed and retained by the log consumers, so the data leaks as surely as if it had been logged.",
+		Help:   "Publish an opaque identifier or a redacted view instead of the sensitive data.",
+		Bad:    `w.WriteMessages(ctx, kafka.Message{Value: []byte(user.Password)})`,
+		Good:   `w.WriteMessages(ctx, kafka.Message{Value: []byte(user.ID)})`,
+		Remediation: "Topics feeding log indexers and SIEMs are read by far more people and systems than the service publishing to them. " +
+			"Leave sensitive fields out of the message, or mask them before publishing; if a consumer needs the value, publish it to a dedicated topic with restricted access instead. " +
+			"Producer sinks are configured per client library under producer-sinks in the config file.",
+		SecuritySeverity: 7.0,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+	},
+	{
+		ID:     SARIFRuleIDHardcodedSecret,
+		RuleID: RuleIDHardcodedSecret,
+		Name:   "HardcodedSecret",
+		Short:  "Secret is hardcoded in a struct tag or constant",
+		Full:   "A struct tag or a string constant holds a secret: the default or example value of a field tagged sensitive or whose name matches the sensitive-name patterns (password, token, secret, ...), a constant with such a name, or a connection string with a password. The secret ships in the source and the binary, and ends up in the logs wherever the default is used. This audit rule is disabled by default.",
+		Help:   "Read the secret from the environment or a secret store at run time, and leave the default empty.",
+		Bad:    "Password string `env:\"DB_PASSWORD\" envDefault:\"hunter2\"`",
+		Good:   "Password string `env:\"DB_PASSWORD,required\"`",
+		Remediation: "Secrets written in the code are shared with everyone who can read the repository or the binary, cannot be rotated without a release, and leak through logs, documentation and error messages built from the defaults. " +
+			"Remove the value, make the setting required, and rotate the secret if it was ever used. " +
+			"Enable the audit with audit.hardcoded-secrets.enabled in the config file, and tune audit.hardcoded-secrets.patterns to your naming conventions.",
+		SecuritySeverity: 6.0,
+		CWE:              []string{"CWE-798"},
+		OWASP:            []string{"A07:2021"},
+		Severity:         SeverityWarning,
+	},
+	{
+		ID:     SARIFRuleIDCrashHandlerDump,
+		RuleID: RuleIDCrashHandlerDump,
+		Name:   "CrashHandlerDump",
+		Short:  "Crash handler logs sensitive-looking local variables with the stack",
+		Full:   "A function recovering from a panic dumps the stack with debug.PrintStack, debug.Stack or runtime.Stack, and logs a local variable or parameter whose name matches the sensitive-name patterns (password, token, secret, ...). Crash handlers are written to capture as much context as possible, and are rarely reviewed for what that context holds: the values end up next to the stack trace in crash reports and error trackers. This audit rule is disabled by default.",
+		Help:   "Log the panic value and the stack, and identify the request or operation by an opaque ID rather than its secrets.",
+		Bad:    `defer func() { if r := recover(); r != nil { log.Printf("panic: %v token=%s\n%s", r, token, debug.Stack()) } }()`,
+		Good:   `defer func() { if r := recover(); r != nil { log.Printf("panic: %v request=%s\n%s", r, requestID, debug.Stack()) } }()`,
+		Remediation: "Crash reports are copied to error trackers and incident tickets, where they are read far more widely than the logs. " +
+			"Remove the secret from the crash handler's log call, or mask it, and log an identifier of the failed operation instead. " +
+			"Enable the audit with audit.crash-handlers.enabled in the config file, and tune audit.crash-handlers.patterns to your naming conventions.",
+		SecuritySeverity: 5.0,
+		CWE:              []string{"CWE-532"},
+		OWASP:            []string{"A09:2021"},
+		Severity:         SeverityWarning,
+	},
+}
+
+// RuleDocs returns the documentation for every rule in SARIF rule ID order.
+// The returned slice is a copy and may be modified by the caller.
+func RuleDocs() []RuleDoc {
+	return append([]RuleDoc(nil), ruleDocs...)
+}
+
+// LookupRuleDoc returns the documentation for a rule, accepting either the
+// SARIF rule ID ("LH0003", case-insensitive) or the detector rule ID
+// ("sensitive-struct").
+func LookupRuleDoc(id string) (RuleDoc, bool) {
+	for _, doc := range ruleDocs {
+		if strings.EqualFold(doc.ID, id) || doc.RuleID == id {
+			return doc, true
+		}
+	}
+	return RuleDoc{}, false
+}
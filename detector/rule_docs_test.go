@@ -0,0 +1,47 @@
+package detector
+
+import "testing"
+
+func TestRuleDocs_CoverEveryRule(t *testing.T) {
+	t.Parallel()
+
+	docs := RuleDocs()
+	if len(docs) != len(ruleIDToSARIF) {
+		t.Fatalf("RuleDocs() returned %d docs, want %d", len(docs), len(ruleIDToSARIF))
+	}
+	for _, doc := range docs {
+		if want := ToSARIFRuleID(doc.RuleID); doc.ID != want {
+			t.Errorf("doc %s: RuleID %q maps to %q", doc.ID, doc.RuleID, want)
+		}
+		if doc.Name == "" || doc.Short == "" || doc.Full == "" || doc.Help == "" ||
+			doc.Bad == "" || doc.Good == "" || doc.Remediation == "" {
+			t.Errorf("doc %s has empty fields: %+v", doc.ID, doc)
+		}
+	}
+}
+
+func TestLookupRuleDoc(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id     string
+		wantID string
+		wantOK bool
+	}{
+		{"LH0003", "LH0003", true},
+		{"lh0003", "LH0003", true},
+		{RuleIDSensitiveVar, "LH0001", true},
+		{"LH9999", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.id, func(t *testing.T) {
+			t.Parallel()
+			doc, ok := LookupRuleDoc(tt.id)
+			if ok != tt.wantOK || doc.ID != tt.wantID {
+				t.Errorf("LookupRuleDoc(%q) = (%q, %v), want (%q, %v)", tt.id, doc.ID, ok, tt.wantID, tt.wantOK)
+			}
+		})
+	}
+}
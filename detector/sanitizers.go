@@ -0,0 +1,59 @@
+package detector
+
+import (
+	"go/ast"
+	"go/types"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/analysis"
+)
+
+// SanitizerMatcher matches calls to the sanitizers of a configuration:
+// functions and methods, such as (*Redactor).Mask or a package's Hash, whose
+// results are safe to log whatever flowed into them. Calls are matched like
+// custom logging targets.
+type SanitizerMatcher struct {
+	targets *LogDetector
+}
+
+// NewSanitizerMatcher creates a matcher for cfg's sanitizers, or returns nil
+// when none are configured
+func NewSanitizerMatcher(pass *analysis.Pass, cfg *config.Config) *SanitizerMatcher {
+	if cfg == nil || len(cfg.Sanitizers) == 0 {
+		return nil
+	}
+	return &SanitizerMatcher{
+		targets: NewLogDetectorWithConfig(pass, &config.Config{Targets: cfg.Sanitizers}),
+	}
+}
+
+// IsSanitizer reports whether call calls a sanitizer, so that its result
+// carries no taint from its arguments or receiver
+func (m *SanitizerMatcher) IsSanitizer(call *ast.CallExpr, info *types.Info) bool {
+	if m == nil {
+		return false
+	}
+	if m.targets.CustomTarget(call, info) != "" {
+		return true
+	}
+	// Sanitizers declared in the analyzed package are called unqualified
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	fn, ok := info.Uses[ident].(*types.Func)
+	return ok && fn.Pkg() != nil && m.targets.matchCustomTarget(fn.Pkg().Path(), fn.Name(), fn) != ""
+}
+
+// SetSanitizers sets the sanitizers whose results are clean, both when
+// logged directly and when assigned or returned
+func (d *Detector) SetSanitizers(m *SanitizerMatcher) {
+	d.sanitizers = m
+	d.varTracker.checker.sanitizers = m
+}
+
+// isSanitized reports whether expr is a call to a sanitizer
+func (d *Detector) isSanitized(expr ast.Expr) bool {
+	call, ok := ast.Unparen(expr).(*ast.CallExpr)
+	return ok && d.sanitizers.IsSanitizer(call, d.pass.TypesInfo)
+}
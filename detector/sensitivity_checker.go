@@ -3,6 +3,7 @@ package detector
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 
 	"golang.org/x/tools/go/analysis"
@@ -14,6 +15,9 @@ import (
 type SensitivityChecker struct {
 	pass            *analysis.Pass
 	sensitiveFields map[sensitiveField]bool
+	sanitizers      *SanitizerMatcher    // Calls whose results are clean; nil for none
+	builders        *EventBuilderMatcher // Calls attaching values to events; nil for none
+	commandLine     *CommandLineSources  // Flags and arguments declared sensitive; nil for none
 }
 
 // checkSensitiveExpr checks if an expression is sensitive.
@@ -24,8 +28,22 @@ func (sc *SensitivityChecker) checkSensitiveExpr(
 	vars map[*types.Var]SensitiveSource,
 	funcs map[types.Object]SensitiveSource,
 ) *SensitiveSource {
+	// Authorization headers, JWTs and unwrapped secrets:
+	// r.Header.Get("Authorization"), account.Password.Expose()
+	if source := builtinSource(expr, sc.pass.TypesInfo); source != nil {
+		return source
+	}
+	// Flags and arguments declared sensitive: *dbPassword, os.Args[2]
+	if source := sc.commandLine.Source(expr, sc.pass.TypesInfo); source != nil {
+		return source
+	}
+
 	switch e := expr.(type) {
 	case *ast.SelectorExpr:
+		// Package-level variable of another package: secrets.Token
+		if ident := varRef(e, sc.pass.TypesInfo); ident != nil {
+			return sc.checkSensitiveExpr(ident, vars, funcs)
+		}
 		// Direct field access: user.Password
 		return sc.checkSensitiveFieldAccess(e)
 
@@ -39,13 +57,79 @@ func (sc *SensitivityChecker) checkSensitiveExpr(
 			}
 		}
 
+	case *ast.ParenExpr:
+		return sc.checkSensitiveExpr(e.X, vars, funcs)
+
+	case *ast.TypeAssertExpr:
+		// Type assertion: v.(string) carries the taint of v
+		return sc.checkSensitiveExpr(e.X, vars, funcs)
+
+	case *ast.StarExpr:
+		// Dereference: *p carries the taint of p
+		return sc.checkSensitiveExpr(e.X, vars, funcs)
+
+	case *ast.SliceExpr:
+		// Slicing: attrs[:n] carries the taint of attrs
+		return sc.checkSensitiveExpr(e.X, vars, funcs)
+
 	case *ast.CallExpr:
+		// Sanitizer: redactor.Mask(user.Password)
+		if sc.sanitizers.IsSanitizer(e, sc.pass.TypesInfo) {
+			return nil
+		}
+		// Conversion: string(b), any(user.Password)
+		if isConversion(e, sc.pass.TypesInfo) {
+			return sc.checkSensitiveExpr(e.Args[0], vars, funcs)
+		}
+		// Substitution: os.Expand(s, mapping) yields what mapping returns
+		if mapping := expandMapping(e, sc.pass.TypesInfo); mapping != nil {
+			return sc.mappingSource(mapping, e.Pos(), vars, funcs)
+		}
+		// Assembly: fmt.Sprintf("postgres://%s:%s@db", user, pass), u.String()
+		if source := sc.carriedSource(e, vars, funcs); source != nil {
+			return source
+		}
 		// Function call: getPassword(user)
 		if funObj := sc.getFunctionObject(e.Fun); funObj != nil {
 			if source, found := funcs[funObj]; found {
 				return &source
 			}
 		}
+		eval := func(v ast.Expr) *SensitiveSource {
+			return sc.checkSensitiveExpr(v, vars, funcs)
+		}
+		// Attr constructor: slog.String("token", cfg.Token), slog.Group(...)
+		if source := slogAttrSource(e, sc.pass.TypesInfo, eval); source != nil {
+			return source
+		}
+		// Field constructor: zap.String("token", cfg.Token)
+		return zapFieldSource(e, sc.pass.TypesInfo, eval)
+
+	case *ast.CompositeLit:
+		eval := func(v ast.Expr) *SensitiveSource {
+			return sc.checkSensitiveExpr(v, vars, funcs)
+		}
+		// Attr literal: slog.Attr{Key: "token", Value: slog.StringValue(cfg.Token)}
+		if source := slogAttrSource(e, sc.pass.TypesInfo, eval); source != nil {
+			return source
+		}
+		// Any other literal: telemetry.Config{Token: secrets.APIKey}
+		return compositeLitSource(e, sc.pass.TypesInfo, eval)
+
+	case *ast.UnaryExpr:
+		// Address-of: &telemetry.Config{Token: secrets.APIKey}
+		if e.Op == token.AND {
+			return sc.checkSensitiveExpr(e.X, vars, funcs)
+		}
+
+	case *ast.BinaryExpr:
+		// Concatenation: "postgres://" + user + ":" + pass + "@db"
+		if isStringConcat(e, sc.pass.TypesInfo) {
+			if source := sc.checkSensitiveExpr(e.X, vars, funcs); source != nil {
+				return source
+			}
+			return sc.checkSensitiveExpr(e.Y, vars, funcs)
+		}
 	}
 
 	return nil
@@ -68,6 +152,7 @@ func (sc *SensitivityChecker) checkSensitiveFieldAccess(sel *ast.SelectorExpr) *
 	if !ok {
 		return nil
 	}
+	named = fieldOwner(sel, sc.pass.TypesInfo, named)
 
 	obj := named.Obj()
 	if obj == nil {
@@ -86,24 +171,170 @@ func (sc *SensitivityChecker) checkSensitiveFieldAccess(sel *ast.SelectorExpr) *
 	if sc.sensitiveFields[sf] {
 		return &SensitiveSource{
 			FieldName: fmt.Sprintf("%s.%s", typeName, fieldName),
+			FieldPos:  selectedFieldPos(sel, sc.pass.TypesInfo),
 			Position:  sel.Pos(),
-			FlowPath:  []string{fmt.Sprintf("%s.%s", typeName, fieldName)},
+			FlowPath:  []FlowStep{{Label: fmt.Sprintf("%s.%s", typeName, fieldName), Pos: sel.Pos()}},
+			Level:     fieldLevel(named, fieldName),
+			PII:       !checkSensitiveFieldFromTypeInfo(nil, named, fieldName),
+		}
+	}
+
+	return nil
+}
+
+// varRef returns the identifier naming the variable expr refers to, for a
+// plain identifier (token) or a qualified one (secrets.Token), or nil when
+// expr is not a variable reference.
+func varRef(expr ast.Expr, info *types.Info) *ast.Ident {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			if _, ok := info.Uses[x].(*types.PkgName); ok {
+				return e.Sel
+			}
 		}
 	}
+	return nil
+}
+
+// isConversion reports whether call is a type conversion such as string(b).
+func isConversion(call *ast.CallExpr, info *types.Info) bool {
+	if info == nil || len(call.Args) != 1 {
+		return false
+	}
+	tv, ok := info.Types[call.Fun]
+	return ok && tv.IsType()
+}
 
+// compositeLitSource reports the sensitive source of the first element of lit
+// that eval considers sensitive, so a literal such as
+// telemetry.Config{Token: secrets.APIKey} carries the taint of its fields.
+// Struct field keys are names, not values, and are skipped; map keys are
+// evaluated like values.
+func compositeLitSource(lit *ast.CompositeLit, info *types.Info, eval func(ast.Expr) *SensitiveSource) *SensitiveSource {
+	typeName := "composite"
+	isMap := false
+	if info != nil {
+		if tv, ok := info.Types[lit]; ok && tv.Type != nil {
+			if named, ok := tv.Type.(*types.Named); ok && named.Obj() != nil {
+				typeName = named.Obj().Name()
+			}
+			_, isMap = tv.Type.Underlying().(*types.Map)
+		}
+	}
+	for _, elt := range compositeLitValues(lit, isMap) {
+		source := eval(elt.value)
+		if source == nil {
+			continue
+		}
+		label := typeName + "{}"
+		if elt.field != "" {
+			label = fmt.Sprintf("%s{%s}", typeName, elt.field)
+		}
+		withStep := source.withStep(label, elt.value.Pos())
+		return &withStep
+	}
 	return nil
 }
 
+// compositeLitElt is a value-bearing expression inside a composite literal,
+// along with the struct field it initialises (empty for positional elements,
+// slices, arrays and maps).
+type compositeLitElt struct {
+	field string
+	value ast.Expr
+}
+
+// compositeLitValues flattens the elements of lit into the expressions whose
+// data ends up in the composite value.
+func compositeLitValues(lit *ast.CompositeLit, isMap bool) []compositeLitElt {
+	var elts []compositeLitElt
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			elts = append(elts, compositeLitElt{value: elt})
+			continue
+		}
+		if isMap {
+			elts = append(elts, compositeLitElt{value: kv.Key}, compositeLitElt{value: kv.Value})
+			continue
+		}
+		field := ""
+		if key, ok := kv.Key.(*ast.Ident); ok {
+			field = key.Name
+		}
+		elts = append(elts, compositeLitElt{field: field, value: kv.Value})
+	}
+	return elts
+}
+
+// selectedFieldPos returns the declaration position of the field selected by
+// sel, or token.NoPos when sel is not a field selection.
+func selectedFieldPos(sel *ast.SelectorExpr, info *types.Info) token.Pos {
+	if info == nil {
+		return token.NoPos
+	}
+	if selection, ok := info.Selections[sel]; ok && selection.Kind() == types.FieldVal {
+		return selection.Obj().Pos()
+	}
+	return token.NoPos
+}
+
+// fieldOwner returns the named struct type that declares the field selected
+// by sel. For a field promoted through embedding (w.Secret where Secret is
+// declared on an embedded Credentials) that is the embedded type rather than
+// the type of sel.X, which is returned unchanged for direct selections.
+func fieldOwner(sel *ast.SelectorExpr, info *types.Info, named *types.Named) *types.Named {
+	if info == nil {
+		return named
+	}
+	selection, ok := info.Selections[sel]
+	if !ok || selection.Kind() != types.FieldVal || len(selection.Index()) < 2 {
+		return named
+	}
+	owner := named
+	for _, idx := range selection.Index()[:len(selection.Index())-1] {
+		st, ok := owner.Underlying().(*types.Struct)
+		if !ok || idx >= st.NumFields() {
+			return named
+		}
+		typ := st.Field(idx).Type()
+		if ptr, ok := typ.(*types.Pointer); ok {
+			typ = ptr.Elem()
+		}
+		next, ok := typ.(*types.Named)
+		if !ok {
+			return named
+		}
+		owner = next
+	}
+	return owner
+}
+
+// calleeOrigin maps a method of an instantiated generic type (Box[int].Reveal)
+// to its generic declaration, the object that function definitions and
+// sensitive return facts are keyed by.
+func calleeOrigin(obj types.Object) types.Object {
+	if fn, ok := obj.(*types.Func); ok {
+		return fn.Origin()
+	}
+	return obj
+}
+
 // getFunctionObject gets the function object from a call expression
 func (sc *SensitivityChecker) getFunctionObject(fun ast.Expr) types.Object {
 	switch f := fun.(type) {
 	case *ast.Ident:
 		if obj := sc.pass.TypesInfo.Uses[f]; obj != nil {
-			return obj
+			return calleeOrigin(obj)
 		}
 	case *ast.SelectorExpr:
+		// Promoted methods (w.GetSecret() with GetSecret declared on an
+		// embedded type) resolve to the embedded type's method object.
 		if obj := sc.pass.TypesInfo.Uses[f.Sel]; obj != nil {
-			return obj
+			return calleeOrigin(obj)
 		}
 	}
 	return nil
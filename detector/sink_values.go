@@ -0,0 +1,167 @@
+package detector
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// RecordSinkValues finds the variables, parameters and struct fields that
+// hold a sink function value, so calls through them are treated as log calls:
+//
+//	logFn := slog.Info
+//	logFn("login", "password", u.Password) // sink
+//
+//	func run(logf func(string, ...any)) { logf("%s", secret) }
+//	run(log.Printf) // run's logf parameter holds a sink
+//
+// Method values such as f := logger.Info are recorded the same way. It must
+// run before log calls are collected. Values copied from other
+// sink-holding variables are followed until nothing changes.
+func (ld *LogDetector) RecordSinkValues(files []*ast.File, info *types.Info) {
+	if info == nil {
+		return
+	}
+	for changed := true; changed; {
+		changed = false
+		record := func(v *types.Var, value ast.Expr) {
+			if v == nil || ld.sinkValues[v] != nil {
+				return
+			}
+			if fn := ld.sinkValueOrigin(value, info); fn != nil {
+				ld.sinkValues[v] = fn
+				changed = true
+			}
+		}
+
+		for _, file := range files {
+			ast.Inspect(file, func(n ast.Node) bool {
+				switch node := n.(type) {
+				case *ast.AssignStmt:
+					if len(node.Lhs) != len(node.Rhs) {
+						return true
+					}
+					for i, lhs := range node.Lhs {
+						record(assignedVar(lhs, info), node.Rhs[i])
+					}
+
+				case *ast.ValueSpec:
+					if len(node.Names) != len(node.Values) {
+						return true
+					}
+					for i, name := range node.Names {
+						v, _ := info.Defs[name].(*types.Var)
+						record(v, node.Values[i])
+					}
+
+				case *ast.CompositeLit:
+					// Struct literals: Server{logf: log.Printf}
+					for _, elt := range node.Elts {
+						kv, ok := elt.(*ast.KeyValueExpr)
+						if !ok {
+							continue
+						}
+						if key, ok := kv.Key.(*ast.Ident); ok {
+							v, _ := info.Uses[key].(*types.Var)
+							record(v, kv.Value)
+						}
+					}
+
+				case *ast.CallExpr:
+					// Arguments bound to parameters of a statically known callee
+					fn, ok := resolveCallee(node.Fun, info).(*types.Func)
+					if !ok {
+						return true
+					}
+					params := fn.Type().(*types.Signature).Params()
+					for i, arg := range node.Args {
+						if i < params.Len() {
+							record(params.At(i), arg)
+						}
+					}
+				}
+				return true
+			})
+		}
+	}
+}
+
+// assignedVar returns the variable or struct field assigned by lhs
+func assignedVar(lhs ast.Expr, info *types.Info) *types.Var {
+	switch e := ast.Unparen(lhs).(type) {
+	case *ast.Ident:
+		if v, ok := info.Defs[e].(*types.Var); ok {
+			return v
+		}
+		v, _ := info.Uses[e].(*types.Var)
+		return v
+	case *ast.SelectorExpr:
+		v, _ := info.Uses[e.Sel].(*types.Var)
+		return v
+	}
+	return nil
+}
+
+// sinkValueOrigin returns the sink function that expr evaluates to when
+// expr is used as a function value — a reference to a sink such as
+// slog.Info, or a variable, parameter or field recorded by
+// RecordSinkValues — and nil otherwise.
+func (ld *LogDetector) sinkValueOrigin(expr ast.Expr, info *types.Info) *types.Func {
+	if fn := ld.heldSink(expr, info); fn != nil {
+		return fn
+	}
+	var id *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		if selection, ok := info.Selections[e]; ok {
+			// Method values bind the receiver: f := logger.Info. Method
+			// expressions take it as the first argument: (*slog.Logger).Info.
+			if selection.Kind() == types.FieldVal {
+				return nil
+			}
+			if fn, ok := selection.Obj().(*types.Func); ok && ld.isSinkFunc(fn) {
+				return fn
+			}
+			return nil
+		}
+		id = e.Sel
+	default:
+		return nil
+	}
+	if fn, ok := info.Uses[id].(*types.Func); ok && ld.isSinkFunc(fn) {
+		return fn
+	}
+	return nil
+}
+
+// heldSink returns the sink function held by the variable, parameter or
+// struct field that expr refers to, or nil
+func (ld *LogDetector) heldSink(expr ast.Expr, info *types.Info) *types.Func {
+	var id *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		id = e
+	case *ast.SelectorExpr:
+		id = e.Sel
+	default:
+		return nil
+	}
+	if v, ok := info.Uses[id].(*types.Var); ok {
+		return ld.sinkValues[v]
+	}
+	return nil
+}
+
+// SinkName is like the package-level SinkName, but also names the sink
+// behind a call through a function value, e.g. "log/slog.Info" for
+// logFn(...) after logFn := slog.Info.
+func (ld *LogDetector) SinkName(call *ast.CallExpr, info *types.Info) string {
+	if name := SinkName(call, info); name != "" {
+		return name
+	}
+	if fn := ld.heldSink(call.Fun, info); fn != nil {
+		return fn.FullName()
+	}
+	return ""
+}
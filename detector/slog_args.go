@@ -0,0 +1,134 @@
+package detector
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// slogArgRole is the part an argument plays in a log/slog output call
+type slogArgRole int
+
+const (
+	slogArgOther   slogArgRole = iota // The receiver of a method expression
+	slogArgContext                    // The context.Context, which is not written
+	slogArgLevel                      // The slog.Level, which is not written
+	slogArgMessage                    // The record message
+	slogArgKey                        // Key of a key-value pair
+	slogArgValue                      // Value of a key-value pair, or a lone value logged under !BADKEY
+	slogArgAttr                       // A slog.Attr, or a []slog.Attr spread into LogAttrs
+)
+
+// slogLayout is the positional layout of the arguments of a log/slog output
+// function or *slog.Logger method. Indexes are -1 for arguments the function
+// does not take. The key-value or Attr arguments follow the message.
+type slogLayout struct {
+	context int  // context.Context
+	level   int  // slog.Level
+	message int  // The record message; With has none and starts with its key-value arguments
+	attrs   bool // The arguments after the message are slog.Attrs rather than key-value pairs
+}
+
+// slogLayouts maps log/slog output functions and *slog.Logger methods, which
+// share their names, to their argument layouts:
+//
+//	Info(msg, args...)
+//	InfoContext(ctx, msg, args...)
+//	Log(ctx, level, msg, args...)
+//	LogAttrs(ctx, level, msg, attrs...)
+var slogLayouts = map[string]slogLayout{
+	"Debug":        {context: -1, level: -1, message: 0},
+	"Info":         {context: -1, level: -1, message: 0},
+	"Warn":         {context: -1, level: -1, message: 0},
+	"Error":        {context: -1, level: -1, message: 0},
+	"DebugContext": {context: 0, level: -1, message: 1},
+	"InfoContext":  {context: 0, level: -1, message: 1},
+	"WarnContext":  {context: 0, level: -1, message: 1},
+	"ErrorContext": {context: 0, level: -1, message: 1},
+	"Log":          {context: 0, level: 1, message: 2},
+	"LogAttrs":     {context: 0, level: 1, message: 2, attrs: true},
+	"With":         {context: -1, level: -1, message: -1},
+}
+
+// slogArgRoles returns the role of each argument of a log/slog output call
+// or With call, following its layout (see slogLayouts) and how slog itself
+// pairs the arguments: a string is a key for the argument after it, a
+// slog.Attr stands alone, and anything else is a value logged under the key
+// !BADKEY. A spread argument (args...) is treated as a value, and as Attrs
+// for LogAttrs. Returns nil when call is not such a call.
+func (ld *LogDetector) slogArgRoles(call *ast.CallExpr, info *types.Info) []slogArgRole {
+	fn := ld.heldSink(call.Fun, info)
+	if fn == nil {
+		fn, _ = resolveCallee(call.Fun, info).(*types.Func)
+	}
+	if fn == nil || fn.Pkg() == nil || fn.Pkg().Path() != "log/slog" {
+		return nil
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || (sig.Recv() != nil && !isSlogLoggerType(sig.Recv().Type())) {
+		return nil
+	}
+	layout, ok := slogLayouts[fn.Name()]
+	if !ok {
+		return nil
+	}
+
+	// A method expression takes the receiver as its first argument
+	offset := 0
+	if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && sig.Recv() != nil {
+		if selection, ok := info.Selections[sel]; ok && selection.Kind() == types.MethodExpr {
+			offset = 1
+		}
+	}
+
+	roles := make([]slogArgRole, len(call.Args))
+	for _, arg := range []struct {
+		index int
+		role  slogArgRole
+	}{
+		{layout.context, slogArgContext},
+		{layout.level, slogArgLevel},
+		{layout.message, slogArgMessage},
+	} {
+		if arg.index >= 0 && offset+arg.index < len(roles) {
+			roles[offset+arg.index] = arg.role
+		}
+	}
+	first := offset + layout.message + 1
+
+	for i := first; i < len(call.Args); i++ {
+		if layout.attrs {
+			roles[i] = slogArgAttr
+			continue
+		}
+		if call.Ellipsis.IsValid() && i == len(call.Args)-1 {
+			roles[i] = slogArgValue
+			break
+		}
+		t := info.TypeOf(call.Args[i])
+		switch {
+		case t != nil && isSlogNamedType(t, "Attr"):
+			roles[i] = slogArgAttr
+		case t != nil && isStringType(t) && i+1 < len(call.Args):
+			roles[i] = slogArgKey
+			roles[i+1] = slogArgValue
+			i++
+		default:
+			roles[i] = slogArgValue
+		}
+	}
+	return roles
+}
+
+// isStringType reports whether t is a string type, including untyped string
+// constants
+func isStringType(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+	return ok && basic.Info()&types.IsString != 0
+}
+
+// isConstantArg reports whether expr is a compile-time constant, such as a
+// key or a literal masked value ("***"), which cannot carry field data
+func isConstantArg(expr ast.Expr, info *types.Info) bool {
+	tv, ok := info.Types[expr]
+	return ok && tv.Value != nil
+}
@@ -0,0 +1,78 @@
+package detector
+
+import (
+	"go/ast"
+	"slices"
+	"testing"
+)
+
+func TestSlogArgRoles(t *testing.T) {
+	t.Parallel()
+
+	const src = `package test
+
+import (
+	"context"
+	"log/slog"
+)
+
+func calls(ctx context.Context, l *slog.Logger, v string, n int, args []any, attrs []slog.Attr, level slog.Level) {
+	slog.Info("msg", "key", v, slog.String("k", v), n)
+	slog.Info("msg", "key")
+	slog.InfoContext(ctx, "msg", "key", v)
+	l.Log(ctx, slog.LevelInfo, "msg", "key", v)
+	l.LogAttrs(ctx, slog.LevelInfo, "msg", slog.Int("n", n))
+	l.With("key", v).Info("msg")
+	(*slog.Logger).Warn(l, "msg", "key", v)
+	slog.Error("msg", args...)
+	slog.String("key", v)
+	slog.Log(ctx, level, "msg", "key", v, n)
+	l.LogAttrs(ctx, level, "msg", attrs...)
+	(*slog.Logger).LogAttrs(l, ctx, level, "msg", slog.Int("n", n))
+}
+`
+	_, file, info := typeCheckSource(t, src)
+	var calls []*ast.CallExpr
+	for _, stmt := range findFuncDecl(t, file, "calls").Body.List {
+		calls = append(calls, stmt.(*ast.ExprStmt).X.(*ast.CallExpr))
+	}
+	ld := NewLogDetector(nil)
+
+	const (
+		O = slogArgOther
+		C = slogArgContext
+		L = slogArgLevel
+		M = slogArgMessage
+		K = slogArgKey
+		V = slogArgValue
+		A = slogArgAttr
+	)
+	tests := []struct {
+		name string
+		call *ast.CallExpr
+		want []slogArgRole
+	}{
+		{"pairs, attrs and lone values", calls[0], []slogArgRole{M, K, V, A, V}},
+		{"dangling key is a value", calls[1], []slogArgRole{M, V}},
+		{"context variant", calls[2], []slogArgRole{C, M, K, V}},
+		{"Log", calls[3], []slogArgRole{C, L, M, K, V}},
+		{"LogAttrs", calls[4], []slogArgRole{C, L, M, A}},
+		{"With", calls[5].Fun.(*ast.SelectorExpr).X.(*ast.CallExpr), []slogArgRole{K, V}},
+		{"method expression", calls[6], []slogArgRole{O, M, K, V}},
+		{"spread arguments", calls[7], []slogArgRole{M, V}},
+		{"attr constructor is not an output call", calls[8], nil},
+		{"package-level Log", calls[9], []slogArgRole{C, L, M, K, V, V}},
+		{"spread Attr slice", calls[10], []slogArgRole{C, L, M, A}},
+		{"LogAttrs method expression", calls[11], []slogArgRole{O, C, L, M, A}},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ld.slogArgRoles(tt.call, info); !slices.Equal(got, tt.want) {
+				t.Errorf("slogArgRoles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
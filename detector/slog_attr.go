@@ -0,0 +1,124 @@
+package detector
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// slogAttrValueArgIndex maps log/slog attribute and value constructors to the
+// index of their first value argument. Arguments before the index are keys;
+// every argument from the index on contributes to the resulting Attr/Value
+// (Group and GroupValue take a variadic list of attrs or key-value pairs).
+var slogAttrValueArgIndex = map[string]int{
+	"String":        1,
+	"Int":           1,
+	"Int64":         1,
+	"Uint64":        1,
+	"Float64":       1,
+	"Bool":          1,
+	"Time":          1,
+	"Duration":      1,
+	"Any":           1,
+	"Group":         1,
+	"StringValue":   0,
+	"IntValue":      0,
+	"Int64Value":    0,
+	"Uint64Value":   0,
+	"Float64Value":  0,
+	"BoolValue":     0,
+	"TimeValue":     0,
+	"DurationValue": 0,
+	"AnyValue":      0,
+	"GroupValue":    0,
+}
+
+// slogAttrValueArgs returns the value arguments of a log/slog Attr or Value
+// constructor call (slog.String, slog.Group, slog.AnyValue, ...), skipping the
+// key. The second result is false when call is not such a constructor.
+func slogAttrValueArgs(call *ast.CallExpr, info *types.Info) ([]ast.Expr, bool) {
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "log/slog" {
+		return nil, false
+	}
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return nil, false
+	}
+	idx, ok := slogAttrValueArgIndex[fn.Name()]
+	if !ok {
+		return nil, false
+	}
+	if idx >= len(call.Args) {
+		return nil, true
+	}
+	return call.Args[idx:], true
+}
+
+// slogAttrLitValue returns the Value expression of a slog.Attr composite
+// literal (slog.Attr{Key: "k", Value: v} or slog.Attr{"k", v}). The second
+// result is false when lit is not a slog.Attr literal.
+func slogAttrLitValue(lit *ast.CompositeLit, info *types.Info) (ast.Expr, bool) {
+	if info == nil {
+		return nil, false
+	}
+	tv, ok := info.Types[lit]
+	if !ok || !isSlogNamedType(tv.Type, "Attr") {
+		return nil, false
+	}
+	for i, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Value" {
+				return kv.Value, true
+			}
+			continue
+		}
+		if i == 1 {
+			return elt, true
+		}
+	}
+	return nil, true
+}
+
+// isSlogNamedType reports whether t is the named log/slog type with the given name.
+func isSlogNamedType(t types.Type, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj != nil && obj.Name() == name && obj.Pkg() != nil && obj.Pkg().Path() == "log/slog"
+}
+
+// slogAttrSource reports the sensitive source carried by a log/slog Attr or
+// Value built from expr, evaluating each value argument (or the Value element
+// of a slog.Attr literal) with eval. Nested slog.Group trees are handled by
+// eval recursing back into this function. Returns nil when expr is not an
+// attr constructor or none of its values are sensitive.
+func slogAttrSource(expr ast.Expr, info *types.Info, eval func(ast.Expr) *SensitiveSource) *SensitiveSource {
+	var values []ast.Expr
+	var label string
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		args, ok := slogAttrValueArgs(e, info)
+		if !ok {
+			return nil
+		}
+		values = args
+		label = "slog." + resolveCallee(e.Fun, info).Name()
+	case *ast.CompositeLit:
+		value, ok := slogAttrLitValue(e, info)
+		if !ok || value == nil {
+			return nil
+		}
+		values = []ast.Expr{value}
+		label = "slog.Attr"
+	default:
+		return nil
+	}
+	for _, v := range values {
+		if source := eval(v); source != nil {
+			withStep := source.withStep(label, expr.Pos())
+			return &withStep
+		}
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+)
+
+// StringerFindings returns the findings (LH0014) for String, GoString,
+// Format and MarshalText methods that render sensitive data: fmt calls the
+// first three whenever a value of the type is printed with %v, %s or %+v, and
+// slog's handlers call MarshalText, so every log of the type leaks the data.
+//
+//	func (u User) String() string { return "user " + u.Name + ":" + u.Password }
+//
+// The results of String, GoString and MarshalText are checked, and the values
+// Format writes to its fmt.State, as in io.WriteString(s, u.Password).
+func (c *DataFlowCollector) StringerFindings() []Finding {
+	var findings []Finding
+	for _, file := range c.pass.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || fn.Body == nil {
+				continue
+			}
+			method, ok := c.pass.TypesInfo.Defs[fn.Name].(*types.Func)
+			if !ok || !isFormatterMethod(method) {
+				continue
+			}
+			for _, expr := range c.formattedExprs(fn, method) {
+				argFindings := c.detector.CheckArgForSensitiveData(expr)
+				asStringerFindings(argFindings, method)
+				findings = append(findings, argFindings...)
+			}
+		}
+	}
+	return findings
+}
+
+// formattedExprs returns the expressions fn renders: the results of a String,
+// GoString or MarshalText method, or the arguments of the calls writing to the fmt.State
+// of a Format method other than sinks, whose arguments are checked with
+// every other sink call. Function literals are skipped, as their results are
+// not the method's.
+func (c *DataFlowCollector) formattedExprs(fn *ast.FuncDecl, method *types.Func) []ast.Expr {
+	var state types.Object
+	if method.Name() == "Format" {
+		if names := fn.Type.Params.List[0].Names; len(names) > 0 {
+			state = c.pass.TypesInfo.Defs[names[0]]
+		}
+		if state == nil {
+			return nil // The state is unnamed, so nothing is written to it
+		}
+	}
+
+	var exprs []ast.Expr
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.ReturnStmt:
+			if state == nil {
+				exprs = append(exprs, n.Results...)
+			}
+		case *ast.CallExpr:
+			if state == nil || !c.writesTo(n, state) || c.logDetector.IsLogCallWithInfo(n, c.pass.TypesInfo) {
+				return true // Sink calls such as fmt.Fprintf are reported as such
+			}
+			for _, arg := range n.Args {
+				if !c.refersTo(arg, state) {
+					exprs = append(exprs, arg)
+				}
+			}
+		}
+		return true
+	})
+	return exprs
+}
+
+// writesTo reports whether call passes state to a function, as in
+// fmt.Fprintf(s, ...), or calls a method of state, as in s.Write(...)
+func (c *DataFlowCollector) writesTo(call *ast.CallExpr, state types.Object) bool {
+	if sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr); ok && c.refersTo(sel.X, state) {
+		return true
+	}
+	for _, arg := range call.Args {
+		if c.refersTo(arg, state) {
+			return true
+		}
+	}
+	return false
+}
+
+// refersTo reports whether expr is an identifier for obj
+func (c *DataFlowCollector) refersTo(expr ast.Expr, obj types.Object) bool {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	return ok && c.pass.TypesInfo.Uses[ident] == obj
+}
+
+// isFormatterMethod reports whether method implements fmt.Stringer,
+// fmt.GoStringer, fmt.Formatter or encoding.TextMarshaler
+func isFormatterMethod(method *types.Func) bool {
+	sig, ok := method.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return false
+	}
+	switch method.Name() {
+	case "String", "GoString":
+		return sig.Params().Len() == 0 && sig.Results().Len() == 1 &&
+			types.Identical(sig.Results().At(0).Type(), types.Typ[types.String])
+	case "Format":
+		if sig.Params().Len() != 2 || sig.Results().Len() != 0 {
+			return false
+		}
+		named, ok := types.Unalias(sig.Params().At(0).Type()).(*types.Named)
+		return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "fmt" &&
+			named.Obj().Name() == "State" &&
+			types.Identical(sig.Params().At(1).Type(), types.Typ[types.Rune])
+	case "MarshalText":
+		return sig.Params().Len() == 0 && sig.Results().Len() == 2 &&
+			types.Identical(sig.Results().At(0).Type(), types.NewSlice(types.Typ[types.Byte])) &&
+			types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+	}
+	return false
+}
+
+// asStringerFindings reports findings for the data rendered by method under
+// LH0014, naming the method and its type
+func asStringerFindings(findings []Finding, method *types.Func) {
+	recv := method.Type().(*types.Signature).Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	typeName := types.TypeString(recv, func(*types.Package) string { return "" })
+	verb := "returns"
+	if method.Name() == "Format" {
+		verb = "writes"
+	}
+	for i := range findings {
+		what := findings[i].Expr
+		if findings[i].Field != "" {
+			what = findings[i].Field
+		}
+		findings[i].RuleID = RuleIDSensitiveStringer
+		findings[i].Message = fmt.Sprintf(
+			"%s method of '%s' %s sensitive data from '%s', so every log of the type leaks it",
+			method.Name(), typeName, verb, what)
+		findings[i].Func = method.FullName()
+		findings[i].Fixes = nil // redact.New does not return a string
+		if findings[i].Severity == "" {
+			findings[i].Severity = SeverityError
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package detector
+
+import (
+	"go/types"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+)
+
+// SetStructScope sets the struct-rule-scope: which structs with sensitive
+// fields are reported when logged as a whole (LH0003)
+func (d *Detector) SetStructScope(scope string) {
+	d.structScope = scope
+}
+
+// inStructScope reports whether a whole-struct finding for named is within
+// the struct-rule-scope. Without module information from the driver, module
+// scope takes every package outside the standard library for the module's.
+func (d *Detector) inStructScope(named *types.Named) bool {
+	pkg := named.Obj().Pkg()
+	if pkg == nil || d.pass.Pkg == nil || pkg.Path() == d.pass.Pkg.Path() {
+		return true
+	}
+	switch d.structScope {
+	case config.StructScopeLocal:
+		return false
+	case config.StructScopeModule:
+		if d.pass.Module == nil {
+			return !IsStdlibPackagePath(pkg.Path())
+		}
+		return inModule(pkg.Path(), d.pass.Module.Path)
+	}
+	return true
+}
+
+// inModule reports whether the package pkgPath belongs to the module
+// modulePath, by its import path
+func inModule(pkgPath, modulePath string) bool {
+	return pkgPath == modulePath || strings.HasPrefix(pkgPath, modulePath+"/")
+}
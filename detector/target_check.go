@@ -0,0 +1,170 @@
+package detector
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+	"golang.org/x/tools/go/packages"
+)
+
+// TargetReport describes how one configured target resolves against the
+// loaded packages
+type TargetReport struct {
+	Target     config.TargetConfig
+	Package    *types.Package // Resolved package (one match of a glob), nil if no loaded package has the path
+	ImportedBy []string       // Analyzed packages importing the target package, sorted
+	Candidates []string       // When Package is nil, loaded packages whose path ends in the target path
+	Entries    []TargetEntryReport
+}
+
+// TargetEntryReport describes a single configured function or method
+type TargetEntryReport struct {
+	Entry    string      // Formatted like TargetEntries
+	Declared bool        // The function or method exists in the resolved package
+	Calls    []token.Pos // Call sites treated as sinks, in source order
+}
+
+// ExplainTargets resolves each target in cfg against pkgs without running
+// detection: the package it names, which of pkgs import it, whether each
+// configured function and method is declared, and the call sites that would
+// be treated as sinks.
+func ExplainTargets(cfg *config.Config, pkgs []*packages.Package, fset *token.FileSet) []TargetReport {
+	if cfg == nil || len(cfg.Targets) == 0 {
+		return nil
+	}
+
+	ld := NewLogDetectorWithConfig(nil, cfg)
+	calls := make(map[string][]token.Pos)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			ast.Inspect(file, func(n ast.Node) bool {
+				if call, ok := n.(*ast.CallExpr); ok {
+					if entry := ld.CustomTarget(call, pkg.TypesInfo); entry != "" {
+						calls[entry] = append(calls[entry], call.Pos())
+					}
+				}
+				return true
+			})
+		}
+	}
+	for _, positions := range calls {
+		sort.Slice(positions, func(i, j int) bool {
+			pi, pj := fset.Position(positions[i]), fset.Position(positions[j])
+			if pi.Filename != pj.Filename {
+				return pi.Filename < pj.Filename
+			}
+			return pi.Offset < pj.Offset
+		})
+	}
+
+	reports := make([]TargetReport, 0, len(cfg.Targets))
+	for _, target := range cfg.Targets {
+		report := TargetReport{Target: target}
+		for _, pkg := range pkgs {
+			if target.MatchesPackage(pkg.PkgPath) && pkg.Types != nil {
+				report.Package = pkg.Types
+			}
+			if imp := matchingImport(pkg, target); imp != nil {
+				report.ImportedBy = append(report.ImportedBy, pkg.PkgPath)
+				if report.Package == nil {
+					report.Package = imp.Types
+				}
+			}
+		}
+		sort.Strings(report.ImportedBy)
+		if report.Package == nil && !config.IsPackageGlob(target.Package) {
+			report.Candidates = candidatePackages(pkgs, target.Package)
+		}
+
+		for _, fn := range target.Functions {
+			entry := targetEntry(target.Package, "", fn)
+			report.Entries = append(report.Entries, TargetEntryReport{
+				Entry:    entry,
+				Declared: declaresFunc(report.Package, fn),
+				Calls:    calls[entry],
+			})
+		}
+		for _, method := range target.Methods {
+			for _, name := range method.Names {
+				entry := targetEntry(target.Package, method.Receiver, name)
+				report.Entries = append(report.Entries, TargetEntryReport{
+					Entry:    entry,
+					Declared: declaresMethod(report.Package, method.Receiver, name),
+					Calls:    calls[entry],
+				})
+			}
+		}
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// matchingImport returns the package imported by pkg that target applies to,
+// the first in import path order when the target's package is a glob, or nil
+func matchingImport(pkg *packages.Package, target config.TargetConfig) *packages.Package {
+	if imp, ok := pkg.Imports[target.Package]; ok {
+		return imp
+	}
+	if !config.IsPackageGlob(target.Package) {
+		return nil
+	}
+	var match *packages.Package
+	for path, imp := range pkg.Imports {
+		if target.MatchesPackage(path) && (match == nil || path < match.PkgPath) {
+			match = imp
+		}
+	}
+	return match
+}
+
+// declaresFunc reports whether pkg declares a package-level function name
+func declaresFunc(pkg *types.Package, name string) bool {
+	if pkg == nil {
+		return false
+	}
+	_, ok := pkg.Scope().Lookup(name).(*types.Func)
+	return ok
+}
+
+// declaresMethod reports whether the named type of receiver ("*Logger",
+// "Logger" or "*Logger[T]") in pkg has a method name in the receiver's method
+// set. A generic receiver must have as many type parameters as the type.
+func declaresMethod(pkg *types.Package, receiver, name string) bool {
+	if pkg == nil {
+		return false
+	}
+	recv, err := config.ParseReceiver(receiver)
+	if err != nil {
+		return false
+	}
+	obj, ok := pkg.Scope().Lookup(recv.Name).(*types.TypeName)
+	if !ok {
+		return false
+	}
+	var t types.Type = obj.Type()
+	if named, ok := t.(*types.Named); recv.Generic() && (!ok || named.TypeParams().Len() != len(recv.TypeParams)) {
+		return false
+	}
+	if recv.Pointer {
+		t = types.NewPointer(t)
+	}
+	sel := types.NewMethodSet(t).Lookup(pkg, name)
+	return sel != nil
+}
+
+// candidatePackages returns the loaded package paths ending in "/"+path, to
+// suggest the full import path for a target configured with a short one
+func candidatePackages(pkgs []*packages.Package, path string) []string {
+	var out []string
+	for _, pkg := range pkgs {
+		if strings.HasSuffix(pkg.PkgPath, "/"+path) {
+			out = append(out, pkg.PkgPath)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,135 @@
+package detector
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"slices"
+)
+
+// templateDataArg returns the index in call.Args of the data executed by a
+// text/template or html/template call writing to a sink writer (see
+// config.TemplateWriters), or -1:
+//
+//	tmpl.Execute(os.Stdout, user)              // data is user
+//	tmpl.ExecuteTemplate(w, "page", session)   // w is an http.ResponseWriter
+//
+// Writers only held in local variables or wrapped by anything but
+// io.MultiWriter are not recognized.
+func (ld *LogDetector) templateDataArg(call *ast.CallExpr, info *types.Info) int {
+	sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return -1
+	}
+	fn, ok := info.Uses[sel.Sel].(*types.Func)
+	if !ok || !isTemplateExecute(fn) {
+		return -1
+	}
+	writers := ld.config.TemplateWriters()
+	if len(writers) == 0 {
+		return -1
+	}
+
+	// Execute(w, data) and ExecuteTemplate(w, name, data); a method
+	// expression takes the template as its first argument
+	w, data := 0, len(call.Args)-1
+	if selection, ok := info.Selections[sel]; ok && selection.Kind() == types.MethodExpr {
+		w = 1
+	}
+	if data <= w || !isSinkWriter(call.Args[w], info, writers) {
+		return -1
+	}
+	return data
+}
+
+// isTemplateExecute reports whether fn is the Execute or ExecuteTemplate
+// method of text/template or html/template
+func isTemplateExecute(fn *types.Func) bool {
+	if fn.Pkg() == nil {
+		return false
+	}
+	if path := fn.Pkg().Path(); path != "text/template" && path != "html/template" {
+		return false
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	return ok && sig.Recv() != nil && (fn.Name() == "Execute" || fn.Name() == "ExecuteTemplate")
+}
+
+// isSinkWriter reports whether expr is one of writers: a reference to a
+// listed package-level variable, a call to a listed function, a value of a
+// listed type, or an io.MultiWriter combining any of them
+func isSinkWriter(expr ast.Expr, info *types.Info, writers []string) bool {
+	expr = ast.Unparen(expr)
+	if call, ok := expr.(*ast.CallExpr); ok {
+		if fn, ok := resolveCallee(call.Fun, info).(*types.Func); ok {
+			if fn.FullName() == "io.MultiWriter" {
+				return slices.ContainsFunc(call.Args, func(arg ast.Expr) bool {
+					return isSinkWriter(arg, info, writers)
+				})
+			}
+			if slices.Contains(writers, fn.FullName()) {
+				return true
+			}
+		}
+	}
+	if ident := varRef(expr, info); ident != nil {
+		if v, ok := info.Uses[ident].(*types.Var); ok && v.Pkg() != nil && v.Parent() == v.Pkg().Scope() &&
+			slices.Contains(writers, v.Pkg().Path()+"."+v.Name()) {
+			return true
+		}
+	}
+
+	typ := info.TypeOf(expr)
+	if ptr, ok := typ.(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return slices.Contains(writers, named.Obj().Pkg().Path()+"."+named.Obj().Name())
+}
+
+// expandMapping returns the mapping function of os.Expand(s, mapping), or
+// nil if call is not a call to os.Expand
+func expandMapping(call *ast.CallExpr, info *types.Info) ast.Expr {
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	if !ok || fn.FullName() != "os.Expand" || len(call.Args) != 2 {
+		return nil
+	}
+	return call.Args[1]
+}
+
+// mappingSource returns the source of the sensitive data substituted by an
+// os.Expand call at pos through mapping — a function literal, function or
+// function value returning sensitive data — or nil
+func (sc *SensitivityChecker) mappingSource(
+	mapping ast.Expr,
+	pos token.Pos,
+	vars map[*types.Var]SensitiveSource,
+	funcs map[types.Object]SensitiveSource,
+) *SensitiveSource {
+	var source *SensitiveSource
+	if lit, ok := ast.Unparen(mapping).(*ast.FuncLit); ok {
+		ast.Inspect(lit.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.FuncLit:
+				return false // Returns of nested literals belong to them
+			case *ast.ReturnStmt:
+				if len(node.Results) == 1 && source == nil {
+					source = sc.checkSensitiveExpr(node.Results[0], vars, funcs)
+				}
+			}
+			return source == nil
+		})
+	} else if obj := sc.getFunctionObject(ast.Unparen(mapping)); obj != nil {
+		if s, found := funcs[obj]; found {
+			source = &s
+		}
+	}
+	if source == nil {
+		return nil
+	}
+	expanded := source.withStep("os.Expand", pos)
+	return &expanded
+}
@@ -0,0 +1,88 @@
+package detector
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// typeCheckedPass restricts pass to the files that type-check. Type errors
+// leave nil objects and missing types in TypesInfo, which make the collectors
+// skip expressions silently, so files with errors are left out as a whole and
+// returned along with a notification listing them. Cgo files that were not
+// built, which typically cause the errors when cgo is disabled, are listed too.
+// bestEffort tells the notification that the files left out are still matched
+// syntactically (see BestEffortFindings). pass itself and no files are
+// returned when the package type-checks.
+func typeCheckedPass(pass *analysis.Pass, bestEffort bool) (*analysis.Pass, []*ast.File, *Notification) {
+	if len(pass.TypeErrors) == 0 {
+		return pass, nil, nil
+	}
+
+	// First type error per file, by file name
+	broken := make(map[string]string)
+	for _, err := range pass.TypeErrors {
+		name := err.Fset.PositionFor(err.Pos, false).Filename
+		if _, ok := broken[name]; !ok && name != "" {
+			broken[name] = err.Msg
+		}
+	}
+
+	var files, brokenFiles []*ast.File
+	var skipped []string
+	for _, file := range pass.Files {
+		name := pass.Fset.PositionFor(file.Pos(), false).Filename
+		if msg, ok := broken[name]; ok {
+			skipped = append(skipped, fmt.Sprintf("%s does not type-check (%s)", filepath.Base(name), msg))
+			brokenFiles = append(brokenFiles, file)
+			continue
+		}
+		files = append(files, file)
+	}
+	if len(brokenFiles) == 0 {
+		return pass, nil, nil
+	}
+	for _, name := range pass.IgnoredFiles {
+		if importsC(name) {
+			skipped = append(skipped, fmt.Sprintf("%s uses cgo and was not built", filepath.Base(name)))
+		}
+	}
+
+	pkgPath := ""
+	if pass.Pkg != nil {
+		pkgPath = pass.Pkg.Path()
+	}
+	format := "package %s: only the files that type-check are analyzed, skipped %s"
+	if bestEffort {
+		format = "package %s: files that do not type-check are only matched syntactically, with best-effort findings: %s"
+	}
+	note := Notification{
+		Level:   SeverityWarning,
+		Package: pkgPath,
+		Message: fmt.Sprintf(format, pkgPath, strings.Join(skipped, "; ")),
+	}
+
+	checked := *pass
+	checked.Files = files
+	return &checked, brokenFiles, &note
+}
+
+// importsC reports whether the Go file at path imports "C", i.e. uses cgo
+func importsC(path string) bool {
+	file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.ImportsOnly)
+	if err != nil {
+		return false
+	}
+	for _, imp := range file.Imports {
+		if p, err := strconv.Unquote(imp.Path.Value); err == nil && p == "C" {
+			return true
+		}
+	}
+	return false
+}
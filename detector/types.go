@@ -20,7 +20,40 @@ type sensitiveReturnKey struct {
 
 // SensitiveSource describes where a sensitive value came from
 type SensitiveSource struct {
-	FieldName string    // Original sensitive field name (e.g., "User.Password")
-	Position  token.Pos // Position where the value was assigned/passed
-	FlowPath  []string  // Data flow path for nested tracking
+	FieldName   string     // Original sensitive field name (e.g., "User.Password")
+	FieldPos    token.Pos  // Declaration position of the sensitive field
+	Position    token.Pos  // Position where the value was assigned/passed
+	FlowPath    []FlowStep // Data flow path for nested tracking
+	Level       Severity   // Level option of the field's sensitive tag, "" for the default
+	PII         bool       // Field holds personal data rather than a secret (PII mode)
+	Credential  bool       // Value is an Authorization header or a JWT (see credentialSource)
+	Exposed     bool       // Value was unwrapped from a redact wrapper (see exposedSource)
+	CommandLine bool       // Value was passed on the command line (see CommandLineSources)
+}
+
+// FlowStep is a single hop on the path a sensitive value takes from its
+// source field to a sink.
+type FlowStep struct {
+	Label string    // e.g. "User.Password", "password", "logValue param val"
+	Pos   token.Pos // Where the hop happens
+}
+
+// severity returns the severity of findings for the source
+func (s SensitiveSource) severity() Severity {
+	return levelOrError(s.Level)
+}
+
+// levelOrError returns level, or SeverityError for the default level ""
+func levelOrError(level Severity) Severity {
+	if level == "" {
+		return SeverityError
+	}
+	return level
+}
+
+// withStep returns a copy of the source with one more hop appended to its
+// flow path. The original FlowPath slice is never shared.
+func (s SensitiveSource) withStep(label string, pos token.Pos) SensitiveSource {
+	s.FlowPath = append(append([]FlowStep{}, s.FlowPath...), FlowStep{Label: label, Pos: pos})
+	return s
 }
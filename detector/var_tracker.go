@@ -36,6 +36,7 @@ func newVarTracker(pass *analysis.Pass, sensitiveFields map[sensitiveField]bool,
 		sensitiveVars    map[*types.Var]SensitiveSource
 		sensitiveFuncs   map[types.Object]SensitiveSource
 		sensitiveFuncPos map[sensitiveReturnKey]SensitiveSource
+		sensitiveYields  map[sensitiveReturnKey]SensitiveSource
 		sensitiveParams  map[*types.Var]SensitiveSource
 		funcDefs         map[types.Object]*ast.FuncDecl
 	)
@@ -43,12 +44,14 @@ func newVarTracker(pass *analysis.Pass, sensitiveFields map[sensitiveField]bool,
 		sensitiveVars = world.sensitiveVars
 		sensitiveFuncs = world.sensitiveFuncs
 		sensitiveFuncPos = world.sensitiveFuncPos
+		sensitiveYields = world.sensitiveYields
 		sensitiveParams = world.sensitiveParams
 		funcDefs = world.funcDefs
 	} else {
 		sensitiveVars = make(map[*types.Var]SensitiveSource)
 		sensitiveFuncs = make(map[types.Object]SensitiveSource)
 		sensitiveFuncPos = make(map[sensitiveReturnKey]SensitiveSource)
+		sensitiveYields = make(map[sensitiveReturnKey]SensitiveSource)
 		sensitiveParams = make(map[*types.Var]SensitiveSource)
 		funcDefs = make(map[types.Object]*ast.FuncDecl)
 	}
@@ -63,6 +66,7 @@ func newVarTracker(pass *analysis.Pass, sensitiveFields map[sensitiveField]bool,
 		sensitiveVars:    sensitiveVars,
 		sensitiveFuncs:   sensitiveFuncs,
 		sensitiveFuncPos: sensitiveFuncPos,
+		sensitiveYields:  sensitiveYields,
 		sensitiveParams:  sensitiveParams,
 		funcDefs:         funcDefs,
 	}
@@ -96,11 +100,56 @@ func (vt *VarTracker) SetCurrentFunction(funcObj types.Object) {
 	vt.facts.SetCurrentFunction(funcObj)
 }
 
+// CollectReceiver delegates to FactCollector
+func (vt *VarTracker) CollectReceiver(funcDecl *ast.FuncDecl) {
+	vt.facts.CollectReceiver(funcDecl)
+}
+
 // CollectAssignment delegates to FactCollector
 func (vt *VarTracker) CollectAssignment(assign *ast.AssignStmt) {
 	vt.facts.CollectAssignment(assign)
 }
 
+// CollectValueSpec delegates to FactCollector
+func (vt *VarTracker) CollectValueSpec(spec *ast.ValueSpec) {
+	vt.facts.CollectValueSpec(spec)
+}
+
+// CollectInitializer delegates to FactCollector
+func (vt *VarTracker) CollectInitializer(init *types.Initializer) {
+	vt.facts.CollectInitializer(init)
+}
+
+// CollectRange delegates to FactCollector
+func (vt *VarTracker) CollectRange(rs *ast.RangeStmt) {
+	vt.facts.CollectRange(rs)
+}
+
+// CollectEventBuilder delegates to FactCollector
+func (vt *VarTracker) CollectEventBuilder(call *ast.CallExpr) {
+	vt.facts.CollectEventBuilder(call)
+}
+
+// CollectFlagBinding delegates to FactCollector
+func (vt *VarTracker) CollectFlagBinding(call *ast.CallExpr) {
+	vt.facts.CollectFlagBinding(call)
+}
+
+// CollectYield delegates to FactCollector
+func (vt *VarTracker) CollectYield(call *ast.CallExpr) {
+	vt.facts.CollectYield(call)
+}
+
+// HasSensitiveYields reports whether an iterator yields sensitive values
+func (vt *VarTracker) HasSensitiveYields() bool {
+	return len(vt.facts.sensitiveYields) > 0
+}
+
+// CollectTypeSwitch delegates to FactCollector
+func (vt *VarTracker) CollectTypeSwitch(ts *ast.TypeSwitchStmt) {
+	vt.facts.CollectTypeSwitch(ts)
+}
+
 // CollectReturn delegates to FactCollector
 func (vt *VarTracker) CollectReturn(ret *ast.ReturnStmt) {
 	vt.facts.CollectReturn(ret)
@@ -111,6 +160,11 @@ func (vt *VarTracker) AnalyzeDataFlow() {
 	vt.analyzer.Analyze()
 }
 
+// FuncDecl returns the collected declaration of a function, or nil
+func (vt *VarTracker) FuncDecl(funcObj types.Object) *ast.FuncDecl {
+	return vt.facts.funcDefs[funcObj]
+}
+
 // IsSensitiveVar checks if a variable is sensitive
 func (vt *VarTracker) IsSensitiveVar(obj types.Object) (SensitiveSource, bool) {
 	if v, ok := obj.(*types.Var); ok {
@@ -122,6 +176,13 @@ func (vt *VarTracker) IsSensitiveVar(obj types.Object) (SensitiveSource, bool) {
 
 // IsSensitiveCall checks if a function call returns sensitive data
 func (vt *VarTracker) IsSensitiveCall(call *ast.CallExpr) (SensitiveSource, bool) {
+	if mapping := expandMapping(call, vt.checker.pass.TypesInfo); mapping != nil {
+		if source := vt.checker.mappingSource(mapping, call.Pos(), vt.sensitiveVars, vt.sensitiveFuncs); source != nil {
+			return *source, true
+		}
+		return SensitiveSource{}, false
+	}
+
 	funObj := vt.checker.getFunctionObject(call.Fun)
 	if funObj == nil {
 		return SensitiveSource{}, false
@@ -95,6 +95,12 @@ func runSinkAnalyzer(pass *analysis.Pass) (interface{}, error) {
 					switch node := inner.(type) {
 					case *ast.AssignStmt:
 						vt.CollectAssignment(node)
+					case *ast.ValueSpec:
+						vt.CollectValueSpec(node)
+					case *ast.RangeStmt:
+						vt.CollectRange(node)
+					case *ast.TypeSwitchStmt:
+						vt.CollectTypeSwitch(node)
 					case *ast.ReturnStmt:
 						vt.CollectReturn(node)
 					}
@@ -797,3 +803,252 @@ func TestVarTracker_GetSensitiveVars_ReturnsMap(t *testing.T) {
 	t.Log("Full testing of GetSensitiveVars is already covered by sinkAnalyzer-based tests (TC-1 to TC-10)")
 	t.Log("VarTracker cannot be initialized without analysis.Pass, so we only verify the type here")
 }
+
+// TC-12: Parallel := assigns each RHS to its own LHS
+func TestVarTracker_ParallelDefine(t *testing.T) {
+	src := fmt.Sprintf(`package vartest
+
+type User struct {
+	Password string %s
+	Name     string
+}
+
+func sink(v string) {}
+
+func test() {
+	u := User{}
+	p, n := u.Password, u.Name
+	sink(p) // want "sensitive var: p from User.Password"
+	sink(n) // not sensitive
+}
+`, sensitiveStructTag())
+
+	dir := writeTempPkg(t, "vartest", src)
+	analysistest.Run(t, dir, sinkAnalyzer, "vartest")
+}
+
+// TC-13: Plain = to an existing variable, and := that redeclares an existing
+// variable alongside a new one, both record taint on the existing object
+func TestVarTracker_AssignToExistingVariable(t *testing.T) {
+	src := fmt.Sprintf(`package vartest
+
+type User struct {
+	Password string %s
+	Name     string
+}
+
+func sink(v string) {}
+
+func getPassword(u User) (string, error) {
+	return u.Password, nil
+}
+
+func test() {
+	u := User{}
+	var x string
+	x = u.Password
+	sink(x) // want "sensitive var: x from User.Password"
+
+	y, err := "safe", error(nil)
+	y, err2 := u.Password, err
+	sink(y) // want "sensitive var: y from User.Password"
+	_ = err2
+
+	var z string
+	z, err = getPassword(u)
+	sink(z) // want "sensitive var: z from User.Password"
+}
+`, sensitiveStructTag())
+
+	dir := writeTempPkg(t, "vartest", src)
+	analysistest.Run(t, dir, sinkAnalyzer, "vartest")
+}
+
+// TC-14: Swaps evaluate every RHS before assigning, so a value that becomes
+// tainted mid-statement does not leak into a later LHS. Taint is never
+// cleared, so the variable swapped a safe value into stays tainted (see
+// FactCollector.CollectAssignment)
+func TestVarTracker_SwapUsesPreAssignmentTaint(t *testing.T) {
+	src := fmt.Sprintf(`package vartest
+
+type User struct {
+	Password string %s
+	Name     string
+}
+
+func sink(v string) {}
+
+func test() {
+	u := User{}
+	a, b := u.Name, u.Password
+	a, c := b, a
+	sink(a) // want "sensitive var: a from User.Password"
+	sink(c) // not sensitive: c receives a's value from before the statement
+	sink(b) // want "sensitive var: b from User.Password"
+}
+
+func swap() {
+	u := User{}
+	x, y := u.Password, "safe"
+	sink(x) // want "sensitive var: x from User.Password"
+	x, y = y, x
+	sink(y) // want "sensitive var: y from User.Password"
+	sink(x) // want "sensitive var: x from User.Password"
+}
+`, sensitiveStructTag())
+
+	dir := writeTempPkg(t, "vartest", src)
+	analysistest.Run(t, dir, sinkAnalyzer, "vartest")
+}
+
+// TC-15: Comma-ok forms taint only the value, never the bool
+func TestVarTracker_CommaOkTuple(t *testing.T) {
+	src := fmt.Sprintf(`package vartest
+
+type User struct {
+	Password string %s
+	Name     string
+}
+
+func sink(v string) {}
+func sinkBool(v bool) {}
+
+func test(u User) {
+	v, ok := any(u.Password).(string)
+	sink(v) // want "sensitive var: v from User.Password"
+	sinkBool(ok)
+}
+`, sensitiveStructTag())
+
+	dir := writeTempPkg(t, "vartest", src)
+	analysistest.Run(t, dir, sinkAnalyzer, "vartest")
+}
+
+// TC-16: An inner := that shadows a tainted variable with a safe value is a
+// distinct object and must not inherit the outer taint, and vice versa
+func TestVarTracker_ShadowingInInitializers(t *testing.T) {
+	src := fmt.Sprintf(`package vartest
+
+type User struct {
+	Password string %s
+	Name     string
+}
+
+func sink(v string) {}
+
+func shadowTaintedWithSafe(u User) {
+	p := u.Password
+	if p := u.Name; p != "" {
+		sink(p) // not sensitive: inner p shadows the tainted outer p
+	}
+	for p := "safe"; p != ""; p = "" {
+		sink(p) // not sensitive
+	}
+	switch p := u.Name; p {
+	default:
+		sink(p) // not sensitive
+	}
+	sink(p) // want "sensitive var: p from User.Password"
+}
+
+func shadowSafeWithTainted(u User) {
+	n := u.Name
+	if n := u.Password; n != "" {
+		sink(n) // want "sensitive var: n from User.Password"
+	}
+	{
+		n := u.Password
+		sink(n) // want "sensitive var: n from User.Password"
+	}
+	sink(n) // not sensitive: outer n was never reassigned
+}
+
+func reassignOuterFromInnerScope(u User) {
+	n := u.Name
+	if u.Name != "" {
+		n = u.Password // = writes the outer n, it does not shadow it
+	}
+	sink(n) // want "sensitive var: n from User.Password"
+}
+`, sensitiveStructTag())
+
+	dir := writeTempPkg(t, "vartest", src)
+	analysistest.Run(t, dir, sinkAnalyzer, "vartest")
+}
+
+// TC-17: var declarations with initializers are tracked like :=, including
+// shadowing declarations in nested blocks
+func TestVarTracker_VarDeclaration(t *testing.T) {
+	src := fmt.Sprintf(`package vartest
+
+type User struct {
+	Password string %s
+	Name     string
+}
+
+func sink(v string) {}
+
+func getPassword(u User) (string, error) {
+	return u.Password, nil
+}
+
+func test(u User) {
+	var p = u.Password
+	sink(p) // want "sensitive var: p from User.Password"
+
+	var a, b = u.Name, u.Password
+	sink(a) // not sensitive
+	sink(b) // want "sensitive var: b from User.Password"
+
+	var v, err = getPassword(u)
+	sink(v) // want "sensitive var: v from User.Password"
+	_ = err
+
+	if u.Name != "" {
+		var p = u.Name
+		sink(p) // not sensitive: shadows the tainted outer p
+	}
+}
+`, sensitiveStructTag())
+
+	dir := writeTempPkg(t, "vartest", src)
+	analysistest.Run(t, dir, sinkAnalyzer, "vartest")
+}
+
+// TC-18: Range over a tainted collection taints the element variable but not
+// the index, for both := and = forms
+func TestVarTracker_RangeOverTaintedCollection(t *testing.T) {
+	src := fmt.Sprintf(`package vartest
+
+type User struct {
+	Password string %s
+	Name     string
+}
+
+func sink(v string) {}
+func sinkInt(v int) {}
+
+func getPasswords(u User) []string {
+	return []string{u.Password}
+}
+
+func test(u User) {
+	for i, p := range getPasswords(u) {
+		sink(p) // want "sensitive var: p from User.Password"
+		sinkInt(i)
+	}
+
+	var q string
+	for _, q = range []string{u.Password} {
+	}
+	sink(q) // want "sensitive var: q from User.Password"
+
+	for _, n := range []string{u.Name} {
+		sink(n) // not sensitive
+	}
+}
+`, sensitiveStructTag())
+
+	dir := writeTempPkg(t, "vartest", src)
+	analysistest.Run(t, dir, sinkAnalyzer, "vartest")
+}
@@ -3,6 +3,7 @@ package detector
 import (
 	"fmt"
 	"go/ast"
+	"go/token"
 	"go/types"
 	"sort"
 	"strings"
@@ -36,6 +37,48 @@ type WholeProgramCollector struct {
 
 	// Resolved static call graph (caller func obj → call site → callees).
 	graph *CallGraph
+
+	// Flags and arguments declared sensitive; nil for none
+	commandLine *CommandLineSources
+
+	// progress is notified as packages are collected and analyzed; nil for
+	// none (see SetProgress)
+	progress func(Progress)
+
+	// panics are the panics recovered during the analysis (see guard)
+	panics []*PanicError
+}
+
+// Phases of a whole-program run reported by Progress
+const (
+	PhaseLoaded     = "loaded"     // Package loaded and type-checked (reported by the driver)
+	PhaseCollected  = "collected"  // Facts of the package collected
+	PhasePropagated = "propagated" // Cross-package data flow propagated, once for the whole program
+	PhaseAnalyzed   = "analyzed"   // Findings of the package detected
+)
+
+// Progress is the progress of a whole-program run after a package is done
+// with a phase
+type Progress struct {
+	Phase    string // One of the Phase constants
+	Package  string // Import path; "" for PhasePropagated
+	Done     int    // Packages done with the phase, this one included
+	Total    int    // Packages in the phase
+	Findings int    // Findings in the package before suppression, for PhaseAnalyzed
+}
+
+// SetProgress sets the function notified with the progress of Collect and
+// Analyze. Cross-package sink findings (LH0006) are detected once every
+// package is analyzed and are not counted in the packages' findings.
+func (wp *WholeProgramCollector) SetProgress(fn func(Progress)) {
+	wp.progress = fn
+}
+
+// report notifies the progress function, if any, of p
+func (wp *WholeProgramCollector) report(p Progress) {
+	if wp.progress != nil {
+		wp.progress(p)
+	}
 }
 
 type wholeProgramLogCall struct {
@@ -56,6 +99,7 @@ func NewWholeProgramCollector(world *WorldView, cfg *config.Config) *WholeProgra
 		cfg:           cfg,
 		pkgCollectors: make(map[*packages.Package]*DataFlowCollector),
 		graph:         NewCallGraph(),
+		commandLine:   NewCommandLineSources(cfg),
 	}
 }
 
@@ -66,44 +110,187 @@ func (wp *WholeProgramCollector) CallGraph() *CallGraph { return wp.graph }
 // cross-package data flow propagation.
 func (wp *WholeProgramCollector) Collect() {
 	// Phase 1: collect facts per package into shared world state.
-	for _, pkg := range wp.world.Packages {
+	// Dependencies are collected before their importers so that facts such
+	// as a callee's sensitive return positions are known when the caller's
+	// assignments are evaluated.
+	ordered := dependencyOrder(wp.world.Packages)
+	collectors := make([]*DataFlowCollector, 0, len(ordered))
+	for _, pkg := range ordered {
 		if pkg == nil || pkg.Types == nil || pkg.TypesInfo == nil {
 			continue
 		}
-		pass := buildPassForPackage(pkg)
-		c := NewDataFlowCollectorForWorld(pass, wp.cfg, wp.world, pkg)
-		c.CollectFacts()
-		wp.pkgCollectors[pkg] = c
-		for _, call := range c.LogCalls() {
-			wp.logCalls = append(wp.logCalls, wholeProgramLogCall{
-				pkg:    pkg,
-				call:   call,
-				caller: enclosingFuncForCall(pkg, call),
-			})
+		wp.guard(pkg.PkgPath, "setting up", func() {
+			pass := buildPassForPackage(pkg)
+			collectors = append(collectors, NewDataFlowCollectorForWorld(pass, wp.cfg, wp.world, pkg))
+		})
+	}
+
+	// Function values holding sinks can be passed across packages, e.g.
+	// lib.Run(log.Printf), so they are recorded for every package before
+	// any log call is collected.
+	for {
+		before := len(wp.world.sinkValues)
+		recorded := collectors[:0]
+		for _, c := range collectors {
+			if wp.guard(c.pkg.PkgPath, "recording sink values", func() {
+				c.LogDetector().RecordSinkValues(c.pass.Files, c.pass.TypesInfo)
+			}) {
+				recorded = append(recorded, c)
+			}
+		}
+		collectors = recorded
+		if len(wp.world.sinkValues) == before {
+			break
+		}
+	}
+
+	for i, c := range collectors {
+		pkg := c.pkg
+		var calls []wholeProgramLogCall
+		ok := wp.guard(pkg.PkgPath, "collecting facts", func() {
+			before := wp.world.trackedFacts()
+			c.CollectFacts()
+			// A package whose facts alone go over the memory budget is
+			// degraded before propagation can multiply them
+			if c.budget > 0 {
+				after := wp.world.trackedFacts()
+				c.enforceBudget(trackedFacts{
+					vars:     after.vars - before.vars,
+					funcDefs: after.funcDefs - before.funcDefs,
+					logCalls: len(c.logCalls),
+				})
+			}
+			for _, call := range c.LogCalls() {
+				calls = append(calls, wholeProgramLogCall{
+					pkg:    pkg,
+					call:   call,
+					caller: enclosingFuncForCall(pkg, call),
+				})
+			}
+		})
+		if ok {
+			wp.pkgCollectors[pkg] = c
+			wp.logCalls = append(wp.logCalls, calls...)
+		} else {
+			// The facts collected before the panic are dropped too, so
+			// propagation does not walk the package's functions again
+			wp.world.forgetPackage(pkg.Types)
 		}
+		wp.report(Progress{Phase: PhaseCollected, Package: pkg.PkgPath, Done: i + 1, Total: len(collectors)})
 	}
 
 	// Phase 2: cross-package data flow + sink propagation.
-	wp.analyzeCrossPackage()
+	wp.guard("", "propagating cross-package data flow", wp.analyzeCrossPackage)
+	wp.enforceBudgets()
+	wp.report(Progress{Phase: PhasePropagated, Done: 1, Total: 1})
+}
+
+// orderedCollectors returns the package collectors sorted by import path.
+func (wp *WholeProgramCollector) orderedCollectors() []*DataFlowCollector {
+	collectors := make([]*DataFlowCollector, 0, len(wp.pkgCollectors))
+	for _, c := range wp.pkgCollectors {
+		collectors = append(collectors, c)
+	}
+	sort.Slice(collectors, func(i, j int) bool { return collectors[i].pkg.PkgPath < collectors[j].pkg.PkgPath })
+	return collectors
+}
+
+// dependencyOrder returns pkgs sorted so that every package follows the
+// packages it imports. Packages are visited in import-path order, making the
+// result independent of the order in which pkgs were loaded or flattened.
+func dependencyOrder(pkgs []*packages.Package) []*packages.Package {
+	inWorld := make(map[*packages.Package]bool, len(pkgs))
+	for _, p := range pkgs {
+		if p != nil {
+			inWorld[p] = true
+		}
+	}
+	sorted := make([]*packages.Package, 0, len(inWorld))
+	for p := range inWorld {
+		sorted = append(sorted, p)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].PkgPath < sorted[j].PkgPath })
+
+	ordered := make([]*packages.Package, 0, len(sorted))
+	visited := make(map[*packages.Package]bool, len(sorted))
+	var visit func(p *packages.Package)
+	visit = func(p *packages.Package) {
+		if visited[p] {
+			return
+		}
+		visited[p] = true
+		paths := make([]string, 0, len(p.Imports))
+		for path := range p.Imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			if imp := p.Imports[path]; inWorld[imp] {
+				visit(imp)
+			}
+		}
+		ordered = append(ordered, p)
+	}
+	for _, p := range sorted {
+		visit(p)
+	}
+	return ordered
 }
 
 // Analyze runs Phase 3: detection over collected log calls and a separate
-// scan for cross-package sink call sites (LH0006). Findings are returned
-// sorted by source position (filename, line, column, then rule ID) so output
-// is stable across runs regardless of the map-iteration order in which
-// packages and function decls are visited.
+// scan for cross-package sink call sites (LH0006), plus key sink arguments
+// (LH0008), producer sink payloads (LH0017), formatting methods rendering
+// sensitive data (LH0014), and the untagged-field, config-dump,
+// hardcoded-secret and crash-handler audits (LH0007, LH0013, LH0018,
+// LH0019), sensitive types
+// crossing into boundary packages (LH0015) and logger hooks forwarding
+// entries to external sinks (LH0016) when they are configured.
+// Findings are returned sorted by source position (filename, line, column,
+// then rule ID) so output is stable across runs regardless of the
+// map-iteration order in which packages and function decls are visited.
 func (wp *WholeProgramCollector) Analyze() []Finding {
-	var findings []Finding
+	logCalls := make(map[*packages.Package][]wholeProgramLogCall, len(wp.pkgCollectors))
 	for _, lc := range wp.logCalls {
-		c := wp.pkgCollectors[lc.pkg]
-		if c == nil {
-			continue
-		}
-		for _, arg := range lc.call.Args {
-			findings = append(findings, wp.checkArg(c, lc, arg)...)
-		}
+		logCalls[lc.pkg] = append(logCalls[lc.pkg], lc)
 	}
-	findings = append(findings, wp.detectCrossPkgSinks()...)
+
+	var findings []Finding
+	collectors := wp.orderedCollectors()
+	for i, c := range collectors {
+		var pkgFindings []Finding
+		if !wp.guard(c.pkg.PkgPath, "detecting findings", func() {
+			for _, lc := range logCalls[c.pkg] {
+				for _, arg := range c.LogDetector().LoggedArgs(lc.call, lc.pkg.TypesInfo) {
+					sink := c.LogDetector().SinkName(arg.Call, lc.pkg.TypesInfo)
+					argFindings := wp.checkArg(c, lc, arg)
+					reclassify(argFindings)
+					annotateSink(argFindings, lc.call, sink, funcName(lc.caller), arg.Index+1)
+					pkgFindings = append(pkgFindings, argFindings...)
+				}
+			}
+			pkgFindings = append(pkgFindings, c.KeyFindings()...)
+			pkgFindings = append(pkgFindings, c.ProducerFindings()...)
+			pkgFindings = append(pkgFindings, c.StringerFindings()...)
+			pkgFindings = append(pkgFindings, c.AuditFindings()...)
+			pkgFindings = append(pkgFindings, c.HardcodedSecretFindings()...)
+			pkgFindings = append(pkgFindings, c.CrashHandlerFindings()...)
+			pkgFindings = append(pkgFindings, c.ConfigDumpFindings()...)
+			pkgFindings = append(pkgFindings, c.BoundaryFindings()...)
+			pkgFindings = append(pkgFindings, c.HookFindings()...)
+			pkgFindings = append(pkgFindings, c.BestEffortFindings()...)
+		}) {
+			pkgFindings = nil
+		}
+		findings = append(findings, pkgFindings...)
+		wp.report(Progress{Phase: PhaseAnalyzed, Package: c.pkg.PkgPath, Done: i + 1, Total: len(collectors), Findings: len(pkgFindings)})
+	}
+	var crossPkg []Finding
+	wp.guard("", "detecting cross-package sink calls", func() {
+		crossPkg = wp.detectCrossPkgSinks()
+	})
+	reclassify(crossPkg)
+	findings = append(findings, crossPkg...)
+	classifyFields(findings, wp.world.fieldClasses)
 	wp.sortFindings(findings)
 	return findings
 }
@@ -114,7 +301,12 @@ func (wp *WholeProgramCollector) Analyze() []Finding {
 // follows map iteration over packages, which is non-deterministic. Resolving to
 // (filename, line, column) makes the ordering reproducible.
 func (wp *WholeProgramCollector) sortFindings(findings []Finding) {
-	fset := wp.world.Fset
+	SortFindings(findings, wp.world.Fset)
+}
+
+// SortFindings sorts findings by source position: filename, line, column,
+// then rule ID. It does nothing when fset is nil.
+func SortFindings(findings []Finding, fset *token.FileSet) {
 	if fset == nil {
 		return
 	}
@@ -162,16 +354,19 @@ func (wp *WholeProgramCollector) detectCrossPkgSinks() []Finding {
 // checkArg dispatches detection for a single argument of a log call. It
 // reuses the per-package Detector for LH0001-LH0004 findings and adds the
 // cross-package upgrades (LH0005, LH0006).
-func (wp *WholeProgramCollector) checkArg(c *DataFlowCollector, lc wholeProgramLogCall, arg ast.Expr) []Finding {
-	findings := c.Detector().CheckArgForSensitiveData(arg)
+func (wp *WholeProgramCollector) checkArg(c *DataFlowCollector, lc wholeProgramLogCall, arg LoggedArg) []Finding {
+	findings := c.Detector().CheckLoggedArg(arg.Call, arg.Index)
 	for i := range findings {
 		// Promote sensitive-call findings to cross-package variant when the
 		// callee belongs to a different package than the caller.
 		if findings[i].RuleID != RuleIDSensitiveCall {
 			continue
 		}
-		call, ok := arg.(*ast.CallExpr)
-		if !ok {
+		// The finding may come from a call nested inside the argument, e.g.
+		// slog.Group("auth", "secret", pkg.Secret()), so promote based on the
+		// call that was actually flagged rather than the outer argument.
+		call := findingCall(arg.Expr(), findings[i])
+		if call == nil {
 			continue
 		}
 		calleePkg := wp.calleePackagePath(lc.pkg, call)
@@ -187,6 +382,22 @@ func (wp *WholeProgramCollector) checkArg(c *DataFlowCollector, lc wholeProgramL
 	return findings
 }
 
+// findingCall returns the call expression within arg whose span matches f.
+func findingCall(arg ast.Expr, f Finding) *ast.CallExpr {
+	var found *ast.CallExpr
+	ast.Inspect(arg, func(n ast.Node) bool {
+		if found != nil {
+			return false
+		}
+		if call, ok := n.(*ast.CallExpr); ok && call.Pos() == f.Pos && call.End() == f.End {
+			found = call
+			return false
+		}
+		return true
+	})
+	return found
+}
+
 // analyzeCrossPackage runs the convergence-based data flow + sink propagation
 // over the world's funcDefs, satisfying design doc §7(d).
 //
@@ -267,9 +478,10 @@ func (wp *WholeProgramCollector) propagateThroughFunc(callerObj types.Object, ca
 
 	var toEnqueue []types.Object
 	callerParamBecameSink := false
-	markCallerSink := func(p *types.Var) {
+	markCallerSink := func(p *types.Var, sink string) {
 		if !wp.world.sinkParams[p] {
 			wp.world.sinkParams[p] = true
+			wp.world.paramSinks[p] = sink
 			callerParamBecameSink = true
 		}
 	}
@@ -283,9 +495,9 @@ func (wp *WholeProgramCollector) propagateThroughFunc(callerObj types.Object, ca
 		// Sink back-propagation: if this call is a log call and any arg
 		// references a caller param, that param is now a sink.
 		if c := wp.pkgCollectors[callerPkg]; c != nil && c.LogDetector().IsLogCallWithInfo(call, callerInfo) {
-			for _, arg := range call.Args {
-				if p := identifiedParam(arg, callerInfo, callerParams); p != nil {
-					markCallerSink(p)
+			for _, arg := range c.LogDetector().LoggedArgs(call, callerInfo) {
+				if p := identifiedParam(arg.Expr(), callerInfo, callerParams); p != nil {
+					markCallerSink(p, c.LogDetector().SinkName(arg.Call, callerInfo))
 				}
 			}
 			return true
@@ -305,16 +517,14 @@ func (wp *WholeProgramCollector) propagateThroughFunc(callerObj types.Object, ca
 		}
 
 		for argIdx, arg := range call.Args {
+			paramIdx := paramIndex(calleeDecl, argIdx, len(calleeParams))
 			// Forward propagation: arg(sensitive) → callee.param(sensitive)
-			if argIdx < len(calleeParams) && calleeParams[argIdx] != nil {
-				paramVar := calleeParams[argIdx]
+			if paramIdx >= 0 && calleeParams[paramIdx] != nil {
+				paramVar := calleeParams[paramIdx]
 				if _, already := wp.world.sensitiveParams[paramVar]; !already {
 					if src := wp.evalSensitive(arg, callerInfo); src != nil {
-						newSource := SensitiveSource{
-							FieldName: src.FieldName,
-							Position:  arg.Pos(),
-							FlowPath:  append(append([]string{}, src.FlowPath...), fmt.Sprintf("parameter '%s'", paramVar.Name())),
-						}
+						newSource := src.withStep(fmt.Sprintf("%s param %s", calleeObj.Name(), paramVar.Name()), paramVar.Pos())
+						newSource.Position = arg.Pos()
 						wp.world.sensitiveParams[paramVar] = newSource
 						wp.world.sensitiveVars[paramVar] = newSource
 						// The callee now carries sensitivity inward; let it
@@ -326,9 +536,9 @@ func (wp *WholeProgramCollector) propagateThroughFunc(callerObj types.Object, ca
 
 			// Sink back-propagation: arg refers to caller's param AND callee's
 			// param at this index is a sink → caller's param is a sink.
-			if argIdx < len(calleeParams) && calleeParams[argIdx] != nil && wp.world.sinkParams[calleeParams[argIdx]] {
+			if paramIdx >= 0 && calleeParams[paramIdx] != nil && wp.world.sinkParams[calleeParams[paramIdx]] {
 				if p := identifiedParam(arg, callerInfo, callerParams); p != nil {
-					markCallerSink(p)
+					markCallerSink(p, wp.world.paramSinks[calleeParams[paramIdx]])
 				}
 			}
 		}
@@ -375,9 +585,10 @@ func (wp *WholeProgramCollector) seedDirectSinks() {
 			if !c.LogDetector().IsLogCallWithInfo(call, pkg.TypesInfo) {
 				return true
 			}
-			for _, arg := range call.Args {
-				if p := identifiedParam(arg, pkg.TypesInfo, params); p != nil {
+			for _, arg := range c.LogDetector().LoggedArgs(call, pkg.TypesInfo) {
+				if p := identifiedParam(arg.Expr(), pkg.TypesInfo, params); p != nil && !wp.world.sinkParams[p] {
 					wp.world.sinkParams[p] = true
+					wp.world.paramSinks[p] = c.LogDetector().SinkName(arg.Call, pkg.TypesInfo)
 				}
 			}
 			return true
@@ -417,7 +628,7 @@ func (wp *WholeProgramCollector) buildCallGraph() {
 // detectSinkAtCallSite emits an LH0006 finding when a call passes a sensitive
 // value to a callee whose parameter at that position is a known sink, AND
 // the call site is in a package different from the callee's package.
-func (wp *WholeProgramCollector) detectSinkAtCallSite(callerPkg *packages.Package, _ types.Object, call *ast.CallExpr) []Finding {
+func (wp *WholeProgramCollector) detectSinkAtCallSite(callerPkg *packages.Package, callerObj types.Object, call *ast.CallExpr) []Finding {
 	if callerPkg == nil || callerPkg.TypesInfo == nil {
 		return nil
 	}
@@ -437,22 +648,43 @@ func (wp *WholeProgramCollector) detectSinkAtCallSite(callerPkg *packages.Packag
 
 	var findings []Finding
 	for argIdx, arg := range call.Args {
-		if argIdx >= len(calleeParams) || calleeParams[argIdx] == nil {
+		paramIdx := paramIndex(calleeDecl, argIdx, len(calleeParams))
+		if paramIdx < 0 || calleeParams[paramIdx] == nil {
 			continue
 		}
-		if !wp.world.sinkParams[calleeParams[argIdx]] {
+		param := calleeParams[paramIdx]
+		if !wp.world.sinkParams[param] {
 			continue
 		}
 		src := wp.evalSensitive(arg, callerPkg.TypesInfo)
 		if src == nil {
 			continue
 		}
+		// The sink is the logging function the parameter finally reaches
+		sink, downstream := wp.world.paramSinks[param], ""
+		if sink == "" {
+			sink = SinkName(call, callerPkg.TypesInfo)
+		} else {
+			downstream = " by " + ShortFuncName(sink)
+		}
 		findings = append(findings, Finding{
-			Pos: arg.Pos(),
+			Pos:  arg.Pos(),
+			End:  arg.End(),
+			Expr: types.ExprString(arg),
 			Message: fmt.Sprintf(
-				"sensitive field %q is passed to cross-package function %q whose parameter %q is logged downstream",
-				src.FieldName, calleeObj.Name(), calleeParams[argIdx].Name()),
-			RuleID: RuleIDCrossPkgSensitiveSink,
+				"sensitive field %q is passed to cross-package function %q whose parameter %q is logged downstream%s",
+				src.FieldName, calleeObj.Name(), param.Name(), downstream),
+			RuleID:      RuleIDCrossPkgSensitiveSink,
+			Severity:    src.severity(),
+			PII:         src.PII,
+			Credential:  src.Credential,
+			Exposed:     src.Exposed,
+			CommandLine: src.CommandLine,
+			Field:       src.FieldName,
+			FieldPos:    src.FieldPos,
+			Sink:        sink,
+			Func:        funcName(callerObj),
+			FlowPath:    src.withStep(fmt.Sprintf("%s param %s", calleeObj.Name(), param.Name()), param.Pos()).FlowPath,
 		})
 	}
 	return findings
@@ -464,6 +696,12 @@ func (wp *WholeProgramCollector) evalSensitive(expr ast.Expr, info *types.Info)
 	if info == nil {
 		return nil
 	}
+	if src := builtinSource(expr, info); src != nil {
+		return src
+	}
+	if src := wp.commandLine.Source(expr, info); src != nil {
+		return src
+	}
 	switch e := expr.(type) {
 	case *ast.SelectorExpr:
 		return wp.sensitiveFieldAccessWithInfo(e, info)
@@ -475,12 +713,36 @@ func (wp *WholeProgramCollector) evalSensitive(expr ast.Expr, info *types.Info)
 				}
 			}
 		}
+	case *ast.ParenExpr:
+		return wp.evalSensitive(e.X, info)
+	case *ast.TypeAssertExpr:
+		return wp.evalSensitive(e.X, info)
+	case *ast.StarExpr:
+		return wp.evalSensitive(e.X, info)
 	case *ast.CallExpr:
+		if isConversion(e, info) {
+			return wp.evalSensitive(e.Args[0], info)
+		}
 		if obj := resolveCallee(e.Fun, info); obj != nil {
 			if src, ok := wp.world.sensitiveFuncs[obj]; ok {
 				return &src
 			}
 		}
+		return slogAttrSource(e, info, func(v ast.Expr) *SensitiveSource {
+			return wp.evalSensitive(v, info)
+		})
+	case *ast.CompositeLit:
+		eval := func(v ast.Expr) *SensitiveSource {
+			return wp.evalSensitive(v, info)
+		}
+		if src := slogAttrSource(e, info, eval); src != nil {
+			return src
+		}
+		return compositeLitSource(e, info, eval)
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			return wp.evalSensitive(e.X, info)
+		}
 	}
 	return nil
 }
@@ -500,6 +762,7 @@ func (wp *WholeProgramCollector) sensitiveFieldAccessWithInfo(sel *ast.SelectorE
 	if !ok {
 		return nil
 	}
+	named = fieldOwner(sel, info, named)
 	obj := named.Obj()
 	if obj == nil {
 		return nil
@@ -509,8 +772,11 @@ func (wp *WholeProgramCollector) sensitiveFieldAccessWithInfo(sel *ast.SelectorE
 	if wp.world.sensitiveFields[sensitiveField{typeName: typeName, fieldName: fieldName}] {
 		return &SensitiveSource{
 			FieldName: fmt.Sprintf("%s.%s", typeName, fieldName),
+			FieldPos:  selectedFieldPos(sel, info),
 			Position:  sel.Pos(),
-			FlowPath:  []string{fmt.Sprintf("%s.%s", typeName, fieldName)},
+			FlowPath:  []FlowStep{{Label: fmt.Sprintf("%s.%s", typeName, fieldName), Pos: sel.Pos()}},
+			Level:     fieldLevel(named, fieldName),
+			PII:       !checkSensitiveFieldFromTypeInfo(nil, named, fieldName),
 		}
 	}
 	// Fall back to struct-tag lookup so cross-package types without a cached
@@ -518,8 +784,10 @@ func (wp *WholeProgramCollector) sensitiveFieldAccessWithInfo(sel *ast.SelectorE
 	if checkSensitiveFieldFromTypeInfo(nil, named, fieldName) {
 		return &SensitiveSource{
 			FieldName: fmt.Sprintf("%s.%s", typeName, fieldName),
+			FieldPos:  selectedFieldPos(sel, info),
 			Position:  sel.Pos(),
-			FlowPath:  []string{fmt.Sprintf("%s.%s", typeName, fieldName)},
+			FlowPath:  []FlowStep{{Label: fmt.Sprintf("%s.%s", typeName, fieldName), Pos: sel.Pos()}},
+			Level:     fieldLevel(named, fieldName),
 		}
 	}
 	return nil
@@ -546,13 +814,25 @@ func (wp *WholeProgramCollector) calleePackagePath(callerPkg *packages.Package,
 // abstraction, so reusing them keeps the implementation small.
 func buildPassForPackage(pkg *packages.Package) *analysis.Pass {
 	return &analysis.Pass{
-		Fset:      pkg.Fset,
-		Files:     pkg.Syntax,
-		Pkg:       pkg.Types,
-		TypesInfo: pkg.TypesInfo,
-		Report:    func(analysis.Diagnostic) {},
-		ResultOf:  map[*analysis.Analyzer]any{},
+		Fset:         pkg.Fset,
+		Files:        pkg.Syntax,
+		IgnoredFiles: pkg.IgnoredFiles,
+		Pkg:          pkg.Types,
+		TypesInfo:    pkg.TypesInfo,
+		TypeErrors:   pkg.TypeErrors,
+		Module:       passModule(pkg.Module),
+		Report:       func(analysis.Diagnostic) {},
+		ResultOf:     map[*analysis.Analyzer]any{},
+	}
+}
+
+// passModule converts the module of a loaded package for its pass, nil when
+// the package was loaded without packages.NeedModule or has no module
+func passModule(m *packages.Module) *analysis.Module {
+	if m == nil {
+		return nil
 	}
+	return &analysis.Module{Path: m.Path, Version: m.Version, GoVersion: m.GoVersion}
 }
 
 // enclosingFuncForCall locates the function object whose body contains the
@@ -613,6 +893,24 @@ func paramObjects(decl *ast.FuncDecl, info *types.Info) []*types.Var {
 	return params
 }
 
+// paramIndex returns the index among nparams parameters of decl of the one
+// receiving the argument at argIdx, or -1. Arguments past the last parameter
+// of a variadic function, like a slice spread into it, land in the variadic
+// parameter.
+func paramIndex(decl *ast.FuncDecl, argIdx, nparams int) int {
+	if argIdx < nparams {
+		return argIdx
+	}
+	if nparams == 0 || decl == nil || decl.Type == nil || decl.Type.Params == nil {
+		return -1
+	}
+	list := decl.Type.Params.List
+	if _, ok := list[len(list)-1].Type.(*ast.Ellipsis); ok {
+		return nparams - 1
+	}
+	return -1
+}
+
 // paramSet returns the set of parameter vars for fast membership testing in
 // "does this ident reference one of my params" checks.
 func paramSet(decl *ast.FuncDecl, info *types.Info) map[*types.Var]bool {
@@ -649,8 +947,9 @@ func identifiedParam(arg ast.Expr, info *types.Info, params map[*types.Var]bool)
 
 // IsStdlibPackagePath reports whether pkgPath belongs to the Go standard
 // library. The heuristic: a path is stdlib when its first segment contains no
-// dot (e.g. "fmt", "log/slog", "internal/abi"), whereas module paths always
-// carry a dotted domain in the first segment ("example.com/x", "github.com/y").
+// dot (e.g. "fmt", "log/slog", "internal/abi"), whereas module paths usually
+// carry a dotted domain in the first segment ("example.com/x", "github.com/y");
+// prefer IsStdlibPackage when the loaded package is at hand.
 //
 // Whole-program analysis excludes stdlib *dependencies* from the WorldView:
 // their bodies are never sinks we must propagate through — slog/log/fmt calls
@@ -666,6 +965,15 @@ func IsStdlibPackagePath(pkgPath string) bool {
 	return !strings.Contains(first, ".")
 }
 
+// IsStdlibPackage reports whether p belongs to the Go standard library. Unlike
+// IsStdlibPackagePath it is not fooled by modules whose path has no dotted
+// domain ("module myapp"), whose packages such as "myapp/log" belong to a
+// module: packages must be loaded with packages.NeedModule for the check to
+// tell them apart.
+func IsStdlibPackage(p *packages.Package) bool {
+	return p.Module == nil && IsStdlibPackagePath(p.PkgPath)
+}
+
 // resolveCallee returns the *types.Object representing the function being
 // called by the given Fun expression, using the provided TypesInfo. Returns
 // nil for non-resolvable calls (dynamic dispatch, type conversions, etc.).
@@ -675,9 +983,13 @@ func resolveCallee(fun ast.Expr, info *types.Info) types.Object {
 	}
 	switch f := fun.(type) {
 	case *ast.Ident:
-		return info.Uses[f]
+		if obj := info.Uses[f]; obj != nil {
+			return calleeOrigin(obj)
+		}
 	case *ast.SelectorExpr:
-		return info.Uses[f.Sel]
+		if obj := info.Uses[f.Sel]; obj != nil {
+			return calleeOrigin(obj)
+		}
 	}
 	return nil
 }
@@ -23,9 +23,10 @@ func typeCheckSource(t *testing.T, src string) (*token.FileSet, *ast.File, *type
 		t.Fatalf("parse: %v", err)
 	}
 	info := &types.Info{
-		Types: make(map[ast.Expr]types.TypeAndValue),
-		Defs:  make(map[*ast.Ident]types.Object),
-		Uses:  make(map[*ast.Ident]types.Object),
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
 	}
 	conf := types.Config{Importer: importer.Default()}
 	if _, err := conf.Check("test", fset, []*ast.File{file}, info); err != nil {
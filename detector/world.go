@@ -26,9 +26,11 @@ type WorldView struct {
 	// types.Object identity is globally unique across packages, so a single
 	// map per kind is sufficient.
 	sensitiveFields  map[sensitiveField]bool
+	fieldClasses     map[sensitiveField]string
 	sensitiveVars    map[*types.Var]SensitiveSource
 	sensitiveFuncs   map[types.Object]SensitiveSource
 	sensitiveFuncPos map[sensitiveReturnKey]SensitiveSource
+	sensitiveYields  map[sensitiveReturnKey]SensitiveSource
 	sensitiveParams  map[*types.Var]SensitiveSource
 
 	// sinkParams marks function parameters that are forwarded (directly or
@@ -36,6 +38,16 @@ type WorldView struct {
 	// drive LH0006 (cross-package sensitive sink) detection.
 	sinkParams map[*types.Var]bool
 
+	// paramSinks maps each sink parameter to the logging function its
+	// value finally reaches, e.g. "(*go.uber.org/zap.Logger).Info", the
+	// sink reported by its LH0006 findings.
+	paramSinks map[*types.Var]string
+
+	// sinkValues maps variables, parameters and fields holding a sink
+	// function value to that function, shared by every package's
+	// LogDetector (see LogDetector.RecordSinkValues).
+	sinkValues map[*types.Var]*types.Func
+
 	// funcDefs maps function objects (including methods) to their AST decls.
 	funcDefs map[types.Object]*ast.FuncDecl
 
@@ -54,11 +66,15 @@ func NewWorldView(fset *token.FileSet, pkgs []*packages.Package) *WorldView {
 		Fset:             fset,
 		Packages:         pkgs,
 		sensitiveFields:  make(map[sensitiveField]bool),
+		fieldClasses:     make(map[sensitiveField]string),
 		sensitiveVars:    make(map[*types.Var]SensitiveSource),
 		sensitiveFuncs:   make(map[types.Object]SensitiveSource),
 		sensitiveFuncPos: make(map[sensitiveReturnKey]SensitiveSource),
+		sensitiveYields:  make(map[sensitiveReturnKey]SensitiveSource),
 		sensitiveParams:  make(map[*types.Var]SensitiveSource),
 		sinkParams:       make(map[*types.Var]bool),
+		paramSinks:       make(map[*types.Var]string),
+		sinkValues:       make(map[*types.Var]*types.Func),
 		funcDefs:         make(map[types.Object]*ast.FuncDecl),
 		funcPkg:          make(map[types.Object]*packages.Package),
 		pkgByPath:        make(map[string]*packages.Package),
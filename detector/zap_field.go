@@ -0,0 +1,56 @@
+package detector
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// zapFieldValueArgs returns the value arguments of a go.uber.org/zap Field
+// constructor call (zap.String, zap.Any, zap.Object, ...), skipping the key.
+// The second result is false when call is not such a constructor.
+func zapFieldValueArgs(call *ast.CallExpr, info *types.Info) ([]ast.Expr, bool) {
+	fn, ok := resolveCallee(call.Fun, info).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != "go.uber.org/zap" {
+		return nil, false
+	}
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() != nil || sig.Results().Len() != 1 || !isZapField(sig.Results().At(0).Type()) {
+		return nil, false
+	}
+	args := call.Args
+	if sig.Params().Len() > 0 && sig.Params().At(0).Name() == "key" && len(args) > 0 {
+		args = args[1:]
+	}
+	return args, true
+}
+
+// isZapField reports whether t is go.uber.org/zap/zapcore.Field, which
+// zap.Field aliases
+func isZapField(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := named.Obj()
+	return obj.Name() == "Field" && obj.Pkg() != nil && obj.Pkg().Path() == "go.uber.org/zap/zapcore"
+}
+
+// zapFieldSource reports the sensitive source carried by a zap.Field built
+// by call, evaluating each value argument with eval, so fields built ahead of
+// time and stored in variables or slices keep the taint of their values:
+//
+//	fields := []zap.Field{zap.String("pwd", user.Password)}
+//	logger.Info("login", fields...)
+func zapFieldSource(call *ast.CallExpr, info *types.Info, eval func(ast.Expr) *SensitiveSource) *SensitiveSource {
+	args, ok := zapFieldValueArgs(call, info)
+	if !ok {
+		return nil
+	}
+	for _, arg := range args {
+		if source := eval(arg); source != nil {
+			withStep := source.withStep("zap."+resolveCallee(call.Fun, info).Name(), call.Pos())
+			return &withStep
+		}
+	}
+	return nil
+}
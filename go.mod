@@ -2,10 +2,12 @@ module github.com/nilpoona/leakhound
 
 go 1.26
 
-require golang.org/x/tools v0.37.0
+require (
+	golang.org/x/tools v0.37.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	golang.org/x/mod v0.28.0 // indirect
 	golang.org/x/sync v0.17.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,197 @@
+// Package owners maps source files to their owning teams with a
+// CODEOWNERS file, so findings can be routed to the people who own the
+// leaking code.
+package owners
+
+import (
+	"bufio"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// DefaultFiles are the CODEOWNERS locations searched, in order, when none
+// is configured. They are the locations GitHub reads.
+var DefaultFiles = []string{
+	filepath.Join(".github", "CODEOWNERS"),
+	"CODEOWNERS",
+	filepath.Join("docs", "CODEOWNERS"),
+}
+
+// rule is a CODEOWNERS line: a path pattern and the owners of the files it
+// matches
+type rule struct {
+	pattern *regexp.Regexp
+	owners  []string // Empty for a pattern that leaves its files unowned
+}
+
+// Map assigns owners to slash-separated file paths relative to the root of
+// a CODEOWNERS file
+type Map struct {
+	rules []rule
+}
+
+// Parse reads a CODEOWNERS file: one "pattern owner..." rule per line, with
+// blank lines and "#" comments ignored. Patterns follow the gitignore rules
+// GitHub uses: a pattern containing a "/" other than a trailing one is
+// relative to the root, others match at any depth; "*" matches within a
+// path segment and "**" across segments; a directory matches every file
+// below it.
+func Parse(r io.Reader) (*Map, error) {
+	m := &Map{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		re, err := compilePattern(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("CODEOWNERS line %d: %w", n, err)
+		}
+		m.rules = append(m.rules, rule{pattern: re, owners: fields[1:]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Load reads the CODEOWNERS file at path
+func Load(path string) (*Map, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open CODEOWNERS file: %w", err)
+	}
+	defer f.Close()
+	m, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return m, nil
+}
+
+// Find returns the first of DefaultFiles present in dir, or else in the
+// closest of its parents that has one, so a run from a subdirectory finds the
+// file of its repository. The search stops at the repository root, the
+// directory holding .git. Returns "" if no file is found.
+func Find(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	for {
+		for _, name := range DefaultFiles {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Root returns the directory the patterns of the CODEOWNERS file at path, one
+// of DefaultFiles, are relative to: the repository root, which holds the file
+// itself or the .github or docs directory containing it
+func Root(path string) string {
+	dir := filepath.Dir(path)
+	switch filepath.Base(dir) {
+	case ".github", "docs":
+		return filepath.Dir(dir)
+	}
+	return dir
+}
+
+// Owners returns the owners of the file at path, slash-separated and
+// relative to the root. As on GitHub, the last matching rule wins.
+func (m *Map) Owners(path string) []string {
+	if m == nil {
+		return nil
+	}
+	for i := len(m.rules) - 1; i >= 0; i-- {
+		if m.rules[i].pattern.MatchString(path) {
+			return m.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// Annotate sets the Owners of each finding from the file it is reported in.
+// Files outside root have no owners.
+func (m *Map) Annotate(findings []detector.Finding, fset *token.FileSet, root string) {
+	if m == nil {
+		return
+	}
+	for i := range findings {
+		rel, err := filepath.Rel(root, fset.Position(findings[i].Pos).Filename)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		findings[i].Owners = m.Owners(filepath.ToSlash(rel))
+	}
+}
+
+// compilePattern converts a CODEOWNERS pattern to a regexp matching the
+// relative paths of the files it covers
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	if strings.HasPrefix(pattern, "!") || strings.Contains(pattern, "[") {
+		return nil, fmt.Errorf("unsupported pattern %q: negation and character ranges are not supported by CODEOWNERS", pattern)
+	}
+	dirOnly := strings.HasSuffix(pattern, "/")
+	trimmed := strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(trimmed, "/")
+	trimmed = strings.TrimPrefix(trimmed, "/")
+
+	var b strings.Builder
+	if anchored {
+		b.WriteString("^")
+	} else {
+		b.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(trimmed); i++ {
+		switch {
+		case strings.HasPrefix(trimmed[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(trimmed[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case trimmed[i] == '*':
+			b.WriteString("[^/]*")
+		case trimmed[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(trimmed[i : i+1]))
+		}
+	}
+	last := trimmed[strings.LastIndex(trimmed, "/")+1:]
+	switch {
+	case dirOnly:
+		b.WriteString("/.*$")
+	case !strings.ContainsAny(last, "*?"):
+		// A pattern naming a directory covers every file below it, while
+		// "docs/*" covers the files directly in docs only
+		b.WriteString("(?:/.*)?$")
+	default:
+		b.WriteString("$")
+	}
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,173 @@
+package owners
+
+import (
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+const testCodeowners = `# Default owners
+*                   @org/platform
+
+*.sql               @org/dba
+/internal/billing/  @org/payments @alice   # billing code
+docs/*              @org/docs
+**/audit            @org/security
+/cmd/tools          @org/tooling
+/internal/billing/generated/
+`
+
+func TestMap_Owners(t *testing.T) {
+	t.Parallel()
+
+	m, err := Parse(strings.NewReader(testCodeowners))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@org/platform"}},
+		{"db/schema.sql", []string{"@org/dba"}},
+		{"internal/billing/charge.go", []string{"@org/payments", "@alice"}},
+		{"internal/billing/stripe/client.go", []string{"@org/payments", "@alice"}},
+		{"pkg/internal/billing/charge.go", []string{"@org/platform"}}, // anchored to the root
+		{"internal/billing/generated/models.go", nil},                 // no owners: unowned
+		{"docs/guide.go", []string{"@org/docs"}},
+		{"docs/examples/main.go", []string{"@org/platform"}}, // "docs/*" is not recursive
+		{"app/audit/log.go", []string{"@org/security"}},
+		{"audit/log.go", []string{"@org/security"}},
+		{"cmd/tools/gen/main.go", []string{"@org/tooling"}}, // a directory covers the files below it
+		{"cmd/toolsx/main.go", []string{"@org/platform"}},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.path, func(t *testing.T) {
+			t.Parallel()
+			if got := m.Owners(tt.path); !reflect.DeepEqual(got, tt.want) && (len(got) > 0 || len(tt.want) > 0) {
+				t.Errorf("Owners(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_Unsupported(t *testing.T) {
+	t.Parallel()
+
+	for _, line := range []string{"!vendor/ @org/a", "file[0-9].go @org/a"} {
+		if _, err := Parse(strings.NewReader("* @org/b\n" + line + "\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("Parse(%q) error = %v, want an unsupported pattern error on line 2", line, err)
+		}
+	}
+}
+
+func TestMap_Annotate(t *testing.T) {
+	t.Parallel()
+
+	m, err := Parse(strings.NewReader(testCodeowners))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	fset := token.NewFileSet()
+	billing := fset.AddFile("/repo/internal/billing/charge.go", 1, 10)
+	outside := fset.AddFile("/elsewhere/main.go", 20, 10)
+	findings := []detector.Finding{{Pos: billing.Pos(0)}, {Pos: outside.Pos(0)}}
+
+	m.Annotate(findings, fset, "/repo")
+	if want := []string{"@org/payments", "@alice"}; !reflect.DeepEqual(findings[0].Owners, want) {
+		t.Errorf("Owners = %v, want %v", findings[0].Owners, want)
+	}
+	if findings[1].Owners != nil {
+		t.Errorf("finding outside the root: Owners = %v, want none", findings[1].Owners)
+	}
+
+	var nilMap *Map
+	nilMap.Annotate(findings, fset, "/repo") // must not panic
+}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	if got := Find(root); got != "" {
+		t.Errorf("Find() = %q, want none", got)
+	}
+
+	for _, name := range []string{"CODEOWNERS", filepath.Join(".github", "CODEOWNERS")} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("* @org/a\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := Find(root), filepath.Join(root, ".github", "CODEOWNERS"); got != want {
+		t.Errorf("Find() = %q, want %q: .github/CODEOWNERS comes first", got, want)
+	}
+}
+
+func TestFind_Subdirectory(t *testing.T) {
+	t.Parallel()
+
+	repo := t.TempDir()
+	sub := filepath.Join(repo, "internal", "billing")
+	for _, dir := range []string{filepath.Join(repo, ".git"), filepath.Join(repo, ".github"), sub} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	path := filepath.Join(repo, ".github", "CODEOWNERS")
+	if err := os.WriteFile(path, []byte("*           @org/platform\n/internal/  @org/payments\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := Find(sub)
+	if got != path {
+		t.Fatalf("Find(%q) = %q, want the repository's %q", sub, got, path)
+	}
+	if root := Root(got); root != repo {
+		t.Errorf("Root(%q) = %q, want the repository root %q", got, root, repo)
+	}
+
+	m, err := Load(got)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	fset := token.NewFileSet()
+	file := fset.AddFile(filepath.Join(sub, "charge.go"), 1, 10)
+	findings := []detector.Finding{{Pos: file.Pos(0)}}
+	m.Annotate(findings, fset, Root(got))
+	if want := []string{"@org/payments"}; !reflect.DeepEqual(findings[0].Owners, want) {
+		t.Errorf("Owners = %v, want %v: anchored patterns match from the repository root", findings[0].Owners, want)
+	}
+
+	// The search stops at the repository root
+	nested := filepath.Join(repo, "vendor", "other")
+	if err := os.MkdirAll(filepath.Join(nested, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := Find(nested); got != "" {
+		t.Errorf("Find(%q) = %q, want none past the root of its repository", nested, got)
+	}
+}
+
+func TestRoot(t *testing.T) {
+	t.Parallel()
+
+	repo := filepath.FromSlash("/repo")
+	for _, name := range DefaultFiles {
+		path := filepath.Join(repo, name)
+		if got := Root(path); got != repo {
+			t.Errorf("Root(%q) = %q, want %q", path, got, repo)
+		}
+	}
+}
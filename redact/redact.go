@@ -0,0 +1,64 @@
+// Package redact provides wrapper types that keep sensitive values out of
+// logs. A Secret renders as "[REDACTED]" through fmt, log/slog, encoding/json
+// and encoding.TextMarshaler, and leakhound treats it as safe to log:
+//
+//	type User struct {
+//		Name     string
+//		Password redact.Secret[string]
+//	}
+//
+//	slog.Info("user", "user", u) // logs {Name:alice Password:[REDACTED]}
+//
+// The wrapped value is only available through Expose.
+package redact
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Placeholder is what a Secret renders as
+const Placeholder = "[REDACTED]"
+
+// Secret holds a value that must not be logged. The zero value wraps the
+// zero value of T.
+type Secret[T any] struct {
+	value T
+}
+
+// New wraps value in a Secret
+func New[T any](value T) Secret[T] {
+	return Secret[T]{value: value}
+}
+
+// Expose returns the wrapped value
+func (s Secret[T]) Expose() T {
+	return s.value
+}
+
+// String returns Placeholder
+func (s Secret[T]) String() string {
+	return Placeholder
+}
+
+// Format writes Placeholder for every verb, including %#v and %d
+func (s Secret[T]) Format(f fmt.State, verb rune) {
+	io.WriteString(f, Placeholder)
+}
+
+// LogValue implements slog.LogValuer
+func (s Secret[T]) LogValue() slog.Value {
+	return slog.StringValue(Placeholder)
+}
+
+// MarshalJSON encodes Placeholder as a JSON string
+func (s Secret[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Placeholder)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s Secret[T]) MarshalText() ([]byte, error) {
+	return []byte(Placeholder), nil
+}
@@ -0,0 +1,83 @@
+package redact
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSecret_Renders(t *testing.T) {
+	t.Parallel()
+
+	type user struct {
+		Name     string
+		Password Secret[string]
+		PIN      Secret[int]
+	}
+	u := user{Name: "alice", Password: New("hunter2"), PIN: New(1234)}
+
+	tests := []struct {
+		name string
+		got  string
+	}{
+		{"%v", fmt.Sprintf("%v", u)},
+		{"%+v", fmt.Sprintf("%+v", u)},
+		{"%#v", fmt.Sprintf("%#v", u.Password)},
+		{"%s", fmt.Sprintf("%s", u.Password)},
+		{"%d", fmt.Sprintf("%d", u.PIN)},
+		{"String", u.Password.String()},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if strings.Contains(tt.got, "hunter2") || strings.Contains(tt.got, "1234") {
+				t.Errorf("%s = %q, leaks the secret", tt.name, tt.got)
+			}
+			if !strings.Contains(tt.got, Placeholder) {
+				t.Errorf("%s = %q, want %q", tt.name, tt.got, Placeholder)
+			}
+		})
+	}
+}
+
+func TestSecret_JSON(t *testing.T) {
+	t.Parallel()
+
+	got, err := json.Marshal(struct {
+		Token Secret[string] `json:"token"`
+	}{New("abc")})
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if want := `{"token":"[REDACTED]"}`; string(got) != want {
+		t.Errorf("json.Marshal() = %s, want %s", got, want)
+	}
+}
+
+func TestSecret_Slog(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	logger.Info("login", "password", New("hunter2"))
+	if out := buf.String(); strings.Contains(out, "hunter2") || !strings.Contains(out, "password=[REDACTED]") {
+		t.Errorf("slog output = %q, want password=[REDACTED]", out)
+	}
+}
+
+func TestSecret_Expose(t *testing.T) {
+	t.Parallel()
+
+	if got := New("hunter2").Expose(); got != "hunter2" {
+		t.Errorf("Expose() = %q, want %q", got, "hunter2")
+	}
+	var zero Secret[int]
+	if got := zero.Expose(); got != 0 {
+		t.Errorf("zero Expose() = %d, want 0", got)
+	}
+}
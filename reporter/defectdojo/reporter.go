@@ -0,0 +1,231 @@
+// Package defectdojo writes findings in DefectDojo's Generic Findings Import
+// JSON format, so results can be uploaded with the "Generic Findings Import"
+// scan type without converting SARIF first.
+package defectdojo
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nilpoona/leakhound/config"
+	"github.com/nilpoona/leakhound/detector"
+	"github.com/nilpoona/leakhound/reporter"
+)
+
+func init() {
+	reporter.Register("defectdojo", func(opts reporter.Options) reporter.Reporter {
+		return NewReporter(opts.WorkDir, opts.Rules)
+	})
+}
+
+// ScanType is the DefectDojo scan type that accepts this format
+const ScanType = "Generic Findings Import"
+
+// Document is the top-level Generic Findings Import document
+type Document struct {
+	Findings []Finding `json:"findings"`
+}
+
+// Finding is a single DefectDojo finding
+type Finding struct {
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	Severity         string   `json:"severity"` // Critical, High, Medium, Low or Info
+	Mitigation       string   `json:"mitigation,omitempty"`
+	References       string   `json:"references,omitempty"`
+	Date             string   `json:"date"` // YYYY-MM-DD
+	CWE              int      `json:"cwe,omitempty"`
+	FilePath         string   `json:"file_path"`
+	Line             int      `json:"line"`
+	VulnIDFromTool   string   `json:"vuln_id_from_tool"`   // Rule ID, e.g. "LH0004"
+	UniqueIDFromTool string   `json:"unique_id_from_tool"` // Stable across runs for deduplication
+	StaticFinding    bool     `json:"static_finding"`
+	DynamicFinding   bool     `json:"dynamic_finding"`
+	Active           bool     `json:"active"` // false for suppressed findings
+	Verified         bool     `json:"verified"`
+	Tags             []string `json:"tags,omitempty"` // Owners of the file from CODEOWNERS, for routing
+}
+
+// Reporter collects findings from multiple packages and writes a single
+// Generic Findings Import document
+type Reporter struct {
+	workDir  string
+	cfg      *config.Config // Per-rule security-severity; nil uses defaults
+	findings []reporter.Finding
+	now      func() time.Time // Clock for the finding date; nil means time.Now
+}
+
+// NewReporter creates a DefectDojo reporter. File paths are written relative
+// to workDir, and severities are derived from each finding's level and its
+// rule's security-severity, as configured in cfg (see levelSeverity).
+func NewReporter(workDir string, cfg *config.Config) *Reporter {
+	return &Reporter{
+		workDir: workDir,
+		cfg:     cfg,
+	}
+}
+
+// AddFindings adds findings from a single package analysis
+func (r *Reporter) AddFindings(findings []detector.Finding, fset *token.FileSet) {
+	r.findings = append(r.findings, reporter.Resolve(findings, fset)...)
+}
+
+// Report writes the collected findings followed by findings as a Generic
+// Findings Import document
+func (r *Reporter) Report(writer io.Writer, findings []reporter.Finding) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(r.buildDocument(slices.Concat(r.findings, findings)))
+}
+
+func (r *Reporter) buildDocument(resolved []reporter.Finding) *Document {
+	now := time.Now
+	if r.now != nil {
+		now = r.now
+	}
+	date := now().UTC().Format(time.DateOnly)
+
+	findings := make([]Finding, 0, len(resolved))
+	for _, f := range resolved {
+		findings = append(findings, r.buildFinding(f, date))
+	}
+	return &Document{Findings: findings}
+}
+
+func (r *Reporter) buildFinding(f reporter.Finding, date string) Finding {
+	path := r.relativePath(f.Start.Filename)
+	ruleID := f.RuleID
+	doc, _ := detector.LookupRuleDoc(ruleID)
+
+	title := ruleID
+	if doc.Short != "" {
+		title = fmt.Sprintf("%s: %s", ruleID, doc.Short)
+	}
+
+	return Finding{
+		Title:            title,
+		Description:      r.description(f, doc),
+		Severity:         levelSeverity(f.Level, r.securitySeverity(doc)),
+		Mitigation:       doc.Help,
+		References:       doc.HelpURI(),
+		Date:             date,
+		CWE:              cweNumber(doc.CWE),
+		FilePath:         path,
+		Line:             f.Start.Line,
+		VulnIDFromTool:   ruleID,
+		UniqueIDFromTool: uniqueID(f.Finding, ruleID, path, f.Start.Line),
+		StaticFinding:    true,
+		Active:           !f.Finding.Suppressed,
+		Tags:             f.Finding.Owners,
+	}
+}
+
+// description renders the finding message followed by its source field,
+// sink, confidence and taint flow, in the Markdown DefectDojo displays.
+func (r *Reporter) description(f reporter.Finding, doc detector.RuleDoc) string {
+	var b strings.Builder
+	b.WriteString(f.Finding.Message)
+	if doc.Full != "" {
+		fmt.Fprintf(&b, "\n\n%s", doc.Full)
+	}
+	if f.Finding.Field != "" {
+		fmt.Fprintf(&b, "\n\n**Sensitive field:** `%s`", f.Finding.Field)
+	}
+	if f.Finding.Sink != "" {
+		fmt.Fprintf(&b, "\n\n**Sink:** `%s`", f.Finding.Sink)
+	}
+	if len(f.Finding.Owners) > 0 {
+		fmt.Fprintf(&b, "\n\n**Owners:** %s", strings.Join(f.Finding.Owners, " "))
+	}
+	fmt.Fprintf(&b, "\n\n**Confidence:** %.2f (%s)", f.Finding.ConfidenceScore(), f.Finding.Confidence())
+	if len(f.Finding.FlowPath) > 0 {
+		b.WriteString("\n\n**Flow:**\n")
+		for _, step := range f.Finding.FlowPath {
+			pos := f.Fset.Position(step.Pos)
+			fmt.Fprintf(&b, "\n- `%s` (%s:%d)", step.Label, r.relativePath(pos.Filename), pos.Line)
+		}
+	}
+	return b.String()
+}
+
+func (r *Reporter) securitySeverity(doc detector.RuleDoc) float64 {
+	if s, ok := r.cfg.RuleSecuritySeverity(doc.ID); ok {
+		return s
+	}
+	return doc.SecuritySeverity
+}
+
+// relativePath converts absPath to a slash-separated path relative to workDir
+func (r *Reporter) relativePath(absPath string) string {
+	relPath, err := filepath.Rel(r.workDir, absPath)
+	if err != nil {
+		return filepath.ToSlash(absPath)
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// severity maps a security-severity score onto DefectDojo's severity scale
+// using the CVSS v3 qualitative bands
+func severity(score float64) string {
+	switch {
+	case score >= 9.0:
+		return "Critical"
+	case score >= 7.0:
+		return "High"
+	case score >= 4.0:
+		return "Medium"
+	case score > 0:
+		return "Low"
+	default:
+		return "Info"
+	}
+}
+
+// severityScale lists DefectDojo's severities from the least severe
+var severityScale = []string{"Info", "Low", "Medium", "High", "Critical"}
+
+// levelCeilings are the most severe DefectDojo severities of findings
+// lowered below error, by a level tag, PII mode or a severity override
+var levelCeilings = map[detector.Severity]string{
+	detector.SeverityWarning: "Medium",
+	detector.SeverityNote:    "Low",
+}
+
+// levelSeverity maps a finding at level onto DefectDojo's severity scale:
+// the band of its rule's security-severity score (see severity), capped at
+// Medium for warnings and Low for notes. Errors, and findings without a
+// level, take the band of the score.
+func levelSeverity(level detector.Severity, score float64) string {
+	s := severity(score)
+	if ceiling, ok := levelCeilings[level]; ok && slices.Index(severityScale, s) > slices.Index(severityScale, ceiling) {
+		return ceiling
+	}
+	return s
+}
+
+// cweNumber returns the number of the first CWE ID ("CWE-532" → 532), or 0
+func cweNumber(ids []string) int {
+	for _, id := range ids {
+		if n, err := strconv.Atoi(strings.TrimPrefix(id, "CWE-")); err == nil {
+			return n
+		}
+	}
+	return 0
+}
+
+// uniqueID prefers the finding's ID, its content fingerprint made unique
+// among repeats (see detector.AssignFingerprints), which survives line
+// moves, and falls back to the rule and location.
+func uniqueID(f detector.Finding, ruleID, path string, line int) string {
+	if f.ID != "" {
+		return f.ID
+	}
+	return fmt.Sprintf("%s:%s:%d", ruleID, path, line)
+}
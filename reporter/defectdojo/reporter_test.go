@@ -0,0 +1,271 @@
+package defectdojo
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nilpoona/leakhound/config"
+	"github.com/nilpoona/leakhound/detector"
+)
+
+func TestReporter_Report(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/home/user/project/internal/user.go", 1, 100)
+	file.SetLines([]int{0, 20, 40, 60})
+
+	high := 9.5
+	tests := []struct {
+		name    string
+		cfg     *config.Config
+		finding detector.Finding
+		want    Finding
+	}{
+		{
+			name: "field finding with default severity",
+			finding: detector.Finding{
+				Pos:     file.Pos(45),
+				Message: "sensitive field 'User.Password' should not be logged",
+				RuleID:  detector.RuleIDSensitiveField,
+				Field:   "User.Password",
+				Sink:    "log/slog.Info",
+			},
+			want: Finding{
+				Title:            "LH0004: Sensitive struct field is logged",
+				Severity:         "High",
+				Mitigation:       "Avoid logging fields marked as sensitive. Remove the field from the log call or redact its value.",
+				References:       "https://github.com/nilpoona/leakhound#LH0004",
+				Date:             "2025-01-02",
+				CWE:              532,
+				FilePath:         "internal/user.go",
+				Line:             3,
+				VulnIDFromTool:   "LH0004",
+				UniqueIDFromTool: "LH0004:internal/user.go:3",
+				StaticFinding:    true,
+				Active:           true,
+			},
+		},
+		{
+			name: "configured security-severity and suppression",
+			cfg: &config.Config{Rules: map[string]config.RuleConfig{
+				"LH0003": {SecuritySeverity: &high},
+			}},
+			finding: detector.Finding{
+				Pos:        file.Pos(5),
+				Message:    "struct 'User' contains sensitive fields and should not be logged",
+				RuleID:     detector.RuleIDSensitiveStruct,
+				Expr:       "u",
+				Func:       "example.com/app.handle",
+				Suppressed: true,
+			},
+			want: Finding{
+				Title:            "LH0003: Struct containing sensitive fields is logged",
+				Severity:         "Critical",
+				Mitigation:       "Avoid logging entire structs that contain sensitive fields. Log only the non-sensitive fields individually.",
+				References:       "https://github.com/nilpoona/leakhound#LH0003",
+				Date:             "2025-01-02",
+				CWE:              532,
+				FilePath:         "internal/user.go",
+				Line:             1,
+				VulnIDFromTool:   "LH0003",
+				UniqueIDFromTool: detector.Finding{RuleID: detector.RuleIDSensitiveStruct, Expr: "u", Func: "example.com/app.handle"}.Fingerprint(),
+				StaticFinding:    true,
+				Active:           false,
+			},
+		},
+		{
+			name: "owners from CODEOWNERS as tags",
+			finding: detector.Finding{
+				Pos:     file.Pos(45),
+				Message: "sensitive field 'User.Password' should not be logged",
+				RuleID:  detector.RuleIDSensitiveField,
+				Owners:  []string{"@org/payments", "@alice"},
+			},
+			want: Finding{
+				Title:            "LH0004: Sensitive struct field is logged",
+				Severity:         "High",
+				Mitigation:       "Avoid logging fields marked as sensitive. Remove the field from the log call or redact its value.",
+				References:       "https://github.com/nilpoona/leakhound#LH0004",
+				Date:             "2025-01-02",
+				CWE:              532,
+				FilePath:         "internal/user.go",
+				Line:             3,
+				VulnIDFromTool:   "LH0004",
+				UniqueIDFromTool: "LH0004:internal/user.go:3",
+				StaticFinding:    true,
+				Active:           true,
+				Tags:             []string{"@org/payments", "@alice"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewReporter("/home/user/project", tt.cfg)
+			r.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+			r.AddFindings([]detector.Finding{tt.finding}, fset)
+
+			var buf bytes.Buffer
+			if err := r.Report(&buf, nil); err != nil {
+				t.Fatalf("Report() error = %v", err)
+			}
+			var doc Document
+			if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("Report() produced invalid JSON: %v", err)
+			}
+			if len(doc.Findings) != 1 {
+				t.Fatalf("got %d findings, want 1", len(doc.Findings))
+			}
+			got := doc.Findings[0]
+			if got.Description == "" {
+				t.Error("Description is empty")
+			}
+			if want := "**Confidence:** 1.00 (high)"; !strings.Contains(got.Description, want) {
+				t.Errorf("Description = %q, want it to contain %q", got.Description, want)
+			}
+			got.Description = ""
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("finding mismatch\ngot:  %+v\nwant: %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReporter_ReportEmpty(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewReporter("/tmp", nil).Report(&buf, nil); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	// DefectDojo rejects a missing findings key, so an empty run must still emit []
+	if !bytes.Contains(buf.Bytes(), []byte(`"findings": []`)) {
+		t.Errorf("Report() = %s, want an empty findings array", buf.String())
+	}
+}
+
+func TestReporter_ReportRepeatedFindings(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/home/user/project/dup.go", 1, 100)
+	file.SetLines([]int{0, 20, 40, 60})
+
+	// The same value logged twice to the same sink of a function shares a
+	// fingerprint, but DefectDojo must not merge the two findings
+	leak := detector.Finding{RuleID: detector.RuleIDSensitiveField, Func: "example.com/app.handle", Sink: "log.Println", Expr: "u.Password"}
+	first, second := leak, leak
+	first.Pos, second.Pos = file.Pos(25), file.Pos(65)
+
+	r := NewReporter("/home/user/project", nil)
+	r.AddFindings([]detector.Finding{second, first}, fset)
+
+	var buf bytes.Buffer
+	if err := r.Report(&buf, nil); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	var doc Document
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Report() produced invalid JSON: %v", err)
+	}
+	got := map[int]string{}
+	for _, f := range doc.Findings {
+		got[f.Line] = f.UniqueIDFromTool
+	}
+	fp := leak.Fingerprint()
+	want := map[int]string{2: fp, 4: fp + ":1"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unique IDs by line = %v, want %v", got, want)
+	}
+}
+
+func TestReporter_ReportLevel(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/home/user/project/user.go", 1, 100)
+	file.SetLines([]int{0, 20, 40, 60})
+
+	// LH0004 scores High; a finding lowered by a level tag or an override
+	// must not be reported as High
+	tests := []struct {
+		level detector.Severity
+		want  string
+	}{
+		{detector.SeverityError, "High"},
+		{"", "High"},
+		{detector.SeverityWarning, "Medium"},
+		{detector.SeverityNote, "Low"},
+	}
+	for _, tt := range tests {
+		r := NewReporter("/home/user/project", nil)
+		r.AddFindings([]detector.Finding{{Pos: file.Pos(5), RuleID: detector.RuleIDSensitiveField, Severity: tt.level}}, fset)
+
+		var buf bytes.Buffer
+		if err := r.Report(&buf, nil); err != nil {
+			t.Fatalf("Report() error = %v", err)
+		}
+		var doc Document
+		if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+			t.Fatalf("Report() produced invalid JSON: %v", err)
+		}
+		if got := doc.Findings[0].Severity; got != tt.want {
+			t.Errorf("level %q: severity = %q, want %q", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestLevelSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		level detector.Severity
+		score float64
+		want  string
+	}{
+		{detector.SeverityError, 9.5, "Critical"},
+		{detector.SeverityError, 3.0, "Low"},
+		{"", 7.5, "High"},
+		{detector.SeverityWarning, 9.5, "Medium"},
+		{detector.SeverityWarning, 3.0, "Low"},
+		{detector.SeverityNote, 7.5, "Low"},
+		{detector.SeverityNote, 0, "Info"},
+	}
+	for _, tt := range tests {
+		if got := levelSeverity(tt.level, tt.score); got != tt.want {
+			t.Errorf("levelSeverity(%q, %v) = %q, want %q", tt.level, tt.score, got, tt.want)
+		}
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{10.0, "Critical"},
+		{9.0, "Critical"},
+		{8.0, "High"},
+		{7.0, "High"},
+		{6.5, "Medium"},
+		{4.0, "Medium"},
+		{0.1, "Low"},
+		{0, "Info"},
+	}
+	for _, tt := range tests {
+		if got := severity(tt.score); got != tt.want {
+			t.Errorf("severity(%v) = %q, want %q", tt.score, got, tt.want)
+		}
+	}
+}
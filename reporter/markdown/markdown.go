@@ -0,0 +1,128 @@
+// Package markdown writes a Markdown summary of a run, such as the job
+// summary of a GitHub Actions step.
+package markdown
+
+import (
+	"fmt"
+	"go/token"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// EnvStepSummary is set by GitHub Actions to the file whose Markdown is
+// shown on the run's summary page
+const EnvStepSummary = "GITHUB_STEP_SUMMARY"
+
+// maxRows bounds the findings listed, as GitHub caps step summaries at 1MiB
+const maxRows = 50
+
+// WriteSummary writes the unsuppressed findings as Markdown: their count per
+// rule, then a table of the first findings with their file paths relative to
+// workDir. An owners column is added when any finding has owners.
+func WriteSummary(w io.Writer, findings []detector.Finding, fset *token.FileSet, workDir string) error {
+	var active []detector.Finding
+	suppressed := 0
+	for _, f := range findings {
+		if f.Suppressed {
+			suppressed++
+			continue
+		}
+		active = append(active, f)
+	}
+
+	var b strings.Builder
+	b.WriteString("### leakhound\n\n")
+	if len(active) == 0 {
+		b.WriteString("No leaks found")
+	} else {
+		fmt.Fprintf(&b, "**%d finding%s**", len(active), plural(len(active)))
+	}
+	if suppressed > 0 {
+		fmt.Fprintf(&b, " (%d suppressed)", suppressed)
+	}
+	b.WriteString("\n")
+
+	if len(active) > 0 {
+		writeRuleCounts(&b, active)
+		writeFindings(&b, active, fset, workDir)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeRuleCounts writes the number of findings per rule, by rule ID
+func writeRuleCounts(b *strings.Builder, findings []detector.Finding) {
+	counts := make(map[string]int)
+	for _, f := range findings {
+		counts[f.SARIFRuleID()]++
+	}
+	ids := make([]string, 0, len(counts))
+	for id := range counts {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	b.WriteString("\n| Rule | Description | Findings |\n| --- | --- | ---: |\n")
+	for _, id := range ids {
+		doc, _ := detector.LookupRuleDoc(id)
+		fmt.Fprintf(b, "| %s | %s | %d |\n", id, escape(doc.Short), counts[id])
+	}
+}
+
+// writeFindings lists the first maxRows findings
+func writeFindings(b *strings.Builder, findings []detector.Finding, fset *token.FileSet, workDir string) {
+	owned := false
+	for _, f := range findings {
+		owned = owned || len(f.Owners) > 0
+	}
+
+	b.WriteString("\n| Location | Rule | Confidence | Message |")
+	if owned {
+		b.WriteString(" Owners |")
+	}
+	b.WriteString("\n| --- | --- | --- | --- |")
+	if owned {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+
+	for i, f := range findings {
+		if i == maxRows {
+			fmt.Fprintf(b, "\n… and %d more\n", len(findings)-maxRows)
+			break
+		}
+		pos := fset.Position(f.Pos)
+		fmt.Fprintf(b, "| `%s:%d` | %s | %.2f | %s |", relativePath(pos.Filename, workDir), pos.Line, f.SARIFRuleID(), f.ConfidenceScore(), escape(f.Message))
+		if owned {
+			fmt.Fprintf(b, " %s |", escape(strings.Join(f.Owners, " ")))
+		}
+		b.WriteString("\n")
+	}
+}
+
+// relativePath converts path to a slash-separated path relative to workDir
+func relativePath(path, workDir string) string {
+	if rel, err := filepath.Rel(workDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		path = rel
+	}
+	return filepath.ToSlash(path)
+}
+
+// escape keeps s on a single table cell: pipes would end the cell and
+// newlines the row
+func escape(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+// plural returns "s" unless n is 1
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
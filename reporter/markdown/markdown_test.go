@@ -0,0 +1,117 @@
+package markdown
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// testFileSet returns a file set with /work/app/a.go, whose line n starts
+// at token.Pos(10*(n-1)+1)
+func testFileSet(t *testing.T) *token.FileSet {
+	t.Helper()
+	fset := token.NewFileSet()
+	file := fset.AddFile("/work/app/a.go", 1, 1000)
+	lines := make([]int, 100)
+	for i := range lines {
+		lines[i] = 10 * i
+	}
+	file.SetLines(lines)
+	return fset
+}
+
+func TestWriteSummary(t *testing.T) {
+	t.Parallel()
+
+	many := make([]detector.Finding, maxRows+3)
+	for i := range many {
+		many[i] = detector.Finding{Pos: token.Pos(1), RuleID: "sensitive-field", Message: fmt.Sprintf("leak %d", i)}
+	}
+
+	tests := []struct {
+		name     string
+		findings []detector.Finding
+		want     []string
+		notWant  []string
+	}{
+		{
+			name:     "no findings",
+			findings: nil,
+			want:     []string{"### leakhound\n\nNo leaks found\n"},
+			notWant:  []string{"| Rule |"},
+		},
+		{
+			name: "suppressed only",
+			findings: []detector.Finding{
+				{Pos: token.Pos(1), RuleID: "sensitive-field", Message: "leak", Suppressed: true},
+			},
+			want:    []string{"No leaks found (1 suppressed)\n"},
+			notWant: []string{"| Location |"},
+		},
+		{
+			name: "counts per rule",
+			findings: []detector.Finding{
+				{Pos: token.Pos(21), RuleID: "sensitive-field", Message: "leak a"},
+				{Pos: token.Pos(31), RuleID: "sensitive-var", Message: "leak b", FlowPath: []detector.FlowStep{{Label: "User.Password"}, {Label: "pw"}}},
+				{Pos: token.Pos(41), RuleID: "sensitive-field", Message: "leak c", Suppressed: true},
+			},
+			want: []string{
+				"**2 findings** (1 suppressed)\n",
+				"| LH0004 | ",
+				" | 1 |\n",
+				"| `app/a.go:3` | LH0004 | 1.00 | leak a |\n",
+				"| `app/a.go:4` | LH0001 | 0.75 | leak b |\n",
+			},
+			notWant: []string{"leak c", "Owners"},
+		},
+		{
+			name: "escaped message",
+			findings: []detector.Finding{
+				{Pos: token.Pos(1), RuleID: "sensitive-field", Message: "a|b\nc"},
+			},
+			want: []string{"**1 finding**\n", `| a\|b c |`},
+		},
+		{
+			name: "owners",
+			findings: []detector.Finding{
+				{Pos: token.Pos(1), RuleID: "sensitive-field", Message: "leak a", Owners: []string{"@org/a", "@bob"}},
+				{Pos: token.Pos(11), RuleID: "sensitive-field", Message: "leak b"},
+			},
+			want: []string{
+				"| Location | Rule | Confidence | Message | Owners |\n| --- | --- | --- | --- | --- |\n",
+				"| leak a | @org/a @bob |\n",
+				"| leak b |  |\n",
+			},
+		},
+		{
+			name:     "capped rows",
+			findings: many,
+			want:     []string{fmt.Sprintf("leak %d |", maxRows-1), "\n… and 3 more\n"},
+			notWant:  []string{fmt.Sprintf("leak %d |", maxRows)},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var b strings.Builder
+			if err := WriteSummary(&b, tt.findings, testFileSet(t), "/work"); err != nil {
+				t.Fatalf("WriteSummary() error = %v", err)
+			}
+			got := b.String()
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("WriteSummary() missing %q in:\n%s", want, got)
+				}
+			}
+			for _, notWant := range tt.notWant {
+				if strings.Contains(got, notWant) {
+					t.Errorf("WriteSummary() contains %q in:\n%s", notWant, got)
+				}
+			}
+		})
+	}
+}
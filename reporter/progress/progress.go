@@ -0,0 +1,141 @@
+// Package progress reports the progress of a whole-program run, package by
+// package, so long runs are not silent until the end: as text lines for
+// people, or as an NDJSON stream, one JSON object per line, for CI wrappers.
+package progress
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// Progress formats
+const (
+	FormatText   = "text"
+	FormatNDJSON = "ndjson"
+)
+
+// Formats are the valid progress formats
+var Formats = []string{FormatText, FormatNDJSON}
+
+// Event is one line of the NDJSON stream
+type Event struct {
+	Phase    string   `json:"phase"`              // loaded, collected, propagated or analyzed
+	Package  string   `json:"package,omitempty"`  // Import path, omitted for propagated
+	Done     int      `json:"done"`               // Packages done with the phase
+	Total    int      `json:"total"`              // Packages in the phase
+	Findings *int     `json:"findings,omitempty"` // Findings in the package before suppression, when analyzed
+	Elapsed  float64  `json:"elapsed"`            // Seconds since the start of the run
+	ETA      *float64 `json:"eta,omitempty"`      // Estimated seconds until the phase is done, once a package is; omitted for loaded
+}
+
+// Reporter writes progress events to a writer, usually stderr. It is safe for
+// concurrent use.
+type Reporter struct {
+	mu         sync.Mutex
+	w          io.Writer
+	format     string
+	now        func() time.Time
+	start      time.Time
+	phaseStart map[string]time.Time
+}
+
+// NewReporter returns a reporter writing progress to w in format, started
+// now
+func NewReporter(w io.Writer, format string) (*Reporter, error) {
+	return newReporter(w, format, time.Now)
+}
+
+func newReporter(w io.Writer, format string, now func() time.Time) (*Reporter, error) {
+	if format != FormatText && format != FormatNDJSON {
+		return nil, fmt.Errorf("invalid progress format %q: want %s or %s", format, FormatText, FormatNDJSON)
+	}
+	return &Reporter{w: w, format: format, now: now, start: now(), phaseStart: make(map[string]time.Time)}, nil
+}
+
+// Report writes p. Write errors are ignored: progress is best effort and
+// must not fail the run.
+func (r *Reporter) Report(p detector.Progress) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := r.event(p)
+	if r.format == FormatNDJSON {
+		line, err := json.Marshal(e)
+		if err != nil {
+			return
+		}
+		r.w.Write(append(line, '\n'))
+		return
+	}
+	fmt.Fprintln(r.w, formatText(e))
+}
+
+// event returns the event for p, estimating the time left in its phase from
+// the time its packages took so far
+func (r *Reporter) event(p detector.Progress) Event {
+	now := r.now()
+	// A phase starts when the previous one ended, so its first package is
+	// timed too
+	phaseStart, ok := r.phaseStart[p.Phase]
+	if !ok {
+		phaseStart = r.start
+		for _, t := range r.phaseStart {
+			if t.After(phaseStart) {
+				phaseStart = t
+			}
+		}
+		r.phaseStart[p.Phase] = phaseStart
+	}
+	if p.Done == p.Total {
+		r.phaseStart[p.Phase] = now // Phases that follow start from here
+	}
+
+	e := Event{
+		Phase:   p.Phase,
+		Package: p.Package,
+		Done:    p.Done,
+		Total:   p.Total,
+		Elapsed: round(now.Sub(r.start).Seconds()),
+	}
+	if p.Phase == detector.PhaseAnalyzed {
+		findings := p.Findings
+		e.Findings = &findings
+	}
+	// Packages are loaded all at once and reported once they are
+	if p.Phase != detector.PhaseLoaded && p.Done > 0 && p.Done < p.Total {
+		perPackage := now.Sub(phaseStart).Seconds() / float64(p.Done)
+		eta := round(perPackage * float64(p.Total-p.Done))
+		e.ETA = &eta
+	}
+	return e
+}
+
+// formatText renders e as a line for people, e.g.
+//
+//	leakhound: [12/340] analyzed example.com/app/auth: 3 findings (ETA 1m20s)
+func formatText(e Event) string {
+	line := fmt.Sprintf("leakhound: [%d/%d] %s", e.Done, e.Total, e.Phase)
+	if e.Package != "" {
+		line += " " + e.Package
+	}
+	if e.Findings != nil {
+		line += fmt.Sprintf(": %d finding", *e.Findings)
+		if *e.Findings != 1 {
+			line += "s"
+		}
+	}
+	if e.ETA != nil {
+		line += fmt.Sprintf(" (ETA %s)", (time.Duration(*e.ETA * float64(time.Second))).Round(time.Second))
+	}
+	return line
+}
+
+// round rounds seconds to milliseconds
+func round(seconds float64) float64 {
+	return float64(time.Duration(seconds*float64(time.Second)).Round(time.Millisecond).Milliseconds()) / 1000
+}
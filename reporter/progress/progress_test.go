@@ -0,0 +1,106 @@
+package progress
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// clock returns a clock starting at a fixed time and advancing by the given
+// steps on each call after the first
+func clock(steps ...time.Duration) func() time.Time {
+	t := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	i := -1
+	return func() time.Time {
+		if i >= 0 && i < len(steps) {
+			t = t.Add(steps[i])
+		}
+		i++
+		return t
+	}
+}
+
+func TestReporter_Text(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	r, err := newReporter(&buf, FormatText, clock(time.Second, 2*time.Second, time.Second, time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Report(detector.Progress{Phase: detector.PhaseCollected, Package: "example.com/a", Done: 1, Total: 2})
+	r.Report(detector.Progress{Phase: detector.PhaseCollected, Package: "example.com/b", Done: 2, Total: 2})
+	r.Report(detector.Progress{Phase: detector.PhasePropagated, Done: 1, Total: 1})
+	r.Report(detector.Progress{Phase: detector.PhaseAnalyzed, Package: "example.com/a", Done: 1, Total: 2, Findings: 1})
+
+	want := []string{
+		"leakhound: [1/2] collected example.com/a (ETA 1s)",
+		"leakhound: [2/2] collected example.com/b",
+		"leakhound: [1/1] propagated",
+		"leakhound: [1/2] analyzed example.com/a: 1 finding (ETA 1s)",
+	}
+	if got := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n"); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("output =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestReporter_NDJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	r, err := newReporter(&buf, FormatNDJSON, clock(500*time.Millisecond, 500*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Report(detector.Progress{Phase: detector.PhaseAnalyzed, Package: "example.com/a", Done: 1, Total: 4, Findings: 0})
+	r.Report(detector.Progress{Phase: detector.PhaseAnalyzed, Package: "example.com/b", Done: 4, Total: 4, Findings: 2})
+
+	var events []Event
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var e Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+		}
+		events = append(events, e)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	first := events[0]
+	if first.Phase != "analyzed" || first.Package != "example.com/a" || first.Done != 1 || first.Total != 4 ||
+		first.Findings == nil || *first.Findings != 0 || first.Elapsed != 0.5 || first.ETA == nil || *first.ETA != 1.5 {
+		t.Errorf("events[0] = %+v, want example.com/a analyzed 1/4 with 0 findings after 0.5s, ETA 1.5s", first)
+	}
+	last := events[1]
+	if last.Findings == nil || *last.Findings != 2 || last.Elapsed != 1 || last.ETA != nil {
+		t.Errorf("events[1] = %+v, want 2 findings after 1s and no ETA", last)
+	}
+}
+
+func TestNewReporter_InvalidFormat(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewReporter(&bytes.Buffer{}, "json"); err == nil {
+		t.Error("NewReporter(json) error = nil, want error")
+	}
+}
+
+func TestReporter_LoadedHasNoETA(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	r, err := newReporter(&buf, FormatText, clock(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r.Report(detector.Progress{Phase: detector.PhaseLoaded, Package: "example.com/a", Done: 1, Total: 2})
+	if got, want := buf.String(), "leakhound: [1/2] loaded example.com/a\n"; got != want {
+		t.Errorf("output = %q, want %q", got, want)
+	}
+}
@@ -1,49 +1,122 @@
+// Package reporter defines the interface output formats implement, and the
+// registry formats register themselves in, so the driver writes any format
+// the same way: resolve the findings, look the format up, report.
+//
+// A format is added by a package registering a Factory in its init function,
+// the way the text, sarif and defectdojo packages do; importing the package
+// makes the format available.
 package reporter
 
 import (
 	"fmt"
-	"os"
+	"go/token"
+	"io"
+	"slices"
+	"sort"
+	"sync"
 
+	"github.com/nilpoona/leakhound/config"
 	"github.com/nilpoona/leakhound/detector"
-	"github.com/nilpoona/leakhound/reporter/sarif"
-	"github.com/nilpoona/leakhound/reporter/text"
-	"golang.org/x/tools/go/analysis"
 )
 
-// Format specifies the output format
-type Format string
+// Finding is a finding resolved for output: its location as file, line and
+// column, and its effective rule ID and level
+type Finding struct {
+	Finding detector.Finding
+	Start   token.Position    // Start of the finding
+	End     token.Position    // End of the finding; invalid when unknown or in another file
+	RuleID  string            // SARIF rule ID, e.g. "LH0001"
+	Level   detector.Severity // Severity after overrides
 
-const (
-	FormatText  Format = "text"
-	FormatSARIF Format = "sarif"
-)
+	// Fset resolves the other positions the finding carries, such as those of
+	// its flow steps
+	Fset *token.FileSet
+}
 
-// Reporter is the interface that all reporters must implement
+// Resolve resolves the positions of findings, reported by analyzing files of
+// fset. Findings that did not go through the post-processing pipeline get
+// their IDs assigned here (see detector.AssignFingerprints).
+func Resolve(findings []detector.Finding, fset *token.FileSet) []Finding {
+	if slices.ContainsFunc(findings, func(f detector.Finding) bool { return f.ID == "" && f.Expr != "" }) {
+		findings = detector.AssignFingerprints(slices.Clone(findings), nil)
+	}
+	resolved := make([]Finding, 0, len(findings))
+	for _, f := range findings {
+		r := Finding{
+			Finding: f,
+			Start:   fset.Position(f.Pos),
+			RuleID:  f.SARIFRuleID(),
+			Level:   f.Level(),
+			Fset:    fset,
+		}
+		if f.End.IsValid() && f.End > f.Pos {
+			if end := fset.Position(f.End); end.Filename == r.Start.Filename {
+				r.End = end
+			}
+		}
+		resolved = append(resolved, r)
+	}
+	return resolved
+}
+
+// Reporter writes findings in an output format
 type Reporter interface {
-	Report(findings []detector.Finding) error
+	// Report writes findings to w. Formats that aggregate findings also
+	// write the findings added to them before.
+	Report(w io.Writer, findings []Finding) error
 }
 
-// Config configures the reporter
-type Config struct {
-	Format  Format
-	WorkDir string // For SARIF: base directory for relative paths
+// Options configures a reporter created by a Factory. Formats ignore the
+// options that do not apply to them.
+type Options struct {
+	WorkDir   string         // Base directory of relative paths (for SARIF, the source root)
+	Rules     *config.Config // Per-rule settings such as security-severity; nil uses the defaults
+	Verbosity int            // For text: 1 prints findings, 2 adds taint flows
+	Color     bool           // For text: colorize the output
+	AbsPaths  bool           // For text: print absolute paths
 }
 
-// New creates a reporter based on the given configuration
-func New(pass *analysis.Pass, config Config) (Reporter, error) {
-	switch config.Format {
-	case FormatText, "":
-		return text.NewReporter(pass), nil
-	case FormatSARIF:
-		if config.WorkDir == "" {
-			wd, err := os.Getwd()
-			if err != nil {
-				return nil, fmt.Errorf("failed to get working directory: %w", err)
-			}
-			config.WorkDir = wd
-		}
-		return sarif.NewReporter(pass, os.Stdout, config.WorkDir), nil
-	default:
-		return nil, fmt.Errorf("unsupported format: %s", config.Format)
+// Factory creates a reporter for a format
+type Factory func(opts Options) Reporter
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register makes a format available under name. It panics if the name is
+// already registered or factory is nil.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if factory == nil {
+		panic("reporter: Register factory is nil for format " + name)
+	}
+	if _, dup := registry[name]; dup {
+		panic("reporter: Register called twice for format " + name)
+	}
+	registry[name] = factory
+}
+
+// New creates a reporter for the format registered under name
+func New(name string, opts Options) (Reporter, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported format: %s", name)
+	}
+	return factory(opts), nil
+}
+
+// Formats returns the names of the registered formats, sorted
+func Formats() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+	return names
 }
@@ -0,0 +1,162 @@
+package reporter
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// lineReporter writes the rule ID and location of each finding on a line
+type lineReporter struct {
+	prefix string
+}
+
+func (r lineReporter) Report(w io.Writer, findings []Finding) error {
+	for _, f := range findings {
+		if _, err := fmt.Fprintf(w, "%s%s %s:%d:%d\n", r.prefix, f.RuleID, f.Start.Filename, f.Start.Line, f.Start.Column); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/home/user/project/main.go", -1, 100)
+	file.SetLinesForContent([]byte("package main\n\nfunc main() {}\n"))
+	other := fset.AddFile("/home/user/project/other.go", -1, 100)
+	pos := func(f *token.File, line, col int) token.Pos { return f.LineStart(line) + token.Pos(col-1) }
+
+	tests := []struct {
+		name      string
+		finding   detector.Finding
+		wantStart string
+		wantEnd   string // "-" when the end is invalid
+		wantRule  string
+		wantLevel detector.Severity
+	}{
+		{
+			name:      "with end",
+			finding:   detector.Finding{Pos: pos(file, 3, 6), End: pos(file, 3, 10), RuleID: detector.RuleIDSensitiveVar},
+			wantStart: "/home/user/project/main.go:3:6",
+			wantEnd:   "/home/user/project/main.go:3:10",
+			wantRule:  "LH0001",
+			wantLevel: detector.SeverityError,
+		},
+		{
+			name:      "without end",
+			finding:   detector.Finding{Pos: pos(file, 1, 1), RuleID: detector.RuleIDSensitiveField, Severity: detector.SeverityNote},
+			wantStart: "/home/user/project/main.go:1:1",
+			wantEnd:   "-",
+			wantRule:  "LH0004",
+			wantLevel: detector.SeverityNote,
+		},
+		{
+			name:      "end in another file",
+			finding:   detector.Finding{Pos: pos(file, 3, 1), End: other.Pos(10), RuleID: detector.RuleIDSensitiveVar},
+			wantStart: "/home/user/project/main.go:3:1",
+			wantEnd:   "-",
+			wantRule:  "LH0001",
+			wantLevel: detector.SeverityError,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Resolve([]detector.Finding{tt.finding}, fset)
+			if len(got) != 1 {
+				t.Fatalf("Resolve() returned %d findings, want 1", len(got))
+			}
+			f := got[0]
+			if f.Start.String() != tt.wantStart {
+				t.Errorf("Start = %s, want %s", f.Start, tt.wantStart)
+			}
+			if f.End.String() != tt.wantEnd {
+				t.Errorf("End = %s, want %s", f.End, tt.wantEnd)
+			}
+			if f.RuleID != tt.wantRule {
+				t.Errorf("RuleID = %q, want %q", f.RuleID, tt.wantRule)
+			}
+			if f.Level != tt.wantLevel {
+				t.Errorf("Level = %q, want %q", f.Level, tt.wantLevel)
+			}
+			if f.Fset != fset {
+				t.Error("Fset is not the FileSet of the findings")
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	Register("test-lines", func(opts Options) Reporter {
+		return lineReporter{prefix: opts.WorkDir + ": "}
+	})
+
+	if !slices.Contains(Formats(), "test-lines") {
+		t.Errorf("Formats() = %v, want it to contain test-lines", Formats())
+	}
+	if !slices.IsSorted(Formats()) {
+		t.Errorf("Formats() = %v, want them sorted", Formats())
+	}
+
+	rep, err := New("test-lines", Options{WorkDir: "root"})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	fset := token.NewFileSet()
+	fset.AddFile("/home/user/project/main.go", 1, 100)
+	findings := Resolve([]detector.Finding{{Pos: token.Pos(5), RuleID: detector.RuleIDSensitiveCall}}, fset)
+
+	var buf bytes.Buffer
+	if err := rep.Report(&buf, findings); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	if want := "root: LH0002 /home/user/project/main.go:1:5\n"; buf.String() != want {
+		t.Errorf("Report() wrote %q, want %q", buf.String(), want)
+	}
+}
+
+func TestRegister_Panics(t *testing.T) {
+	t.Parallel()
+
+	Register("test-duplicate", func(Options) Reporter { return lineReporter{} })
+
+	tests := []struct {
+		name    string
+		format  string
+		factory Factory
+	}{
+		{"duplicate", "test-duplicate", func(Options) Reporter { return lineReporter{} }},
+		{"nil factory", "test-nil", nil},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) did not panic", tt.format)
+				}
+			}()
+			Register(tt.format, tt.factory)
+		})
+	}
+}
+
+func TestNew_UnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	if _, err := New("test-unknown", Options{}); err == nil {
+		t.Error("New() error = nil, want an unsupported format error")
+	}
+}
@@ -1,71 +1,186 @@
 package sarif
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
 	"go/token"
 	"io"
-	"path/filepath"
+	"slices"
+	"time"
 
+	"github.com/nilpoona/leakhound/config"
 	"github.com/nilpoona/leakhound/detector"
+	"github.com/nilpoona/leakhound/reporter"
 )
 
-// FindingWithFset pairs a finding with its FileSet for position information
-type FindingWithFset struct {
-	Finding detector.Finding
-	Fset    *token.FileSet
+func init() {
+	reporter.Register("sarif", func(opts reporter.Options) reporter.Reporter {
+		return NewAggregatingReporterWithConfig(opts.WorkDir, opts.Rules)
+	})
 }
 
 // AggregatingReporter collects findings from multiple packages and builds a single SARIF document
 type AggregatingReporter struct {
-	workDir  string
-	findings []FindingWithFset
-	version  string // Tool version
+	workDir    string
+	findings   []reporter.Finding
+	version    string           // Tool version
+	cfg        *config.Config   // Per-rule security-severity and rank; nil uses defaults
+	invocation *Invocation      // Optional run bookkeeping, see SetInvocation
+	now        func() time.Time // Clock for the invocation end time; nil means time.Now
+
+	notifications []Notification // Emitted as the invocation's toolExecutionNotifications
+
+	pathMappings []PathMapping // Applied to file paths before making them relative
+
+	validate bool // Validate the document against the SARIF schema before writing it
 }
 
 // NewAggregatingReporter creates a new aggregating reporter for multi-package analysis
 func NewAggregatingReporter(workDir string) *AggregatingReporter {
 	return &AggregatingReporter{
 		workDir:  workDir,
-		findings: []FindingWithFset{},
+		findings: []reporter.Finding{},
 		version:  Version, // Capture version at creation time
 	}
 }
 
-// AddFindings adds findings from a single package analysis
-func (r *AggregatingReporter) AddFindings(findings []detector.Finding, fset *token.FileSet) {
-	for _, f := range findings {
-		r.findings = append(r.findings, FindingWithFset{
-			Finding: f,
-			Fset:    fset,
+// NewAggregatingReporterWithConfig creates an aggregating reporter that
+// applies the per-rule security-severity and rank settings from cfg
+func NewAggregatingReporterWithConfig(workDir string, cfg *config.Config) *AggregatingReporter {
+	r := NewAggregatingReporter(workDir)
+	r.cfg = cfg
+	return r
+}
+
+// SetPathMappings sets the path prefix mappings applied to file paths before
+// they are made relative to workDir
+func (r *AggregatingReporter) SetPathMappings(mappings []PathMapping) {
+	r.pathMappings = mappings
+}
+
+// SetInvocation records how the tool was run. The invocation is emitted as
+// run.invocations, with its end time and exit code filled in when the report
+// is written.
+func (r *AggregatingReporter) SetInvocation(inv Invocation) {
+	r.invocation = &inv
+}
+
+// AddNotifications adds notifications about the run, such as packages
+// degraded to direct-access-only detection by the memory budget. They are
+// emitted as the invocation's toolExecutionNotifications; a panic recovered
+// while analyzing a package is reported with its exception and stack.
+func (r *AggregatingReporter) AddNotifications(notes []detector.Notification) {
+	for _, n := range notes {
+		r.notifications = append(r.notifications, Notification{
+			Level:     string(n.Level),
+			Message:   Message{Text: n.Message},
+			Exception: buildException(n),
 		})
 	}
 }
 
-// Report builds and writes a single SARIF document containing all collected findings
-func (r *AggregatingReporter) Report(writer io.Writer) error {
-	doc := r.buildDocument()
-	encoder := json.NewEncoder(writer)
+// buildException returns the exception of a notification reporting a panic,
+// or nil for other notifications. Stack frames are located in leakhound's
+// own sources rather than the analyzed code, so their files are absolute
+// file URIs instead of paths relative to %SRCROOT%.
+func buildException(n detector.Notification) *Exception {
+	if n.PanicType == "" {
+		return nil
+	}
+	exc := &Exception{Kind: n.PanicType, Message: n.Message}
+	if len(n.Stack) == 0 {
+		return exc
+	}
+	exc.Stack = &Stack{}
+	for _, frame := range n.Stack {
+		exc.Stack.Frames = append(exc.Stack.Frames, StackFrame{
+			Location: Location{
+				PhysicalLocation: PhysicalLocation{
+					ArtifactLocation: ArtifactLocation{URI: fileURI(frame.File)},
+					Region:           Region{StartLine: frame.Line},
+				},
+				LogicalLocations: []LogicalLocation{{FullyQualifiedName: frame.Function, Kind: "function"}},
+			},
+		})
+	}
+	return exc
+}
+
+// AddFindings adds findings from a single package analysis
+func (r *AggregatingReporter) AddFindings(findings []detector.Finding, fset *token.FileSet) {
+	r.findings = append(r.findings, reporter.Resolve(findings, fset)...)
+}
+
+// SetValidate sets whether Report validates the document against the SARIF
+// schema (see Validate) before writing it, failing instead of writing an
+// invalid document
+func (r *AggregatingReporter) SetValidate(validate bool) {
+	r.validate = validate
+}
+
+// Report builds and writes a single SARIF document containing the collected
+// findings followed by findings
+func (r *AggregatingReporter) Report(writer io.Writer, findings []reporter.Finding) error {
+	doc := r.buildDocument(slices.Concat(r.findings, findings))
+	if !r.validate {
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
 	encoder.SetIndent("", "  ")
-	return encoder.Encode(doc)
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	if err := Validate(buf.Bytes()); err != nil {
+		return fmt.Errorf("SARIF output does not match the schema: %w", err)
+	}
+	_, err := buf.WriteTo(writer)
+	return err
 }
 
-// buildDocument creates SARIF document from all collected findings
-func (r *AggregatingReporter) buildDocument() *Document {
+// buildDocument creates SARIF document from findings
+func (r *AggregatingReporter) buildDocument(findings []reporter.Finding) *Document {
 	return &Document{
-		Version: "2.1.0",
-		Schema:  "https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/schemas/sarif-schema-2.1.0.json",
+		Version: SARIFVersion,
+		Schema:  SchemaURI,
 		Runs: []Run{
 			{
-				Tool:              r.buildTool(),
-				Results:           r.buildResults(),
-				AutomationDetails: r.buildAutomationDetails(),
+				Tool:               r.buildTool(),
+				Results:            r.buildResults(findings),
+				AutomationDetails:  r.buildAutomationDetails(),
+				OriginalURIBaseIDs: originalURIBaseIDs(r.workDir),
+				Taxonomies:         BuildTaxonomies(),
+				Invocations:        r.buildInvocations(),
 			},
 		},
 	}
 }
 
+// buildInvocations completes the recorded invocation, if any. Notifications
+// are only reported through an invocation, so one is emitted for them even
+// when none was recorded.
+func (r *AggregatingReporter) buildInvocations() []Invocation {
+	if r.invocation == nil && len(r.notifications) == 0 {
+		return nil
+	}
+	now := time.Now
+	if r.now != nil {
+		now = r.now
+	}
+	var inv Invocation
+	if r.invocation != nil {
+		inv = *r.invocation
+	}
+	inv.ToolExecutionNotifications = r.notifications
+	inv.complete(now())
+	return []Invocation{inv}
+}
+
 // buildAutomationDetails creates automation details for the run
 func (r *AggregatingReporter) buildAutomationDetails() *AutomationDetails {
 	return &AutomationDetails{
@@ -82,35 +197,37 @@ func (r *AggregatingReporter) buildTool() Tool {
 
 	return Tool{
 		Driver: Driver{
-			Name:            "leakhound",
-			FullName:        "LeakHound Sensitive Data Detector",
-			InformationURI:  "https://github.com/nilpoona/leakhound",
-			Version:         version,
-			SemanticVersion: version,
-			Rules:           r.buildRules(),
+			Name:                "leakhound",
+			FullName:            "LeakHound Sensitive Data Detector",
+			InformationURI:      "https://github.com/nilpoona/leakhound",
+			Version:             version,
+			SemanticVersion:     version,
+			Rules:               r.buildRules(),
+			SupportedTaxonomies: supportedTaxonomies(BuildTaxonomies()),
 		},
 	}
 }
 
 // buildRules returns all rule descriptors using shared definitions
 func (r *AggregatingReporter) buildRules() []ReportingDescriptor {
-	return BuildRules()
+	return BuildRulesWithConfig(r.cfg)
 }
 
-// buildResults converts all findings to SARIF results
-func (r *AggregatingReporter) buildResults() []Result {
-	results := make([]Result, 0, len(r.findings))
-	for _, f := range r.findings {
-		results = append(results, r.buildResult(f))
+// buildResults converts findings to SARIF results
+func (r *AggregatingReporter) buildResults(findings []reporter.Finding) []Result {
+	results := make([]Result, 0, len(findings))
+	for _, f := range findings {
+		result := r.buildResult(f)
+		addContentFingerprint(&result, f.Finding)
+		results = append(results, result)
 	}
 	return results
 }
 
 // buildResult converts a single finding to SARIF result
-func (r *AggregatingReporter) buildResult(f FindingWithFset) Result {
-	pos := f.Fset.Position(f.Finding.Pos)
-	relPath := r.relativePath(pos.Filename)
-	sarifRuleID := f.Finding.SARIFRuleID()
+func (r *AggregatingReporter) buildResult(f reporter.Finding) Result {
+	relPath := r.relativePath(f.Start.Filename)
+	sarifRuleID := f.RuleID
 
 	result := Result{
 		RuleID: sarifRuleID,
@@ -120,19 +237,15 @@ func (r *AggregatingReporter) buildResult(f FindingWithFset) Result {
 		Locations: []Location{
 			{
 				PhysicalLocation: PhysicalLocation{
-					ArtifactLocation: ArtifactLocation{
-						URI:       relPath,
-						URIBaseID: "%SRCROOT%",
-					},
-					Region: Region{
-						StartLine:   pos.Line,
-						StartColumn: pos.Column,
-					},
+					ArtifactLocation: artifactLocation(relPath),
+					Region:           buildRegion(f),
 				},
 			},
 		},
-		Level:               "error",
-		PartialFingerprints: r.buildFingerprints(relPath, pos.Line, sarifRuleID),
+		Level:               string(f.Level),
+		Rank:                resultRank(sarifRuleID, r.cfg),
+		PartialFingerprints: r.buildFingerprints(relPath, f.Start.Line, sarifRuleID),
+		Properties:          resultProperties(f.Finding),
 	}
 
 	if f.Finding.Suppressed {
@@ -155,14 +268,8 @@ func (r *AggregatingReporter) buildFingerprints(filePath string, line int, ruleI
 	}
 }
 
-// relativePath converts absolute path to relative from workDir
+// relativePath converts an analyzed file path to a URI relative to workDir,
+// the source root, after applying any path prefix mappings
 func (r *AggregatingReporter) relativePath(absPath string) string {
-	relPath, err := filepath.Rel(r.workDir, absPath)
-	if err != nil {
-		// Fallback to absolute path if relative conversion fails
-		return absPath
-	}
-
-	// Normalize path separators for cross-platform compatibility
-	return filepath.ToSlash(relPath)
+	return artifactURI(absPath, r.workDir, r.pathMappings)
 }
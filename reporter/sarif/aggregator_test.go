@@ -6,8 +6,10 @@ import (
 	"go/token"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/nilpoona/leakhound/detector"
+	"github.com/nilpoona/leakhound/reporter"
 )
 
 func TestNewAggregatingReporter(t *testing.T) {
@@ -23,7 +25,7 @@ func TestNewAggregatingReporter(t *testing.T) {
 			args: "/home/user/project",
 			want: &AggregatingReporter{
 				workDir:  "/home/user/project",
-				findings: []FindingWithFset{},
+				findings: []reporter.Finding{},
 				version:  Version,
 			},
 		},
@@ -32,7 +34,7 @@ func TestNewAggregatingReporter(t *testing.T) {
 			args: "",
 			want: &AggregatingReporter{
 				workDir:  "",
-				findings: []FindingWithFset{},
+				findings: []reporter.Finding{},
 				version:  Version,
 			},
 		},
@@ -41,7 +43,7 @@ func TestNewAggregatingReporter(t *testing.T) {
 			args: "./project",
 			want: &AggregatingReporter{
 				workDir:  "./project",
-				findings: []FindingWithFset{},
+				findings: []reporter.Finding{},
 				version:  Version,
 			},
 		},
@@ -213,6 +215,7 @@ func TestAggregatingReporter_Report(t *testing.T) {
 						Text: "test finding",
 					},
 					Level: "error",
+					Rank:  floatPtr(75),
 					Locations: []Location{
 						{
 							PhysicalLocation: PhysicalLocation{
@@ -228,6 +231,7 @@ func TestAggregatingReporter_Report(t *testing.T) {
 						},
 					},
 					PartialFingerprints: result.PartialFingerprints, // Copy fingerprints for comparison
+					Properties:          map[string]string{"confidence": "high", "confidenceScore": "1.00", "remediation": "add-sanitizer"},
 				}
 
 				if !reflect.DeepEqual(result, want) {
@@ -302,8 +306,8 @@ func TestAggregatingReporter_Report(t *testing.T) {
 				if run.Tool.Driver.Name != "leakhound" {
 					t.Errorf("tool name = %q, want %q", run.Tool.Driver.Name, "leakhound")
 				}
-				if len(run.Tool.Driver.Rules) != 6 {
-					t.Errorf("rules count = %d, want 6", len(run.Tool.Driver.Rules))
+				if len(run.Tool.Driver.Rules) != 19 {
+					t.Errorf("rules count = %d, want 19", len(run.Tool.Driver.Rules))
 				}
 				if run.AutomationDetails == nil {
 					t.Error("automation details should not be nil")
@@ -330,7 +334,7 @@ func TestAggregatingReporter_Report(t *testing.T) {
 			reporter.AddFindings(tt.findings, fset)
 
 			var buf bytes.Buffer
-			err := reporter.Report(&buf)
+			err := reporter.Report(&buf, nil)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Report() error = %v, wantErr %v", err, tt.wantErr)
@@ -369,7 +373,7 @@ func TestAggregatingReporter_ReportValidJSON(t *testing.T) {
 	reporter.AddFindings(findings, fset)
 
 	var buf bytes.Buffer
-	if err := reporter.Report(&buf); err != nil {
+	if err := reporter.Report(&buf, nil); err != nil {
 		t.Fatalf("Report() failed: %v", err)
 	}
 
@@ -416,7 +420,7 @@ func TestAggregatingReporter_MultiplePackages(t *testing.T) {
 	reporter.AddFindings(findings2, fset2)
 
 	var buf bytes.Buffer
-	if err := reporter.Report(&buf); err != nil {
+	if err := reporter.Report(&buf, nil); err != nil {
 		t.Fatalf("Report() failed: %v", err)
 	}
 
@@ -458,7 +462,7 @@ func TestAggregatingReporter_Fingerprints(t *testing.T) {
 	reporter.AddFindings(findings, fset)
 
 	var buf bytes.Buffer
-	if err := reporter.Report(&buf); err != nil {
+	if err := reporter.Report(&buf, nil); err != nil {
 		t.Fatalf("Report() failed: %v", err)
 	}
 
@@ -533,7 +537,7 @@ func TestAggregatingReporter_RelativePaths(t *testing.T) {
 			reporter.AddFindings(findings, fset)
 
 			var buf bytes.Buffer
-			if err := reporter.Report(&buf); err != nil {
+			if err := reporter.Report(&buf, nil); err != nil {
 				t.Fatalf("Report() failed: %v", err)
 			}
 
@@ -554,3 +558,406 @@ func TestAggregatingReporter_RelativePaths(t *testing.T) {
 		})
 	}
 }
+
+func TestAggregatingReporter_RegionEnd(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		pos, end   token.Pos
+		wantRegion Region
+	}{
+		{
+			name:       "end on same line",
+			pos:        token.Pos(5),
+			end:        token.Pos(18),
+			wantRegion: Region{StartLine: 1, StartColumn: 5, EndLine: 1, EndColumn: 18},
+		},
+		{
+			name:       "end on following line",
+			pos:        token.Pos(5),
+			end:        token.Pos(25),
+			wantRegion: Region{StartLine: 1, StartColumn: 5, EndLine: 2, EndColumn: 5},
+		},
+		{
+			name:       "unknown end omitted",
+			pos:        token.Pos(5),
+			end:        token.NoPos,
+			wantRegion: Region{StartLine: 1, StartColumn: 5},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reporter := NewAggregatingReporter("/home/user/project")
+			fset := token.NewFileSet()
+			file := fset.AddFile("/home/user/project/test.go", 1, 100)
+			file.SetLines([]int{0, 20, 40})
+
+			reporter.AddFindings([]detector.Finding{
+				{Pos: tt.pos, End: tt.end, Message: "test", RuleID: "sensitive-field"},
+			}, fset)
+
+			var buf bytes.Buffer
+			if err := reporter.Report(&buf, nil); err != nil {
+				t.Fatalf("Report() failed: %v", err)
+			}
+
+			var doc Document
+			if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("Failed to parse SARIF JSON: %v", err)
+			}
+
+			got := doc.Runs[0].Results[0].Locations[0].PhysicalLocation.Region
+			if !reflect.DeepEqual(got, tt.wantRegion) {
+				t.Errorf("region = %+v, want %+v", got, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestAggregatingReporter_ContentFingerprints(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/home/user/project/test.go", 1, 100)
+	file.SetLines([]int{0, 20, 40, 60})
+
+	findings := []detector.Finding{
+		{Pos: token.Pos(5), RuleID: "sensitive-field", Func: "app.handle", Expr: "u.Password"},
+		{Pos: token.Pos(45), RuleID: "sensitive-field", Func: "app.handle", Expr: "u.Password"},
+		{Pos: token.Pos(65), RuleID: "sensitive-field", Func: "app.handle", Expr: "u.APIKey"},
+		{Pos: token.Pos(25), RuleID: "sensitive-field"},
+	}
+
+	reporter := NewAggregatingReporter("/home/user/project")
+	reporter.AddFindings(findings, fset)
+
+	var buf bytes.Buffer
+	if err := reporter.Report(&buf, nil); err != nil {
+		t.Fatalf("Report() failed: %v", err)
+	}
+
+	var doc Document
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse SARIF JSON: %v", err)
+	}
+
+	results := doc.Runs[0].Results
+	first := results[0].PartialFingerprints[contentFingerprintKey]
+	if first != findings[0].Fingerprint() {
+		t.Errorf("content hash = %q, want %q", first, findings[0].Fingerprint())
+	}
+	if got, want := results[1].PartialFingerprints[contentFingerprintKey], first+":1"; got != want {
+		t.Errorf("repeated content hash = %q, want %q", got, want)
+	}
+	if got := results[2].PartialFingerprints[contentFingerprintKey]; got == "" || got == first {
+		t.Errorf("distinct expression content hash = %q, want a different non-empty hash", got)
+	}
+	if _, ok := results[3].PartialFingerprints[contentFingerprintKey]; ok {
+		t.Errorf("finding without expression should not carry a content hash")
+	}
+	if _, ok := results[3].PartialFingerprints["primaryLocationLineHash"]; !ok {
+		t.Errorf("primaryLocationLineHash should still be present")
+	}
+}
+
+func TestAggregatingReporter_ResultProperties(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	fset.AddFile("/home/user/project/test.go", 1, 100)
+
+	findings := []detector.Finding{
+		{Pos: token.Pos(5), RuleID: "personal-data", Field: "User.Email", Classification: "matched default pattern 'e_?mail'", Owners: []string{"@org/payments", "@alice"}, Sink: "(*go.uber.org/zap.Logger).Info"},
+		{Pos: token.Pos(25), RuleID: "sensitive-field", Field: "User.Password"},
+		{Pos: token.Pos(45), RuleID: "sensitive-field", Field: "User.Password", BestEffort: true},
+	}
+
+	reporter := NewAggregatingReporter("/home/user/project")
+	reporter.AddFindings(findings, fset)
+
+	var buf bytes.Buffer
+	if err := reporter.Report(&buf, nil); err != nil {
+		t.Fatalf("Report() failed: %v", err)
+	}
+
+	var doc Document
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse SARIF JSON: %v", err)
+	}
+
+	results := doc.Runs[0].Results
+	if got, want := results[0].Properties["classification"], findings[0].Classification; got != want {
+		t.Errorf("classification property = %q, want %q", got, want)
+	}
+	if got, want := results[0].Properties["owners"], "@org/payments @alice"; got != want {
+		t.Errorf("owners property = %q, want %q", got, want)
+	}
+	if got, want := results[0].Properties["sink"], findings[0].Sink; got != want {
+		t.Errorf("sink property = %q, want %q", got, want)
+	}
+	if got, want := results[0].Properties["sinkPackage"], "go.uber.org/zap"; got != want {
+		t.Errorf("sinkPackage property = %q, want %q", got, want)
+	}
+	want := map[string]string{"confidence": "high", "confidenceScore": "1.00", "remediation": "remove-arg"}
+	if !reflect.DeepEqual(results[1].Properties, want) {
+		t.Errorf("unowned tagged field result properties = %v, want %v", results[1].Properties, want)
+	}
+	if got := results[2].Properties["bestEffort"]; got != "true" {
+		t.Errorf("bestEffort property = %q, want %q", got, "true")
+	}
+	for i, want := range []string{"0.45", "1.00", "0.45"} {
+		if got := results[i].Properties["confidenceScore"]; got != want {
+			t.Errorf("results[%d] confidenceScore property = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestAggregatingReporter_Invocations(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	end := start.Add(1500 * time.Millisecond)
+
+	reporter := NewAggregatingReporter("/home/user/project")
+	reporter.now = func() time.Time { return end }
+	reporter.SetInvocation(NewInvocation(
+		[]string{"leakhound", "--format=sarif", "./..."}, "/home/user/project", start))
+
+	var buf bytes.Buffer
+	if err := reporter.Report(&buf, nil); err != nil {
+		t.Fatalf("Report() failed: %v", err)
+	}
+
+	var doc Document
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse SARIF JSON: %v", err)
+	}
+
+	if len(doc.Runs[0].Invocations) != 1 {
+		t.Fatalf("len(invocations) = %d, want 1", len(doc.Runs[0].Invocations))
+	}
+	inv := doc.Runs[0].Invocations[0]
+
+	if inv.CommandLine != "leakhound --format=sarif ./..." {
+		t.Errorf("commandLine = %q", inv.CommandLine)
+	}
+	if !reflect.DeepEqual(inv.Arguments, []string{"--format=sarif", "./..."}) {
+		t.Errorf("arguments = %v", inv.Arguments)
+	}
+	if inv.StartTimeUTC != "2024-05-01T00:00:00Z" {
+		t.Errorf("startTimeUtc = %q, want %q", inv.StartTimeUTC, "2024-05-01T00:00:00Z")
+	}
+	if inv.EndTimeUTC != "2024-05-01T00:00:01.5Z" {
+		t.Errorf("endTimeUtc = %q, want %q", inv.EndTimeUTC, "2024-05-01T00:00:01.5Z")
+	}
+	if inv.ExitCode == nil || *inv.ExitCode != 0 || !inv.ExecutionSuccessful {
+		t.Errorf("exitCode = %v, executionSuccessful = %v, want 0 and true", inv.ExitCode, inv.ExecutionSuccessful)
+	}
+	if inv.WorkingDirectory == nil || inv.WorkingDirectory.URI != "file:///home/user/project/" {
+		t.Errorf("workingDirectory = %+v, want file:///home/user/project/", inv.WorkingDirectory)
+	}
+	for _, key := range []string{"os", "arch", "goVersion"} {
+		if inv.Properties[key] == "" {
+			t.Errorf("properties[%q] is empty", key)
+		}
+	}
+}
+
+func TestAggregatingReporter_NoInvocationByDefault(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := NewAggregatingReporter("/home/user/project").Report(&buf, nil); err != nil {
+		t.Fatalf("Report() failed: %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte(`"invocations"`)) {
+		t.Errorf("invocations emitted without SetInvocation:\n%s", buf.String())
+	}
+}
+
+func TestAggregatingReporter_Notifications(t *testing.T) {
+	t.Parallel()
+
+	notes := []detector.Notification{{
+		Level:   detector.SeverityWarning,
+		Package: "example.com/app",
+		Message: "package example.com/app tracks about 2.0MiB of data flow facts",
+	}}
+
+	tests := []struct {
+		name       string
+		invocation bool
+	}{
+		{"with invocation", true},
+		{"without invocation", false},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reporter := NewAggregatingReporter("/home/user/project")
+			if tt.invocation {
+				reporter.SetInvocation(NewInvocation([]string{"leakhound", "./..."}, "/home/user/project", time.Now()))
+			}
+			reporter.AddNotifications(notes)
+
+			var buf bytes.Buffer
+			if err := reporter.Report(&buf, nil); err != nil {
+				t.Fatalf("Report() failed: %v", err)
+			}
+			var doc Document
+			if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("Failed to parse SARIF JSON: %v", err)
+			}
+			if len(doc.Runs[0].Invocations) != 1 {
+				t.Fatalf("len(invocations) = %d, want 1", len(doc.Runs[0].Invocations))
+			}
+			inv := doc.Runs[0].Invocations[0]
+			want := []Notification{{Level: "warning", Message: Message{Text: notes[0].Message}}}
+			if !reflect.DeepEqual(inv.ToolExecutionNotifications, want) {
+				t.Errorf("toolExecutionNotifications = %+v, want %+v", inv.ToolExecutionNotifications, want)
+			}
+			if !inv.ExecutionSuccessful {
+				t.Errorf("executionSuccessful = false, want true")
+			}
+		})
+	}
+}
+
+func TestAggregatingReporter_PanicNotification(t *testing.T) {
+	t.Parallel()
+
+	notes := []detector.Notification{{
+		Level:     detector.SeverityError,
+		Package:   "example.com/app",
+		Message:   "package example.com/app: analysis panicked while detecting findings, the package was skipped: boom",
+		PanicType: "string",
+		Stack: []detector.StackFrame{
+			{Function: "github.com/nilpoona/leakhound/detector.(*Detector).checkArg", File: "/src/leakhound/detector/detector.go", Line: 42},
+		},
+	}}
+
+	reporter := NewAggregatingReporter("/home/user/project")
+	reporter.AddNotifications(notes)
+
+	var buf bytes.Buffer
+	if err := reporter.Report(&buf, nil); err != nil {
+		t.Fatalf("Report() failed: %v", err)
+	}
+	var doc Document
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("Failed to parse SARIF JSON: %v", err)
+	}
+	got := doc.Runs[0].Invocations[0].ToolExecutionNotifications
+	want := []Notification{{
+		Level:   "error",
+		Message: Message{Text: notes[0].Message},
+		Exception: &Exception{
+			Kind:    "string",
+			Message: notes[0].Message,
+			Stack: &Stack{Frames: []StackFrame{{
+				Location: Location{
+					PhysicalLocation: PhysicalLocation{
+						ArtifactLocation: ArtifactLocation{URI: "file:///src/leakhound/detector/detector.go"},
+						Region:           Region{StartLine: 42},
+					},
+					LogicalLocations: []LogicalLocation{{
+						FullyQualifiedName: "github.com/nilpoona/leakhound/detector.(*Detector).checkArg",
+						Kind:               "function",
+					}},
+				},
+			}}},
+		},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("toolExecutionNotifications = %+v, want %+v", got, want)
+	}
+}
+
+func TestDirectoryURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"/home/user/project", "file:///home/user/project/"},
+		{"/home/user/project/", "file:///home/user/project/"},
+		{"/home/user/my project", "file:///home/user/my%20project/"},
+		{"C:/src/app", "file:///C:/src/app/"},
+		{`C:\src\app`, "file:///C:/src/app/"},
+		{`\\fileserver\share\app`, "file://fileserver/share/app/"},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.dir, func(t *testing.T) {
+			t.Parallel()
+			if got := directoryURI(tt.dir); got != tt.want {
+				t.Errorf("directoryURI(%q) = %q, want %q", tt.dir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregatingReporter_OriginalURIBaseIDs(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/home/user/project/pkg/test.go", 1, 100)
+	file.SetLines([]int{0, 20, 40})
+
+	tests := []struct {
+		name    string
+		workDir string
+		want    map[string]ArtifactLocation
+	}{
+		{
+			name:    "absolute workDir",
+			workDir: "/home/user/project",
+			want:    map[string]ArtifactLocation{"%SRCROOT%": {URI: "file:///home/user/project/"}},
+		},
+		{
+			name:    "empty workDir",
+			workDir: "",
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			reporter := NewAggregatingReporter(tt.workDir)
+			reporter.AddFindings([]detector.Finding{{Pos: token.Pos(25), RuleID: "sensitive-field"}}, fset)
+
+			var buf bytes.Buffer
+			if err := reporter.Report(&buf, nil); err != nil {
+				t.Fatalf("Report() failed: %v", err)
+			}
+			var doc Document
+			if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+				t.Fatalf("Failed to parse SARIF JSON: %v", err)
+			}
+
+			run := doc.Runs[0]
+			if !reflect.DeepEqual(run.OriginalURIBaseIDs, tt.want) {
+				t.Errorf("originalUriBaseIds = %v, want %v", run.OriginalURIBaseIDs, tt.want)
+			}
+			// Every uriBaseId used by a result must be declared.
+			if tt.want != nil {
+				for _, result := range run.Results {
+					baseID := result.Locations[0].PhysicalLocation.ArtifactLocation.URIBaseID
+					if _, ok := run.OriginalURIBaseIDs[baseID]; !ok {
+						t.Errorf("uriBaseId %q is not declared in originalUriBaseIds", baseID)
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package sarif
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// NewInvocation describes the current process for run.invocations. args is
+// the full command line including the program name, and start is when the
+// analysis began. End time and exit code are filled in when the report is
+// written.
+func NewInvocation(args []string, workDir string, start time.Time) Invocation {
+	inv := Invocation{
+		CommandLine:  strings.Join(args, " "),
+		StartTimeUTC: formatTime(start),
+		Properties: map[string]string{
+			"os":        runtime.GOOS,
+			"arch":      runtime.GOARCH,
+			"goVersion": runtime.Version(),
+		},
+	}
+	if len(args) > 1 {
+		inv.Arguments = append([]string{}, args[1:]...)
+	}
+	if host, err := os.Hostname(); err == nil {
+		inv.Machine = host
+	}
+	if workDir != "" {
+		inv.WorkingDirectory = &ArtifactLocation{URI: directoryURI(workDir)}
+	}
+	return inv
+}
+
+// complete marks the invocation as finished successfully at end.
+func (inv *Invocation) complete(end time.Time) {
+	if inv.EndTimeUTC == "" {
+		inv.EndTimeUTC = formatTime(end)
+	}
+	if inv.ExitCode == nil {
+		exitCode := 0
+		inv.ExitCode = &exitCode
+		inv.ExecutionSuccessful = true
+	}
+}
+
+// formatTime renders t in the UTC date-time form SARIF requires.
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+// directoryURI converts an absolute directory path to a file URI with a
+// trailing slash, as SARIF expects for directories.
+func directoryURI(dir string) string {
+	uri := fileURI(dir)
+	if !strings.HasSuffix(uri, "/") {
+		uri += "/"
+	}
+	return uri
+}
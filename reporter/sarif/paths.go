@@ -0,0 +1,215 @@
+package sarif
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PathMapping rewrites the prefix From of an analyzed file path to To before
+// the path is made relative to the source root. It lets builds that run under
+// one directory (e.g. /workspace in a CI container) report paths as they
+// appear in the repository.
+type PathMapping struct {
+	From string
+	To   string
+}
+
+// ParsePathMappings parses a comma-separated list of FROM=TO pairs, e.g.
+// "/workspace=/src/app,/tmp/build=". An empty TO strips the prefix, leaving a
+// path that is used as a repository-relative URI as is.
+func ParsePathMappings(s string) ([]PathMapping, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	var mappings []PathMapping
+	for _, pair := range strings.Split(s, ",") {
+		from, to, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || from == "" {
+			return nil, fmt.Errorf("invalid path prefix mapping %q: want FROM=TO", pair)
+		}
+		mappings = append(mappings, PathMapping{From: from, To: to})
+	}
+	return mappings, nil
+}
+
+// mapPath applies the mapping with the longest matching From prefix to path.
+// Prefixes only match whole path components, so /workspace does not match
+// /workspace2; Windows paths match case-insensitively and with either
+// separator. The second result reports whether a mapping applied.
+func mapPath(path string, mappings []PathMapping) (string, bool) {
+	sorted := make([]PathMapping, len(mappings))
+	copy(sorted, mappings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i].From) > len(sorted[j].From)
+	})
+	for _, m := range sorted {
+		rest, ok := cutPathPrefix(path, m.From)
+		if !ok {
+			continue
+		}
+		return joinMapped(m.To, rest), true
+	}
+	return path, false
+}
+
+// cutPathPrefix returns path relative to prefix when prefix names path or one
+// of its parent directories.
+func cutPathPrefix(path, prefix string) (string, bool) {
+	if isWindowsAbs(path) || isWindowsAbs(prefix) {
+		p, pre := toSlash(path), strings.TrimSuffix(toSlash(prefix), "/")
+		if len(p) < len(pre) || !strings.EqualFold(p[:len(pre)], pre) {
+			return "", false
+		}
+		rest := p[len(pre):]
+		if rest != "" && rest[0] != '/' {
+			return "", false
+		}
+		return strings.TrimPrefix(rest, "/"), true
+	}
+	from := filepath.Clean(prefix)
+	if path == from {
+		return "", true
+	}
+	if !strings.HasSuffix(from, string(filepath.Separator)) {
+		from += string(filepath.Separator)
+	}
+	return strings.CutPrefix(path, from)
+}
+
+// joinMapped appends the remainder of a mapped path to the mapping target
+func joinMapped(to, rest string) string {
+	switch {
+	case to == "":
+		return rest
+	case rest == "":
+		return to
+	case isWindowsAbs(to):
+		return strings.TrimSuffix(toSlash(to), "/") + "/" + toSlash(rest)
+	default:
+		return filepath.Join(to, rest)
+	}
+}
+
+// artifactURI converts the path of an analyzed file to the URI reported in
+// results: path prefix mappings are applied first, and an absolute result is
+// made relative to srcRoot. The URI always uses forward slashes. When the
+// path cannot be made relative, e.g. it is on another Windows drive than
+// srcRoot, an absolute file URI is returned instead.
+func artifactURI(path, srcRoot string, mappings []PathMapping) string {
+	path, _ = mapPath(path, mappings)
+	if isWindowsAbs(path) {
+		if rel, ok := windowsRel(srcRoot, path); ok {
+			return rel
+		}
+		return fileURI(path)
+	}
+	if !filepath.IsAbs(path) {
+		return filepath.ToSlash(filepath.Clean(path))
+	}
+	relPath, err := filepath.Rel(srcRoot, path)
+	if err != nil {
+		return fileURI(path)
+	}
+	return filepath.ToSlash(relPath)
+}
+
+// artifactLocation returns the location for a URI built by artifactURI;
+// relative URIs resolve against %SRCROOT%.
+func artifactLocation(uri string) ArtifactLocation {
+	if strings.HasPrefix(uri, "file:") {
+		return ArtifactLocation{URI: uri}
+	}
+	return ArtifactLocation{URI: uri, URIBaseID: srcRootBaseID}
+}
+
+// isWindowsAbs reports whether p is an absolute Windows path: a drive path
+// (C:\src or C:/src) or a UNC path (\\server\share\src). It is independent
+// of the host OS so reports built from Windows paths are handled anywhere.
+func isWindowsAbs(p string) bool {
+	_, _, ok := splitWindowsPath(p)
+	return ok
+}
+
+// splitWindowsPath splits an absolute Windows path into its volume ("C:" or
+// "//server/share") and the slash-separated remainder.
+func splitWindowsPath(p string) (volume, rest string, ok bool) {
+	p = toSlash(p)
+	if len(p) >= 3 && isDriveLetter(p[0]) && p[1] == ':' && p[2] == '/' {
+		return p[:2], p[2:], true
+	}
+	if strings.HasPrefix(p, "//") {
+		parts := strings.SplitN(p[2:], "/", 3)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return "", "", false
+		}
+		volume = "//" + parts[0] + "/" + parts[1]
+		return volume, strings.TrimPrefix(p, volume), true
+	}
+	return "", "", false
+}
+
+func isDriveLetter(c byte) bool {
+	return ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// toSlash replaces backslashes with forward slashes regardless of the host
+// OS, unlike filepath.ToSlash
+func toSlash(p string) string {
+	return strings.ReplaceAll(p, `\`, "/")
+}
+
+// windowsRel returns target relative to base for Windows paths, comparing
+// volumes and path components case-insensitively as the file system does.
+// It fails when the paths are on different volumes.
+func windowsRel(base, target string) (string, bool) {
+	baseVol, baseRest, ok := splitWindowsPath(base)
+	if !ok {
+		return "", false
+	}
+	targetVol, targetRest, ok := splitWindowsPath(target)
+	if !ok || !strings.EqualFold(baseVol, targetVol) {
+		return "", false
+	}
+	baseParts := pathComponents(baseRest)
+	targetParts := pathComponents(targetRest)
+
+	common := 0
+	for common < len(baseParts) && common < len(targetParts) && strings.EqualFold(baseParts[common], targetParts[common]) {
+		common++
+	}
+	parts := make([]string, 0, len(baseParts)-common+len(targetParts)-common)
+	for range baseParts[common:] {
+		parts = append(parts, "..")
+	}
+	parts = append(parts, targetParts[common:]...)
+	if len(parts) == 0 {
+		return ".", true
+	}
+	return strings.Join(parts, "/"), true
+}
+
+// pathComponents splits a slash-separated path into cleaned components
+func pathComponents(p string) []string {
+	cleaned := path.Clean("/" + p)
+	if cleaned == "/" {
+		return nil
+	}
+	return strings.Split(cleaned[1:], "/")
+}
+
+// fileURI converts an absolute path to a file URI. Drive paths become
+// file:///C:/src and UNC paths file://server/share/src.
+func fileURI(p string) string {
+	if vol, rest, ok := splitWindowsPath(p); ok {
+		if strings.HasPrefix(vol, "//") {
+			host, share, _ := strings.Cut(vol[2:], "/")
+			return (&url.URL{Scheme: "file", Host: host, Path: "/" + share + rest}).String()
+		}
+		return (&url.URL{Scheme: "file", Path: "/" + vol + rest}).String()
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(p)}).String()
+}
@@ -0,0 +1,182 @@
+package sarif
+
+import (
+	"bytes"
+	"encoding/json"
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+func TestParsePathMappings(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		in      string
+		want    []PathMapping
+		wantErr bool
+	}{
+		{name: "empty", in: "", want: nil},
+		{name: "single", in: "/workspace=/src/app", want: []PathMapping{{From: "/workspace", To: "/src/app"}}},
+		{
+			name: "multiple with strip",
+			in:   "/workspace=/src/app, /tmp/build=",
+			want: []PathMapping{{From: "/workspace", To: "/src/app"}, {From: "/tmp/build", To: ""}},
+		},
+		{name: "missing separator", in: "/workspace", wantErr: true},
+		{name: "empty from", in: "=/src", wantErr: true},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParsePathMappings(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePathMappings(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePathMappings(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArtifactURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		path     string
+		srcRoot  string
+		mappings []PathMapping
+		want     string
+	}{
+		{name: "under srcroot", path: "/repo/pkg/user.go", srcRoot: "/repo", want: "pkg/user.go"},
+		{name: "out-of-tree build without mapping", path: "/workspace/pkg/user.go", srcRoot: "/repo/sub", want: "../../workspace/pkg/user.go"},
+		{
+			name:     "mapped onto srcroot",
+			path:     "/workspace/pkg/user.go",
+			srcRoot:  "/repo",
+			mappings: []PathMapping{{From: "/workspace", To: "/repo"}},
+			want:     "pkg/user.go",
+		},
+		{
+			name:     "stripped prefix is used as is",
+			path:     "/workspace/pkg/user.go",
+			srcRoot:  "/elsewhere",
+			mappings: []PathMapping{{From: "/workspace/", To: ""}},
+			want:     "pkg/user.go",
+		},
+		{
+			name:     "prefix matches whole components only",
+			path:     "/workspace2/pkg/user.go",
+			srcRoot:  "/",
+			mappings: []PathMapping{{From: "/workspace", To: ""}},
+			want:     "workspace2/pkg/user.go",
+		},
+		{
+			name:     "longest prefix wins",
+			path:     "/workspace/vendor/lib/a.go",
+			srcRoot:  "/repo",
+			mappings: []PathMapping{{From: "/workspace", To: "/repo"}, {From: "/workspace/vendor", To: "third_party"}},
+			want:     "third_party/lib/a.go",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := artifactURI(tt.path, tt.srcRoot, tt.mappings); got != tt.want {
+				t.Errorf("artifactURI(%q, %q) = %q, want %q", tt.path, tt.srcRoot, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregatingReporter_PathMappings(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/workspace/internal/user.go", 1, 100)
+
+	r := NewAggregatingReporter("/home/runner/work/app")
+	r.SetPathMappings([]PathMapping{{From: "/workspace", To: "/home/runner/work/app"}})
+	r.AddFindings([]detector.Finding{{Pos: file.Pos(1), Message: "leak", RuleID: detector.RuleIDSensitiveField}}, fset)
+
+	var buf bytes.Buffer
+	if err := r.Report(&buf, nil); err != nil {
+		t.Fatalf("Report() error = %v", err)
+	}
+	var doc Document
+	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	got := doc.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation
+	if got.URI != "internal/user.go" || got.URIBaseID != "%SRCROOT%" {
+		t.Errorf("artifact location = %+v, want internal/user.go relative to %%SRCROOT%%", got)
+	}
+}
+
+func TestArtifactURI_Windows(t *testing.T) {
+	t.Parallel()
+
+	// Windows paths are handled the same way on every host OS.
+	tests := []struct {
+		name     string
+		path     string
+		srcRoot  string
+		mappings []PathMapping
+		want     string
+	}{
+		{name: "backslashes", path: `C:\src\app\pkg\user.go`, srcRoot: `C:\src\app`, want: "pkg/user.go"},
+		{name: "drive letter case", path: `c:\src\app\pkg\user.go`, srcRoot: `C:\src\app`, want: "pkg/user.go"},
+		{name: "directory case", path: `C:\Src\App\pkg\user.go`, srcRoot: `C:\src\app`, want: "pkg/user.go"},
+		{name: "mixed separators", path: `C:/src/app\pkg/user.go`, srcRoot: `C:\src\app\`, want: "pkg/user.go"},
+		{name: "sibling directory", path: `C:\src\lib\a.go`, srcRoot: `C:\src\app`, want: "../lib/a.go"},
+		{name: "different drive", path: `D:\build\pkg\user.go`, srcRoot: `C:\src\app`, want: "file:///D:/build/pkg/user.go"},
+		{name: "UNC under srcroot", path: `\\fileserver\share\app\pkg\user.go`, srcRoot: `\\FileServer\Share\app`, want: "pkg/user.go"},
+		{name: "UNC on another share", path: `\\fileserver\other\user.go`, srcRoot: `\\fileserver\share\app`, want: "file://fileserver/other/user.go"},
+		{name: "drive vs UNC", path: `\\fileserver\share\user.go`, srcRoot: `C:\src`, want: "file://fileserver/share/user.go"},
+		{name: "escaped spaces", path: `D:\my build\user.go`, srcRoot: `C:\src`, want: "file:///D:/my%20build/user.go"},
+		{
+			name:     "case-insensitive mapping",
+			path:     `D:\a\App\pkg\user.go`,
+			srcRoot:  `C:\src\app`,
+			mappings: []PathMapping{{From: `d:\a\app`, To: `C:\src\app`}},
+			want:     "pkg/user.go",
+		},
+		{
+			name:     "mapping strips to relative",
+			path:     `D:\a\app\pkg\user.go`,
+			srcRoot:  `C:\src`,
+			mappings: []PathMapping{{From: `D:\a\app`, To: ""}},
+			want:     "pkg/user.go",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := artifactURI(tt.path, tt.srcRoot, tt.mappings); got != tt.want {
+				t.Errorf("artifactURI(%q, %q) = %q, want %q", tt.path, tt.srcRoot, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArtifactLocation(t *testing.T) {
+	t.Parallel()
+
+	if got := artifactLocation("pkg/user.go"); got != (ArtifactLocation{URI: "pkg/user.go", URIBaseID: "%SRCROOT%"}) {
+		t.Errorf("relative location = %+v, want a %%SRCROOT%% base", got)
+	}
+	if got := artifactLocation("file:///D:/build/user.go"); got != (ArtifactLocation{URI: "file:///D:/build/user.go"}) {
+		t.Errorf("absolute location = %+v, want no uriBaseId", got)
+	}
+}
@@ -1,162 +1,57 @@
 package sarif
 
 import (
-	"crypto/sha256"
-	"encoding/json"
-	"fmt"
-	"io"
 	"path/filepath"
 
 	"github.com/nilpoona/leakhound/detector"
-	"golang.org/x/tools/go/analysis"
+	"github.com/nilpoona/leakhound/reporter"
 )
 
-// Reporter builds and outputs SARIF documents
-type Reporter struct {
-	pass    *analysis.Pass
-	writer  io.Writer
-	workDir string // Repository root for relative paths
-	version string // Tool version
-}
-
-// Version of leakhound (exported for backward compatibility and build-time injection)
-var Version = "0.0.8"
-
-// NewReporter creates a SARIF reporter
-func NewReporter(pass *analysis.Pass, writer io.Writer, workDir string) *Reporter {
-	return &Reporter{
-		pass:    pass,
-		writer:  writer,
-		workDir: workDir,
-		version: Version, // Capture version at creation time
-	}
-}
-
-// Report converts findings to SARIF and writes to output
-func (r *Reporter) Report(findings []detector.Finding) error {
-	doc := r.buildDocument(findings)
-	return r.writeDocument(doc)
-}
-
-// buildDocument creates SARIF document from findings
-func (r *Reporter) buildDocument(findings []detector.Finding) *Document {
-	return &Document{
-		Version: "2.1.0",
-		Schema:  "https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/schemas/sarif-schema-2.1.0.json",
-		Runs: []Run{
-			{
-				Tool:              r.buildTool(),
-				Results:           r.buildResults(findings),
-				AutomationDetails: r.buildAutomationDetails(),
-			},
-		},
-	}
-}
+// srcRootBaseID is the uriBaseId that result locations are relative to.
+const srcRootBaseID = "%SRCROOT%"
 
-// buildAutomationDetails creates automation details for the run
-func (r *Reporter) buildAutomationDetails() *AutomationDetails {
-	return &AutomationDetails{
-		ID: "leakhound/analysis",
+// originalURIBaseIDs declares srcRootBaseID as the file URI of workDir so
+// consumers can resolve relative result paths. It returns nil when workDir
+// cannot be made absolute.
+func originalURIBaseIDs(workDir string) map[string]ArtifactLocation {
+	if workDir == "" {
+		return nil
 	}
-}
-
-// buildTool creates tool descriptor
-func (r *Reporter) buildTool() Tool {
-	version := r.version
-	if version == "" {
-		version = "dev"
-	}
-
-	return Tool{
-		Driver: Driver{
-			Name:            "leakhound",
-			FullName:        "LeakHound Sensitive Data Detector",
-			InformationURI:  "https://github.com/nilpoona/leakhound",
-			Version:         version,
-			SemanticVersion: version,
-			Rules:           r.buildRules(),
-		},
+	abs, err := filepath.Abs(workDir)
+	if err != nil {
+		return nil
 	}
-}
-
-// buildRules returns all rule descriptors using shared definitions
-func (r *Reporter) buildRules() []ReportingDescriptor {
-	return BuildRules()
-}
-
-// buildResults converts findings to SARIF results
-func (r *Reporter) buildResults(findings []detector.Finding) []Result {
-	results := make([]Result, 0, len(findings))
-	for _, f := range findings {
-		results = append(results, r.buildResult(f))
+	return map[string]ArtifactLocation{
+		srcRootBaseID: {URI: directoryURI(abs)},
 	}
-	return results
 }
 
-// buildResult converts a single finding to SARIF result
-func (r *Reporter) buildResult(f detector.Finding) Result {
-	pos := r.pass.Fset.Position(f.Pos)
-	relPath := r.relativePath(pos.Filename)
-	sarifRuleID := f.SARIFRuleID()
+// Version of leakhound (exported for backward compatibility and build-time injection)
+var Version = "0.0.8"
 
-	result := Result{
-		RuleID: sarifRuleID,
-		Message: Message{
-			Text: f.Message,
-		},
-		Locations: []Location{
-			{
-				PhysicalLocation: PhysicalLocation{
-					ArtifactLocation: ArtifactLocation{
-						URI:       relPath,
-						URIBaseID: "%SRCROOT%",
-					},
-					Region: Region{
-						StartLine:   pos.Line,
-						StartColumn: pos.Column,
-					},
-				},
-			},
-		},
-		Level:               "error",
-		PartialFingerprints: r.buildFingerprints(relPath, pos.Line, sarifRuleID),
+// buildRegion converts a finding's resolved range to a SARIF region. The end
+// is omitted when unknown.
+func buildRegion(f reporter.Finding) Region {
+	region := Region{
+		StartLine:   f.Start.Line,
+		StartColumn: f.Start.Column,
 	}
-
-	if f.Suppressed {
-		result.Suppressions = []Suppression{{Kind: f.SuppressionKind, State: "accepted"}}
+	if f.End.IsValid() {
+		region.EndLine = f.End.Line
+		region.EndColumn = f.End.Column
 	}
-
-	return result
+	return region
 }
 
-// buildFingerprints generates stable fingerprints for result matching
-func (r *Reporter) buildFingerprints(filePath string, line int, ruleID string) map[string]string {
-	// Create a stable fingerprint based on file path, line number, and rule ID
-	// This ensures the same issue at the same location gets the same fingerprint
-	fingerprint := fmt.Sprintf("%s:%d:%s", filePath, line, ruleID)
-	hash := sha256.Sum256([]byte(fingerprint))
-	primaryLocationHash := fmt.Sprintf("%x", hash[:16]) // Use first 16 bytes
-
-	return map[string]string{
-		"primaryLocationLineHash": primaryLocationHash,
-	}
-}
+// contentFingerprintKey is the partialFingerprints key for the line-independent
+// content hash, the ID of the finding (see detector.AssignFingerprints).
+const contentFingerprintKey = "leakhoundContentHash/v2"
 
-// relativePath converts absolute path to relative from workDir
-func (r *Reporter) relativePath(absPath string) string {
-	relPath, err := filepath.Rel(r.workDir, absPath)
-	if err != nil {
-		// Fallback to absolute path if relative conversion fails
-		return absPath
+// addContentFingerprint adds the content-based fingerprint to result.
+// Identical expressions logged more than once to the same sink of a function
+// share a content hash, so the ID disambiguates repeats by their ordinal.
+func addContentFingerprint(result *Result, f detector.Finding) {
+	if f.ID != "" {
+		result.PartialFingerprints[contentFingerprintKey] = f.ID
 	}
-
-	// Normalize path separators for cross-platform compatibility
-	return filepath.ToSlash(relPath)
-}
-
-// writeDocument serializes and writes SARIF JSON
-func (r *Reporter) writeDocument(doc *Document) error {
-	encoder := json.NewEncoder(r.writer)
-	encoder.SetIndent("", "  ") // Pretty print
-	return encoder.Encode(doc)
 }
@@ -3,79 +3,29 @@ package sarif
 import (
 	"bytes"
 	"encoding/json"
-	"go/ast"
 	"go/token"
-	"go/types"
 	"reflect"
 	"testing"
 
 	"github.com/nilpoona/leakhound/detector"
-	"golang.org/x/tools/go/analysis"
+	"github.com/nilpoona/leakhound/reporter"
 )
 
-func TestNewReporter(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name    string
-		workDir string
-	}{
-		{
-			name:    "normal initialization",
-			workDir: "/home/user/project",
-		},
-		{
-			name:    "empty workDir",
-			workDir: "",
-		},
-	}
-
-	for _, tt := range tests {
-		tt := tt // capture range variable
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			pass := &analysis.Pass{}
-			writer := &bytes.Buffer{}
-
-			got := NewReporter(pass, writer, tt.workDir)
-
-			if got == nil {
-				t.Fatal("NewReporter() returned nil")
-			}
-			if got.workDir != tt.workDir {
-				t.Errorf("NewReporter().workDir = %q, want %q", got.workDir, tt.workDir)
-			}
-			if got.pass != pass {
-				t.Errorf("NewReporter().pass should be the same instance")
-			}
-			if got.writer != writer {
-				t.Errorf("NewReporter().writer should be the same instance")
-			}
-			if got.version != Version {
-				t.Errorf("NewReporter().version = %q, want %q", got.version, Version)
-			}
-		})
-	}
-}
-
-func TestReporter_Report(t *testing.T) {
+func TestAggregatingReporter_ResolvedReport(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
 		name        string
 		findings    []detector.Finding
-		setupPass   func() *analysis.Pass
+		setupFset   func() *token.FileSet
 		wantErr     bool
 		validateDoc func(t *testing.T, doc *Document)
 	}{
 		{
 			name:     "report with no findings",
 			findings: []detector.Finding{},
-			setupPass: func() *analysis.Pass {
-				return &analysis.Pass{
-					Fset: token.NewFileSet(),
-				}
+			setupFset: func() *token.FileSet {
+				return token.NewFileSet()
 			},
 			wantErr: false,
 			validateDoc: func(t *testing.T, doc *Document) {
@@ -96,12 +46,10 @@ func TestReporter_Report(t *testing.T) {
 					RuleID:  "sensitive-var",
 				},
 			},
-			setupPass: func() *analysis.Pass {
+			setupFset: func() *token.FileSet {
 				fset := token.NewFileSet()
 				fset.AddFile("/home/user/project/test.go", 1, 100)
-				return &analysis.Pass{
-					Fset: fset,
-				}
+				return fset
 			},
 			wantErr: false,
 			validateDoc: func(t *testing.T, doc *Document) {
@@ -124,6 +72,31 @@ func TestReporter_Report(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "report uses finding severity as level",
+			findings: []detector.Finding{
+				{
+					Pos:      token.Pos(1),
+					Message:  "test finding",
+					RuleID:   "sensitive-struct",
+					Severity: detector.SeverityWarning,
+				},
+			},
+			setupFset: func() *token.FileSet {
+				fset := token.NewFileSet()
+				fset.AddFile("/home/user/project/test.go", 1, 100)
+				return fset
+			},
+			wantErr: false,
+			validateDoc: func(t *testing.T, doc *Document) {
+				if len(doc.Runs[0].Results) != 1 {
+					t.Fatalf("results count = %d, want 1", len(doc.Runs[0].Results))
+				}
+				if got := doc.Runs[0].Results[0].Level; got != "warning" {
+					t.Errorf("level = %q, want %q", got, "warning")
+				}
+			},
+		},
 		{
 			name: "report with multiple findings",
 			findings: []detector.Finding{
@@ -143,12 +116,10 @@ func TestReporter_Report(t *testing.T) {
 					RuleID:  "sensitive-struct",
 				},
 			},
-			setupPass: func() *analysis.Pass {
+			setupFset: func() *token.FileSet {
 				fset := token.NewFileSet()
 				fset.AddFile("/home/user/project/test.go", 1, 100)
-				return &analysis.Pass{
-					Fset: fset,
-				}
+				return fset
 			},
 			wantErr: false,
 			validateDoc: func(t *testing.T, doc *Document) {
@@ -176,12 +147,10 @@ func TestReporter_Report(t *testing.T) {
 					RuleID:  "sensitive-field",
 				},
 			},
-			setupPass: func() *analysis.Pass {
+			setupFset: func() *token.FileSet {
 				fset := token.NewFileSet()
 				fset.AddFile("/home/user/project/test.go", 1, 100)
-				return &analysis.Pass{
-					Fset: fset,
-				}
+				return fset
 			},
 			wantErr: false,
 			validateDoc: func(t *testing.T, doc *Document) {
@@ -196,8 +165,8 @@ func TestReporter_Report(t *testing.T) {
 				if run.Tool.Driver.Name != "leakhound" {
 					t.Errorf("tool name = %q, want %q", run.Tool.Driver.Name, "leakhound")
 				}
-				if len(run.Tool.Driver.Rules) != 6 {
-					t.Errorf("rules count = %d, want 6", len(run.Tool.Driver.Rules))
+				if len(run.Tool.Driver.Rules) != 19 {
+					t.Errorf("rules count = %d, want 19", len(run.Tool.Driver.Rules))
 				}
 
 				wantAutomation := &AutomationDetails{
@@ -216,12 +185,10 @@ func TestReporter_Report(t *testing.T) {
 				{Pos: token.Pos(3), Message: "struct", RuleID: "sensitive-struct"},
 				{Pos: token.Pos(4), Message: "field", RuleID: "sensitive-field"},
 			},
-			setupPass: func() *analysis.Pass {
+			setupFset: func() *token.FileSet {
 				fset := token.NewFileSet()
 				fset.AddFile("/home/user/project/test.go", 1, 100)
-				return &analysis.Pass{
-					Fset: fset,
-				}
+				return fset
 			},
 			wantErr: false,
 			validateDoc: func(t *testing.T, doc *Document) {
@@ -242,11 +209,11 @@ func TestReporter_Report(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			pass := tt.setupPass()
+			fset := tt.setupFset()
 			var buf bytes.Buffer
-			reporter := NewReporter(pass, &buf, "/home/user/project")
+			r := NewAggregatingReporter("/home/user/project")
 
-			err := reporter.Report(tt.findings)
+			err := r.Report(&buf, reporter.Resolve(tt.findings, fset))
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Report() error = %v, wantErr %v", err, tt.wantErr)
@@ -267,16 +234,12 @@ func TestReporter_Report(t *testing.T) {
 	}
 }
 
-func TestReporter_ReportValidJSON(t *testing.T) {
+func TestAggregatingReporter_ResolvedReportValidJSON(t *testing.T) {
 	t.Parallel()
 
 	fset := token.NewFileSet()
 	fset.AddFile("/home/user/project/test.go", 1, 100)
 
-	pass := &analysis.Pass{
-		Fset: fset,
-	}
-
 	findings := []detector.Finding{
 		{
 			Pos:     token.Pos(1),
@@ -286,9 +249,9 @@ func TestReporter_ReportValidJSON(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	reporter := NewReporter(pass, &buf, "/home/user/project")
+	r := NewAggregatingReporter("/home/user/project")
 
-	if err := reporter.Report(findings); err != nil {
+	if err := r.Report(&buf, reporter.Resolve(findings, fset)); err != nil {
 		t.Fatalf("Report() failed: %v", err)
 	}
 
@@ -305,7 +268,7 @@ func TestReporter_ReportValidJSON(t *testing.T) {
 	}
 }
 
-func TestReporter_RelativePaths(t *testing.T) {
+func TestAggregatingReporter_ResolvedRelativePaths(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
@@ -348,10 +311,6 @@ func TestReporter_RelativePaths(t *testing.T) {
 			fset := token.NewFileSet()
 			fset.AddFile(tt.filePath, 1, 100)
 
-			pass := &analysis.Pass{
-				Fset: fset,
-			}
-
 			findings := []detector.Finding{
 				{
 					Pos:     token.Pos(1),
@@ -361,9 +320,9 @@ func TestReporter_RelativePaths(t *testing.T) {
 			}
 
 			var buf bytes.Buffer
-			reporter := NewReporter(pass, &buf, tt.workDir)
+			r := NewAggregatingReporter(tt.workDir)
 
-			if err := reporter.Report(findings); err != nil {
+			if err := r.Report(&buf, reporter.Resolve(findings, fset)); err != nil {
 				t.Fatalf("Report() failed: %v", err)
 			}
 
@@ -386,7 +345,7 @@ func TestReporter_RelativePaths(t *testing.T) {
 	}
 }
 
-func TestReporter_Fingerprints(t *testing.T) {
+func TestAggregatingReporter_ResolvedFingerprints(t *testing.T) {
 	t.Parallel()
 
 	tests := []struct {
@@ -431,14 +390,10 @@ func TestReporter_Fingerprints(t *testing.T) {
 			file.AddLine(20) // Line 2 starts at position 20
 			file.AddLine(40) // Line 3 starts at position 40
 
-			pass := &analysis.Pass{
-				Fset: fset,
-			}
-
 			var buf bytes.Buffer
-			reporter := NewReporter(pass, &buf, "/home/user/project")
+			r := NewAggregatingReporter("/home/user/project")
 
-			if err := reporter.Report(tt.findings); err != nil {
+			if err := r.Report(&buf, reporter.Resolve(tt.findings, fset)); err != nil {
 				t.Fatalf("Report() failed: %v", err)
 			}
 
@@ -481,25 +436,21 @@ func TestReporter_Fingerprints(t *testing.T) {
 	}
 }
 
-func TestReporter_VersionHandling(t *testing.T) {
+func TestAggregatingReporter_ResolvedVersionHandling(t *testing.T) {
 	t.Parallel()
 
 	fset := token.NewFileSet()
 	fset.AddFile("/home/user/project/test.go", 1, 100)
 
-	pass := &analysis.Pass{
-		Fset: fset,
-	}
-
 	findings := []detector.Finding{
 		{Pos: token.Pos(1), Message: "test", RuleID: "sensitive-var"},
 	}
 
 	// Test that version is captured at reporter creation time
 	var buf bytes.Buffer
-	reporter := NewReporter(pass, &buf, "/home/user/project")
+	r := NewAggregatingReporter("/home/user/project")
 
-	if err := reporter.Report(findings); err != nil {
+	if err := r.Report(&buf, reporter.Resolve(findings, fset)); err != nil {
 		t.Fatalf("Report() failed: %v", err)
 	}
 
@@ -523,30 +474,24 @@ func TestReporter_VersionHandling(t *testing.T) {
 	}
 }
 
-func TestReporter_EmptyVersionFallback(t *testing.T) {
+func TestAggregatingReporter_ResolvedEmptyVersionFallback(t *testing.T) {
 	t.Parallel()
 
 	fset := token.NewFileSet()
 	fset.AddFile("/home/user/project/test.go", 1, 100)
 
-	pass := &analysis.Pass{
-		Fset: fset,
-	}
-
 	findings := []detector.Finding{
 		{Pos: token.Pos(1), Message: "test", RuleID: "sensitive-var"},
 	}
 
 	var buf bytes.Buffer
 	// Create reporter with empty version by directly constructing
-	reporter := &Reporter{
-		pass:    pass,
-		writer:  &buf,
+	r := &AggregatingReporter{
 		workDir: "/home/user/project",
 		version: "", // Empty version should fall back to "dev"
 	}
 
-	if err := reporter.Report(findings); err != nil {
+	if err := r.Report(&buf, reporter.Resolve(findings, fset)); err != nil {
 		t.Fatalf("Report() failed: %v", err)
 	}
 
@@ -566,49 +511,3 @@ func TestReporter_EmptyVersionFallback(t *testing.T) {
 		t.Errorf("semanticVersion = %q, want %q", gotSemVer, wantVersion)
 	}
 }
-
-func TestReporter_CompletePass(t *testing.T) {
-	t.Parallel()
-
-	// Create a more complete Pass structure
-	fset := token.NewFileSet()
-	file := fset.AddFile("/home/user/project/test.go", 1, 100)
-
-	pass := &analysis.Pass{
-		Fset: fset,
-		Files: []*ast.File{
-			{
-				Name: &ast.Ident{Name: "test"},
-			},
-		},
-		TypesInfo: &types.Info{},
-		Pkg:       types.NewPackage("test/pkg", "pkg"),
-	}
-
-	findings := []detector.Finding{
-		{
-			Pos:     file.Pos(10),
-			Message: "sensitive data logged",
-			RuleID:  "sensitive-var",
-		},
-	}
-
-	var buf bytes.Buffer
-	reporter := NewReporter(pass, &buf, "/home/user/project")
-
-	if err := reporter.Report(findings); err != nil {
-		t.Fatalf("Report() failed: %v", err)
-	}
-
-	var doc Document
-	if err := json.Unmarshal(buf.Bytes(), &doc); err != nil {
-		t.Fatalf("Failed to parse SARIF JSON: %v", err)
-	}
-
-	if len(doc.Runs) != 1 {
-		t.Errorf("runs count = %d, want 1", len(doc.Runs))
-	}
-	if len(doc.Runs[0].Results) != 1 {
-		t.Errorf("results count = %d, want 1", len(doc.Runs[0].Results))
-	}
-}
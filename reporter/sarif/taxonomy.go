@@ -0,0 +1,137 @@
+package sarif
+
+import (
+	"strings"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// Taxonomy names used in tool components and relationships.
+const (
+	taxonomyCWE   = "CWE"
+	taxonomyOWASP = "OWASP"
+)
+
+// taxonCatalog describes every taxon a rule may reference, keyed by ID.
+var taxonCatalog = map[string]Taxon{
+	"CWE-532": {
+		ID:               "CWE-532",
+		Name:             "Insertion of Sensitive Information into Log File",
+		ShortDescription: &MessageString{Text: "Information written to log files can be of a sensitive nature and give valuable guidance to an attacker or expose sensitive user information."},
+		HelpURI:          "https://cwe.mitre.org/data/definitions/532.html",
+	},
+	"CWE-359": {
+		ID:               "CWE-359",
+		Name:             "Exposure of Private Personal Information to an Unauthorized Actor",
+		ShortDescription: &MessageString{Text: "Private personal information is not properly protected from actors who are not explicitly authorized to access it."},
+		HelpURI:          "https://cwe.mitre.org/data/definitions/359.html",
+	},
+	"CWE-798": {
+		ID:               "CWE-798",
+		Name:             "Use of Hard-coded Credentials",
+		ShortDescription: &MessageString{Text: "The product contains hard-coded credentials, such as a password or cryptographic key."},
+		HelpURI:          "https://cwe.mitre.org/data/definitions/798.html",
+	},
+	"A07:2021": {
+		ID:               "A07:2021",
+		Name:             "Identification and Authentication Failures",
+		ShortDescription: &MessageString{Text: "Authentication weaknesses, including hard-coded and default credentials."},
+		HelpURI:          "https://owasp.org/Top10/A07_2021-Identification_and_Authentication_Failures/",
+	},
+	"A09:2021": {
+		ID:               "A09:2021",
+		Name:             "Security Logging and Monitoring Failures",
+		ShortDescription: &MessageString{Text: "Logging and monitoring failures, including logging sensitive data that should be protected."},
+		HelpURI:          "https://owasp.org/Top10/A09_2021-Security_Logging_and_Monitoring_Failures/",
+	},
+}
+
+// BuildTaxonomies returns the CWE and OWASP taxonomies, containing only the
+// taxa referenced by at least one rule.
+func BuildTaxonomies() []ToolComponent {
+	cwe := ToolComponent{
+		Name:             taxonomyCWE,
+		Version:          "4.14",
+		Organization:     "MITRE",
+		InformationURI:   "https://cwe.mitre.org/",
+		ShortDescription: &MessageString{Text: "The MITRE Common Weakness Enumeration"},
+	}
+	owasp := ToolComponent{
+		Name:             taxonomyOWASP,
+		Version:          "2021",
+		Organization:     "OWASP Foundation",
+		InformationURI:   "https://owasp.org/Top10/",
+		ShortDescription: &MessageString{Text: "OWASP Top 10 Web Application Security Risks"},
+	}
+
+	seen := make(map[string]bool)
+	for _, doc := range detector.RuleDocs() {
+		cwe.Taxa = appendTaxa(cwe.Taxa, doc.CWE, seen)
+		owasp.Taxa = appendTaxa(owasp.Taxa, doc.OWASP, seen)
+	}
+
+	var components []ToolComponent
+	for _, c := range []ToolComponent{cwe, owasp} {
+		if len(c.Taxa) > 0 {
+			components = append(components, c)
+		}
+	}
+	return components
+}
+
+// supportedTaxonomies references the taxonomies emitted by BuildTaxonomies
+func supportedTaxonomies(taxonomies []ToolComponent) []ToolComponentReference {
+	refs := make([]ToolComponentReference, 0, len(taxonomies))
+	for _, t := range taxonomies {
+		refs = append(refs, ToolComponentReference{Name: t.Name})
+	}
+	return refs
+}
+
+// appendTaxa appends the catalog entry for each ID not yet seen
+func appendTaxa(taxa []Taxon, ids []string, seen map[string]bool) []Taxon {
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		if taxon, ok := taxonCatalog[id]; ok {
+			seen[id] = true
+			taxa = append(taxa, taxon)
+		}
+	}
+	return taxa
+}
+
+// ruleRelationships links a rule to each of its CWE and OWASP taxa. The rule
+// is a superset of the weakness: every finding is an instance of it.
+func ruleRelationships(doc detector.RuleDoc) []Relationship {
+	var rels []Relationship
+	add := func(ids []string, taxonomy string) {
+		for _, id := range ids {
+			rels = append(rels, Relationship{
+				Target: ReportingDescriptorReference{
+					ID:            id,
+					ToolComponent: &ToolComponentReference{Name: taxonomy},
+				},
+				Kinds: []string{"superset"},
+			})
+		}
+	}
+	add(doc.CWE, taxonomyCWE)
+	add(doc.OWASP, taxonomyOWASP)
+	return rels
+}
+
+// ruleTags returns the property-bag tags for a rule. "security" enables
+// security-severity on GitHub, and the external/cwe tags follow the
+// convention code scanning uses to display CWE links.
+func ruleTags(doc detector.RuleDoc) []string {
+	tags := []string{"security"}
+	for _, id := range doc.CWE {
+		tags = append(tags, "external/cwe/"+strings.ToLower(id))
+	}
+	for _, id := range doc.OWASP {
+		tags = append(tags, "external/owasp/"+strings.ToLower(id))
+	}
+	return tags
+}
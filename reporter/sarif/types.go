@@ -1,5 +1,13 @@
 package sarif
 
+import (
+	"strconv"
+	"strings"
+
+	"github.com/nilpoona/leakhound/config"
+	"github.com/nilpoona/leakhound/detector"
+)
+
 // Document represents the root SARIF document
 type Document struct {
 	Version string `json:"version"` // "2.1.0"
@@ -9,10 +17,53 @@ type Document struct {
 
 // Run represents an analysis run
 type Run struct {
-	Tool                     Tool                    `json:"tool"`
-	Results                  []Result                `json:"results"`
-	AutomationDetails        *AutomationDetails      `json:"automationDetails,omitempty"`
-	VersionControlProvenance []VersionControlDetails `json:"versionControlProvenance,omitempty"`
+	Tool                     Tool                        `json:"tool"`
+	Results                  []Result                    `json:"results"`
+	AutomationDetails        *AutomationDetails          `json:"automationDetails,omitempty"`
+	VersionControlProvenance []VersionControlDetails     `json:"versionControlProvenance,omitempty"`
+	Invocations              []Invocation                `json:"invocations,omitempty"`
+	OriginalURIBaseIDs       map[string]ArtifactLocation `json:"originalUriBaseIds,omitempty"` // Resolves uriBaseId values such as %SRCROOT%
+	Taxonomies               []ToolComponent             `json:"taxonomies,omitempty"`         // CWE and OWASP classifications referenced by rules
+}
+
+// Invocation describes a single execution of the tool for run bookkeeping
+type Invocation struct {
+	CommandLine         string            `json:"commandLine,omitempty"`
+	Arguments           []string          `json:"arguments,omitempty"`
+	StartTimeUTC        string            `json:"startTimeUtc,omitempty"` // RFC 3339, UTC
+	EndTimeUTC          string            `json:"endTimeUtc,omitempty"`   // RFC 3339, UTC
+	ExitCode            *int              `json:"exitCode,omitempty"`
+	ExecutionSuccessful bool              `json:"executionSuccessful"`
+	Machine             string            `json:"machine,omitempty"` // Host name
+	WorkingDirectory    *ArtifactLocation `json:"workingDirectory,omitempty"`
+	Properties          map[string]string `json:"properties,omitempty"` // os, arch, goVersion
+
+	ToolExecutionNotifications []Notification `json:"toolExecutionNotifications,omitempty"` // E.g. packages degraded by the memory budget
+}
+
+// Notification reports a condition met while running the tool, as opposed to
+// a result about the analyzed code
+type Notification struct {
+	Level     string     `json:"level,omitempty"` // "error", "warning", "note"
+	Message   Message    `json:"message"`
+	Exception *Exception `json:"exception,omitempty"` // A panic recovered while analyzing a package
+}
+
+// Exception describes a panic raised by the tool
+type Exception struct {
+	Kind    string `json:"kind,omitempty"` // Go type of the panic value, e.g. "runtime.Error"
+	Message string `json:"message,omitempty"`
+	Stack   *Stack `json:"stack,omitempty"`
+}
+
+// Stack is the call stack of an exception, innermost frame first
+type Stack struct {
+	Frames []StackFrame `json:"frames"`
+}
+
+// StackFrame is a function call on a stack
+type StackFrame struct {
+	Location Location `json:"location"`
 }
 
 // VersionControlDetails represents version control information
@@ -35,23 +86,67 @@ type Tool struct {
 
 // Driver represents the analysis tool driver
 type Driver struct {
-	Name            string                `json:"name"`               // "leakhound"
-	FullName        string                `json:"fullName,omitempty"` // Full display name
-	InformationURI  string                `json:"informationUri"`     // GitHub repo
-	Version         string                `json:"version"`            // Tool version
-	SemanticVersion string                `json:"semanticVersion"`    // SemVer
-	Rules           []ReportingDescriptor `json:"rules"`
+	Name                string                   `json:"name"`               // "leakhound"
+	FullName            string                   `json:"fullName,omitempty"` // Full display name
+	InformationURI      string                   `json:"informationUri"`     // GitHub repo
+	Version             string                   `json:"version"`            // Tool version
+	SemanticVersion     string                   `json:"semanticVersion"`    // SemVer
+	Rules               []ReportingDescriptor    `json:"rules"`
+	SupportedTaxonomies []ToolComponentReference `json:"supportedTaxonomies,omitempty"`
+}
+
+// ToolComponent describes a taxonomy such as CWE or the OWASP Top 10
+type ToolComponent struct {
+	Name             string         `json:"name"`
+	Version          string         `json:"version,omitempty"`
+	Organization     string         `json:"organization,omitempty"`
+	InformationURI   string         `json:"informationUri,omitempty"`
+	ShortDescription *MessageString `json:"shortDescription,omitempty"`
+	Taxa             []Taxon        `json:"taxa,omitempty"`
+}
+
+// Taxon is a single entry in a taxonomy, e.g. CWE-532
+type Taxon struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name,omitempty"`
+	ShortDescription *MessageString `json:"shortDescription,omitempty"`
+	HelpURI          string         `json:"helpUri,omitempty"`
+}
+
+// ToolComponentReference identifies a taxonomy by name
+type ToolComponentReference struct {
+	Name string `json:"name"`
+}
+
+// Relationship links a rule to a taxon
+type Relationship struct {
+	Target ReportingDescriptorReference `json:"target"`
+	Kinds  []string                     `json:"kinds,omitempty"` // e.g. "superset"
+}
+
+// ReportingDescriptorReference points at a taxon within a taxonomy
+type ReportingDescriptorReference struct {
+	ID            string                  `json:"id"`
+	ToolComponent *ToolComponentReference `json:"toolComponent,omitempty"`
 }
 
 // ReportingDescriptor represents a rule definition
 type ReportingDescriptor struct {
-	ID                   string        `json:"id"`   // "LH0001"
-	Name                 string        `json:"name"` // "SensitiveVariableLogged"
-	ShortDescription     MessageString `json:"shortDescription"`
-	FullDescription      MessageString `json:"fullDescription,omitempty"`
-	Help                 MessageString `json:"help,omitempty"`
-	HelpURI              string        `json:"helpUri,omitempty"` // URL to detailed rule documentation
-	DefaultConfiguration Configuration `json:"defaultConfiguration"`
+	ID                   string          `json:"id"`   // "LH0001"
+	Name                 string          `json:"name"` // "SensitiveVariableLogged"
+	ShortDescription     MessageString   `json:"shortDescription"`
+	FullDescription      MessageString   `json:"fullDescription,omitempty"`
+	Help                 MessageString   `json:"help,omitempty"`
+	HelpURI              string          `json:"helpUri,omitempty"` // URL to detailed rule documentation
+	DefaultConfiguration Configuration   `json:"defaultConfiguration"`
+	Relationships        []Relationship  `json:"relationships,omitempty"` // CWE / OWASP classification
+	Properties           *RuleProperties `json:"properties,omitempty"`
+}
+
+// RuleProperties holds the rule property bag read by code scanning tools
+type RuleProperties struct {
+	Tags             []string `json:"tags,omitempty"`              // "security" enables security-severity on GitHub
+	SecuritySeverity string   `json:"security-severity,omitempty"` // CVSS-like score, e.g. "7.5"
 }
 
 // MessageString represents a message with text
@@ -70,8 +165,42 @@ type Result struct {
 	Message             Message           `json:"message"`
 	Locations           []Location        `json:"locations"`
 	Level               string            `json:"level,omitempty"`               // "error", "warning", "note"
+	Rank                *float64          `json:"rank,omitempty"`                // Priority, 0.0-100.0
 	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"` // Stable fingerprints for result matching
 	Suppressions        []Suppression     `json:"suppressions,omitempty"`        // Present when result is suppressed
+	Properties          map[string]string `json:"properties,omitempty"`          // e.g. "confidence" and "remediation"
+}
+
+// resultProperties returns the SARIF property bag of a finding: its
+// estimated remediation and its confidence level and score (see
+// detector.Finding.Remediation and ConfidenceScore), the sink function and
+// its package, so production loggers can be fixed before fmt debugging
+// statements, the classification of a field recognized by heuristics rather
+// than its tags, the owners of its file, space-separated as in CODEOWNERS,
+// and whether it was matched syntactically in a file that does not
+// type-check
+func resultProperties(f detector.Finding) map[string]string {
+	props := map[string]string{
+		"confidence":      f.Confidence(),
+		"confidenceScore": strconv.FormatFloat(f.ConfidenceScore(), 'f', 2, 64),
+	}
+	if r := f.Remediation(); r != "" {
+		props["remediation"] = r
+	}
+	if f.Sink != "" {
+		props["sink"] = f.Sink
+		props["sinkPackage"] = f.SinkPackage()
+	}
+	if f.Classification != "" {
+		props["classification"] = f.Classification
+	}
+	if f.BestEffort {
+		props["bestEffort"] = "true"
+	}
+	if len(f.Owners) > 0 {
+		props["owners"] = strings.Join(f.Owners, " ")
+	}
+	return props
 }
 
 // Suppression represents a suppression entry on a SARIF result
@@ -87,7 +216,14 @@ type Message struct {
 
 // Location represents a location in source code
 type Location struct {
-	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+	PhysicalLocation PhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []LogicalLocation `json:"logicalLocations,omitempty"` // E.g. the function of a stack frame
+}
+
+// LogicalLocation names a program element, such as a function
+type LogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind,omitempty"` // "function"
 }
 
 // PhysicalLocation represents physical location information
@@ -125,112 +261,78 @@ const (
 	RuleIDSensitiveField          = "LH0004"
 	RuleIDCrossPkgSensitiveReturn = "LH0005"
 	RuleIDCrossPkgSensitiveSink   = "LH0006"
+	RuleIDUntaggedSensitiveField  = "LH0007"
+	RuleIDSensitiveKey            = "LH0008"
+	RuleIDPersonalData            = "LH0009"
+	RuleIDBearerCredential        = "LH0010"
+	RuleIDExposedSecret           = "LH0011"
+	RuleIDCommandLineSecret       = "LH0012"
+	RuleIDConfigDump              = "LH0013"
+	RuleIDSensitiveStringer       = "LH0014"
+	RuleIDSensitiveBoundary       = "LH0015"
+	RuleIDHookForwarding          = "LH0016"
+	RuleIDSensitivePublished      = "LH0017"
+	RuleIDHardcodedSecret         = "LH0018"
+	RuleIDCrashHandlerDump        = "LH0019"
 )
 
-// BuildRules returns all rule descriptors for SARIF output
+// BuildRules returns all rule descriptors for SARIF output. Descriptions are
+// taken from detector.RuleDocs so they stay in sync with `leakhound explain`.
 func BuildRules() []ReportingDescriptor {
-	return []ReportingDescriptor{
-		{
-			ID:   RuleIDSensitiveVar,
-			Name: "SensitiveVariableLogged",
-			ShortDescription: MessageString{
-				Text: "Variable containing sensitive data is logged",
-			},
-			FullDescription: MessageString{
-				Text: "A variable that contains data from a field tagged with sensitive:\"true\" is passed to a logging function.",
-			},
-			Help: MessageString{
-				Text: "Avoid logging variables that contain sensitive information. Consider redacting or removing the sensitive data before logging.",
-			},
-			HelpURI: "https://github.com/nilpoona/leakhound#" + RuleIDSensitiveVar,
-			DefaultConfiguration: Configuration{
-				Level: "error",
-			},
-		},
-		{
-			ID:   RuleIDSensitiveCall,
-			Name: "SensitiveFunctionCallLogged",
-			ShortDescription: MessageString{
-				Text: "Function call returning sensitive data is logged",
-			},
-			FullDescription: MessageString{
-				Text: "A function call that returns sensitive data (from a field tagged with sensitive:\"true\") is passed to a logging function.",
-			},
-			Help: MessageString{
-				Text: "Avoid logging function return values that contain sensitive information. Store the result in a variable and redact sensitive fields before logging.",
-			},
-			HelpURI: "https://github.com/nilpoona/leakhound#" + RuleIDSensitiveCall,
-			DefaultConfiguration: Configuration{
-				Level: "error",
-			},
-		},
-		{
-			ID:   RuleIDSensitiveStruct,
-			Name: "SensitiveStructLogged",
-			ShortDescription: MessageString{
-				Text: "Struct containing sensitive fields is logged",
-			},
-			FullDescription: MessageString{
-				Text: "An entire struct that contains fields tagged with sensitive:\"true\" is passed to a logging function.",
-			},
-			Help: MessageString{
-				Text: "Avoid logging entire structs that contain sensitive fields. Log only the non-sensitive fields individually.",
-			},
-			HelpURI: "https://github.com/nilpoona/leakhound#" + RuleIDSensitiveStruct,
-			DefaultConfiguration: Configuration{
-				Level: "error",
-			},
-		},
-		{
-			ID:   RuleIDSensitiveField,
-			Name: "SensitiveFieldLogged",
-			ShortDescription: MessageString{
-				Text: "Sensitive struct field is logged",
-			},
-			FullDescription: MessageString{
-				Text: "A struct field tagged with sensitive:\"true\" is directly accessed and passed to a logging function.",
-			},
-			Help: MessageString{
-				Text: "Avoid logging fields marked as sensitive. Remove the field from the log call or redact its value.",
-			},
-			HelpURI: "https://github.com/nilpoona/leakhound#" + RuleIDSensitiveField,
-			DefaultConfiguration: Configuration{
-				Level: "error",
-			},
-		},
-		{
-			ID:   RuleIDCrossPkgSensitiveReturn,
-			Name: "CrossPackageSensitiveReturnLogged",
+	return BuildRulesWithConfig(nil)
+}
+
+// BuildRulesWithConfig returns all rule descriptors, applying per-rule
+// security-severity overrides from cfg. A nil cfg uses the defaults.
+func BuildRulesWithConfig(cfg *config.Config) []ReportingDescriptor {
+	docs := detector.RuleDocs()
+	rules := make([]ReportingDescriptor, 0, len(docs))
+	for _, doc := range docs {
+		rules = append(rules, ReportingDescriptor{
+			ID:   doc.ID,
+			Name: doc.Name,
 			ShortDescription: MessageString{
-				Text: "Cross-package function returning sensitive data is logged",
+				Text: doc.Short,
 			},
 			FullDescription: MessageString{
-				Text: "A function defined in a different package returns data derived from a field tagged with sensitive:\"true\", and the result is passed to a logging function.",
+				Text: doc.Full,
 			},
 			Help: MessageString{
-				Text: "Avoid logging the return value of cross-package functions that surface sensitive data. Redact or transform the value before logging.",
+				Text: doc.Help,
 			},
-			HelpURI: "https://github.com/nilpoona/leakhound#" + RuleIDCrossPkgSensitiveReturn,
+			HelpURI: doc.HelpURI(),
 			DefaultConfiguration: Configuration{
-				Level: "error",
-			},
-		},
-		{
-			ID:   RuleIDCrossPkgSensitiveSink,
-			Name: "CrossPackageSensitiveSink",
-			ShortDescription: MessageString{
-				Text: "Sensitive data flows into a logging sink in another package",
-			},
-			FullDescription: MessageString{
-				Text: "Sensitive data (from a field tagged with sensitive:\"true\") is passed as an argument to a function in a different package whose body forwards that parameter to a logging function.",
+				Level: string(doc.Level()),
 			},
-			Help: MessageString{
-				Text: "Avoid passing sensitive values to cross-package functions that log their parameters. Redact upstream or switch to a non-logging API.",
+			Relationships: ruleRelationships(doc),
+			Properties: &RuleProperties{
+				Tags:             ruleTags(doc),
+				SecuritySeverity: strconv.FormatFloat(securitySeverity(doc, cfg), 'f', 1, 64),
 			},
-			HelpURI: "https://github.com/nilpoona/leakhound#" + RuleIDCrossPkgSensitiveSink,
-			DefaultConfiguration: Configuration{
-				Level: "error",
-			},
-		},
+		})
+	}
+	return rules
+}
+
+// securitySeverity returns the configured security-severity for a rule, or
+// the rule's documented default.
+func securitySeverity(doc detector.RuleDoc, cfg *config.Config) float64 {
+	if s, ok := cfg.RuleSecuritySeverity(doc.ID); ok {
+		return s
+	}
+	return doc.SecuritySeverity
+}
+
+// resultRank returns the rank for results of a rule: the configured rank, or
+// the rule's security-severity scaled to SARIF's 0-100 range.
+func resultRank(ruleID string, cfg *config.Config) *float64 {
+	rank, ok := cfg.RuleRank(ruleID)
+	if !ok {
+		doc, found := detector.LookupRuleDoc(ruleID)
+		if !found {
+			return nil
+		}
+		rank = securitySeverity(doc, cfg) * 10
 	}
+	return &rank
 }
@@ -4,17 +4,34 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/nilpoona/leakhound/config"
 	"github.com/nilpoona/leakhound/detector"
 )
 
+// Every rule but LH0009 maps to CWE-532 and OWASP A09:2021.
+var (
+	logRuleRelationships = []Relationship{
+		{
+			Target: ReportingDescriptorReference{ID: "CWE-532", ToolComponent: &ToolComponentReference{Name: "CWE"}},
+			Kinds:  []string{"superset"},
+		},
+		{
+			Target: ReportingDescriptorReference{ID: "A09:2021", ToolComponent: &ToolComponentReference{Name: "OWASP"}},
+			Kinds:  []string{"superset"},
+		},
+	}
+	logRuleTags = []string{"security", "external/cwe/cwe-532", "external/owasp/a09:2021"}
+)
+
 func TestBuildRules(t *testing.T) {
 	t.Parallel()
 
+	const wantRules = 19
 	rules := BuildRules()
 
 	// Test basic properties
-	if len(rules) != 6 {
-		t.Fatalf("BuildRules() returned %d rules, want 6", len(rules))
+	if len(rules) != wantRules {
+		t.Fatalf("BuildRules() returned %d rules, want %d", len(rules), wantRules)
 	}
 
 	// Expected rule definitions
@@ -35,6 +52,11 @@ func TestBuildRules(t *testing.T) {
 			DefaultConfiguration: Configuration{
 				Level: "error",
 			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "7.5",
+			},
 		},
 		{
 			ID:   "LH0002",
@@ -52,6 +74,11 @@ func TestBuildRules(t *testing.T) {
 			DefaultConfiguration: Configuration{
 				Level: "error",
 			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "7.5",
+			},
 		},
 		{
 			ID:   "LH0003",
@@ -69,6 +96,11 @@ func TestBuildRules(t *testing.T) {
 			DefaultConfiguration: Configuration{
 				Level: "error",
 			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "6.5",
+			},
 		},
 		{
 			ID:   "LH0004",
@@ -86,6 +118,11 @@ func TestBuildRules(t *testing.T) {
 			DefaultConfiguration: Configuration{
 				Level: "error",
 			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "8.0",
+			},
 		},
 		{
 			ID:   "LH0005",
@@ -103,6 +140,11 @@ func TestBuildRules(t *testing.T) {
 			DefaultConfiguration: Configuration{
 				Level: "error",
 			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "7.5",
+			},
 		},
 		{
 			ID:   "LH0006",
@@ -120,6 +162,315 @@ func TestBuildRules(t *testing.T) {
 			DefaultConfiguration: Configuration{
 				Level: "error",
 			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "7.5",
+			},
+		},
+		{
+			ID:   "LH0007",
+			Name: "UntaggedSensitiveField",
+			ShortDescription: MessageString{
+				Text: "Field that looks sensitive has no sensitive tag",
+			},
+			FullDescription: MessageString{
+				Text: "A field of an exported struct has a name matching the sensitive-name patterns (password, token, secret, ...) but is not tagged with sensitive:\"true\", so leaks of it are not detected. This audit rule is disabled by default.",
+			},
+			Help: MessageString{
+				Text: "Tag the field with sensitive:\"true\" so leakhound tracks it, or tag it sensitive:\"false\" if it does not hold sensitive data.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0007",
+			DefaultConfiguration: Configuration{
+				Level: "warning",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "3.0",
+			},
+		},
+		{
+			ID:   "LH0008",
+			Name: "SensitiveDataInKey",
+			ShortDescription: MessageString{
+				Text: "Sensitive data is used in a cache key or metric name",
+			},
+			FullDescription: MessageString{
+				Text: "Data from a field tagged with sensitive:\"true\" is used to build a key passed to a configured key sink, such as a Redis or Memcached key or a metric name or label. Keys are routinely written to infrastructure logs, slow-query logs and dashboards.",
+			},
+			Help: MessageString{
+				Text: "Build keys from non-sensitive identifiers, or hash the sensitive value with a keyed hash before using it in a key.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0008",
+			DefaultConfiguration: Configuration{
+				Level: "error",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "6.5",
+			},
+		},
+		{
+			ID:   "LH0009",
+			Name: "PersonalDataLogged",
+			ShortDescription: MessageString{
+				Text: "Personal data is logged",
+			},
+			FullDescription: MessageString{
+				Text: "Personally identifiable information, from a field tagged with pii:\"true\" or one whose name matches the PII patterns (email, SSN, phone, date of birth), is passed to a logging function. This rule is reported only in PII mode, which is disabled by default, so privacy findings can be tracked apart from leaked secrets.",
+			},
+			Help: MessageString{
+				Text: "Avoid logging personal data. Log an opaque identifier such as a user ID, or a masked form of the value.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0009",
+			DefaultConfiguration: Configuration{
+				Level: "warning",
+			},
+			Relationships: []Relationship{
+				{
+					Target: ReportingDescriptorReference{ID: "CWE-359", ToolComponent: &ToolComponentReference{Name: "CWE"}},
+					Kinds:  []string{"superset"},
+				},
+				{
+					Target: ReportingDescriptorReference{ID: "A09:2021", ToolComponent: &ToolComponentReference{Name: "OWASP"}},
+					Kinds:  []string{"superset"},
+				},
+			},
+			Properties: &RuleProperties{
+				Tags:             []string{"security", "external/cwe/cwe-359", "external/owasp/a09:2021"},
+				SecuritySeverity: "4.0",
+			},
+		},
+		{
+			ID:   "LH0010",
+			Name: "BearerCredentialLogged",
+			ShortDescription: MessageString{
+				Text: "Authorization header value or JWT is logged",
+			},
+			FullDescription: MessageString{
+				Text: "The value of an Authorization or Proxy-Authorization request header, or an encoded JWT, is passed to a logging function. These are recognized without struct tags, including after the token is extracted with helpers such as strings.TrimPrefix(auth, \"Bearer \").",
+			},
+			Help: MessageString{
+				Text: "Never log bearer tokens or JWTs. Log the token's subject or ID claim, or whether a token was present, instead.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0010",
+			DefaultConfiguration: Configuration{
+				Level: "error",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "8.0",
+			},
+		},
+		{
+			ID:   "LH0011",
+			Name: "ExposedSecretLogged",
+			ShortDescription: MessageString{
+				Text: "Secret unwrapped from a redact wrapper is logged",
+			},
+			FullDescription: MessageString{
+				Text: "A value unwrapped from a redact.Secret with its Expose method is passed to a logging function. The wrapper renders as [REDACTED], but the unwrapped value does not.",
+			},
+			Help: MessageString{
+				Text: "Log the redact.Secret itself rather than the result of Expose, and call Expose only where the raw value is consumed.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0011",
+			DefaultConfiguration: Configuration{
+				Level: "error",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "7.5",
+			},
+		},
+		{
+			ID:   "LH0012",
+			Name: "CommandLineSecretLogged",
+			ShortDescription: MessageString{
+				Text: "Flag or command-line argument declared sensitive is logged",
+			},
+			FullDescription: MessageString{
+				Text: "The value of a flag or an element of os.Args declared sensitive in the command-line section of the config file is passed to a logging function, directly or after flowing through variables and functions.",
+			},
+			Help: MessageString{
+				Text: "Do not log secrets passed on the command line. Log whether the value was set instead.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0012",
+			DefaultConfiguration: Configuration{
+				Level: "error",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "7.5",
+			},
+		},
+		{
+			ID:   "LH0013",
+			Name: "ConfigStructPrinted",
+			ShortDescription: MessageString{
+				Text: "Configuration struct is printed whole",
+			},
+			FullDescription: MessageString{
+				Text: "A struct declared in a configuration package (config, settings, ...) with more than the configured number of fields is passed to fmt or log Print, Printf or Println. Such debugging dumps print every field the configuration holds, tagged or not, including the secrets it grows later. This audit rule is disabled by default.",
+			},
+			Help: MessageString{
+				Text: "Remove the debugging print, or log the few fields needed by name.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0013",
+			DefaultConfiguration: Configuration{
+				Level: "warning",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "3.0",
+			},
+		},
+		{
+			ID:   "LH0014",
+			Name: "SensitiveStringer",
+			ShortDescription: MessageString{
+				Text: "String, Format or MarshalText method renders sensitive data",
+			},
+			FullDescription: MessageString{
+				Text: "A String, GoString or MarshalText method returns sensitive data, or a Format method writes it to its fmt.State. fmt calls these methods whenever a value of the type is printed with %v, %s or %+v, and log/slog handlers call MarshalText, so every log of the type leaks the data, wherever it happens.",
+			},
+			Help: MessageString{
+				Text: "Render a redacted view of the sensitive data in the method.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0014",
+			DefaultConfiguration: Configuration{
+				Level: "error",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "7.5",
+			},
+		},
+		{
+			ID:   "LH0015",
+			Name: "SensitiveTypeAtBoundary",
+			ShortDescription: MessageString{
+				Text: "Boundary package accepts a sensitive type",
+			},
+			FullDescription: MessageString{
+				Text: "A function or method declared in a boundary package, one the config declares sensitive types must never cross into such as a logging or telemetry package, accepts a struct with sensitive fields, directly or in a pointer, slice, array, map or channel. Whatever such a package is given ends up logged or exported, so the signature invites leaks at the API design level. This rule only applies to the packages listed in boundaries.",
+			},
+			Help: MessageString{
+				Text: "Accept a redacted view or the non-sensitive values instead of the sensitive type.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0015",
+			DefaultConfiguration: Configuration{
+				Level: "warning",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "5.0",
+			},
+		},
+		{
+			ID:   "LH0016",
+			Name: "LogHookForwarding",
+			ShortDescription: MessageString{
+				Text: "Logger hook forwards log entries to an external sink",
+			},
+			FullDescription: MessageString{
+				Text: "A logger hook registered with a configured registration point, such as logrus.AddHook or zap.Hooks, passes the entries it receives, or values derived from them, to an external sink such as an HTTP client or a Kafka producer. Hooks see every entry logged, including the sensitive data other rules report, and ship it out of the process past the redaction of the log pipeline. This rule only applies to the registrations listed in hooks.registrations.",
+			},
+			Help: MessageString{
+				Text: "Forward a redacted view of the entry, or only the fields the external system needs.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0016",
+			DefaultConfiguration: Configuration{
+				Level: "warning",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "6.5",
+			},
+		},
+		{
+			ID:   "LH0017",
+			Name: "SensitiveDataPublished",
+			ShortDescription: MessageString{
+				Text: "Sensitive data is published to a message queue feeding logs",
+			},
+			FullDescription: MessageString{
+				Text: "Data from a field tagged with sensitive:\"true\" is passed in the payload of a configured producer sink, such as a Kafka writer, an SQS queue or a Pub/Sub topic whose messages feed a logging pipeline. The message is indexed and retained by the log consumers, so the data leaks as surely as if it had been logged.",
+			},
+			Help: MessageString{
+				Text: "Publish an opaque identifier or a redacted view instead of the sensitive data.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0017",
+			DefaultConfiguration: Configuration{
+				Level: "error",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "7.0",
+			},
+		},
+		{
+			ID:   "LH0018",
+			Name: "HardcodedSecret",
+			ShortDescription: MessageString{
+				Text: "Secret is hardcoded in a struct tag or constant",
+			},
+			FullDescription: MessageString{
+				Text: "A struct tag or a string constant holds a secret: the default or example value of a field tagged sensitive or whose name matches the sensitive-name patterns (password, token, secret, ...), a constant with such a name, or a connection string with a password. The secret ships in the source and the binary, and ends up in the logs wherever the default is used. This audit rule is disabled by default.",
+			},
+			Help: MessageString{
+				Text: "Read the secret from the environment or a secret store at run time, and leave the default empty.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0018",
+			DefaultConfiguration: Configuration{
+				Level: "warning",
+			},
+			Relationships: []Relationship{
+				{
+					Target: ReportingDescriptorReference{ID: "CWE-798", ToolComponent: &ToolComponentReference{Name: "CWE"}},
+					Kinds:  []string{"superset"},
+				},
+				{
+					Target: ReportingDescriptorReference{ID: "A07:2021", ToolComponent: &ToolComponentReference{Name: "OWASP"}},
+					Kinds:  []string{"superset"},
+				},
+			},
+			Properties: &RuleProperties{
+				Tags:             []string{"security", "external/cwe/cwe-798", "external/owasp/a07:2021"},
+				SecuritySeverity: "6.0",
+			},
+		},
+		{
+			ID:   "LH0019",
+			Name: "CrashHandlerDump",
+			ShortDescription: MessageString{
+				Text: "Crash handler logs sensitive-looking local variables with the stack",
+			},
+			FullDescription: MessageString{
+				Text: "A function recovering from a panic dumps the stack with debug.PrintStack, debug.Stack or runtime.Stack, and logs a local variable or parameter whose name matches the sensitive-name patterns (password, token, secret, ...). Crash handlers are written to capture as much context as possible, and are rarely reviewed for what that context holds: the values end up next to the stack trace in crash reports and error trackers. This audit rule is disabled by default.",
+			},
+			Help: MessageString{
+				Text: "Log the panic value and the stack, and identify the request or operation by an opaque ID rather than its secrets.",
+			},
+			HelpURI: "https://github.com/nilpoona/leakhound#LH0019",
+			DefaultConfiguration: Configuration{
+				Level: "warning",
+			},
+			Relationships: logRuleRelationships,
+			Properties: &RuleProperties{
+				Tags:             logRuleTags,
+				SecuritySeverity: "5.0",
+			},
 		},
 	}
 
@@ -148,7 +499,7 @@ func TestBuildRules_RuleIDs(t *testing.T) {
 	}
 
 	// Test that all expected rule IDs are present
-	expectedIDs := []string{"LH0001", "LH0002", "LH0003", "LH0004", "LH0005", "LH0006"}
+	expectedIDs := []string{"LH0001", "LH0002", "LH0003", "LH0004", "LH0005", "LH0006", "LH0007", "LH0008", "LH0009", "LH0010", "LH0011", "LH0012", "LH0013", "LH0014", "LH0015", "LH0016", "LH0017", "LH0018", "LH0019"}
 	for _, expectedID := range expectedIDs {
 		if !ruleIDs[expectedID] {
 			t.Errorf("Missing expected rule ID: %s", expectedID)
@@ -168,6 +519,19 @@ func TestBuildRules_RuleNames(t *testing.T) {
 		"LH0004": "SensitiveFieldLogged",
 		"LH0005": "CrossPackageSensitiveReturnLogged",
 		"LH0006": "CrossPackageSensitiveSink",
+		"LH0007": "UntaggedSensitiveField",
+		"LH0008": "SensitiveDataInKey",
+		"LH0009": "PersonalDataLogged",
+		"LH0010": "BearerCredentialLogged",
+		"LH0011": "ExposedSecretLogged",
+		"LH0012": "CommandLineSecretLogged",
+		"LH0013": "ConfigStructPrinted",
+		"LH0014": "SensitiveStringer",
+		"LH0015": "SensitiveTypeAtBoundary",
+		"LH0016": "LogHookForwarding",
+		"LH0017": "SensitiveDataPublished",
+		"LH0018": "HardcodedSecret",
+		"LH0019": "CrashHandlerDump",
 	}
 
 	for _, rule := range rules {
@@ -206,9 +570,11 @@ func TestBuildRules_AllHaveRequiredFields(t *testing.T) {
 		if rule.HelpURI == "" {
 			t.Errorf("Rule[%d]: HelpURI should not be empty", i)
 		}
-		if rule.DefaultConfiguration.Level != "error" {
-			t.Errorf("Rule[%d]: DefaultConfiguration.Level = %q, want %q",
-				i, rule.DefaultConfiguration.Level, "error")
+		switch rule.DefaultConfiguration.Level {
+		case "error", "warning", "note":
+		default:
+			t.Errorf("Rule[%d]: DefaultConfiguration.Level = %q, want a SARIF level",
+				i, rule.DefaultConfiguration.Level)
 		}
 	}
 }
@@ -324,3 +690,118 @@ func TestBuildRules_OrderConsistency(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildRulesWithConfig_SecuritySeverity(t *testing.T) {
+	t.Parallel()
+
+	cfg := &config.Config{Rules: map[string]config.RuleConfig{
+		"LH0003": {SecuritySeverity: floatPtr(9.1)},
+		"all":    {SecuritySeverity: floatPtr(4)},
+	}}
+
+	want := map[string]string{
+		"LH0001": "4.0",
+		"LH0003": "9.1",
+	}
+	for _, rule := range BuildRulesWithConfig(cfg) {
+		w, ok := want[rule.ID]
+		if !ok {
+			continue
+		}
+		if rule.Properties == nil || rule.Properties.SecuritySeverity != w {
+			t.Errorf("rule %s security-severity = %+v, want %q", rule.ID, rule.Properties, w)
+		}
+	}
+}
+
+func TestResultRank(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		ruleID string
+		cfg    *config.Config
+		want   *float64
+	}{
+		{"default from security-severity", "LH0004", nil, floatPtr(80)},
+		{"scaled from configured security-severity", "LH0003", &config.Config{Rules: map[string]config.RuleConfig{
+			"LH0003": {SecuritySeverity: floatPtr(9.5)},
+		}}, floatPtr(95)},
+		{"explicit rank wins", "LH0003", &config.Config{Rules: map[string]config.RuleConfig{
+			"LH0003": {SecuritySeverity: floatPtr(9.5), Rank: floatPtr(12.5)},
+		}}, floatPtr(12.5)},
+		{"unknown rule", "LH9999", nil, nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := resultRank(tt.ruleID, tt.cfg)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resultRank(%q) = %v, want %v", tt.ruleID, got, tt.want)
+			}
+		})
+	}
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestBuildTaxonomies(t *testing.T) {
+	t.Parallel()
+
+	taxonomies := BuildTaxonomies()
+	if len(taxonomies) != 2 {
+		t.Fatalf("BuildTaxonomies() returned %d taxonomies, want 2", len(taxonomies))
+	}
+
+	type taxon struct{ id, uri string }
+	tests := []struct {
+		name string
+		taxa []taxon
+	}{
+		{name: "CWE", taxa: []taxon{
+			{"CWE-532", "https://cwe.mitre.org/data/definitions/532.html"},
+			{"CWE-359", "https://cwe.mitre.org/data/definitions/359.html"},
+			{"CWE-798", "https://cwe.mitre.org/data/definitions/798.html"},
+		}},
+		{name: "OWASP", taxa: []taxon{
+			{"A09:2021", "https://owasp.org/Top10/A09_2021-Security_Logging_and_Monitoring_Failures/"},
+			{"A07:2021", "https://owasp.org/Top10/A07_2021-Identification_and_Authentication_Failures/"},
+		}},
+	}
+	for i, tt := range tests {
+		got := taxonomies[i]
+		if got.Name != tt.name {
+			t.Errorf("taxonomies[%d].Name = %q, want %q", i, got.Name, tt.name)
+			continue
+		}
+		// Taxa are deduplicated across rules
+		if len(got.Taxa) != len(tt.taxa) {
+			t.Errorf("%s has %d taxa, want %d", tt.name, len(got.Taxa), len(tt.taxa))
+			continue
+		}
+		for j, want := range tt.taxa {
+			if got.Taxa[j].ID != want.id || got.Taxa[j].HelpURI != want.uri {
+				t.Errorf("%s taxon = %+v, want ID %q and helpUri %q", tt.name, got.Taxa[j], want.id, want.uri)
+			}
+		}
+	}
+
+	// Every relationship target must resolve to an emitted taxon
+	taxa := make(map[string]bool)
+	for _, tc := range taxonomies {
+		for _, taxon := range tc.Taxa {
+			taxa[tc.Name+"/"+taxon.ID] = true
+		}
+	}
+	for _, rule := range BuildRules() {
+		for _, rel := range rule.Relationships {
+			if !taxa[rel.Target.ToolComponent.Name+"/"+rel.Target.ID] {
+				t.Errorf("rule %s references unknown taxon %s/%s", rule.ID, rel.Target.ToolComponent.Name, rel.Target.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package sarif
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SARIF version and schema written in the $schema property of every document
+const (
+	SARIFVersion = "2.1.0"
+	SchemaURI    = "https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/schemas/sarif-schema-2.1.0.json"
+)
+
+// schemaJSON holds a partial SARIF 2.1.0 schema, covering the objects
+// leakhound writes (see the $comment of the file)
+//
+//go:embed sarif-schema-2.1.0.json
+var schemaJSON []byte
+
+// loadSchema parses schemaJSON once
+var loadSchema = sync.OnceValues(func() (*schema, error) {
+	var s schema
+	if err := json.Unmarshal(schemaJSON, &s); err != nil {
+		return nil, fmt.Errorf("invalid embedded SARIF schema: %w", err)
+	}
+	return &s, nil
+})
+
+// ValidationError is a violation of the SARIF schema by a document
+type ValidationError struct {
+	Path    string // JSON pointer to the offending value, e.g. "/runs/0/results/3/level"
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	path := e.Path
+	if path == "" {
+		path = "/"
+	}
+	return path + ": " + e.Message
+}
+
+// Validate checks that data is a SARIF 2.1.0 document: JSON valid against the
+// schema, with the version and $schema leakhound writes. It returns the
+// violations, each a *ValidationError, joined, or nil when there are none.
+func Validate(data []byte) error {
+	s, err := loadSchema()
+	if err != nil {
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return fmt.Errorf("invalid SARIF JSON: %w", err)
+	}
+
+	v := &validator{root: s}
+	v.validate(s, doc, "")
+	if obj, ok := doc.(map[string]any); ok && obj["$schema"] != nil && obj["$schema"] != SchemaURI {
+		v.fail("/$schema", "schema URI %v is not the SARIF %s schema %s", obj["$schema"], SARIFVersion, SchemaURI)
+	}
+	return errors.Join(v.errs...)
+}
+
+// schema is a JSON Schema, draft-07, limited to the keywords the SARIF schema
+// uses
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaType         `json:"type"`
+	Enum                 []any              `json:"enum"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *schema            `json:"additionalProperties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	MinItems             *int               `json:"minItems"`
+	UniqueItems          bool               `json:"uniqueItems"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Format               string             `json:"format"`
+	Pattern              string             `json:"pattern"`
+	AnyOf                []*schema          `json:"anyOf"`
+	Definitions          map[string]*schema `json:"definitions"`
+
+	never   bool           // The false schema, which no value is valid against
+	pattern *regexp.Regexp // Pattern, compiled
+}
+
+// UnmarshalJSON decodes a schema, including the boolean schemas true and
+// false
+func (s *schema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true":
+		*s = schema{}
+		return nil
+	case "false":
+		*s = schema{never: true}
+		return nil
+	}
+	type plain schema
+	if err := json.Unmarshal(data, (*plain)(s)); err != nil {
+		return err
+	}
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	return nil
+}
+
+// schemaType is the type keyword, a single type or a list of types
+type schemaType []string
+
+// UnmarshalJSON decodes a single type name or a list of them
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = schemaType{name}
+		return nil
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// validator collects the violations of a document against root
+type validator struct {
+	root *schema
+	errs []error
+}
+
+func (v *validator) fail(path, format string, args ...any) {
+	v.errs = append(v.errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// validate checks value, at path, against s
+func (v *validator) validate(s *schema, value any, path string) {
+	if s.Ref != "" {
+		name, ok := strings.CutPrefix(s.Ref, "#/definitions/")
+		def := v.root.Definitions[name]
+		if !ok || def == nil {
+			v.fail(path, "unresolved schema reference %s", s.Ref)
+			return
+		}
+		s = def
+	}
+	if s.never {
+		v.fail(path, "unexpected value")
+		return
+	}
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return hasType(value, t) }) {
+		v.fail(path, "got %s, want %s", typeName(value), strings.Join(s.Type, " or "))
+		return
+	}
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return jsonEqual(e, value) }) {
+		v.fail(path, "value %s is not one of %s", encode(value), encodeAll(s.Enum))
+	}
+	if len(s.AnyOf) > 0 && !slices.ContainsFunc(s.AnyOf, func(alt *schema) bool { return v.matches(alt, value) }) {
+		v.fail(path, "value matches none of the alternatives of the schema, e.g. a required property is missing")
+	}
+
+	switch value := value.(type) {
+	case map[string]any:
+		v.validateObject(s, value, path)
+	case []any:
+		v.validateArray(s, value, path)
+	case json.Number:
+		n, _ := value.Float64()
+		if s.Minimum != nil && n < *s.Minimum {
+			v.fail(path, "%s is less than the minimum %v", value, *s.Minimum)
+		}
+		if s.Maximum != nil && n > *s.Maximum {
+			v.fail(path, "%s is greater than the maximum %v", value, *s.Maximum)
+		}
+	case string:
+		if s.pattern != nil && !s.pattern.MatchString(value) {
+			v.fail(path, "%q does not match pattern %s", value, s.Pattern)
+		}
+		if err := checkFormat(s.Format, value); err != nil {
+			v.fail(path, "%q is not a valid %s: %v", value, s.Format, err)
+		}
+	}
+}
+
+// validateObject checks the properties of an object against s
+func (v *validator) validateObject(s *schema, obj map[string]any, path string) {
+	for _, name := range s.Required {
+		if _, ok := obj[name]; !ok {
+			v.fail(path, "missing required property %q", name)
+		}
+	}
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		propPath := path + "/" + escapePointer(name)
+		switch prop, ok := s.Properties[name]; {
+		case ok:
+			v.validate(prop, obj[name], propPath)
+		case s.AdditionalProperties != nil && s.AdditionalProperties.never:
+			v.fail(propPath, "property %q is not allowed", name)
+		case s.AdditionalProperties != nil:
+			v.validate(s.AdditionalProperties, obj[name], propPath)
+		}
+	}
+}
+
+// validateArray checks the items of an array against s
+func (v *validator) validateArray(s *schema, items []any, path string) {
+	if s.MinItems != nil && len(items) < *s.MinItems {
+		v.fail(path, "%d items, want at least %d", len(items), *s.MinItems)
+	}
+	if s.UniqueItems {
+		seen := make(map[string]int, len(items))
+		for i, item := range items {
+			key := encode(item)
+			if j, ok := seen[key]; ok {
+				v.fail(path+"/"+strconv.Itoa(i), "duplicate of item %d", j)
+				continue
+			}
+			seen[key] = i
+		}
+	}
+	if s.Items != nil {
+		for i, item := range items {
+			v.validate(s.Items, item, path+"/"+strconv.Itoa(i))
+		}
+	}
+}
+
+// matches reports whether value is valid against s, without recording the
+// violations
+func (v *validator) matches(s *schema, value any) bool {
+	sub := &validator{root: v.root}
+	sub.validate(s, value, "")
+	return len(sub.errs) == 0
+}
+
+// hasType reports whether value is of the JSON Schema type t
+func hasType(value any, t string) bool {
+	switch value := value.(type) {
+	case nil:
+		return t == "null"
+	case bool:
+		return t == "boolean"
+	case string:
+		return t == "string"
+	case map[string]any:
+		return t == "object"
+	case []any:
+		return t == "array"
+	case json.Number:
+		if t == "number" {
+			return true
+		}
+		n, err := value.Float64()
+		return t == "integer" && err == nil && n == math.Trunc(n)
+	}
+	return false
+}
+
+// typeName returns the JSON Schema type of value, for messages
+func typeName(value any) string {
+	for _, t := range []string{"null", "boolean", "string", "object", "array", "integer", "number"} {
+		if hasType(value, t) {
+			return t
+		}
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// checkFormat checks value against the format keyword; unknown formats are
+// not checked
+func checkFormat(format, value string) error {
+	switch format {
+	case "uri":
+		u, err := url.Parse(value)
+		if err != nil {
+			return err
+		}
+		if !u.IsAbs() {
+			return errors.New("not an absolute URI")
+		}
+	case "uri-reference":
+		_, err := url.Parse(value)
+		return err
+	case "date-time":
+		_, err := time.Parse(time.RFC3339, value)
+		return err
+	}
+	return nil
+}
+
+// jsonEqual reports whether a and b are the same JSON value
+func jsonEqual(a, b any) bool {
+	return encode(a) == encode(b)
+}
+
+// encode returns the canonical JSON encoding of value: object keys sorted and
+// numbers as written
+func encode(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// encodeAll returns the JSON encodings of values, comma-separated
+func encodeAll(values []any) string {
+	encoded := make([]string, len(values))
+	for i, value := range values {
+		encoded[i] = encode(value)
+	}
+	return strings.Join(encoded, ", ")
+}
+
+// escapePointer escapes a property name for a JSON pointer (RFC 6901)
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
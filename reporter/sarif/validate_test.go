@@ -0,0 +1,264 @@
+package sarif
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nilpoona/leakhound/detector"
+	"github.com/nilpoona/leakhound/reporter"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	valid := `{
+  "version": "2.1.0",
+  "$schema": "` + SchemaURI + `",
+  "runs": [{
+    "tool": {"driver": {"name": "leakhound", "rules": [{"id": "LH0001", "shortDescription": {"text": "x"}}]}},
+    "results": [{
+      "ruleId": "LH0001",
+      "level": "error",
+      "rank": 75,
+      "message": {"text": "x"},
+      "locations": [{"physicalLocation": {"artifactLocation": {"uri": "a.go"}, "region": {"startLine": 1}}}],
+      "suppressions": [{"kind": "inSource", "state": "accepted"}],
+      "properties": {"confidence": "high"}
+    }],
+    "invocations": [{"executionSuccessful": true, "startTimeUtc": "2024-05-01T00:00:01.5Z", "exitCode": 3}]
+  }]
+}`
+
+	tests := []struct {
+		name     string
+		replace  [2]string // Replaces the first occurrence of [0] in valid by [1]
+		wantPath string    // Path of the violation; "" for a valid document
+	}{
+		{"valid", [2]string{}, ""},
+		{"wrong version", [2]string{`"version": "2.1.0"`, `"version": "2.0.0"`}, "/version"},
+		{"mismatched schema URI", [2]string{SchemaURI, "https://json.schemastore.org/sarif-2.1.0.json"}, "/$schema"},
+		{"missing tool", [2]string{`"tool": {"driver": {"name": "leakhound", "rules": [{"id": "LH0001", "shortDescription": {"text": "x"}}]}},`, ""}, "/runs/0"},
+		{"rule without id", [2]string{`"id": "LH0001", `, ""}, "/runs/0/tool/driver/rules/0"},
+		{"invalid level", [2]string{`"level": "error"`, `"level": "info"`}, "/runs/0/results/0/level"},
+		{"rank over 100", [2]string{`"rank": 75`, `"rank": 750`}, "/runs/0/results/0/rank"},
+		{"message without text", [2]string{`"message": {"text": "x"}`, `"message": {}`}, "/runs/0/results/0/message"},
+		{"unknown property", [2]string{`"ruleId": "LH0001"`, `"rule_id": "LH0001"`}, "/runs/0/results/0/rule_id"},
+		{"line 0", [2]string{`"startLine": 1`, `"startLine": 0`}, "/runs/0/results/0/locations/0/physicalLocation/region/startLine"},
+		{"fractional line", [2]string{`"startLine": 1`, `"startLine": 1.5`}, "/runs/0/results/0/locations/0/physicalLocation/region/startLine"},
+		{"empty suppression kind", [2]string{`"kind": "inSource"`, `"kind": ""`}, "/runs/0/results/0/suppressions/0/kind"},
+		{"string property", [2]string{`"properties": {"confidence": "high"}`, `"properties": "high"`}, "/runs/0/results/0/properties"},
+		{"invalid time", [2]string{`"2024-05-01T00:00:01.5Z"`, `"2024-05-01 00:00:01"`}, "/runs/0/invocations/0/startTimeUtc"},
+		{"missing executionSuccessful", [2]string{`"executionSuccessful": true, `, ""}, "/runs/0/invocations/0"},
+	}
+
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			doc := valid
+			if tt.replace[0] != "" {
+				if !strings.Contains(doc, tt.replace[0]) {
+					t.Fatalf("%q not found in the document", tt.replace[0])
+				}
+				doc = strings.Replace(doc, tt.replace[0], tt.replace[1], 1)
+			}
+
+			err := Validate([]byte(doc))
+			if tt.wantPath == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			var verr *ValidationError
+			if !errors.As(err, &verr) {
+				t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+			}
+			if verr.Path != tt.wantPath {
+				t.Errorf("Validate() error = %v, want a violation at %s", err, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestValidate_InvalidJSON(t *testing.T) {
+	t.Parallel()
+
+	err := Validate([]byte(`{"version": "2.1.0",`))
+	var verr *ValidationError
+	if err == nil || errors.As(err, &verr) {
+		t.Errorf("Validate() error = %v, want a JSON syntax error", err)
+	}
+}
+
+// goldenFindings returns findings exercising every part of a result: flows,
+// suppressions, classifications, owners and best-effort matches
+func goldenFindings() ([]detector.Finding, *token.FileSet) {
+	fset := token.NewFileSet()
+	file := fset.AddFile("/home/user/project/internal/app/handler.go", -1, 400)
+	file.SetLinesForContent([]byte(strings.Repeat(strings.Repeat("x", 39)+"\n", 10)))
+	pos := func(line, col int) token.Pos { return file.LineStart(line) + token.Pos(col-1) }
+
+	return []detector.Finding{
+		{
+			Pos:      pos(3, 12),
+			End:      pos(3, 25),
+			Message:  "variable \"pw\" contains sensitive field \"User.Password\" (tagged with sensitive:\"true\") in argument 3 of log/slog.Info",
+			RuleID:   detector.RuleIDSensitiveVar,
+			Field:    "User.Password",
+			Sink:     "log/slog.Info",
+			CallPos:  pos(3, 2),
+			CallEnd:  pos(3, 30),
+			Arg:      3,
+			Expr:     "pw",
+			Func:     "example.com/app/internal/app.handle",
+			FlowPath: []detector.FlowStep{{Label: "User.Password", Pos: pos(2, 2)}, {Label: "pw", Pos: pos(3, 12)}},
+			Owners:   []string{"@org/app", "@org/security"},
+		},
+		{
+			Pos:             pos(5, 2),
+			End:             pos(5, 8),
+			Message:         "sensitive field 'Config.Token' should not be logged in argument 1 of fmt.Println",
+			RuleID:          detector.RuleIDSensitiveField,
+			Severity:        detector.SeverityWarning,
+			Sink:            "fmt.Println",
+			Suppressed:      true,
+			SuppressionKind: "inSource",
+		},
+		{
+			Pos:            pos(7, 1),
+			End:            pos(7, 9),
+			Message:        "local variable 'token' is logged by a crash handler dumping the stack with debug.Stack (matched default pattern 'token')",
+			RuleID:         detector.RuleIDCrashHandlerDump,
+			Severity:       detector.SeverityWarning,
+			Classification: "matched default pattern 'token'",
+			BestEffort:     true,
+		},
+	}, fset
+}
+
+// goldenReporter returns an aggregating reporter holding the golden findings,
+// an invocation and notifications, with a fixed clock and tool version
+func goldenReporter() *AggregatingReporter {
+	start := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+	exitCode := 3
+
+	findings, fset := goldenFindings()
+	reporter := NewAggregatingReporter("/home/user/project")
+	reporter.version = "1.2.3"
+	reporter.now = func() time.Time { return start.Add(1500 * time.Millisecond) }
+	reporter.SetInvocation(Invocation{
+		CommandLine:         "leakhound --format=sarif ./...",
+		Arguments:           []string{"--format=sarif", "./..."},
+		StartTimeUTC:        formatTime(start),
+		ExitCode:            &exitCode,
+		ExecutionSuccessful: true,
+		WorkingDirectory:    &ArtifactLocation{URI: "file:///home/user/project/"},
+		Properties:          map[string]string{"os": "linux", "arch": "amd64"},
+	})
+	reporter.AddNotifications([]detector.Notification{
+		{
+			Level:   detector.SeverityWarning,
+			Package: "example.com/app/internal/big",
+			Message: "package example.com/app/internal/big tracks about 2.0MiB of data flow facts",
+		},
+		{
+			Level:     detector.SeverityError,
+			Package:   "example.com/app/internal/odd",
+			Message:   "package example.com/app/internal/odd: analysis panicked while detecting findings, the package was skipped: boom",
+			PanicType: "string",
+			Stack: []detector.StackFrame{
+				{Function: "github.com/nilpoona/leakhound/detector.(*Detector).checkArg", File: "/src/leakhound/detector/detector.go", Line: 42},
+			},
+		},
+	})
+	reporter.AddFindings(findings, fset)
+	return reporter
+}
+
+func TestAggregatingReporter_Golden(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := goldenReporter().Report(&buf, nil); err != nil {
+		t.Fatalf("Report() failed: %v", err)
+	}
+	if err := Validate(buf.Bytes()); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+
+	golden := filepath.Join("testdata", "aggregated.sarif")
+	if *update {
+		if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("reading golden file (run go test -update to create it): %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), want) {
+		t.Errorf("Report() output differs from %s; run go test -update and review the diff\ngot:\n%s", golden, buf.String())
+	}
+}
+
+func TestAggregatingReporter_ResolvedValidate(t *testing.T) {
+	t.Parallel()
+
+	findings, fset := goldenFindings()
+	var buf bytes.Buffer
+	r := NewAggregatingReporter("/home/user/project")
+	if err := r.Report(&buf, reporter.Resolve(findings, fset)); err != nil {
+		t.Fatalf("Report() failed: %v", err)
+	}
+	if err := Validate(buf.Bytes()); err != nil {
+		t.Errorf("Validate() error = %v, want nil", err)
+	}
+}
+
+func TestAggregatingReporter_SetValidate(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	fset.AddFile("/home/user/project/test.go", 1, 100)
+	// A suppressed finding must say how it was suppressed
+	invalid := []detector.Finding{{Pos: token.Pos(1), Message: "test finding", RuleID: detector.RuleIDSensitiveVar, Suppressed: true}}
+
+	tests := []struct {
+		name     string
+		validate bool
+		findings []detector.Finding
+		wantErr  bool
+	}{
+		{"valid", true, nil, false},
+		{"invalid", true, invalid, true},
+		{"invalid, not validated", false, invalid, false},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			reporter := NewAggregatingReporter("/home/user/project")
+			reporter.SetValidate(tt.validate)
+			reporter.AddFindings(tt.findings, fset)
+
+			var buf bytes.Buffer
+			err := reporter.Report(&buf, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Report() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && buf.Len() > 0 {
+				t.Errorf("Report() wrote an invalid document:\n%s", buf.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package text
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// ColorMode selects when text output is colorized.
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"   // Colorize when writing to a terminal outside CI, unless NO_COLOR is set
+	ColorAlways ColorMode = "always" // Always colorize, e.g. for CI logs that render ANSI escapes
+	ColorNever  ColorMode = "never"  // Never colorize
+)
+
+// ParseColorMode parses the value of the -color flag.
+func ParseColorMode(s string) (ColorMode, error) {
+	switch m := ColorMode(s); m {
+	case ColorAuto, ColorAlways, ColorNever:
+		return m, nil
+	default:
+		return "", fmt.Errorf("invalid color mode %q: want auto, always or never", s)
+	}
+}
+
+// ciEnvVars are set by common CI providers.
+var ciEnvVars = []string{
+	"CI",
+	"GITHUB_ACTIONS",
+	"GITLAB_CI",
+	"CIRCLECI",
+	"BUILDKITE",
+	"JENKINS_URL",
+	"TF_BUILD",
+	"TEAMCITY_VERSION",
+}
+
+// IsCI reports whether the environment looks like a CI run. getenv is
+// os.Getenv in production; CI=false explicitly opts out.
+func IsCI(getenv func(string) string) bool {
+	if v := getenv("CI"); v == "false" || v == "0" {
+		return false
+	}
+	for _, name := range ciEnvVars {
+		if getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// UseColor decides whether output written to f should be colorized. In auto
+// mode color is used only for a terminal, outside CI, when NO_COLOR
+// (https://no-color.org) is unset and TERM is not "dumb".
+func UseColor(mode ColorMode, f *os.File, getenv func(string) string) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if getenv("NO_COLOR") != "" || getenv("TERM") == "dumb" || IsCI(getenv) {
+		return false
+	}
+	return isTerminal(f)
+}
+
+func isTerminal(f *os.File) bool {
+	if f == nil {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// ANSI SGR sequences used by Colorizer.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// Colorizer wraps text in ANSI escapes when enabled. The zero value leaves
+// text unchanged.
+type Colorizer struct {
+	Enabled bool
+}
+
+func (c Colorizer) wrap(code, s string) string {
+	if !c.Enabled || s == "" {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// Location renders a file:line:col location in bold.
+func (c Colorizer) Location(s string) string {
+	return c.wrap(ansiBold, s)
+}
+
+// RuleID renders a rule ID in the color of the finding's severity: red for
+// errors, yellow for warnings and cyan for notes.
+func (c Colorizer) RuleID(id string, level detector.Severity) string {
+	switch level {
+	case detector.SeverityWarning:
+		return c.wrap(ansiYellow, id)
+	case detector.SeverityNote:
+		return c.wrap(ansiCyan, id)
+	default:
+		return c.wrap(ansiRed, id)
+	}
+}
+
+// Faint renders secondary text such as taint flows dimmed.
+func (c Colorizer) Faint(s string) string {
+	return c.wrap(ansiDim, s)
+}
+
+// DisplayPath formats a source path for text output. With abs the path is
+// returned unchanged. Otherwise a path under workDir is shortened to
+// "./rel/path.go"; paths outside workDir stay absolute.
+func DisplayPath(path, workDir string, abs bool) string {
+	if abs || workDir == "" {
+		return path
+	}
+	rel, err := filepath.Rel(workDir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return "./" + filepath.ToSlash(rel)
+}
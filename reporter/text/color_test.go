@@ -0,0 +1,136 @@
+package text
+
+import (
+	"testing"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+func envFunc(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestParseColorMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in      string
+		want    ColorMode
+		wantErr bool
+	}{
+		{in: "auto", want: ColorAuto},
+		{in: "always", want: ColorAlways},
+		{in: "never", want: ColorNever},
+		{in: "yes", wantErr: true},
+		{in: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseColorMode(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseColorMode(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseColorMode(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestIsCI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{name: "no CI variables", env: nil, want: false},
+		{name: "generic CI", env: map[string]string{"CI": "true"}, want: true},
+		{name: "GitHub Actions", env: map[string]string{"GITHUB_ACTIONS": "true"}, want: true},
+		{name: "Jenkins", env: map[string]string{"JENKINS_URL": "http://ci.example.com"}, want: true},
+		{name: "explicit opt-out", env: map[string]string{"CI": "false", "GITHUB_ACTIONS": "true"}, want: false},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := IsCI(envFunc(tt.env)); got != tt.want {
+				t.Errorf("IsCI() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUseColor(t *testing.T) {
+	t.Parallel()
+
+	// A nil file is never a terminal, so auto mode only colors when forced.
+	tests := []struct {
+		name string
+		mode ColorMode
+		env  map[string]string
+		want bool
+	}{
+		{name: "always overrides NO_COLOR", mode: ColorAlways, env: map[string]string{"NO_COLOR": "1"}, want: true},
+		{name: "never", mode: ColorNever, want: false},
+		{name: "auto without terminal", mode: ColorAuto, want: false},
+		{name: "auto with NO_COLOR", mode: ColorAuto, env: map[string]string{"NO_COLOR": "1"}, want: false},
+		{name: "auto in CI", mode: ColorAuto, env: map[string]string{"CI": "true"}, want: false},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := UseColor(tt.mode, nil, envFunc(tt.env)); got != tt.want {
+				t.Errorf("UseColor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColorizer(t *testing.T) {
+	t.Parallel()
+
+	on := Colorizer{Enabled: true}
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{name: "disabled", got: Colorizer{}.RuleID("LH0001", detector.SeverityError), want: "LH0001"},
+		{name: "error", got: on.RuleID("LH0001", detector.SeverityError), want: "\x1b[31mLH0001\x1b[0m"},
+		{name: "warning", got: on.RuleID("LH0001", detector.SeverityWarning), want: "\x1b[33mLH0001\x1b[0m"},
+		{name: "note", got: on.RuleID("LH0001", detector.SeverityNote), want: "\x1b[36mLH0001\x1b[0m"},
+		{name: "location", got: on.Location("./a.go:1:2"), want: "\x1b[1m./a.go:1:2\x1b[0m"},
+		{name: "faint", got: on.Faint("flow: x"), want: "\x1b[2mflow: x\x1b[0m"},
+		{name: "empty", got: on.Faint(""), want: ""},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDisplayPath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		path    string
+		workDir string
+		abs     bool
+		want    string
+	}{
+		{name: "under workDir", path: "/repo/pkg/user.go", workDir: "/repo", want: "./pkg/user.go"},
+		{name: "abs-paths", path: "/repo/pkg/user.go", workDir: "/repo", abs: true, want: "/repo/pkg/user.go"},
+		{name: "outside workDir", path: "/other/user.go", workDir: "/repo", want: "/other/user.go"},
+		{name: "dot-dot prefixed name", path: "/repo/..hidden/user.go", workDir: "/repo", want: "./..hidden/user.go"},
+		{name: "no workDir", path: "/repo/user.go", want: "/repo/user.go"},
+	}
+	for _, tt := range tests {
+		if got := DisplayPath(tt.path, tt.workDir, tt.abs); got != tt.want {
+			t.Errorf("%s: DisplayPath(%q, %q, %v) = %q, want %q", tt.name, tt.path, tt.workDir, tt.abs, got, tt.want)
+		}
+	}
+}
@@ -1,32 +1,194 @@
 package text
 
 import (
+	"fmt"
+	"go/token"
+	"io"
+	"strings"
+
 	"github.com/nilpoona/leakhound/detector"
+	"github.com/nilpoona/leakhound/reporter"
 	"golang.org/x/tools/go/analysis"
 )
 
-// Reporter handles text output formatting
+func init() {
+	reporter.Register("text", func(opts reporter.Options) reporter.Reporter {
+		r := NewReporter(opts.WorkDir, opts.Verbosity)
+		r.SetColorizer(Colorizer{Enabled: opts.Color})
+		r.SetAbsPaths(opts.AbsPaths)
+		return r
+	})
+}
+
+// Verbosity levels for text output.
+const (
+	VerbosityFinding = 1 // Print the finding only (default)
+	VerbosityFlow    = 2 // Also print the taint flow from the sensitive field to the sink
+)
+
+// Reporter writes findings as text, one line per finding, in the format of
+// the diagnostics of the per-package singlechecker mode
 type Reporter struct {
-	pass *analysis.Pass
+	workDir   string // Paths are printed relative to it
+	verbosity int
+	color     Colorizer
+	absPaths  bool // Print absolute paths instead
 }
 
-// NewReporter creates a new text reporter
-func NewReporter(pass *analysis.Pass) *Reporter {
+// NewReporter creates a text reporter printing paths relative to workDir at
+// the given verbosity level. Levels below VerbosityFinding are treated as
+// VerbosityFinding.
+func NewReporter(workDir string, verbosity int) *Reporter {
 	return &Reporter{
-		pass: pass,
+		workDir:   workDir,
+		verbosity: verbosity,
 	}
 }
 
-// Report outputs findings in text format to stderr.
+// SetColorizer sets the colors of the output
+func (r *Reporter) SetColorizer(color Colorizer) {
+	r.color = color
+}
+
+// SetAbsPaths sets whether paths are printed absolute rather than relative
+// to the working directory
+func (r *Reporter) SetAbsPaths(absPaths bool) {
+	r.absPaths = absPaths
+}
+
+// Report writes findings to w, skipping suppressed ones. Like diagnostics,
+// each line ends with the SARIF rule ID (e.g. [LH0001]). At VerbosityFlow each
+// finding is followed by indented lines describing its taint flow and
+// confidence.
+func (r *Reporter) Report(w io.Writer, findings []reporter.Finding) error {
+	for _, f := range findings {
+		if f.Finding.Suppressed {
+			continue
+		}
+		path := DisplayPath(f.Start.Filename, r.workDir, r.absPaths)
+		location := r.color.Location(fmt.Sprintf("%s:%d:%d", path, f.Start.Line, f.Start.Column))
+		if _, err := fmt.Fprintf(w, "%s: %s [%s]\n", location, f.Finding.Message, r.color.RuleID(f.RuleID, f.Level)); err != nil {
+			return err
+		}
+		if r.verbosity < VerbosityFlow {
+			continue
+		}
+		if flow := FormatFlow(f.Finding, f.Fset); flow != "" {
+			if _, err := fmt.Fprintf(w, "\t%s\n", r.color.Faint("flow: "+flow)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintf(w, "\t%s\n", r.color.Faint("confidence: "+FormatConfidence(f.Finding))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DiagnosticReporter reports findings as diagnostics of an analysis pass,
+// which the analysis driver prints
+type DiagnosticReporter struct {
+	pass      *analysis.Pass
+	verbosity int
+}
+
+// NewDiagnosticReporter creates a reporter of the diagnostics of pass with
+// the given verbosity level. Levels below VerbosityFinding are treated as
+// VerbosityFinding.
+func NewDiagnosticReporter(pass *analysis.Pass, verbosity int) *DiagnosticReporter {
+	return &DiagnosticReporter{
+		pass:      pass,
+		verbosity: verbosity,
+	}
+}
+
+// Report reports findings as diagnostics.
 // Suppressed findings are silently skipped.
 // Each message is suffixed with the SARIF rule ID (e.g. [LH0001]) so users
-// know which ID to use in //noleak: comments.
-func (r *Reporter) Report(findings []detector.Finding) error {
+// know which ID to use in //noleak: comments. At VerbosityFlow each hop of the
+// taint flow and the finding's confidence are attached as related
+// information, which the analysis driver prints beneath the finding and
+// editors render as linked locations.
+func (r *DiagnosticReporter) Report(findings []detector.Finding) error {
 	for _, finding := range findings {
 		if finding.Suppressed {
 			continue
 		}
-		r.pass.Reportf(finding.Pos, "%s [%s]", finding.Message, finding.SARIFRuleID())
+		diag := analysis.Diagnostic{
+			Pos:            finding.Pos,
+			End:            finding.End,
+			Message:        fmt.Sprintf("%s [%s]", finding.Message, finding.SARIFRuleID()),
+			SuggestedFixes: finding.Fixes,
+		}
+		if r.verbosity >= VerbosityFlow {
+			diag.Related = flowRelated(finding)
+		}
+		r.pass.Report(diag)
 	}
 	return nil
 }
+
+// flowRelated converts a finding's flow path, followed by the sink call and
+// the finding's confidence, into related-information entries.
+func flowRelated(f detector.Finding) []analysis.RelatedInformation {
+	related := make([]analysis.RelatedInformation, 0, len(f.FlowPath)+2)
+	for _, step := range f.FlowPath {
+		if !step.Pos.IsValid() {
+			continue
+		}
+		related = append(related, analysis.RelatedInformation{
+			Pos:     step.Pos,
+			Message: "flow: " + step.Label,
+		})
+	}
+	if f.Sink != "" {
+		related = append(related, analysis.RelatedInformation{
+			Pos:     f.Pos,
+			Message: "sink: " + ShortFuncName(f.Sink),
+		})
+	}
+	related = append(related, analysis.RelatedInformation{
+		Pos:     f.Pos,
+		Message: "confidence: " + FormatConfidence(f),
+	})
+	return related
+}
+
+// FormatConfidence renders a finding's confidence score and level, e.g.
+// "0.75 (medium)"
+func FormatConfidence(f detector.Finding) string {
+	return fmt.Sprintf("%.2f (%s)", f.ConfidenceScore(), f.Confidence())
+}
+
+// FormatFlow renders a finding's taint flow on a single line, e.g.
+//
+//	User.Password (line 5) → password (line 12) → logValue param val (line 30) → slog.Info (line 31)
+//
+// Returns "" when the finding carries no flow information.
+func FormatFlow(f detector.Finding, fset *token.FileSet) string {
+	if len(f.FlowPath) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(f.FlowPath)+1)
+	for _, step := range f.FlowPath {
+		parts = append(parts, formatHop(step.Label, step.Pos, fset))
+	}
+	if f.Sink != "" {
+		parts = append(parts, formatHop(ShortFuncName(f.Sink), f.Pos, fset))
+	}
+	return strings.Join(parts, " → ")
+}
+
+func formatHop(label string, pos token.Pos, fset *token.FileSet) string {
+	if !pos.IsValid() || fset == nil {
+		return label
+	}
+	return fmt.Sprintf("%s (line %d)", label, fset.Position(pos).Line)
+}
+
+// ShortFuncName trims import paths from a fully qualified function name so it
+// reads like source code: "log/slog.Info" → "slog.Info",
+// "(*log/slog.Logger).Warn" → "(*slog.Logger).Warn".
+func ShortFuncName(name string) string {
+	return detector.ShortFuncName(name)
+}
@@ -0,0 +1,157 @@
+package text
+
+import (
+	"bytes"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/nilpoona/leakhound/detector"
+	"github.com/nilpoona/leakhound/reporter"
+)
+
+func TestShortFuncName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"log/slog.Info", "slog.Info"},
+		{"(*log/slog.Logger).Warn", "(*slog.Logger).Warn"},
+		{"fmt.Println", "fmt.Println"},
+		{"example.com/app/internal/audit.Record", "audit.Record"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.in, func(t *testing.T) {
+			t.Parallel()
+			if got := ShortFuncName(tt.in); got != tt.want {
+				t.Errorf("ShortFuncName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatFlow(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/home/user/project/main.go", 1, 1000)
+	lines := make([]int, 0, 40)
+	for i := 0; i < 40; i++ {
+		lines = append(lines, i*20)
+	}
+	file.SetLines(lines)
+	line := func(n int) token.Pos { return token.Pos(1 + (n-1)*20) }
+
+	f := detector.Finding{
+		Pos:  line(31),
+		Sink: "log/slog.Info",
+		FlowPath: []detector.FlowStep{
+			{Label: "User.Password", Pos: line(12)},
+			{Label: "password", Pos: line(12)},
+			{Label: "logValue param val", Pos: line(30)},
+		},
+	}
+
+	want := "User.Password (line 12) → password (line 12) → logValue param val (line 30) → slog.Info (line 31)"
+	if got := FormatFlow(f, fset); got != want {
+		t.Errorf("FormatFlow() = %q, want %q", got, want)
+	}
+
+	if got := FormatFlow(detector.Finding{Sink: "fmt.Println"}, fset); got != "" {
+		t.Errorf("FormatFlow() without flow = %q, want empty", got)
+	}
+}
+
+func TestFormatConfidence(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		finding detector.Finding
+		want    string
+	}{
+		{"direct", detector.Finding{RuleID: "sensitive-field"}, "1.00 (high)"},
+		{"one flow step", detector.Finding{RuleID: "sensitive-var", FlowPath: []detector.FlowStep{{Label: "User.Password"}, {Label: "pw"}}}, "0.75 (medium)"},
+		{"heuristic", detector.Finding{RuleID: "personal-data", Classification: "matched default pattern 'e_?mail'"}, "0.45 (low)"},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := FormatConfidence(tt.finding); got != tt.want {
+				t.Errorf("FormatConfidence() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReporter_Report(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/home/user/project/internal/app/main.go", -1, 200)
+	file.SetLinesForContent([]byte(strings.Repeat(strings.Repeat("x", 19)+"\n", 10)))
+	pos := func(line, col int) token.Pos { return file.LineStart(line) + token.Pos(col-1) }
+
+	findings := reporter.Resolve([]detector.Finding{
+		{
+			Pos:      pos(5, 3),
+			Message:  "variable \"pw\" contains sensitive field \"User.Password\"",
+			RuleID:   detector.RuleIDSensitiveVar,
+			Sink:     "log/slog.Info",
+			FlowPath: []detector.FlowStep{{Label: "User.Password", Pos: pos(2, 1)}, {Label: "pw", Pos: pos(4, 1)}},
+		},
+		{Pos: pos(7, 1), Message: "suppressed", RuleID: detector.RuleIDSensitiveVar, Suppressed: true},
+		{Pos: pos(8, 2), Message: "sensitive field 'Config.Token' should not be logged", RuleID: detector.RuleIDSensitiveField, Severity: detector.SeverityWarning},
+	}, fset)
+
+	tests := []struct {
+		name      string
+		verbosity int
+		absPaths  bool
+		want      string
+	}{
+		{
+			name:      "findings",
+			verbosity: VerbosityFinding,
+			want: "./internal/app/main.go:5:3: variable \"pw\" contains sensitive field \"User.Password\" [LH0001]\n" +
+				"./internal/app/main.go:8:2: sensitive field 'Config.Token' should not be logged [LH0004]\n",
+		},
+		{
+			name:      "absolute paths",
+			verbosity: VerbosityFinding,
+			absPaths:  true,
+			want: "/home/user/project/internal/app/main.go:5:3: variable \"pw\" contains sensitive field \"User.Password\" [LH0001]\n" +
+				"/home/user/project/internal/app/main.go:8:2: sensitive field 'Config.Token' should not be logged [LH0004]\n",
+		},
+		{
+			name:      "flows",
+			verbosity: VerbosityFlow,
+			want: "./internal/app/main.go:5:3: variable \"pw\" contains sensitive field \"User.Password\" [LH0001]\n" +
+				"\tflow: User.Password (line 2) → pw (line 4) → slog.Info (line 5)\n" +
+				"\tconfidence: 0.75 (medium)\n" +
+				"./internal/app/main.go:8:2: sensitive field 'Config.Token' should not be logged [LH0004]\n" +
+				"\tconfidence: 1.00 (high)\n",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := NewReporter("/home/user/project", tt.verbosity)
+			r.SetAbsPaths(tt.absPaths)
+
+			var buf bytes.Buffer
+			if err := r.Report(&buf, findings); err != nil {
+				t.Fatalf("Report() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("Report() wrote\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
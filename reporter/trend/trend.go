@@ -0,0 +1,152 @@
+// Package trend records per-run finding counts in an append-only JSON Lines
+// file and renders the history as a table, so teams can track leak debt
+// across runs.
+package trend
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// Entry is one line of the trend file
+type Entry struct {
+	Time       time.Time      `json:"time"`
+	Total      int            `json:"total"`      // Unsuppressed findings
+	Suppressed int            `json:"suppressed"` // Findings suppressed inline or by config
+	Rules      map[string]int `json:"rules"`      // Unsuppressed findings per SARIF rule ID
+}
+
+// NewEntry counts findings per rule for a run finished at t
+func NewEntry(findings []detector.Finding, t time.Time) Entry {
+	e := Entry{Time: t.UTC(), Rules: make(map[string]int)}
+	for _, f := range findings {
+		if f.Suppressed {
+			e.Suppressed++
+			continue
+		}
+		e.Total++
+		e.Rules[f.SARIFRuleID()]++
+	}
+	return e
+}
+
+// Append writes e as a single JSON line at the end of the file at path,
+// creating the file if needed
+func Append(path string, e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open trend file: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write trend file: %w", err)
+	}
+	return f.Close()
+}
+
+// Read parses a trend file. Blank lines are skipped; a malformed line is an
+// error naming its line number.
+func Read(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(line, &e); err != nil {
+			return nil, fmt.Errorf("trend file line %d: %w", n, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
+
+// Last returns the most recent entry of the trend file at path, or nil when
+// the file does not exist or records no runs
+func Last(path string) (*Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open trend file: %w", err)
+	}
+	defer f.Close()
+
+	entries, err := Read(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	return &entries[len(entries)-1], nil
+}
+
+// WriteTable renders entries as a table with one row per run, one column per
+// rule seen in any run, and the change in total since the previous run
+func WriteTable(w io.Writer, entries []Entry) error {
+	rules := ruleColumns(entries)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprint(tw, "TIME\tTOTAL\tCHANGE")
+	for _, id := range rules {
+		fmt.Fprintf(tw, "\t%s", id)
+	}
+	fmt.Fprintln(tw, "\tSUPPRESSED")
+
+	for i, e := range entries {
+		change := "-"
+		if i > 0 {
+			change = Signed(e.Total - entries[i-1].Total)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%s", e.Time.UTC().Format(time.RFC3339), e.Total, change)
+		for _, id := range rules {
+			fmt.Fprintf(tw, "\t%d", e.Rules[id])
+		}
+		fmt.Fprintf(tw, "\t%d\n", e.Suppressed)
+	}
+	return tw.Flush()
+}
+
+// ruleColumns returns every rule ID with a count in any entry, sorted
+func ruleColumns(entries []Entry) []string {
+	seen := make(map[string]bool)
+	var rules []string
+	for _, e := range entries {
+		for id := range e.Rules {
+			if !seen[id] {
+				seen[id] = true
+				rules = append(rules, id)
+			}
+		}
+	}
+	sort.Strings(rules)
+	return rules
+}
+
+// Signed formats n, a change in a finding count, with an explicit sign, e.g.
+// "+3", "-1" or "0"
+func Signed(n int) string {
+	if n > 0 {
+		return "+" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
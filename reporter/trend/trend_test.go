@@ -0,0 +1,113 @@
+package trend
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+func TestNewEntry(t *testing.T) {
+	t.Parallel()
+
+	findings := []detector.Finding{
+		{RuleID: detector.RuleIDSensitiveField},
+		{RuleID: detector.RuleIDSensitiveField},
+		{RuleID: detector.RuleIDSensitiveVar},
+		{RuleID: detector.RuleIDSensitiveStruct, Suppressed: true},
+	}
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	got := NewEntry(findings, at)
+	want := Entry{
+		Time:       at.UTC(),
+		Total:      3,
+		Suppressed: 1,
+		Rules:      map[string]int{"LH0004": 2, "LH0001": 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewEntry() = %+v, want %+v", got, want)
+	}
+}
+
+func TestAppendAndRead(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "trend.jsonl")
+	entries := []Entry{
+		{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Total: 2, Rules: map[string]int{"LH0004": 2}},
+		{Time: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), Total: 1, Suppressed: 1, Rules: map[string]int{"LH0001": 1}},
+	}
+	for _, e := range entries {
+		if err := Append(path, e); err != nil {
+			t.Fatalf("Append() error = %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	got, err := Read(f)
+	if err != nil {
+		t.Fatalf("Read() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, entries) {
+		t.Errorf("Read() = %+v, want %+v", got, entries)
+	}
+
+	last, err := Last(path)
+	if err != nil {
+		t.Fatalf("Last() error = %v", err)
+	}
+	if last == nil || !reflect.DeepEqual(*last, entries[1]) {
+		t.Errorf("Last() = %+v, want %+v", last, entries[1])
+	}
+	if last, err := Last(filepath.Join(t.TempDir(), "missing.jsonl")); last != nil || err != nil {
+		t.Errorf("Last(missing) = %+v, %v, want nil, nil", last, err)
+	}
+}
+
+func TestRead_Malformed(t *testing.T) {
+	t.Parallel()
+
+	input := `{"time":"2025-03-01T00:00:00Z","total":1,"rules":{"LH0004":1}}
+
+not json
+`
+	_, err := Read(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("Read() error = %v, want an error for line 3", err)
+	}
+}
+
+func TestWriteTable(t *testing.T) {
+	t.Parallel()
+
+	entries := []Entry{
+		{Time: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), Total: 3, Rules: map[string]int{"LH0004": 2, "LH0001": 1}},
+		{Time: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), Total: 1, Suppressed: 2, Rules: map[string]int{"LH0004": 1}},
+		{Time: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), Total: 1, Suppressed: 2, Rules: map[string]int{"LH0003": 1}},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteTable(&buf, entries); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+
+	want := `TIME                  TOTAL  CHANGE  LH0001  LH0003  LH0004  SUPPRESSED
+2025-03-01T00:00:00Z  3      -       1       0       2       0
+2025-03-02T00:00:00Z  1      -2      0       0       1       2
+2025-03-03T00:00:00Z  1      0       0       1       0       2
+`
+	if got := buf.String(); got != want {
+		t.Errorf("WriteTable() =\n%s\nwant:\n%s", got, want)
+	}
+}
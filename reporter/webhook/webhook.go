@@ -0,0 +1,148 @@
+// Package webhook posts a summary of a run to a webhook, such as a Slack
+// incoming webhook, so security channels are alerted when new leaks land.
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nilpoona/leakhound/reporter/trend"
+)
+
+// EnvURL is the environment variable read for the webhook URL when none is
+// given on the command line, which keeps the URL out of CI logs and SARIF
+// invocations
+const EnvURL = "LEAKHOUND_WEBHOOK_URL"
+
+// timeout bounds the whole request, so an unreachable webhook cannot hang CI
+const timeout = 10 * time.Second
+
+// Summary is the JSON payload posted to the webhook. Text makes it a valid
+// Slack message; the other fields are for generic webhook consumers.
+type Summary struct {
+	Text       string         `json:"text"`
+	Total      int            `json:"total"`            // Unsuppressed findings
+	Suppressed int            `json:"suppressed"`       // Findings suppressed inline, by config or by triage
+	Rules      map[string]int `json:"rules"`            // Unsuppressed findings per SARIF rule ID
+	New        *int           `json:"new,omitempty"`    // Unsuppressed findings not triaged yet, with a triage file
+	Change     *int           `json:"change,omitempty"` // Change in total since the previous run of a trend file
+	Link       string         `json:"link,omitempty"`   // URL of the CI run
+}
+
+// NewSummary summarizes the run counted in e. previous is the last run
+// recorded in the trend file, and untriaged the number of findings missing
+// from the triage file; either is nil when unknown. link is the URL of the
+// CI run, or "".
+func NewSummary(e trend.Entry, previous *trend.Entry, untriaged *int, link string) Summary {
+	s := Summary{
+		Total:      e.Total,
+		Suppressed: e.Suppressed,
+		Rules:      e.Rules,
+		New:        untriaged,
+		Link:       link,
+	}
+	if previous != nil {
+		change := e.Total - previous.Total
+		s.Change = &change
+	}
+	s.Text = s.text()
+	return s
+}
+
+// text renders the summary as a Slack message, e.g.
+// "leakhound: 3 findings (+1 since the previous run, 1 new) — LH0001: 1, LH0004: 2"
+func (s Summary) text() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "leakhound: %d finding%s", s.Total, plural(s.Total))
+
+	var details []string
+	if s.Change != nil {
+		details = append(details, fmt.Sprintf("%s since the previous run", trend.Signed(*s.Change)))
+	}
+	if s.New != nil {
+		details = append(details, fmt.Sprintf("%d new", *s.New))
+	}
+	if s.Suppressed > 0 {
+		details = append(details, fmt.Sprintf("%d suppressed", s.Suppressed))
+	}
+	if len(details) > 0 {
+		fmt.Fprintf(&b, " (%s)", strings.Join(details, ", "))
+	}
+
+	if len(s.Rules) > 0 {
+		ids := make([]string, 0, len(s.Rules))
+		for id := range s.Rules {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		counts := make([]string, len(ids))
+		for i, id := range ids {
+			counts[i] = fmt.Sprintf("%s: %d", id, s.Rules[id])
+		}
+		fmt.Fprintf(&b, " — %s", strings.Join(counts, ", "))
+	}
+	if s.Link != "" {
+		fmt.Fprintf(&b, "\n%s", s.Link)
+	}
+	return b.String()
+}
+
+// Post sends s to the webhook at url. A response other than 2xx is an
+// error. client is nil for a default client with a timeout.
+func Post(client *http.Client, url string, s Summary) error {
+	if client == nil {
+		client = &http.Client{Timeout: timeout}
+	}
+	body, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		// The URL usually embeds a secret token, so it is not echoed
+		return fmt.Errorf("failed to post webhook notification: %w", redactURL(err, url))
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook notification failed: %s", resp.Status)
+	}
+	return nil
+}
+
+// RunLink returns the URL of the current CI run from the variables set by
+// GitHub Actions, GitLab CI, CircleCI, Buildkite or Jenkins, or "" outside
+// CI. getenv is os.Getenv in production.
+func RunLink(getenv func(string) string) string {
+	if server, repo, id := getenv("GITHUB_SERVER_URL"), getenv("GITHUB_REPOSITORY"), getenv("GITHUB_RUN_ID"); server != "" && repo != "" && id != "" {
+		return fmt.Sprintf("%s/%s/actions/runs/%s", server, repo, id)
+	}
+	for _, name := range []string{"CI_PIPELINE_URL", "CIRCLE_BUILD_URL", "BUILDKITE_BUILD_URL", "BUILD_URL"} {
+		if link := getenv(name); link != "" {
+			return link
+		}
+	}
+	return ""
+}
+
+// redactURL replaces url in the message of err
+func redactURL(err error, url string) error {
+	if url == "" || !strings.Contains(err.Error(), url) {
+		return err
+	}
+	return fmt.Errorf("%s", strings.ReplaceAll(err.Error(), url, "[webhook URL]"))
+}
+
+// plural returns "s" unless n is 1
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
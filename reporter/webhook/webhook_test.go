@@ -0,0 +1,129 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nilpoona/leakhound/reporter/trend"
+)
+
+func TestNewSummary(t *testing.T) {
+	t.Parallel()
+
+	entry := trend.Entry{Total: 3, Suppressed: 1, Rules: map[string]int{"LH0004": 2, "LH0001": 1}}
+	untriaged := 1
+
+	tests := []struct {
+		name      string
+		previous  *trend.Entry
+		untriaged *int
+		link      string
+		want      string
+	}{
+		{
+			name: "counts only",
+			want: "leakhound: 3 findings (1 suppressed) — LH0001: 1, LH0004: 2",
+		},
+		{
+			name:      "compared with the previous run and the triage file",
+			previous:  &trend.Entry{Total: 2},
+			untriaged: &untriaged,
+			link:      "https://github.com/org/app/actions/runs/42",
+			want:      "leakhound: 3 findings (+1 since the previous run, 1 new, 1 suppressed) — LH0001: 1, LH0004: 2\nhttps://github.com/org/app/actions/runs/42",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			s := NewSummary(entry, tt.previous, tt.untriaged, tt.link)
+			if s.Text != tt.want {
+				t.Errorf("Text = %q, want %q", s.Text, tt.want)
+			}
+		})
+	}
+
+	clean := NewSummary(trend.Entry{Rules: map[string]int{}}, nil, nil, "")
+	if want := "leakhound: 0 findings"; clean.Text != want {
+		t.Errorf("Text = %q, want %q", clean.Text, want)
+	}
+}
+
+func TestPost(t *testing.T) {
+	t.Parallel()
+
+	var got Summary
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q, want application/json", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &got); err != nil {
+			t.Errorf("payload is not JSON: %v", err)
+		}
+		if strings.HasSuffix(r.URL.Path, "/gone") {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	change := -2
+	want := Summary{Text: "leakhound: 1 finding", Total: 1, Rules: map[string]int{"LH0004": 1}, Change: &change}
+	if err := Post(server.Client(), server.URL+"/hook", want); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("posted %+v, want %+v", got, want)
+	}
+
+	if err := Post(server.Client(), server.URL+"/gone", want); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Post() error = %v, want a 404 error", err)
+	}
+}
+
+func TestPost_RedactsURL(t *testing.T) {
+	t.Parallel()
+
+	url := "http://127.0.0.1:1/services/T000/B000/secret-token"
+	err := Post(nil, url, Summary{})
+	if err == nil {
+		t.Fatal("Post() to a closed port succeeded")
+	}
+	if strings.Contains(err.Error(), "secret-token") {
+		t.Errorf("Post() error = %v, want the URL redacted", err)
+	}
+}
+
+func TestRunLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		want string
+	}{
+		{"outside CI", nil, ""},
+		{
+			"GitHub Actions",
+			map[string]string{"GITHUB_SERVER_URL": "https://github.com", "GITHUB_REPOSITORY": "org/app", "GITHUB_RUN_ID": "42"},
+			"https://github.com/org/app/actions/runs/42",
+		},
+		{"GitLab CI", map[string]string{"CI_PIPELINE_URL": "https://gitlab.com/org/app/-/pipelines/7"}, "https://gitlab.com/org/app/-/pipelines/7"},
+		{"Jenkins", map[string]string{"BUILD_URL": "https://ci.example.com/job/app/9/"}, "https://ci.example.com/job/app/9/"},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			getenv := func(name string) string { return tt.env[name] }
+			if got := RunLink(getenv); got != tt.want {
+				t.Errorf("RunLink() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package selftest checks the installed analyzer against an embedded corpus
+// of leak patterns, so users can see which patterns it detects before
+// trusting a CI gate built on it.
+package selftest
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"go/token"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+// corpus holds a module whose lines marked "// selftest: <pattern>" leak a
+// sensitive value. go.mod is written by WriteCorpus, as a directory holding
+// one cannot be embedded.
+//
+//go:embed testdata/corpus
+var corpus embed.FS
+
+const corpusRoot = "testdata/corpus"
+
+// ModulePath is the module path of the corpus
+const ModulePath = "leakhoundselftest"
+
+// marker introduces the name of the pattern leaked on a corpus line
+const marker = "// selftest: "
+
+// Case is a leak pattern of the corpus, expected to be reported on a line
+type Case struct {
+	Pattern string // e.g. "variable holding a field"
+	File    string // Slash-separated path relative to the corpus root, e.g. "app/flow.go"
+	Line    int
+}
+
+// Result tells whether the analysis reported a finding on the line of a case
+type Result struct {
+	Case
+	Rules []string // SARIF rule IDs of the findings on the line, none if missed
+}
+
+// Detected reports whether the case was reported
+func (r Result) Detected() bool { return len(r.Rules) > 0 }
+
+// Cases returns the leak patterns marked in the corpus, by file and line
+func Cases() ([]Case, error) {
+	var cases []Case
+	err := fs.WalkDir(corpus, corpusRoot, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := corpus.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		file := strings.TrimPrefix(name, corpusRoot+"/")
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for line := 1; scanner.Scan(); line++ {
+			if _, pattern, ok := strings.Cut(scanner.Text(), marker); ok {
+				cases = append(cases, Case{Pattern: strings.TrimSpace(pattern), File: file, Line: line})
+			}
+		}
+		return scanner.Err()
+	})
+	return cases, err
+}
+
+// WriteCorpus writes the corpus module to dir
+func WriteCorpus(dir string) error {
+	gomod := fmt.Sprintf("module %s\n\ngo 1.21\n", ModulePath)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0o644); err != nil {
+		return err
+	}
+	return fs.WalkDir(corpus, corpusRoot, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(strings.TrimPrefix(name, corpusRoot)))
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		data, err := corpus.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0o644)
+	})
+}
+
+// Match looks up the unsuppressed findings of an analysis of the corpus
+// written to dir on the line of each case
+func Match(cases []Case, findings []detector.Finding, fset *token.FileSet, dir string) []Result {
+	rules := make(map[string][]string) // By "file:line"
+	for _, f := range findings {
+		if f.Suppressed {
+			continue
+		}
+		pos := fset.Position(f.Pos)
+		rel, err := filepath.Rel(dir, pos.Filename)
+		if err != nil {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", filepath.ToSlash(rel), pos.Line)
+		if id := f.SARIFRuleID(); !slices.Contains(rules[key], id) {
+			rules[key] = append(rules[key], id)
+		}
+	}
+
+	results := make([]Result, len(cases))
+	for i, c := range cases {
+		ids := rules[fmt.Sprintf("%s:%d", c.File, c.Line)]
+		sort.Strings(ids)
+		results[i] = Result{Case: c, Rules: ids}
+	}
+	return results
+}
+
+// WriteMatrix writes a table of the results, one pattern per row, followed by
+// the number of patterns detected
+func WriteMatrix(w io.Writer, results []Result) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PATTERN\tLOCATION\tRESULT")
+	detected := 0
+	for _, r := range results {
+		result := "MISSED"
+		if r.Detected() {
+			detected++
+			result = fmt.Sprintf("detected (%s)", strings.Join(r.Rules, ", "))
+		}
+		fmt.Fprintf(tw, "%s\t%s:%d\t%s\n", r.Pattern, r.File, r.Line, result)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "\n%d of %d patterns detected\n", detected, len(results))
+	return err
+}
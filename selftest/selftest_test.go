@@ -0,0 +1,110 @@
+package selftest
+
+import (
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/nilpoona/leakhound/config"
+	"github.com/nilpoona/leakhound/detector"
+	"golang.org/x/tools/go/packages"
+)
+
+func TestCases(t *testing.T) {
+	t.Parallel()
+
+	cases, err := Cases()
+	if err != nil {
+		t.Fatalf("Cases() error = %v", err)
+	}
+	if len(cases) < 20 {
+		t.Errorf("Cases() = %d cases, want at least 20", len(cases))
+	}
+	seen := make(map[string]bool)
+	for _, c := range cases {
+		if c.Pattern == "" || c.Line == 0 || strings.Contains(c.File, "testdata") {
+			t.Errorf("malformed case %+v", c)
+		}
+		if seen[c.Pattern] {
+			t.Errorf("duplicate pattern %q", c.Pattern)
+		}
+		seen[c.Pattern] = true
+	}
+}
+
+// TestCorpus verifies that every pattern of the corpus is detected, so the
+// corpus follows the analyzer's capabilities
+func TestCorpus(t *testing.T) {
+	dir := t.TempDir()
+	if err := WriteCorpus(dir); err != nil {
+		t.Fatalf("WriteCorpus() error = %v", err)
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+			packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo |
+			packages.NeedModule,
+		Dir:  dir,
+		Fset: token.NewFileSet(),
+	}
+	pkgs, err := packages.Load(cfg, "./...")
+	if err != nil {
+		t.Fatalf("packages.Load: %v", err)
+	}
+	for _, p := range pkgs {
+		for _, e := range p.Errors {
+			t.Errorf("corpus package %s: %v", p.PkgPath, e)
+		}
+	}
+
+	// The corpus has no dependencies outside the standard library, which
+	// the driver leaves out of the analysis
+	wp := detector.NewWholeProgramCollector(detector.NewWorldView(cfg.Fset, pkgs), &config.Config{})
+	wp.Collect()
+	cases, err := Cases()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range Match(cases, wp.Analyze(), cfg.Fset, dir) {
+		if !r.Detected() {
+			t.Errorf("%s:%d: pattern %q missed", r.File, r.Line, r.Pattern)
+		}
+	}
+}
+
+func TestWriteMatrix(t *testing.T) {
+	t.Parallel()
+
+	fset := token.NewFileSet()
+	file := fset.AddFile("/corpus/app/a.go", 1, 100)
+	file.SetLines([]int{0, 10, 20})
+	findings := []detector.Finding{
+		{Pos: token.Pos(1), RuleID: "sensitive-field"},
+		{Pos: token.Pos(2), RuleID: "sensitive-var"},
+		{Pos: token.Pos(3), RuleID: "sensitive-field"},
+		{Pos: token.Pos(21), RuleID: "sensitive-field", Suppressed: true},
+	}
+	cases := []Case{
+		{Pattern: "field", File: "app/a.go", Line: 1},
+		{Pattern: "struct", File: "app/a.go", Line: 2},
+		{Pattern: "suppressed", File: "app/a.go", Line: 3},
+	}
+
+	results := Match(cases, findings, fset, "/corpus")
+	var b strings.Builder
+	if err := WriteMatrix(&b, results); err != nil {
+		t.Fatalf("WriteMatrix() error = %v", err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		"PATTERN     LOCATION    RESULT\n",
+		"field       app/a.go:1  detected (LH0001, LH0004)\n",
+		"struct      app/a.go:2  MISSED\n",
+		"suppressed  app/a.go:3  MISSED\n",
+		"\n1 of 3 patterns detected\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("WriteMatrix() missing %q in:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package app
+
+import (
+	"log/slog"
+
+	"leakhoundselftest/secret"
+	"leakhoundselftest/telemetry"
+)
+
+func crossPackage(u User) {
+	slog.Info("secret", "password", secret.Password()) // selftest: field returned from another package
+	telemetry.Record("login", u.Password)              // selftest: field passed to a sink in another package
+}
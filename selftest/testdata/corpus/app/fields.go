@@ -0,0 +1,27 @@
+package app
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+type Request struct {
+	User User
+}
+
+func fields(u User, r *Request) {
+	slog.Info("login", "password", u.Password)         // selftest: field passed to slog
+	fmt.Println("password:", u.Password)               // selftest: field passed to fmt.Println
+	log.Printf("password: %s", u.Password)             // selftest: field formatted by log.Printf
+	slog.Info("login", "password", r.User.Password)    // selftest: field of a nested struct
+	slog.Default().Info("login", "pwd", u.Password)    // selftest: field passed to a *slog.Logger method
+	slog.With("pwd", u.Password).Info("login")         // selftest: field attached with slog.With
+	slog.Info("login", slog.String("pwd", u.Password)) // selftest: field wrapped in a slog.Attr
+	slog.Info("login", "name", u.Name)
+}
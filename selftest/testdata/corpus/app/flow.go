@@ -0,0 +1,56 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+type DBConfig struct {
+	Host     string
+	Password string `sensitive:"true"`
+}
+
+var config DBConfig
+
+func variables(u User, db DBConfig) {
+	password := u.Password
+	slog.Info("login", "password", password) // selftest: variable holding a field
+
+	dsn := fmt.Sprintf("postgres://app:%s@%s/app", db.Password, db.Host)
+	slog.Info("connecting", "dsn", dsn) // selftest: field formatted into a DSN
+
+	trimmed := strings.TrimSpace(password)
+	slog.Info("login", "password", trimmed) // selftest: field trimmed by strings functions
+
+	parts := []string{db.Host, db.Password}
+	slog.Info("connecting", "parts", parts) // selftest: field stored in a slice
+}
+
+// logValue logs its parameter, so callers passing a field leak it
+func logValue(v string) {
+	slog.Info("value", "v", v) // selftest: field passed as a parameter
+}
+
+func parameters(u User) {
+	logValue(u.Password)
+}
+
+// password returns a sensitive field
+func password() string {
+	return config.Password
+}
+
+func (c DBConfig) secret() string {
+	return c.Password
+}
+
+func calls(db DBConfig) {
+	slog.Info("config", "password", password())  // selftest: result of a function returning a field
+	slog.Info("config", "password", db.secret()) // selftest: result of a method returning a field
+}
+
+func funcValues(u User) {
+	logFn := slog.Info
+	logFn("login", "password", u.Password) // selftest: field passed to a sink held in a variable
+}
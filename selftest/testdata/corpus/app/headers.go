@@ -0,0 +1,10 @@
+package app
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+func headers(r *http.Request) {
+	slog.Info("request", "auth", r.Header.Get("Authorization")) // selftest: Authorization header
+}
@@ -0,0 +1,18 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// Session embeds the sensitive fields of User
+type Session struct {
+	User
+	ID string
+}
+
+func structs(u User, p *User, s Session) {
+	slog.Info("user", "user", u) // selftest: struct with sensitive fields
+	fmt.Printf("%+v\n", p)       // selftest: pointer to a struct with sensitive fields
+	slog.Info("session", "s", s) // selftest: struct embedding sensitive fields
+}
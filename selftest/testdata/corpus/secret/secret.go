@@ -0,0 +1,14 @@
+// Package secret holds credentials read by the app package.
+package secret
+
+type Credentials struct {
+	User     string
+	Password string `sensitive:"true"`
+}
+
+var current Credentials
+
+// Password returns a sensitive field to another package
+func Password() string {
+	return current.Password
+}
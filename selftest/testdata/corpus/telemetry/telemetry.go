@@ -0,0 +1,10 @@
+// Package telemetry logs the values passed to it.
+package telemetry
+
+import "log/slog"
+
+// Record logs its value, which makes its parameter a sink for callers in
+// other packages
+func Record(name string, value any) {
+	slog.Info(name, "value", value)
+}
@@ -4,6 +4,7 @@ import (
 	"log/slog"
 
 	"example.com/crosspkgflow/secret"
+	"example.com/crosspkgflow/telemetry"
 )
 
 // LeakViaCrossPkgReturn logs the result of a cross-package function whose
@@ -13,18 +14,24 @@ func LeakViaCrossPkgReturn(u secret.User) {
 	slog.Info("msg", "pw", secret.GetPassword(u)) // want "cross-package function call returns sensitive field"
 }
 
+// LeakViaCrossPkgReturnInGroup nests the cross-package call inside an
+// slog.Group. Expected: LH0005 at the GetPassword call, not the Group.
+func LeakViaCrossPkgReturnInGroup(u secret.User) {
+	slog.Info("msg", slog.Group("auth", "pw", secret.GetPassword(u))) // want "cross-package function call returns sensitive field .User.Password."
+}
+
 // LeakViaCrossPkgSink passes a sensitive value into a cross-package function
 // whose body forwards the parameter to a logger. Expected: LH0006 at the
 // position of the sensitive argument.
 func LeakViaCrossPkgSink(u secret.User) {
-	secret.LogIt(u.Password) // want "passed to cross-package function .LogIt. whose parameter"
+	secret.LogIt(u.Password) // want "passed to cross-package function .LogIt. whose parameter .payload. is logged downstream by slog.Info"
 }
 
 // LeakViaIndirectSink exercises transitive sink propagation across packages:
 // secret.Indirect calls secret.LogIt internally, so Indirect's parameter
 // must also be marked as a sink. Expected: LH0006 at the argument.
 func LeakViaIndirectSink(u secret.User) {
-	secret.Indirect(u.Password) // want "passed to cross-package function .Indirect. whose parameter"
+	secret.Indirect(u.Password) // want "passed to cross-package function .Indirect. whose parameter .payload. is logged downstream by slog.Info"
 }
 
 // LeakViaCrossPkgMultiReturn logs position 0 of a cross-package multi-value
@@ -43,11 +50,23 @@ func SafeCrossPkgMultiReturnErr(u secret.User) {
 	slog.Info("msg", "err", err)
 }
 
+// LeakViaConfigLiteral builds a cross-package config object from a sensitive
+// field and hands it to an init function that logs the whole config.
+// Expected: LH0006 at the composite literal.
+func LeakViaConfigLiteral(u secret.User) {
+	telemetry.Init(telemetry.Config{Endpoint: "https://example.com", Token: u.Password}) // want "passed to cross-package function .Init. whose parameter"
+}
+
+// SafeConfigLiteral populates the config from non-sensitive data only.
+func SafeConfigLiteral(u secret.User) {
+	telemetry.Init(telemetry.Config{Endpoint: u.Name})
+}
+
 // LeakViaDeepSink passes a sensitive value into a cross-package function whose
 // parameter only reaches a logger after THREE more hops. Expected: LH0006 at
 // the argument.
 func LeakViaDeepSink(u secret.User) {
-	secret.DeepSink(u.Password) // want "passed to cross-package function .DeepSink. whose parameter"
+	secret.DeepSink(u.Password) // want "passed to cross-package function .DeepSink. whose parameter .payload. is logged downstream by slog.Info"
 }
 
 // SafeCrossPkgCall passes a non-sensitive field across packages — must NOT
@@ -55,3 +74,28 @@ func LeakViaDeepSink(u secret.User) {
 func SafeCrossPkgCall(u secret.User) {
 	secret.LogIt(u.Name)
 }
+
+// LeakPackageVar logs a package-level variable of another package whose
+// initializer returns sensitive data. Expected: LH0001 at the argument.
+func LeakPackageVar() {
+	slog.Info("msg", "pw", secret.AdminPassword) // want "contains sensitive field .User.Password."
+}
+
+// LeakViaVariadicSink passes a sensitive value as a trailing argument of a
+// cross-package function whose variadic parameter is spread into a logger.
+// Expected: LH0006 at the argument.
+func LeakViaVariadicSink(u secret.User) {
+	telemetry.Event("login", "user", u.Name, "pw", u.Password) // want "passed to cross-package function .Event. whose parameter"
+}
+
+// LeakViaSpreadSink spreads a slice holding a sensitive value into the same
+// function. Expected: LH0006 at the slice.
+func LeakViaSpreadSink(u secret.User) {
+	args := []any{"pw", u.Password}
+	telemetry.Event("login", args...) // want "passed to cross-package function .Event. whose parameter"
+}
+
+// SafeVariadicSink passes only non-sensitive values — must NOT be flagged.
+func SafeVariadicSink(u secret.User) {
+	telemetry.Event("login", "user", u.Name)
+}
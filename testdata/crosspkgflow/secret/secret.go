@@ -50,3 +50,14 @@ func deepSink2(payload string) {
 func deepSink1(payload string) {
 	slog.Info("payload", "v", payload)
 }
+
+// AdminPassword is initialized from a helper declared below. Callers in other
+// packages that log it must be flagged even though it is never assigned in a
+// function body.
+var AdminPassword = adminPassword()
+
+func adminPassword() string {
+	return admin.Password
+}
+
+var admin = User{Name: "admin"}
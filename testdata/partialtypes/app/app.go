@@ -0,0 +1,16 @@
+package app
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+// checksum is implemented in assembly
+func checksum(b []byte) uint32
+
+func Login(u User) {
+	slog.Info("login", "password", u.Password)
+	slog.Info("login", "checksum", checksum([]byte(u.Name)))
+}
@@ -0,0 +1,8 @@
+//go:build cgo && partialtypes
+
+package app
+
+// #include <stdlib.h>
+import "C"
+
+func randomID() int { return int(C.rand()) }
@@ -0,0 +1,3 @@
+module partialtypes
+
+go 1.21
@@ -0,0 +1,13 @@
+package app
+
+import "shadowedlog/log"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func Login(u User) {
+	log.Audit(u.Password)
+	log.Audit(u.Name)
+}
@@ -0,0 +1,3 @@
+module shadowedlog
+
+go 1.21
@@ -0,0 +1,12 @@
+// Package log is a local logging wrapper named like the standard library's
+// log. The module path has no dotted domain, so "shadowedlog/log" looks like a
+// standard library path and must still be analyzed as a dependency.
+package log
+
+import "log/slog"
+
+// Audit forwards its parameter to a logging function, so callers passing a
+// sensitive value must be flagged with LH0006
+func Audit(payload string) {
+	slog.Info("audit", "v", payload)
+}
@@ -0,0 +1,27 @@
+package audit
+
+import "log/slog"
+
+type Account struct {
+	Name          string
+	Password      string // want "field 'Account.Password' looks sensitive but is not tagged with sensitive:\"true\""
+	APIKey        string `json:"api_key"` // want `field 'Account.APIKey' looks sensitive but is not tagged with sensitive:"true" \(matched default pattern 'api_\?key'\)`
+	Token         string `sensitive:"true"`
+	NextPageToken string `sensitive:"false"`
+	secret        string
+}
+
+// Unexported types are not part of the audit
+type session struct {
+	Token string
+}
+
+type Credentials struct {
+	ClientID, ClientSecret string // want "field 'Credentials.ClientSecret' looks sensitive"
+}
+
+func handle(a Account, s session, c Credentials) {
+	slog.Info("account", "name", a.Name, "secret", a.secret)
+	_ = s
+	_ = c
+}
@@ -0,0 +1,55 @@
+package authheaders
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Authorization headers and JWTs are credentials whatever the struct tags
+// say, so they are reported (LH0010) without any configuration.
+
+func headers(r *http.Request) {
+	slog.Info("request", "auth", r.Header.Get("Authorization"))       // want `Authorization header carries a bearer credential and should not be logged in argument 3 of slog.Info`
+	slog.Info("request", "auth", r.Header.Get("proxy-authorization")) // want `Proxy-Authorization header carries a bearer credential`
+	slog.Info("request", "auth", r.Header["Authorization"])           // want `Authorization header carries a bearer credential`
+	slog.Info("request", "auth", r.Header.Values("Authorization")[0]) // want `Authorization header carries a bearer credential`
+	log.Printf("auth=%s", fmt.Sprint(r.Header.Get("Authorization")))  // want `Authorization header carries a bearer credential`
+	slog.Info("request", "agent", r.Header.Get("User-Agent"))         // other headers are not credentials
+	slog.Info("request", "auth", r.Header.Get("Authorization") != "") // a comparison reveals nothing
+}
+
+func extracted(r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	token := strings.TrimPrefix(auth, "Bearer ")
+	slog.Info("request", "token", token) // want `Authorization header carries a bearer credential`
+
+	if rest, ok := strings.CutPrefix(auth, "Bearer "); ok {
+		slog.Info("request", "token", rest) // want `Authorization header carries a bearer credential`
+	}
+}
+
+// bearerToken is a typical extraction helper
+func bearerToken(r *http.Request) string {
+	return strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer"))
+}
+
+func helper(r *http.Request) {
+	slog.Info("request", "token", bearerToken(r)) // want `Authorization header carries a bearer credential`
+	t := bearerToken(r)
+	slog.Info("request", "token", "Bearer "+t) // want `Authorization header carries a bearer credential`
+}
+
+func tokens(tok *jwt.Token, key []byte) {
+	slog.Info("jwt", "raw", tok.Raw)       // want `jwt.Token carries a bearer credential`
+	slog.Info("jwt", "claims", tok.Claims) // claims are not the token
+	signed, err := tok.SignedString(key)
+	if err != nil {
+		return
+	}
+	slog.Info("jwt", "signed", signed) // want `jwt.Token carries a bearer credential`
+}
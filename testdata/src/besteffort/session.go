@@ -0,0 +1,25 @@
+package besteffort
+
+import (
+	"fmt"
+	"log/slog"
+
+	metrics "besteffort/telemetry"
+)
+
+type Session struct {
+	ID    string
+	Token string `sensitive:"true,level=warning"`
+}
+
+// This file does not type-check, so only direct accesses to sensitive fields
+// in calls of sink functions are matched, by name
+func newSession(u User, s Session) {
+	slog.Info("session", "id", sessionID(), "password", u.Password) // want `sensitive field 'User.Password' should not be logged \(tagged with sensitive:"true"\) in argument 5 of slog.Info \(best-effort: the file does not type-check\)`
+	fmt.Println(s.Token)                                            // want `sensitive field 'Session.Token' should not be logged`
+	metrics.Record("session", "token", s.Token)                     // want `sensitive field 'Session.Token' should not be logged`
+	slog.Info("session", "name", u.Name)
+
+	password := u.Password
+	slog.Info("session", "password", password) // data flow is not tracked
+}
@@ -0,0 +1,12 @@
+package besteffort
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func login(u User) {
+	slog.Info("login", "password", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
@@ -0,0 +1,24 @@
+// Package boundaries is a telemetry client declared a boundary package:
+// sensitive types must not cross into it.
+package boundaries
+
+import "boundaries/models"
+
+func TrackUser(event string, u models.User) {} // want `TrackUser in boundary package 'boundaries' accepts parameter 'u' of sensitive type 'models.User': telemetry is exported to a vendor`
+
+func TrackUserRef(u *models.User) {} // want `TrackUserRef in boundary package 'boundaries' accepts parameter 'u' of sensitive type 'models.User'`
+
+func TrackUsers(users ...*models.User) {} // want `TrackUsers in boundary package 'boundaries' accepts parameter 'users' of sensitive type 'models.User'`
+
+func TrackByID(byID map[string][]models.User) {} // want `TrackByID in boundary package 'boundaries' accepts parameter 'byID' of sensitive type 'models.User'`
+
+func TrackPair(a, b models.User) {} // want `TrackPair in boundary package 'boundaries' accepts parameter 'a' of sensitive type 'models.User'` `TrackPair in boundary package 'boundaries' accepts parameter 'b' of sensitive type 'models.User'`
+
+type Client struct{}
+
+func (c *Client) Send(models.User) {} // want `Send in boundary package 'boundaries' accepts a parameter of sensitive type 'models.User'`
+
+// Types without sensitive fields and plain values are fine
+func TrackOrder(o models.Order) {}
+
+func TrackUserID(event, userID string) {}
@@ -0,0 +1,12 @@
+package models
+
+type User struct {
+	ID       string
+	Email    string
+	Password string `sensitive:"true"`
+}
+
+type Order struct {
+	ID     string
+	Amount int
+}
@@ -0,0 +1,29 @@
+package callgranularity
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+	Token    string `sensitive:"true"`
+	Hint     string `sensitive:"true,level=warning"`
+}
+
+func handle(u User) {
+	slog.Info("login", "password", u.Password, "token", u.Token) // want `slog.Info logs sensitive data in 2 arguments: "User.Password" \(argument 3\), "User.Token" \(argument 5\) \[LH0004\]`
+	slog.Info("login", "name", u.Name, "token", u.Token)         // want `sensitive field 'User.Token' should not be logged \(tagged with sensitive:"true"\) in argument 5 of slog.Info \[LH0004\]`
+	fmt.Println(u.Password, u.Password)                          // want `fmt.Println logs sensitive data in 2 arguments: "User.Password" \(argument 1\), "User.Password" \(argument 2\) \[LH0004\]`
+	slog.Info("login", "hint", u.Hint, "password", u.Password)   // want `slog.Info logs sensitive data in 2 arguments: "User.Hint" \(argument 3\), "User.Password" \(argument 5\) \[LH0004\]`
+	slog.Info("login", "name", u.Name)
+}
+
+func suppressed(u User) {
+	// The remaining finding is reported at the call
+	slog.Info("login", // want `sensitive field 'User.Token' should not be logged \(tagged with sensitive:"true"\) in argument 3 of slog.Info \[LH0004\]`
+		"token", u.Token,
+		"password", u.Password, //noleak:LH0004
+	)
+}
@@ -0,0 +1,16 @@
+// Package pubsub is a minimal stand-in for cloud.google.com/go/pubsub,
+// covering only the API used by the testdata packages.
+package pubsub
+
+import "context"
+
+type Message struct {
+	Data       []byte
+	Attributes map[string]string
+}
+
+type PublishResult struct{}
+
+type Topic struct{}
+
+func (t *Topic) Publish(ctx context.Context, msg *Message) *PublishResult { return &PublishResult{} }
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type Config struct {
+	Host   string
+	APIKey string
+}
+
+var (
+	dbHost     = flag.String("db-host", "localhost", "database host")
+	dbPassword = flag.String("db-password", "", "database password")
+	cfg        Config
+)
+
+func init() {
+	flag.StringVar(&cfg.Host, "host", "", "API host")
+	flag.StringVar(&cfg.APIKey, "api-key", "", "API key")
+}
+
+func main() {
+	flag.Parse()
+
+	slog.Info("starting", "db-host", *dbHost)
+	slog.Info("starting", "db-password", *dbPassword) // want `flag -db-password is declared sensitive and should not be logged`
+	slog.Info("starting", "db-password-set", *dbPassword != "")
+
+	dsn := fmt.Sprintf("postgres://app:%s@%s/app", *dbPassword, *dbHost)
+	log.Println("connecting to", dsn) // want `flag -db-password is declared sensitive and should not be logged`
+
+	log.Printf("config: %+v", cfg) // want `flag -api-key is declared sensitive and should not be logged`
+	log.Println("host:", cfg.Host)
+
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	password := fs.String("db-password", "", "database password")
+	var token string
+	fs.StringVar(&token, "token", "", "token")
+	log.Println(*password) // want `flag -db-password is declared sensitive and should not be logged`
+	log.Println(token)
+
+	args()
+}
+
+func args() {
+	log.Println("command:", os.Args[1])
+	log.Println("secret:", os.Args[2])                       // want `os.Args\[2\] is declared sensitive and should not be logged`
+	slog.Info("invoked", "args", strings.Join(os.Args, " ")) // want `os.Args is declared sensitive and should not be logged`
+
+	secret := os.Args[2]
+	slog.Info("argument", "secret", secret) // want `os.Args\[2\] is declared sensitive and should not be logged`
+}
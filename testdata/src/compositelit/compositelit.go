@@ -0,0 +1,87 @@
+package compositelit
+
+import (
+	"log/slog"
+)
+
+type Secrets struct {
+	APIKey string `sensitive:"true"`
+	Region string
+}
+
+// TelemetryConfig has no sensitive tags of its own; it only becomes sensitive
+// when populated from a tagged field.
+type TelemetryConfig struct {
+	Endpoint string
+	Token    string
+}
+
+type Options struct {
+	Telemetry TelemetryConfig
+	Labels    map[string]string
+}
+
+func initTelemetry(cfg TelemetryConfig) {
+	slog.Info("telemetry init", "config", cfg) // want "variable \"cfg\" contains sensitive field \"Secrets.APIKey\""
+}
+
+func initTelemetryPtr(cfg *TelemetryConfig) {
+	slog.Info("telemetry init", "config", cfg) // want "variable \"cfg\" contains sensitive field \"Secrets.APIKey\""
+}
+
+func literalVariable(s Secrets) {
+	cfg := TelemetryConfig{Endpoint: "https://example.com", Token: s.APIKey}
+	slog.Info("config", "cfg", cfg) // want "variable \"cfg\" contains sensitive field \"Secrets.APIKey\""
+}
+
+func literalPassedToLogger(s Secrets) {
+	initTelemetry(TelemetryConfig{Token: s.APIKey})
+}
+
+func pointerLiteralPassedToLogger(s Secrets) {
+	initTelemetryPtr(&TelemetryConfig{Token: s.APIKey})
+}
+
+func nestedLiteral(s Secrets) {
+	opts := Options{Telemetry: TelemetryConfig{Token: s.APIKey}}
+	slog.Info("options", "opts", opts) // want "variable \"opts\" contains sensitive field \"Secrets.APIKey\""
+}
+
+func mapLiteral(s Secrets) {
+	labels := map[string]string{"token": s.APIKey}
+	slog.Info("labels", "labels", labels) // want "variable \"labels\" contains sensitive field \"Secrets.APIKey\""
+}
+
+func sliceLiteral(s Secrets) {
+	keys := []string{s.APIKey}
+	slog.Info("keys", "keys", keys) // want "variable \"keys\" contains sensitive field \"Secrets.APIKey\""
+}
+
+func taintedVarInsideLoggedLiteral(s Secrets) {
+	tok := s.APIKey
+	slog.Info("config", "cfg", TelemetryConfig{Token: tok}) // want "variable \"tok\" contains sensitive field \"Secrets.APIKey\""
+}
+
+func fieldStore(s Secrets) {
+	var cfg TelemetryConfig
+	cfg.Endpoint = "https://example.com"
+	cfg.Token = s.APIKey
+	slog.Info("config", "cfg", cfg) // want "variable \"cfg\" contains sensitive field \"Secrets.APIKey\""
+}
+
+func nestedFieldStore(s Secrets) {
+	var opts Options
+	opts.Telemetry.Token = s.APIKey
+	slog.Info("options", "opts", opts) // want "variable \"opts\" contains sensitive field \"Secrets.APIKey\""
+}
+
+// Safe: literal built only from non-sensitive data.
+func safeLiteral(s Secrets) {
+	cfg := TelemetryConfig{Endpoint: s.Region}
+	slog.Info("config", "cfg", cfg)
+	initTelemetrySafe(TelemetryConfig{Endpoint: "https://example.com"})
+}
+
+func initTelemetrySafe(cfg TelemetryConfig) {
+	slog.Info("telemetry init", "config", cfg)
+}
@@ -59,6 +59,33 @@ func logRangeField(users []User) {
 	}
 }
 
+// Range elements of sensitive struct type are reported as whole structs
+// (LH0003) when logged directly, and stay tainted when handed to helpers
+// whose parameters lose the struct type.
+
+func logRangeElement(users []User) {
+	for _, u := range users {
+		slog.Info("u", "user", u) // want `struct 'User' contains sensitive fields`
+	}
+}
+
+func logRangeElementViaHelper(users []*User) {
+	for _, u := range users {
+		logAnything(u)
+	}
+}
+
+func logAnything(v any) {
+	slog.Info("v", "value", v) // want `variable "v" contains sensitive field "User.Password"`
+}
+
+func logRangeOverTaintedSlice(u User) {
+	secrets := []string{u.Password, u.Name}
+	for _, s := range secrets {
+		slog.Info("s", "value", s) // want `variable "s" contains sensitive field "User.Password"`
+	}
+}
+
 // Containers of non-sensitive element types must NOT be flagged.
 
 func safeSlice(s []Safe) {
@@ -74,3 +101,9 @@ func safeStringSlice(s []string) {
 }
 
 func main() {}
+
+func safeRangeIndex(users []User) {
+	for i := range users {
+		slog.Info("i", "index", i)
+	}
+}
@@ -0,0 +1,89 @@
+package crashdump
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+type User struct {
+	Password string `sensitive:"true"`
+}
+
+func deferredLiteral(dsn, password string) {
+	defer func() {
+		if r := recover(); r != nil {
+			debug.PrintStack()
+			log.Printf("panic with %s: %v", dsn, r)               // want `local variable 'dsn' is logged by a crash handler dumping the stack with debug.PrintStack in argument 2 of log.Printf \(matched pattern '\^dsn\$' declared in .leakhound.yaml\)`
+			slog.Error("crash", "password", password, "panic", r) // want `local variable 'password' is logged by a crash handler dumping the stack with debug.PrintStack in argument 3 of slog.Error \(matched pattern 'passw\(or\)\?d' declared in .leakhound.yaml\)`
+			slog.Error("crash", "password", mask(password))       // masked by a helper
+			log.Printf("length %d", len(password))                // not the value
+		}
+	}()
+}
+
+func namedHandler() {
+	defer recoverRequest("secret-token")
+}
+
+func recoverRequest(authToken string) {
+	if r := recover(); r != nil {
+		buf := make([]byte, 4096)
+		n := runtime.Stack(buf, false)
+		fmt.Fprintf(os.Stderr, "panic: %v token=%s\n%s", r, string([]byte(authToken)), buf[:n]) // want `local variable 'authToken' is logged by a crash handler dumping the stack with runtime.Stack in argument 4 of fmt.Fprintf`
+	}
+}
+
+var apiToken = os.Getenv("API_TOKEN")
+
+func notLocal() {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic: %v token=%s\n%s", r, apiToken, debug.Stack()) // package-level variable
+		}
+	}()
+}
+
+func noStackDump(password string) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic: %v password=%s", r, password) // no stack dump
+		}
+	}()
+}
+
+func noRecover(password string) {
+	defer func() {
+		debug.PrintStack()
+		log.Printf("exit password=%s", password) // does not recover
+	}()
+}
+
+func nestedLiteral(password string) {
+	defer func() {
+		if r := recover(); r != nil {
+			debug.PrintStack()
+			go func() {
+				log.Printf("password=%s", password) // a function of its own
+			}()
+		}
+	}()
+}
+
+func trackedVariable(u User) {
+	password := u.Password
+	defer func() {
+		if r := recover(); r != nil {
+			debug.PrintStack()
+			log.Printf("password=%s", password) // want `variable "password" contains sensitive field "User.Password"`
+		}
+	}()
+}
+
+func mask(s string) string {
+	return strings.Repeat("*", len(s))
+}
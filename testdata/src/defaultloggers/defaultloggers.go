@@ -0,0 +1,44 @@
+package defaultloggers
+
+import (
+	"context"
+	"log"
+	"log/slog"
+	"os"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func defaults(ctx context.Context, u User) {
+	slog.Default().Info("login", "user", u.Name)
+	slog.Default().Info("login", "pwd", u.Password)                     // want "sensitive field 'User.Password' should not be logged"
+	slog.Default().With("pwd", u.Password).Info("login")                // want "sensitive field 'User.Password' should not be logged"
+	slog.Default().InfoContext(ctx, "login", "pwd", u.Password)         // want "sensitive field 'User.Password' should not be logged"
+	slog.Default().Log(ctx, slog.LevelWarn, "login", "pwd", u.Password) // want "sensitive field 'User.Password' should not be logged"
+	slog.With("pwd", u.Password).WithGroup("req").Info("login")         // want "sensitive field 'User.Password' should not be logged"
+	slog.Default().WithGroup("req").Info("login", "user", u.Name)
+	log.Default().Println(u.Password)                                              // want "sensitive field 'User.Password' should not be logged"
+	log.Default().Printf("password=%s", u.Password)                                // want "sensitive field 'User.Password' should not be logged"
+	slog.New(slog.NewTextHandler(os.Stderr, nil)).Warn("login", "pwd", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+type server struct {
+	logger *slog.Logger
+	std    *log.Logger
+}
+
+func newServer() *server {
+	return &server{
+		logger: slog.New(slog.NewJSONHandler(os.Stdout, nil)),
+		std:    log.Default(),
+	}
+}
+
+func (s *server) handle(u User) {
+	s.logger.Info("login", "user", u.Name)
+	s.logger.Info("login", "pwd", u.Password) // want "sensitive field 'User.Password' should not be logged"
+	s.std.Println(u.Password)                 // want "sensitive field 'User.Password' should not be logged"
+}
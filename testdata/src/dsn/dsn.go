@@ -0,0 +1,63 @@
+package dsn
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"net/url"
+)
+
+type DBConfig struct {
+	Host     string
+	User     string
+	Password string `sensitive:"true"`
+}
+
+func sprintfDSN(cfg DBConfig) {
+	dsn := fmt.Sprintf("postgres://%s:%s@%s/app", cfg.User, cfg.Password, cfg.Host)
+	slog.Info("connecting", "dsn", dsn) // want `variable "dsn" contains sensitive field "DBConfig.Password"`
+
+	safe := fmt.Sprintf("postgres://%s@%s/app", cfg.User, cfg.Host)
+	slog.Info("connecting", "dsn", safe)
+}
+
+func concatDSN(cfg DBConfig) {
+	pass := cfg.Password
+	dsn := "mysql://" + cfg.User + ":" + pass + "@tcp(" + cfg.Host + ")/app"
+	log.Printf("dsn: %s", dsn)    // want `variable "dsn" contains sensitive field "DBConfig.Password"`
+	log.Print("password=" + pass) // want `variable "pass" contains sensitive field "DBConfig.Password"`
+
+	query := "host=" + cfg.Host + " user=" + cfg.User
+	log.Print("query: " + query)
+}
+
+func urlLiteral(cfg DBConfig) {
+	u := &url.URL{Scheme: "postgres", Host: cfg.Host, User: url.UserPassword(cfg.User, cfg.Password)}
+	log.Println(u)          // want `variable "u" contains sensitive field "DBConfig.Password"`
+	log.Println(u.String()) // want `variable "u" contains sensitive field "DBConfig.Password"`
+	log.Println(u.Redacted())
+	log.Println(u.Host)
+}
+
+func urlUserField(cfg DBConfig) {
+	u := url.URL{Scheme: "redis", Host: cfg.Host}
+	u.User = url.UserPassword(cfg.User, cfg.Password)
+	s := u.String()
+	slog.Info("cache", "url", s) // want `variable "s" contains sensitive field "DBConfig.Password"`
+	pw, _ := u.User.Password()
+	slog.Info("cache", "password", pw)            // want `variable "pw" contains sensitive field "DBConfig.Password"`
+	slog.Info("cache", "user", u.User.Username()) // want `variable "u" contains sensitive field "DBConfig.Password"`
+
+	anon := url.URL{Scheme: "redis", Host: cfg.Host, User: url.User(cfg.User)}
+	slog.Info("cache", "url", anon.String())
+}
+
+func parsed(cfg DBConfig) {
+	raw := fmt.Sprintf("amqp://%s:%s@%s/", cfg.User, cfg.Password, cfg.Host)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return
+	}
+	log.Println(u) // want `variable "u" contains sensitive field "DBConfig.Password"`
+	log.Println(u.Redacted())
+}
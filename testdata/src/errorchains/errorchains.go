@@ -0,0 +1,52 @@
+package errorchains
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+type Credentials struct {
+	User   string
+	APIKey string `sensitive:"true"`
+}
+
+var errAuth = errors.New("authentication failed")
+
+func joined(c Credentials, err1 error) {
+	err := errors.Join(err1, fmt.Errorf("key=%s", c.APIKey))
+	slog.Error("login", "err", err) // want `variable "err" contains sensitive field "Credentials.APIKey"`
+
+	safe := errors.Join(err1, fmt.Errorf("user=%s", c.User))
+	slog.Error("login", "err", safe)
+}
+
+func wrapped(c Credentials) {
+	inner := fmt.Errorf("rejected key %q", c.APIKey)
+	err := fmt.Errorf("login: %w", inner)
+	log.Println(err)         // want `variable "err" contains sensitive field "Credentials.APIKey"`
+	log.Println(err.Error()) // want `variable "err" contains sensitive field "Credentials.APIKey"`
+
+	unwrapped := errors.Unwrap(err)
+	log.Println(unwrapped) // want `variable "unwrapped" contains sensitive field "Credentials.APIKey"`
+
+	plain := fmt.Errorf("login: %w", errAuth)
+	log.Println(plain, plain.Error())
+}
+
+func pkgErrors(c Credentials, err error) {
+	withKey := pkgerrors.Wrapf(err, "key %s", c.APIKey)
+	slog.Error("login", "err", withKey) // want `variable "withKey" contains sensitive field "Credentials.APIKey"`
+
+	wrapped := pkgerrors.Wrap(withKey, "retry")
+	slog.Error("login", "err", wrapped) // want `variable "wrapped" contains sensitive field "Credentials.APIKey"`
+
+	cause := pkgerrors.Cause(wrapped)
+	slog.Error("login", "err", cause) // want `variable "cause" contains sensitive field "Credentials.APIKey"`
+
+	safe := pkgerrors.Wrapf(err, "user %s", c.User)
+	slog.Error("login", "err", safe)
+}
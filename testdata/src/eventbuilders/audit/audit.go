@@ -0,0 +1,11 @@
+package audit
+
+type Event struct {
+	Action  string
+	Details map[string]any
+}
+
+func NewEvent(action string) *Event { return &Event{Action: action} }
+
+func (e *Event) WithDetail(key string, value any) *Event { return e }
+func (e *Event) Set(key string, value any)               {}
@@ -0,0 +1,6 @@
+package bus
+
+// Message is published to a bus that writes every message to a log
+type Message struct{}
+
+func (m *Message) Attach(key string, value any) *Message { return m }
@@ -0,0 +1,41 @@
+package eventbuilders
+
+import (
+	"log/slog"
+
+	"eventbuilders/audit"
+	"eventbuilders/bus"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+// Values attached to an event are reported once the event is logged
+func events(u User) {
+	chained := audit.NewEvent("login").WithDetail("user", u.Name).WithDetail("password", u.Password)
+	slog.Info("audit", "event", chained) // want `variable "chained" contains sensitive field "User.Password"`
+
+	mutated := audit.NewEvent("login")
+	mutated.Set("password", u.Password)
+	slog.Info("audit", "event", mutated) // want `variable "mutated" contains sensitive field "User.Password"`
+
+	reassigned := audit.NewEvent("login")
+	reassigned = reassigned.WithDetail("password", u.Password)
+	slog.Info("audit", "event", reassigned) // want `variable "reassigned" contains sensitive field "User.Password"`
+
+	safe := audit.NewEvent("login").WithDetail("user", u.Name)
+	slog.Info("audit", "event", safe)
+
+	// Attached but never logged
+	unlogged := audit.NewEvent("login").WithDetail("password", u.Password)
+	_ = unlogged
+}
+
+// Values attached to builders configured as eventually logged are reported
+// at the attaching call
+func messages(m *bus.Message, u User) {
+	m.Attach("password", u.Password) // want `sensitive field 'User.Password' should not be logged .* in argument 2 of \(\*bus.Message\).Attach`
+	m.Attach("user", u.Name)
+}
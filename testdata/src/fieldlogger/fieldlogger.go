@@ -0,0 +1,55 @@
+package fieldlogger
+
+import (
+	"log"
+	"log/slog"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+type base struct{}
+
+func (b *base) Info(msg string, args ...any) {}
+
+// AppLogger is the configured receiver; its Info method is promoted from
+// the embedded *base, so the method's declared receiver is *base.
+type AppLogger struct {
+	*base
+}
+
+// Logger is a configured interface receiver
+type Logger interface {
+	Info(msg string, args ...any)
+}
+
+type service struct {
+	log     *AppLogger
+	iface   Logger
+	slog    *slog.Logger
+	std     *log.Logger
+	loggers []*AppLogger
+	nested  struct {
+		inner *AppLogger
+	}
+}
+
+func getLogger() *AppLogger { return &AppLogger{} }
+
+func (s *service) handle(u User) {
+	s.log.Info("login", u.Name)
+	s.log.Info("login", u.Password)          // want "sensitive field 'User.Password' should not be logged"
+	s.loggers[0].Info("login", u.Password)   // want "sensitive field 'User.Password' should not be logged"
+	s.nested.inner.Info("login", u.Password) // want "sensitive field 'User.Password' should not be logged"
+	getLogger().Info("login", u.Password)    // want "sensitive field 'User.Password' should not be logged"
+	s.iface.Info("login", u.Password)        // want "sensitive field 'User.Password' should not be logged"
+	s.slog.Info("login", "pwd", u.Password)  // want "sensitive field 'User.Password' should not be logged"
+	s.std.Println(u.Password)                // want "sensitive field 'User.Password' should not be logged"
+}
+
+func unconfigured(b *base, u User) {
+	// *base itself is not a configured receiver
+	b.Info("login", u.Password)
+}
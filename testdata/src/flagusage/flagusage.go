@@ -0,0 +1,52 @@
+package flagusage
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+type Config struct {
+	Addr     string
+	Password string `sensitive:"true"`
+	Token    string `sensitive:"true"`
+}
+
+var defaults = Config{Addr: ":8080", Password: "hunter2", Token: "s3cr3t"}
+
+// The default values are printed by -h and flag parsing errors
+var token = flag.String("token", defaults.Token, "API token") // want `sensitive field 'Config.Token' should not be logged \(tagged with sensitive:"true"\) in argument 2 of flag.String \[LH0004\]$`
+
+func bind(cfg *Config) {
+	flag.StringVar(&cfg.Addr, "addr", defaults.Addr, "listen address")
+	flag.StringVar(&cfg.Password, "password", "", "database password")         // OK: the bound variable is not printed
+	flag.StringVar(&cfg.Password, "db-password", defaults.Password, "")        // want `in argument 3 of flag.StringVar \[LH0004\]$`
+	flag.String("api-token", "", "API token, e.g. "+defaults.Token)            // want `in argument 3 of flag.String \[LH0004\]$`
+	flag.Func("secret", fmt.Sprintf("secret (default %s)", cfg.Password), nil) // want `in argument 2 of flag.Func \[LH0004\]$`
+}
+
+func flagSet(cfg *Config) *flag.FlagSet {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	fs.StringVar(&cfg.Token, "token", cfg.Token, "API token")                  // want `in argument 3 of \(\*flag.FlagSet\).StringVar \[LH0004\]$`
+	(*flag.FlagSet).StringVar(fs, &cfg.Password, "password", cfg.Password, "") // want `in argument 4 of \(\*flag.FlagSet\).StringVar \[LH0004\]$`
+	fs.Int("port", 8080, "listen port")
+	return fs
+}
+
+func usage(cfg Config) {
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags]\n", os.Args[0])
+		fmt.Fprintf(flag.CommandLine.Output(), "config: %+v\n", cfg)         // want `struct 'Config' contains sensitive fields and should not be logged entirely in argument 3 of fmt.Fprintf \[LH0003\]$`
+		io.WriteString(flag.CommandLine.Output(), "password: "+cfg.Password) // want `in argument 2 of io.WriteString \[LH0004\]$`
+		flag.CommandLine.Output().Write([]byte(cfg.Token))                   // want `in argument 1 of \(io.Writer\).Write \[LH0004\]$`
+		flag.PrintDefaults()
+	}
+}
+
+func unrelated(w io.Writer, cfg Config) {
+	io.WriteString(w, cfg.Password) // OK: not the help text
+	w.Write([]byte(cfg.Token))      // OK: not the help text
+}
+
+func use() { _ = token }
@@ -0,0 +1,23 @@
+package flowhops
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func direct(u User) {
+	slog.Info("login", "password", u.Password) // want `in argument 3 of slog.Info \[LH0004\]$`
+}
+
+func variable(u User) {
+	pw := u.Password
+	slog.Info("login", "password", pw) // want `in argument 3 of slog.Info \[LH0001\]$`
+}
+
+func chain(u User) {
+	pw := u.Password
+	masked := pw
+	slog.Info("login", "password", masked) // want `\(data flow of 2 hops, over max-flow-hops 1\)`
+}
@@ -0,0 +1,23 @@
+package fluentlogger
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func fluent(l *Logger, u User) {
+	l.WithField("user", u.Name).Info("login")
+	l.WithField("user", u.Name).Info("login", u.Password)                        // want "sensitive field 'User.Password' should not be logged"
+	l.WithField("password", u.Password).Info("login")                            // want "sensitive field 'User.Password' should not be logged"
+	l.WithField("user", u.Name).WithField("password", u.Password).Error("login") // want "sensitive field 'User.Password' should not be logged"
+	l.Info("login", u.Password)                                                  // want "sensitive field 'User.Password' should not be logged"
+}
+
+func packageChain(u User) {
+	WithField("password", u.Password).Info("login") // want "sensitive field 'User.Password' should not be logged"
+}
+
+func notLogged(l *Logger, u User) {
+	// String is not a configured method, so the chain is not a sink
+	_ = l.WithField("password", u.Password).String()
+}
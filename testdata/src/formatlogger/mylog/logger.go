@@ -0,0 +1,10 @@
+package mylog
+
+type Logger struct{}
+
+func (l *Logger) Info(msg string, args ...any)     {}
+func (l *Logger) Infof(format string, args ...any) {}
+
+func Error(msg string, args ...any)              {}
+func Errorf(format string, args ...any)          {}
+func Logf(level int, format string, args ...any) {}
@@ -0,0 +1,42 @@
+package formatlogger
+
+import (
+	"fmt"
+
+	"formatlogger/mylog"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func printfStyle(l *mylog.Logger, u User) {
+	mylog.Errorf("login %s", u.Name)
+	mylog.Errorf("login %s %s", u.Name, u.Password) // want "sensitive field 'User.Password' should not be logged"
+	mylog.Errorf("password type %T", u.Password)
+	mylog.Errorf("password at %p", &u.Password)
+	mylog.Errorf("password %[1]T %[1]v", u.Password) // want "sensitive field 'User.Password' should not be logged"
+	mylog.Errorf("%*s %T", 8, u.Name, u.Password)
+	mylog.Errorf("login %s", u.Name, u.Password) // want "sensitive field 'User.Password' should not be logged"
+	l.Infof("password type %T", u.Password)
+	l.Infof("password %v", u.Password) // want "sensitive field 'User.Password' should not be logged"
+	mylog.Logf(1, "password type %T", u.Password)
+	mylog.Logf(1, "password %q", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func nonConstantFormat(u User, format string) {
+	// The verbs are unknown, so every argument is checked
+	mylog.Errorf(format, u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func notPrintfStyle(l *mylog.Logger, u User) {
+	// Without format-arg every argument is checked
+	mylog.Error("password type %T", u.Password) // want "sensitive field 'User.Password' should not be logged"
+	l.Info("password type %T", u.Password)      // want "sensitive field 'User.Password' should not be logged"
+}
+
+func fmtFamily(u User) {
+	fmt.Printf("password type %T\n", u.Password)
+	fmt.Printf("password %s\n", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
@@ -0,0 +1,61 @@
+package funcvalues
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+var logf = log.Printf
+
+func localVar(u User) {
+	logFn := slog.Info
+	logFn("login", "user", u.Name)
+	logFn("login", "password", u.Password) // want "sensitive field 'User.Password' should not be logged.* in argument 3 of slog.Info"
+}
+
+func copied(u User) {
+	logFn := fmt.Println
+	other := logFn
+	other(u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func assigned(u User) {
+	var logFn func(...any)
+	logFn = log.Println
+	logFn(u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func packageVar(u User) {
+	logf("password=%s", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func run(print func(...any), u User) {
+	print(u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func callback(u User) {
+	run(log.Println, u)
+}
+
+type Server struct {
+	logf func(string, ...any)
+}
+
+func (s *Server) handle(u User) {
+	s.logf("password=%s", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func newServer() *Server {
+	return &Server{logf: log.Printf}
+}
+
+func notASink(u User) {
+	format := fmt.Sprintf
+	_ = format("%s", u.Password)
+}
@@ -0,0 +1,38 @@
+package genericlogger
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+// Logger is a generic logging wrapper carrying per-logger context
+type Logger[T any] struct {
+	ctx T
+}
+
+func (l *Logger[T]) Info(msg string, args ...any) {}
+
+type Pair[K comparable, V any] struct{}
+
+func (p Pair[K, V]) Log(msg string, args ...any) {}
+
+// Tracer has two type parameters, so receiver "*Tracer[T]" does not match it
+type Tracer[T, U any] struct{}
+
+func (t *Tracer[T, U]) Span(name string, args ...any) {}
+
+// "*Logger[T]" matches every instantiation of the generic type
+func instantiated(l *Logger[string], p Pair[int, string], u User) {
+	l.Info("login", "password", u.Password) // want `sensitive field 'User.Password' should not be logged`
+	p.Log("login", "password", u.Password)  // want `sensitive field 'User.Password' should not be logged`
+	l.Info("login", "name", u.Name)
+}
+
+// ...including the receiver within generic code
+func generic[T any](l *Logger[T], u User) {
+	l.Info("login", "password", u.Password) // want `sensitive field 'User.Password' should not be logged`
+}
+
+func mismatchedTypeParams(t *Tracer[string, int], u User) {
+	t.Span("login", "password", u.Password)
+}
@@ -0,0 +1,20 @@
+// Package sarama is a minimal stand-in for github.com/IBM/sarama, covering
+// only the API used by the testdata packages.
+package sarama
+
+type Encoder interface {
+	Encode() ([]byte, error)
+}
+
+type StringEncoder string
+
+func (s StringEncoder) Encode() ([]byte, error) { return []byte(s), nil }
+
+type ProducerMessage struct {
+	Topic string
+	Value Encoder
+}
+
+type SyncProducer interface {
+	SendMessage(msg *ProducerMessage) (partition int32, offset int64, err error)
+}
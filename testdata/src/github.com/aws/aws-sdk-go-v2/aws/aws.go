@@ -0,0 +1,5 @@
+// Package aws is a minimal stand-in for github.com/aws/aws-sdk-go-v2/aws,
+// covering only the API used by the testdata packages.
+package aws
+
+func String(v string) *string { return &v }
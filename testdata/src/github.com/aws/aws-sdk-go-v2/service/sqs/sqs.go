@@ -0,0 +1,21 @@
+// Package sqs is a minimal stand-in for
+// github.com/aws/aws-sdk-go-v2/service/sqs, covering only the API used by the
+// testdata packages.
+package sqs
+
+import "context"
+
+type Options struct{}
+
+type SendMessageInput struct {
+	MessageBody *string
+	QueueUrl    *string
+}
+
+type SendMessageOutput struct{}
+
+type Client struct{}
+
+func (c *Client) SendMessage(ctx context.Context, params *SendMessageInput, optFns ...func(*Options)) (*SendMessageOutput, error) {
+	return &SendMessageOutput{}, nil
+}
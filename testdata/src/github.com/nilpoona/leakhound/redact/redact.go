@@ -0,0 +1,15 @@
+// Package redact is a minimal stand-in for github.com/nilpoona/leakhound/redact,
+// covering only the API used by the testdata packages.
+package redact
+
+import "log/slog"
+
+type Secret[T any] struct{ value T }
+
+func New[T any](value T) Secret[T] { return Secret[T]{value: value} }
+
+func (s Secret[T]) Expose() T { return s.value }
+
+func (s Secret[T]) String() string { return "[REDACTED]" }
+
+func (s Secret[T]) LogValue() slog.Value { return slog.StringValue("[REDACTED]") }
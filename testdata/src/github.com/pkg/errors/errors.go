@@ -0,0 +1,21 @@
+// Package errors is a minimal stand-in for github.com/pkg/errors, covering
+// only the API used by the testdata packages.
+package errors
+
+import "fmt"
+
+func New(message string) error { return fmt.Errorf("%s", message) }
+
+func Errorf(format string, args ...interface{}) error { return fmt.Errorf(format, args...) }
+
+func Wrap(err error, message string) error { return fmt.Errorf("%s: %w", message, err) }
+
+func Wrapf(err error, format string, args ...interface{}) error {
+	return fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), err)
+}
+
+func WithMessage(err error, message string) error { return Wrap(err, message) }
+
+func WithStack(err error) error { return err }
+
+func Cause(err error) error { return err }
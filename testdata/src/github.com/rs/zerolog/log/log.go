@@ -0,0 +1,10 @@
+// Package log is a minimal stand-in for github.com/rs/zerolog/log
+package log
+
+import "github.com/rs/zerolog"
+
+var Logger = zerolog.Logger{}
+
+func Debug() *zerolog.Event { return Logger.Debug() }
+func Info() *zerolog.Event  { return Logger.Info() }
+func Error() *zerolog.Event { return Logger.Error() }
@@ -0,0 +1,39 @@
+// Package zerolog is a minimal stand-in for github.com/rs/zerolog, covering
+// only the API used by the testdata packages.
+package zerolog
+
+import "context"
+
+type Level int8
+
+const (
+	DebugLevel Level = iota
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+type Logger struct{}
+
+func (l *Logger) Debug() *Event                { return &Event{} }
+func (l *Logger) Info() *Event                 { return &Event{} }
+func (l *Logger) Warn() *Event                 { return &Event{} }
+func (l *Logger) Error() *Event                { return &Event{} }
+func (l *Logger) WithLevel(level Level) *Event { return &Event{} }
+
+func Ctx(ctx context.Context) *Logger { return &Logger{} }
+
+type Event struct{}
+
+func (e *Event) Str(key, val string) *Event            { return e }
+func (e *Event) Strs(key string, vals []string) *Event { return e }
+func (e *Event) Int(key string, i int) *Event          { return e }
+func (e *Event) Interface(key string, i any) *Event    { return e }
+func (e *Event) Any(key string, i any) *Event          { return e }
+func (e *Event) Err(err error) *Event                  { return e }
+func (e *Event) Dict(key string, dict *Event) *Event   { return e }
+func (e *Event) Msg(msg string)                        {}
+func (e *Event) Msgf(format string, v ...any)          {}
+func (e *Event) Send()                                 {}
+
+func Dict() *Event { return &Event{} }
@@ -0,0 +1,42 @@
+// Package zap is a minimal stand-in for go.uber.org/zap, covering only the
+// API used by the testdata packages.
+package zap
+
+import "go.uber.org/zap/zapcore"
+
+type Field = zapcore.Field
+
+type Logger struct{}
+
+func (l *Logger) Debug(msg string, fields ...Field)                  {}
+func (l *Logger) Info(msg string, fields ...Field)                   {}
+func (l *Logger) Warn(msg string, fields ...Field)                   {}
+func (l *Logger) Error(msg string, fields ...Field)                  {}
+func (l *Logger) Log(lvl zapcore.Level, msg string, fields ...Field) {}
+func (l *Logger) With(fields ...Field) *Logger                       { return l }
+func (l *Logger) Named(s string) *Logger                             { return l }
+func (l *Logger) Sugar() *SugaredLogger                              { return &SugaredLogger{} }
+func (l *Logger) Check(lvl zapcore.Level, msg string) *zapcore.Entry { return nil }
+
+type SugaredLogger struct{}
+
+func (s *SugaredLogger) Debugw(msg string, keysAndValues ...any)              {}
+func (s *SugaredLogger) Infow(msg string, keysAndValues ...any)               {}
+func (s *SugaredLogger) Info(args ...any)                                     {}
+func (s *SugaredLogger) Infof(template string, args ...any)                   {}
+func (s *SugaredLogger) Errorw(msg string, keysAndValues ...any)              {}
+func (s *SugaredLogger) Warnln(args ...any)                                   {}
+func (s *SugaredLogger) Logf(lvl zapcore.Level, template string, args ...any) {}
+func (s *SugaredLogger) Desugar() *Logger                                     { return &Logger{} }
+
+func L() *Logger        { return &Logger{} }
+func S() *SugaredLogger { return &SugaredLogger{} }
+
+func String(key string, val string) Field                  { return Field{Key: key} }
+func Any(key string, val any) Field                        { return Field{Key: key, Interface: val} }
+func Object(key string, val zapcore.ObjectMarshaler) Field { return Field{Key: key, Interface: val} }
+func Inline(val zapcore.ObjectMarshaler) Field             { return Field{Interface: val} }
+
+type Option struct{}
+
+func Hooks(hooks ...func(zapcore.Entry) error) Option { return Option{} }
@@ -0,0 +1,30 @@
+// Package zapcore is a minimal stand-in for go.uber.org/zap/zapcore, covering
+// only the API used by the testdata packages.
+package zapcore
+
+type ObjectEncoder interface {
+	AddString(key, value string)
+}
+
+type ObjectMarshaler interface {
+	MarshalLogObject(enc ObjectEncoder) error
+}
+
+type Field struct {
+	Key       string
+	Interface any
+}
+
+type Level int8
+
+const (
+	DebugLevel Level = iota - 1
+	InfoLevel
+	WarnLevel
+	ErrorLevel
+)
+
+type Entry struct {
+	Level   int8
+	Message string
+}
@@ -50,17 +50,49 @@ func main() {
 	password := user.Password
 	slog.Info("variable in group",
 		slog.Group("credentials",
-			"pass", password, // want "sensitive variable 'password' contains data from 'User.Password' and should not be logged"
+			"pass", password, // want "variable \"password\" contains sensitive field \"User.Password\""
 		),
 	)
 
 	// Test function return value in slog.Group
 	slog.Info("function return in group",
 		slog.Group("auth",
-			"secret", getPassword(user), // want "sensitive function call 'getPassword' returns data from 'User.Password' and should not be logged"
+			"secret", getPassword(user), // want "function call returns sensitive field \"User.Password\""
 		),
 	)
 
+	// Attr built ahead of time carries the taint of its value
+	passAttr := slog.String("password", user.Password)
+	slog.Info("prebuilt attr", passAttr) // want "variable \"passAttr\" contains sensitive field \"User.Password\""
+
+	// Group assembled from a tainted attr
+	creds := slog.Group("creds", "name", user.Name, passAttr)
+	slog.Info("prebuilt group", creds) // want "variable \"creds\" contains sensitive field \"User.Password\""
+
+	// Group of attrs: the finding points at the innermost field access
+	slog.Info("attr tree",
+		slog.Group("outer",
+			slog.String("name", user.Name),
+			slog.Group("inner",
+				slog.Any("pw", user.Password), // want "sensitive field 'User.Password' should not be logged"
+			),
+		),
+	)
+
+	// slog.Attr literal with a sensitive Value
+	slog.Info("attr literal",
+		slog.Attr{Key: "pw", Value: slog.StringValue(user.Password)}, // want "sensitive field 'User.Password' should not be logged"
+	)
+	litAttr := slog.Attr{Key: "pw", Value: slog.StringValue(user.Password)}
+	slog.Info("attr literal var", litAttr) // want "variable \"litAttr\" contains sensitive field \"User.Password\""
+
+	// Attr returned from a helper
+	slog.Info("attr helper", passwordAttr(user)) // want "function call returns sensitive field \"User.Password\""
+
+	// Safe attrs built from non-sensitive data
+	nameAttr := slog.String("name", user.Name)
+	slog.Info("safe attr", nameAttr, slog.Attr{Key: "name", Value: slog.StringValue(user.Name)})
+
 	// Test sensitive data passed as parameter to function that logs it
 	doSomething(user.Password, user.Name)
 
@@ -68,13 +100,17 @@ func main() {
 	logInGroup(user.Password, user.Name)
 }
 
+func passwordAttr(u User) slog.Attr {
+	return slog.String("password", u.Password)
+}
+
 func getPassword(u User) string {
 	return u.Password
 }
 
 func doSomething(a, b string) {
 	// a is sensitive data passed from caller
-	slog.Info("message", "value", a) // want "sensitive parameter 'a' contains data from 'User.Password' and should not be logged"
+	slog.Info("message", "value", a) // want "variable \"a\" contains sensitive field \"User.Password\""
 }
 
 func logInGroup(secret, name string) {
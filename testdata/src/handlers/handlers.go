@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+var admin = User{Name: "root", Password: "hunter2"}
+
+func loadUser(r *http.Request) User { return User{Name: r.URL.Query().Get("name")} }
+
+// Function literals assigned to package-level variables
+var handler = func(w http.ResponseWriter, r *http.Request) {
+	fmt.Fprintf(w, "%s", admin.Password) // want `sensitive field 'User.Password' should not be logged \(tagged with sensitive:"true"\) in argument 3 of fmt.Fprintf \[LH0004\]$`
+}
+
+// Function literals passed to package-level initializers, with their own
+// variables
+var mux = newMux(func(w http.ResponseWriter, r *http.Request) {
+	u := loadUser(r)
+	pw := u.Password
+	slog.Info("login", "password", pw) // want `variable "pw" contains sensitive field "User.Password" \(tagged with sensitive:"true"\) in argument 3 of slog.Info \[LH0001\]$`
+})
+
+func newMux(h http.HandlerFunc) *http.ServeMux {
+	m := http.NewServeMux()
+	m.HandleFunc("/", h)
+	return m
+}
+
+func register(u User) {
+	http.HandleFunc("/password", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, u.Password) // want `in argument 2 of fmt.Fprintf \[LH0004\]$`
+	})
+	http.Handle("/user", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slog.Info("request", "user", loadUser(r)) // want `struct 'User' contains sensitive fields and should not be logged entirely in argument 3 of slog.Info \[LH0003\]$`
+	}))
+
+	m := http.NewServeMux()
+	h := func(w http.ResponseWriter, r *http.Request) {
+		v := loadUser(r)
+		fmt.Fprintln(w, v.Name)     // OK: not sensitive
+		fmt.Fprintln(w, v.Password) // want `in argument 2 of fmt.Fprintln \[LH0004\]$`
+	}
+	m.HandleFunc("/h", h)
+	m.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, loadUser(r).Name) // OK: not sensitive
+	})
+}
+
+// withUser is a middleware returning a handler literal
+func withUser(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		slog.Info("user", "password", loadUser(r).Password) // want `in argument 3 of slog.Info \[LH0004\]$`
+		next.ServeHTTP(w, r)
+	})
+}
+
+// name returns a non-sensitive value; the return of the literal is not its
+// own
+func name() string {
+	check := func() string { return admin.Password }
+	_ = check
+	return admin.Name
+}
+
+func logName() {
+	slog.Info("user", "name", name()) // OK: name does not return the password
+}
+
+// get returns the password through a literal it invokes
+func get(u User) string {
+	return func() string { return u.Password }()
+}
+
+// getNested returns it from a local of a literal invoked by a literal
+func getNested(u User) string {
+	return (func() string {
+		return func() string {
+			pw := u.Password
+			return pw
+		}()
+	})()
+}
+
+func logGet(u User) {
+	slog.Info("user", "password", get(u))       // want `in argument 3 of slog.Info \[LH0002\]$`
+	slog.Info("user", "password", getNested(u)) // want `in argument 3 of slog.Info \[LH0002\]$`
+}
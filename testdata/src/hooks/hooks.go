@@ -0,0 +1,105 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SlackHook posts every entry to a chat webhook
+type SlackHook struct {
+	url string
+}
+
+func (h *SlackHook) Levels() []logrus.Level { return nil }
+
+func (h *SlackHook) Fire(entry *logrus.Entry) error {
+	_, err := http.Post(h.url, "text/plain", strings.NewReader(entry.Message))
+	return err
+}
+
+// ShipHook ships the entry's fields to a log collector as JSON
+type ShipHook struct {
+	client *http.Client
+	url    string
+}
+
+func (h ShipHook) Levels() []logrus.Level { return nil }
+
+func (h ShipHook) Fire(entry *logrus.Entry) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(entry.Data); err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, h.url, &buf)
+	if err != nil {
+		return err
+	}
+	_, err = h.client.Do(req)
+	return err
+}
+
+// CountHook counts entries, and only reports the count
+type CountHook struct {
+	count int
+	url   string
+}
+
+func (h *CountHook) Levels() []logrus.Level { return nil }
+
+func (h *CountHook) Fire(entry *logrus.Entry) error {
+	h.count++
+	_, err := http.PostForm(h.url, url.Values{"count": {"1"}})
+	return err
+}
+
+// Producer publishes messages to a Kafka topic
+type Producer struct{}
+
+func (p *Producer) Send(topic string, value []byte) error { return nil }
+
+// KafkaHook publishes every entry to the log topic
+type KafkaHook struct {
+	producer *Producer
+}
+
+func (h *KafkaHook) Levels() []logrus.Level { return nil }
+
+func (h *KafkaHook) Fire(entry *logrus.Entry) error {
+	line, err := entry.String()
+	if err != nil {
+		return err
+	}
+	return h.producer.Send("logs", []byte(line))
+}
+
+func forwardEntry(e zapcore.Entry) error {
+	_, err := http.PostForm("https://logs.example.com", url.Values{"msg": {e.Message}})
+	return err
+}
+
+func countEntry(e zapcore.Entry) error { return nil }
+
+func setup(logger *logrus.Logger, producer *Producer) []zap.Option {
+	logrus.AddHook(&SlackHook{url: "https://hooks.example.com"}) // want `hook 'SlackHook.Fire' forwards log entries to http.Post, sending any sensitive data logged out of the process in argument 1 of logrus.AddHook`
+	logger.AddHook(ShipHook{client: http.DefaultClient})         // want `hook 'ShipHook.Fire' forwards log entries to http.NewRequest`
+	logger.AddHook(&CountHook{})
+	hook := &KafkaHook{producer: producer}
+	logger.AddHook(hook) // want `hook 'KafkaHook.Fire' forwards log entries to \(\*hooks.Producer\).Send`
+
+	return []zap.Option{
+		zap.Hooks(forwardEntry, countEntry), // want `hook 'forwardEntry' forwards log entries to http.PostForm, .* in argument 1 of zap.Hooks`
+		zap.Hooks(func(e zapcore.Entry) error { // want `hook 'func literal' forwards log entries to \(\*hooks.Producer\).Send`
+			return producer.Send("logs", []byte(e.Message))
+		}),
+		zap.Hooks(func(e zapcore.Entry) error {
+			return producer.Send("logs", []byte("entry dropped"))
+		}),
+	}
+}
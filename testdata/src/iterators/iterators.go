@@ -0,0 +1,99 @@
+package iterators
+
+import (
+	"iter"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+)
+
+type User struct {
+	Name     string
+	Secrets  []string          `sensitive:"true"`
+	Tokens   map[string]string `sensitive:"true"`
+	Aliases  []string
+	Recovery string `sensitive:"true"`
+}
+
+// Ranging over an iterator declared further down is tracked too
+func before(u User) {
+	for s := range u.AllSecrets() {
+		slog.Info("secret", "value", s) // want `variable "s" contains sensitive field "User.Secrets"`
+	}
+}
+
+// AllSecrets returns an iterator over the secrets of u
+func (u User) AllSecrets() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, s := range u.Secrets {
+			if !yield(s) {
+				return
+			}
+		}
+	}
+}
+
+// AllAliases yields nothing sensitive
+func (u User) AllAliases() iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, a := range u.Aliases {
+			if !yield(a) {
+				return
+			}
+		}
+	}
+}
+
+// AllTokens yields the service name, then its token
+func (u User) AllTokens() iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for service, token := range u.Tokens {
+			if !yield(service, token) {
+				return
+			}
+		}
+	}
+}
+
+// Each is an iterator itself, ranged over as a method value
+func (u User) Each(yield func(int, string) bool) {
+	for i, s := range u.Secrets {
+		if !yield(i, s) {
+			return
+		}
+	}
+}
+
+func iterators(u User) {
+	for s := range u.AllSecrets() {
+		slog.Info("secret", "value", s) // want `variable "s" contains sensitive field "User.Secrets"`
+	}
+	for a := range u.AllAliases() {
+		slog.Info("alias", "value", a)
+	}
+	for service, token := range u.AllTokens() {
+		slog.Info("token", "service", service)
+		slog.Info("token", "value", token) // want `variable "token" contains sensitive field "User.Tokens"`
+	}
+	for i, s := range u.Each {
+		slog.Info("secret", "index", i)
+		slog.Info("secret", "value", s) // want `variable "s" contains sensitive field "User.Secrets"`
+	}
+}
+
+// Standard library iterators over sensitive collections carry their taint
+func stdlib(u User) {
+	for s := range slices.Values(u.Secrets) {
+		slog.Info("secret", "value", s) // want `variable "s" contains sensitive field "User.Secrets"`
+	}
+	for _, token := range maps.All(u.Tokens) {
+		slog.Info("token", "value", token) // want `variable "token" contains sensitive field "User.Tokens"`
+	}
+	for code := range strings.FieldsSeq(u.Recovery) {
+		slog.Info("recovery", "code", code) // want `variable "code" contains sensitive field "User.Recovery"`
+	}
+	for name := range slices.Values(u.Aliases) {
+		slog.Info("alias", "value", name)
+	}
+}
@@ -0,0 +1,30 @@
+package keysinks
+
+import (
+	"context"
+	"fmt"
+
+	"keysinks/cache"
+	"keysinks/metrics"
+)
+
+type Session struct {
+	ID    string
+	Token string `sensitive:"true"`
+}
+
+func caching(ctx context.Context, rdb *cache.Client, s Session) {
+	rdb.Set(ctx, fmt.Sprintf("sess:%s", s.Token), s.ID) // want "sensitive field \"Session.Token\" is used in a cache key or metric name"
+	rdb.Set(ctx, fmt.Sprintf("sess:%s", s.ID), s.Token) // OK: values are not keys
+	rdb.Get(ctx, "sess:"+s.ID)
+
+	token := s.Token
+	rdb.Get(ctx, token) // want "sensitive field \"Session.Token\" is used in a cache key or metric name"
+}
+
+func metricNames(requests *metrics.CounterVec, s Session) {
+	metrics.NewCounter(metrics.CounterOpts{Name: "logins_" + s.Token}) // want "sensitive field \"Session.Token\" is used in a cache key or metric name"
+	metrics.NewCounter(metrics.CounterOpts{Name: "logins", Help: "Logins"})
+	requests.WithLabelValues("login", s.Token) // want "sensitive field \"Session.Token\" is used in a cache key or metric name"
+	requests.WithLabelValues("login", s.ID)
+}
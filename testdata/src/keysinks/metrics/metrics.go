@@ -0,0 +1,14 @@
+package metrics
+
+type CounterOpts struct {
+	Name string
+	Help string
+}
+
+type Counter struct{}
+
+type CounterVec struct{}
+
+func NewCounter(opts CounterOpts) *Counter { return &Counter{} }
+
+func (v *CounterVec) WithLabelValues(values ...string) *Counter { return &Counter{} }
@@ -0,0 +1,85 @@
+package lazyinit
+
+import (
+	"log/slog"
+	"sync"
+)
+
+type Config struct {
+	APIKey string `sensitive:"true"`
+	Region string
+}
+
+var cfg Config
+
+var (
+	once   sync.Once
+	cached string
+)
+
+// secret loads the key on first use
+func secret() string {
+	once.Do(func() {
+		cached = cfg.APIKey
+	})
+	return cached
+}
+
+var (
+	regionOnce sync.Once
+	region     string
+)
+
+func currentRegion() string {
+	regionOnce.Do(func() {
+		region = cfg.Region
+	})
+	return region
+}
+
+var (
+	tokenOnce sync.Once
+	token     string
+)
+
+// apiToken initializes through a named function declared further down
+func apiToken() string {
+	tokenOnce.Do(loadToken)
+	return token
+}
+
+// lazySecret is built with the Go 1.21 helpers
+var lazySecret = sync.OnceValue(func() string {
+	return cfg.APIKey
+})
+
+var lazyPair = sync.OnceValues(func() (string, error) {
+	return cfg.APIKey, nil
+})
+
+var lazyRegion = sync.OnceValue(func() string {
+	return cfg.Region
+})
+
+func handler() {
+	slog.Info("request", "key", secret())           // want "function call returns sensitive field \"Config.APIKey\""
+	slog.Info("request", "region", currentRegion()) // OK: not sensitive
+	slog.Info("request", "token", apiToken())       // want "function call returns sensitive field \"Config.APIKey\""
+	slog.Info("request", "key", lazySecret())       // want "function call returns sensitive field \"Config.APIKey\""
+	slog.Info("request", "region", lazyRegion())    // OK: not sensitive
+
+	key, err := lazyPair()
+	slog.Info("request", "key", key) // want "variable \"key\" contains sensitive field \"Config.APIKey\""
+	slog.Info("request", "err", err) // OK: not sensitive
+}
+
+func localGetter() {
+	get := sync.OnceValue(func() string {
+		return cfg.APIKey
+	})
+	slog.Info("request", "key", get()) // want "function call returns sensitive field \"Config.APIKey\""
+}
+
+func loadToken() {
+	token = cfg.APIKey
+}
@@ -0,0 +1,4 @@
+// Package audit is a logging subpackage nested under internal/log
+package audit
+
+func Infof(format string, args ...any) {}
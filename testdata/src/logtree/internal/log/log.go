@@ -0,0 +1,4 @@
+// Package log is one of the logging subpackages of a monorepo
+package log
+
+func Infof(format string, args ...any) {}
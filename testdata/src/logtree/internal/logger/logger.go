@@ -0,0 +1,5 @@
+// Package logger shares a path prefix with internal/log but is not one of its
+// subpackages
+package logger
+
+func Infof(format string, args ...any) {}
@@ -0,0 +1,24 @@
+package logtree
+
+import (
+	"logtree/internal/log"
+	"logtree/internal/log/audit"
+	"logtree/internal/logger"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+// "logtree/internal/log/..." matches the package and all its subpackages
+func subpackages(u User) {
+	log.Infof("login %s", u.Password)   // want `sensitive field 'User.Password' should not be logged`
+	audit.Infof("login %s", u.Password) // want `sensitive field 'User.Password' should not be logged`
+	audit.Infof("login %s", u.Name)
+}
+
+// A package sharing the path prefix is not a subpackage
+func siblings(u User) {
+	logger.Infof("login %s", u.Password)
+}
@@ -0,0 +1,22 @@
+package memorybudget
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func (u User) Secret() string {
+	return u.Password
+}
+
+// The package goes over its max-memory budget, so only direct accesses to
+// sensitive fields are reported
+func handle(u User) {
+	slog.Info("login", "password", u.Password) // want `sensitive field 'User.Password' should not be logged`
+	slog.Info("login", "user", u)              // want `struct 'User' contains sensitive fields`
+	password := u.Password
+	slog.Info("login", "password", password)
+	slog.Info("login", "password", u.Secret())
+}
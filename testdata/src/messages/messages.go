@@ -0,0 +1,14 @@
+package messages
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func login(u User) {
+	slog.Info("login", "password", u.Password) // want `User.Password reaches slog.Info: see https://wiki.example.com/runbooks/leaks#LH0004 \[LH0004\]`
+	pw := u.Password
+	slog.Info("login", "password", pw) // want `variable "pw" contains sensitive field .*\(file a ticket in SEC\) \[LH0001\]`
+}
@@ -0,0 +1,52 @@
+package methodvalues
+
+import (
+	"log"
+	"log/slog"
+	"os"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func methodValue(logger *slog.Logger, u User) {
+	f := logger.Info
+	f("login", "user", u.Name)
+	f("login", "pwd", u.Password) // want "sensitive field 'User.Password' should not be logged.* of \\(\\*slog.Logger\\).Info"
+}
+
+func stdLogger(u User) {
+	l := log.New(os.Stderr, "", 0)
+	printf := l.Printf
+	printf("password=%s", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func methodExpr(logger *slog.Logger, u User) {
+	info := (*slog.Logger).Info
+	info(logger, "login", "pwd", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+type service struct {
+	*slog.Logger
+}
+
+func promoted(s service, u User) {
+	warn := s.Warn
+	warn("login", "pwd", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func passed(logger *slog.Logger, u User) {
+	withLogger(logger.Info, u)
+}
+
+func withLogger(logFn func(string, ...any), u User) {
+	logFn("login", "pwd", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func notASink(logger *slog.Logger, u User) {
+	enabled := logger.Enabled
+	_ = enabled
+	_ = u
+}
@@ -0,0 +1,43 @@
+package pii
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type User struct {
+	ID       int
+	Name     string
+	Email    string
+	Phone    string `json:"phone"`
+	Address  string `pii:"true"`
+	Password string `sensitive:"true"`
+	// Reviewed: a keyed hash of the email address, not personal data
+	EmailHash string `pii:"false"`
+}
+
+type Contact struct {
+	Email  string
+	Mobile string
+}
+
+type Signup struct {
+	DateOfBirth string `pii:"true"`
+}
+
+func handle(u User, c Contact, s Signup) {
+	slog.Info("user", "id", u.ID, "name", u.Name, "hash", u.EmailHash)
+	slog.Info("user", "email", u.Email)       // want `personal data "User.Email" should not be logged in argument 3 of slog.Info \(matched default pattern 'e_\?mail'\)`
+	slog.Info("user", "phone", u.Phone)       // want `personal data "User.Phone" should not be logged in argument 3 of slog.Info \(matched default pattern 'phone'\)`
+	slog.Info("user", "address", u.Address)   // want `personal data "User.Address" should not be logged in argument 3 of slog.Info \[LH0009\]`
+	slog.Info("user", "password", u.Password) // want `sensitive field 'User.Password' should not be logged`
+
+	email := c.Email
+	slog.Info("contact", "email", email)                            // want `personal data "Contact.Email" should not be logged`
+	slog.Info("contact", "mobile", fmt.Sprintf("tel:%s", c.Mobile)) // want `personal data "Contact.Mobile" should not be logged`
+	slog.Info("contact", "contact", c)                              // want `personal data "Contact.Email" should not be logged`
+	slog.Info("signup", "signup", s)                                // want `personal data "Signup.DateOfBirth" should not be logged`
+
+	// A struct holding secrets is reported as one, whatever else it holds
+	slog.Info("user", "user", u) // want `struct 'User' contains sensitive fields`
+}
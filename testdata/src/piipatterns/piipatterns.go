@@ -0,0 +1,18 @@
+package piipatterns
+
+import "log/slog"
+
+type Payee struct {
+	Name     string
+	IBAN     string
+	Postcode string
+	Email    string // The config patterns replace the defaults
+	Country  string `pii:"true"`
+}
+
+func pay(p Payee) {
+	slog.Info("payee", "name", p.Name, "email", p.Email)
+	slog.Info("payee", "iban", p.IBAN)         // want `personal data "Payee.IBAN" should not be logged in argument 3 of slog.Info \(matched pattern '\^iban\$' declared in .leakhound.yaml\)`
+	slog.Info("payee", "postcode", p.Postcode) // want `personal data "Payee.Postcode" should not be logged in argument 3 of slog.Info \(matched pattern 'postcode' declared in .leakhound.yaml\)`
+	slog.Info("payee", "country", p.Country)   // want `personal data "Payee.Country" should not be logged in argument 3 of slog.Info \[LH0009\]`
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+type Config struct {
+	APIKey string `sensitive:"true"`
+	Region string
+}
+
+var cfg = Config{APIKey: "key", Region: "eu"}
+
+// Initialized from a helper declared further down the file
+var token = loadToken()
+
+// Depends on token, so Go initializes it after token
+var header = token
+
+var region = cfg.Region
+
+// Assigned in init, which runs after every initializer above
+var apiKey string
+
+var key, keyRegion = loadKey()
+
+func init() {
+	apiKey = cfg.APIKey
+}
+
+func main() {
+	slog.Info("starting", "token", token)      // want "variable \"token\" contains sensitive field \"Config.APIKey\""
+	slog.Info("starting", "header", header)    // want "variable \"header\" contains sensitive field \"Config.APIKey\""
+	slog.Info("starting", "region", region)    // OK: not sensitive
+	slog.Info("starting", "key", key)          // want "variable \"key\" contains sensitive field \"Config.APIKey\""
+	slog.Info("starting", "region", keyRegion) // OK: not sensitive
+}
+
+func handler(w http.ResponseWriter, r *http.Request) {
+	slog.Info("request", "key", apiKey) // want "variable \"apiKey\" contains sensitive field \"Config.APIKey\""
+}
+
+// currentKey returns a variable tainted during package initialization
+func currentKey() string {
+	return apiKey
+}
+
+func handlerViaGetter(w http.ResponseWriter, r *http.Request) {
+	slog.Info("request", "key", currentKey()) // want "function call returns sensitive field \"Config.APIKey\""
+}
+
+func loadToken() string {
+	return cfg.APIKey
+}
+
+func loadKey() (string, string) {
+	return cfg.APIKey, cfg.Region
+}
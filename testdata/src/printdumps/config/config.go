@@ -0,0 +1,36 @@
+package config
+
+import "time"
+
+// Config is the application configuration, with no sensitive tags
+type Config struct {
+	Addr         string
+	Env          string
+	DatabaseURL  string
+	RedisURL     string
+	SMTPPassword string
+	ReadTimeout  time.Duration
+}
+
+// Limits has too few fields to be reported
+type Limits struct {
+	MaxConns int
+	MaxBody  int
+}
+
+// Database is tagged, so printing it whole is reported under LH0003
+type Database struct {
+	Host     string
+	Port     int
+	User     string
+	Password string `sensitive:"true"`
+	Name     string
+	Options  string
+}
+
+// Redacted prints a redacted view of itself
+type Redacted struct {
+	A, B, C, D, E, F string
+}
+
+func (Redacted) String() string { return "config.Redacted{...}" }
@@ -0,0 +1,30 @@
+package printdumps
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"os"
+
+	"printdumps/config"
+)
+
+// server is not declared in a configuration package
+type server struct {
+	a, b, c, d, e, f string
+}
+
+func debugDumps(cfg config.Config, ptr *config.Config, db config.Database) {
+	fmt.Println(cfg)                      // want `configuration struct 'config.Config' with 6 fields is printed whole and may leak untagged secrets in argument 1 of fmt.Println`
+	fmt.Printf("config: %+v\n", ptr)      // want `configuration struct 'config.Config' with 6 fields is printed whole and may leak untagged secrets in argument 2 of fmt.Printf`
+	log.Println("loaded", cfg)            // want `configuration struct 'config.Config' with 6 fields is printed whole and may leak untagged secrets in argument 2 of log.Println`
+	log.New(os.Stderr, "", 0).Print(ptr)  // want `configuration struct 'config.Config' with 6 fields is printed whole and may leak untagged secrets in argument 1 of \(\*log.Logger\).Print`
+	fmt.Println(db)                       // want `struct 'Database' contains sensitive fields`
+	fmt.Println(config.Limits{})          // too few fields
+	fmt.Println(config.Redacted{})        // formats itself with String
+	fmt.Println(server{})                 // not a configuration package
+	fmt.Println(cfg.Addr)                 // a single field
+	slog.Info("config", "addr", cfg.Addr) // not a debugging print
+	fmt.Fprintln(os.Stderr, cfg)          // not a debugging print
+	_ = fmt.Sprint(cfg)                   // not printed
+}
@@ -0,0 +1,46 @@
+package producers
+
+import (
+	"context"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/IBM/sarama"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	"github.com/segmentio/kafka-go"
+)
+
+type User struct {
+	ID       string
+	Password string `sensitive:"true"`
+}
+
+type Session struct {
+	UserID string
+	Token  string `sensitive:"true"`
+}
+
+func publishSarama(p sarama.SyncProducer, u User) {
+	p.SendMessage(&sarama.ProducerMessage{Topic: "audit-log", Value: sarama.StringEncoder(u.Password)}) // want `sensitive data "User.Password" is published to a message queue feeding a logging pipeline in argument 1 of \(sarama.SyncProducer\).SendMessage`
+	p.SendMessage(&sarama.ProducerMessage{Topic: "audit-log", Value: sarama.StringEncoder(u.ID)})
+}
+
+func publishKafka(ctx context.Context, w *kafka.Writer, u User, s Session) {
+	w.WriteMessages(ctx,
+		kafka.Message{Value: []byte(u.ID)},
+		kafka.Message{Value: []byte(s.Token)}, // want `sensitive data "Session.Token" is published .* in argument 3 of \(\*kafka-go.Writer\).WriteMessages`
+	)
+	token := s.Token
+	w.WriteMessages(ctx, kafka.Message{Key: []byte(u.ID), Value: []byte(token)}) // want `sensitive data "Session.Token" is published`
+}
+
+func publishSQS(ctx context.Context, c *sqs.Client, u User) {
+	c.SendMessage(ctx, &sqs.SendMessageInput{MessageBody: aws.String(u.Password)}) // want `sensitive data "User.Password" is published .* in argument 2 of \(\*sqs.Client\).SendMessage`
+	c.SendMessage(ctx, &sqs.SendMessageInput{MessageBody: aws.String(u.ID)})
+}
+
+func publishPubSub(ctx context.Context, t *pubsub.Topic, u User) {
+	msg := &pubsub.Message{Data: []byte(u.Password)}
+	t.Publish(ctx, msg) // want `sensitive data "User.Password" is published .* in argument 2 of \(\*pubsub.Topic\).Publish`
+	t.Publish(ctx, &pubsub.Message{Data: []byte(u.ID)})
+}
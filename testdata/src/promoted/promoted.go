@@ -0,0 +1,81 @@
+package promoted
+
+import "log/slog"
+
+type Credentials struct {
+	Username string
+	Secret   string `sensitive:"true"`
+}
+
+// GetSecret is promoted to every type embedding Credentials.
+func (c Credentials) GetSecret() string {
+	return c.Secret
+}
+
+// GetSecretPtr has a pointer receiver.
+func (c *Credentials) GetSecretPtr() string {
+	return c.Secret
+}
+
+// GetUsername is not sensitive.
+func (c Credentials) GetUsername() string {
+	return c.Username
+}
+
+type Wrapper struct {
+	Credentials
+	Name string
+}
+
+type PtrWrapper struct {
+	*Credentials
+}
+
+type Outer struct {
+	Wrapper
+}
+
+// Token reads a field promoted from the embedded Credentials.
+func (w Wrapper) Token() string {
+	return w.Secret
+}
+
+type Box[T any] struct {
+	Value  T
+	Secret string `sensitive:"true"`
+}
+
+func (b Box[T]) Reveal() string {
+	return b.Secret
+}
+
+type BoxWrapper struct {
+	Box[int]
+}
+
+func promotedGetters(w Wrapper, pw PtrWrapper, o Outer) {
+	slog.Info("msg", "secret", w.GetSecret())             // want "function call returns sensitive field"
+	slog.Info("msg", "secret", w.GetSecretPtr())          // want "function call returns sensitive field"
+	slog.Info("msg", "secret", pw.GetSecret())            // want "function call returns sensitive field"
+	slog.Info("msg", "secret", o.GetSecret())             // want "function call returns sensitive field"
+	slog.Info("msg", "secret", w.Credentials.GetSecret()) // want "function call returns sensitive field"
+	slog.Info("msg", "user", w.GetUsername())
+
+	s := w.GetSecret()
+	slog.Info("msg", "secret", s) // want "variable \"s\" contains sensitive field"
+}
+
+func promotedFields(w Wrapper) {
+	slog.Info("msg", "secret", w.Token()) // want "function call returns sensitive field"
+	slog.Info("msg", "secret", w.Secret)  // want "sensitive field 'Credentials.Secret' should not be logged"
+}
+
+func genericGetters(b Box[string], bw BoxWrapper) {
+	slog.Info("msg", "secret", b.Reveal())  // want "function call returns sensitive field"
+	slog.Info("msg", "secret", bw.Reveal()) // want "function call returns sensitive field"
+}
+
+func methodValue(w Wrapper) {
+	get := w.GetSecret
+	slog.Info("msg", "secret", get())
+}
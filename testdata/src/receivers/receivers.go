@@ -0,0 +1,53 @@
+package receivers
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+type Config struct {
+	Host  string
+	Token string `sensitive:"true"`
+}
+
+// Dump logs the whole receiver.
+func (c *Config) Dump() {
+	log.Printf("%+v", *c) // want "struct 'Config' contains sensitive fields and should not be logged entirely"
+}
+
+// DumpValue logs a value receiver.
+func (c Config) DumpValue() {
+	slog.Info("config", "c", c) // want "struct 'Config' contains sensitive fields and should not be logged entirely"
+}
+
+// DumpAny loses the static type by storing the receiver in an interface.
+func (c *Config) DumpAny() {
+	var v any = *c
+	log.Printf("%v", v) // want "variable \"v\" contains sensitive field \"Config.Token\""
+}
+
+// DumpVia hands the receiver to a helper that logs its parameter.
+func (c Config) DumpVia() {
+	logValue(c)
+}
+
+func logValue(v any) {
+	slog.Info("value", "v", v) // want "variable \"v\" contains sensitive field \"Config.Token\""
+}
+
+// Describe only logs a non-sensitive field.
+func (c *Config) Describe() {
+	slog.Info("config", "host", c.Host)
+	fmt.Println(c.Host)
+}
+
+type Plain struct {
+	Name string
+}
+
+// Receivers without sensitive fields are not tainted.
+func (p Plain) Dump() {
+	var v any = p
+	log.Printf("%v", v)
+}
@@ -0,0 +1,35 @@
+package redactexpose
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/nilpoona/leakhound/redact"
+)
+
+type Config struct {
+	DSN      string
+	Password redact.Secret[string]
+}
+
+func connect(dsn, password string) {}
+
+func unwrapped(cfg *Config, token redact.Secret[string]) {
+	connect(cfg.DSN, cfg.Password.Expose()) // not a sink
+
+	slog.Info("connect", "password", cfg.Password)                 // the wrapper is safe
+	slog.Info("connect", "password", cfg.Password.Expose())        // want `secret "Config.Password" is unwrapped with Expose and should not be logged in argument 3 of slog.Info`
+	fmt.Println("token:", token.Expose())                          // want `secret "token" is unwrapped with Expose`
+	slog.Info("connect", "dsn", fmt.Sprintf("%s", token.Expose())) // want `secret "token" is unwrapped with Expose`
+
+	password := cfg.Password.Expose()
+	slog.Info("connect", "password", password) // want `secret "Config.Password" is unwrapped with Expose`
+}
+
+func exposed(cfg *Config) string {
+	return cfg.Password.Expose()
+}
+
+func helper(cfg *Config) {
+	slog.Info("connect", "password", exposed(cfg)) // want `secret "Config.Password" is unwrapped with Expose`
+}
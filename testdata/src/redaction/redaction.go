@@ -0,0 +1,106 @@
+package redaction
+
+import (
+	"fmt"
+	"log/slog"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// User renders itself without its password for both slog and zap
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func (u User) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("name", u.Name))
+}
+
+func (u User) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("name", u.Name)
+	return nil
+}
+
+// Leaky implements the interfaces but still writes its password
+type Leaky struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func (l *Leaky) LogValue() slog.Value {
+	return slog.StringValue(l.Password)
+}
+
+func (l *Leaky) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddString("password", l.Password)
+	return nil
+}
+
+// Copied, Converted and Returned hand their receiver on whole, so their
+// LogValue methods are treated as reading every field
+type Copied struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func (c Copied) LogValue() slog.Value {
+	d := c
+	return slog.StringValue(d.Password)
+}
+
+type Converted struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+type untagged struct {
+	Name     string
+	Password string
+}
+
+func (c Converted) LogValue() slog.Value {
+	return slog.AnyValue(untagged(c))
+}
+
+type Returned struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func (r *Returned) LogValue() slog.Value {
+	return slog.AnyValue(r)
+}
+
+// Plain has no redacted view
+type Plain struct {
+	Password string `sensitive:"true"`
+}
+
+func zapLogging(logger *zap.Logger, u User, l *Leaky, p Plain) {
+	logger.Info("login", zap.Object("user", u))
+	logger.Info("login", zap.Inline(u))
+	logger.Info("login", zap.Any("user", u))
+	logger.Info("login", zap.Object("user", l))        // want "struct 'Leaky' contains sensitive fields"
+	logger.Info("login", zap.Any("user", p))           // want "struct 'Plain' contains sensitive fields"
+	logger.Info("login", zap.String("pw", u.Password)) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func slogLogging(u User, l *Leaky, p Plain) {
+	slog.Info("login", "user", u)
+	slog.Info("login", slog.Any("user", u))
+	slog.Info("login", "user", l) // want "struct 'Leaky' contains sensitive fields"
+	slog.Info("login", "user", p) // want "struct 'Plain' contains sensitive fields"
+}
+
+func slogReceiverUses(c Copied, v Converted, r *Returned) {
+	slog.Info("login", "user", c) // want "struct 'Copied' contains sensitive fields"
+	slog.Info("login", "user", v) // want "struct 'Converted' contains sensitive fields"
+	slog.Info("login", "user", r) // want "struct 'Returned' contains sensitive fields"
+}
+
+func otherSinks(u User) {
+	// fmt does not call LogValue or MarshalLogObject
+	fmt.Println(u) // want "struct 'User' contains sensitive fields"
+}
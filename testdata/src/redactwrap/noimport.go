@@ -0,0 +1,7 @@
+package redactwrap
+
+import "log/slog"
+
+func noImport(u User) {
+	slog.Info("user", "password", u.Password) // want `sensitive field 'User.Password' should not be logged`
+}
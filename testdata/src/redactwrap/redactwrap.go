@@ -0,0 +1,50 @@
+package redactwrap
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/nilpoona/leakhound/redact"
+)
+
+// Fields of a redact wrapper type are safe to log, tagged or not
+type Account struct {
+	Name     string
+	Password redact.Secret[string] `sensitive:"true"`
+	APIKey   redact.Secret[string]
+}
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func wrapped(a Account, u User) {
+	slog.Info("account", "account", a)
+	slog.Info("account", "password", a.Password)
+	slog.Info("user", "password", redact.New(u.Password))
+	fmt.Println(fmt.Sprint(redact.New(u.Password)))
+
+	secret := redact.New(u.Password)
+	slog.Info("user", "password", secret)
+}
+
+func getPassword(u User) string {
+	return u.Password
+}
+
+// Sensitive values reaching a sink come with a fix wrapping them in redact.New
+func unwrapped(u User) {
+	slog.Info("user", "password", u.Password) // want `sensitive field 'User.Password' should not be logged`
+	password := u.Password
+	slog.Info("user", "password", password)      // want `variable "password" contains sensitive field "User.Password"`
+	fmt.Printf("password: %s\n", getPassword(u)) // want `function call returns sensitive field "User.Password"`
+	slog.Info("user", "user", u)                 // want `struct 'User' contains sensitive fields`
+}
+
+// Wrapping the value would not compile where a string is required: no fix
+func noFix(u User) {
+	slog.Info("user", slog.String("password", u.Password)) // want `sensitive field 'User.Password' should not be logged`
+	slog.Info("user", "password", "pw: "+u.Password)       // want `sensitive field 'User.Password' should not be logged`
+	slog.Info(u.Password)                                  // want `sensitive field 'User.Password' should not be logged`
+}
@@ -0,0 +1,22 @@
+// Package lh0001 covers LH0001: a variable holding a sensitive field is
+// logged.
+package lh0001
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func assigned(u User) {
+	password := u.Password
+	slog.Info("login", "password", password) // want `variable "password" contains sensitive field "User.Password"`
+
+	var copied string
+	copied = password
+	slog.Info("login", "password", copied) // want `variable "copied" contains sensitive field "User.Password"`
+
+	name := u.Name
+	slog.Info("login", "name", name)
+}
@@ -0,0 +1,33 @@
+// Package lh0002 covers LH0002: the result of a call returning a sensitive
+// field is logged.
+package lh0002
+
+import (
+	"log"
+	"log/slog"
+)
+
+type Config struct {
+	Host  string
+	Token string `sensitive:"true"`
+}
+
+var cfg Config
+
+func token() string {
+	return cfg.Token
+}
+
+func (c *Config) Credential() string {
+	return c.Token
+}
+
+func (c *Config) HostName() string {
+	return c.Host
+}
+
+func calls(c *Config) {
+	log.Printf("token: %s", token())             // want `function call returns sensitive field "Config.Token"`
+	slog.Info("config", "token", c.Credential()) // want `function call returns sensitive field "Config.Token"`
+	slog.Info("config", "host", c.HostName())
+}
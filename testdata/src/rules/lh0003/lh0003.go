@@ -0,0 +1,31 @@
+// Package lh0003 covers LH0003: a struct with sensitive fields is logged as
+// a whole.
+package lh0003
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type User struct {
+	ID       int
+	Password string `sensitive:"true"`
+}
+
+// Session embeds the sensitive fields of User
+type Session struct {
+	User
+	Key string
+}
+
+type Page struct {
+	Number int
+}
+
+func structs(u User, p *User, s Session, page Page) {
+	slog.Info("user", "user", u) // want `struct 'User' contains sensitive fields and should not be logged entirely`
+	fmt.Println(p)               // want `struct 'User' contains sensitive fields`
+	fmt.Printf("%+v\n", s)       // want `struct 'Session' contains sensitive fields`
+	slog.Info("user", "id", u.ID)
+	slog.Info("page", "page", page)
+}
@@ -0,0 +1,22 @@
+// Package lh0004 covers LH0004: a sensitive field is logged directly.
+package lh0004
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type Credentials struct {
+	Username string
+	Password string `sensitive:"true"`
+}
+
+type Request struct {
+	Credentials Credentials
+}
+
+func fields(c Credentials, r *Request) {
+	fmt.Println("password:", c.Password)                   // want `sensitive field 'Credentials.Password' should not be logged`
+	slog.Info("login", "password", r.Credentials.Password) // want `sensitive field 'Credentials.Password' should not be logged`
+	slog.Info("login", "user", c.Username)
+}
@@ -0,0 +1,10 @@
+// Package lh0007 covers LH0007: a field that looks sensitive is not tagged.
+// The audit is enabled by the package's .leakhound.yaml.
+package lh0007
+
+type Account struct {
+	Name          string
+	APIKey        string // want `field 'Account.APIKey' looks sensitive but is not tagged with sensitive:"true"`
+	Password      string `sensitive:"true"`
+	NextPageToken string `sensitive:"false"`
+}
@@ -0,0 +1,7 @@
+package cache
+
+import "context"
+
+type Client struct{}
+
+func (c *Client) Set(ctx context.Context, key string, value any) error { return nil }
@@ -0,0 +1,22 @@
+// Package lh0008 covers LH0008: a sensitive value is used in a key passed
+// to a key sink, configured in the package's .leakhound.yaml. The fix
+// wrapping the value in redact.New is not offered, as it would change the
+// key.
+package lh0008
+
+import (
+	"context"
+	"fmt"
+
+	"rules/lh0008/cache"
+)
+
+type Session struct {
+	ID    string
+	Token string `sensitive:"true"`
+}
+
+func keys(ctx context.Context, rdb *cache.Client, s Session) {
+	rdb.Set(ctx, fmt.Sprintf("sess:%s", s.Token), s.ID) // want `sensitive field "Session.Token" is used in a cache key or metric name`
+	rdb.Set(ctx, fmt.Sprintf("sess:%s", s.ID), s.Token)
+}
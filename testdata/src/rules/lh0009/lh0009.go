@@ -0,0 +1,18 @@
+// Package lh0009 covers LH0009: personal data is logged. PII mode is
+// enabled by the package's .leakhound.yaml.
+package lh0009
+
+import "log/slog"
+
+type User struct {
+	ID      int
+	Email   string
+	Address string `pii:"true"`
+	Hash    string `pii:"false"`
+}
+
+func personal(u User) {
+	slog.Info("signup", "email", u.Email)     // want `personal data "User.Email" should not be logged`
+	slog.Info("signup", "address", u.Address) // want `personal data "User.Address" should not be logged`
+	slog.Info("signup", "id", u.ID, "hash", u.Hash)
+}
@@ -0,0 +1,19 @@
+// Package lh0010 covers LH0010: an Authorization header or a JWT is logged,
+// whatever the struct tags say.
+package lh0010
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+func headers(r *http.Request) {
+	slog.Info("request", "auth", r.Header.Get("Authorization")) // want `Authorization header carries a bearer credential`
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	slog.Info("request", "token", token) // want `Authorization header carries a bearer credential`
+
+	slog.Info("request", "agent", r.Header.Get("User-Agent"))
+	slog.Info("request", "authenticated", r.Header.Get("Authorization") != "")
+}
@@ -0,0 +1,18 @@
+// Package lh0011 covers LH0011: a secret unwrapped from redact.Secret is
+// logged.
+package lh0011
+
+import (
+	"log/slog"
+
+	"github.com/nilpoona/leakhound/redact"
+)
+
+type Config struct {
+	Password redact.Secret[string]
+}
+
+func exposed(cfg Config) {
+	slog.Info("connect", "password", cfg.Password.Expose()) // want `secret "Config.Password" is unwrapped with Expose`
+	slog.Info("connect", "password", cfg.Password)
+}
@@ -0,0 +1,14 @@
+// Package lh0012 covers LH0012: a flag declared sensitive is logged.
+package lh0012
+
+import (
+	"flag"
+	"log/slog"
+)
+
+var dbPassword = flag.String("db-password", "", "database password")
+
+func starting() {
+	slog.Info("starting", "db-password", *dbPassword) // want `flag -db-password is declared sensitive`
+	slog.Info("starting", "db-password-set", *dbPassword != "")
+}
@@ -0,0 +1,17 @@
+// Package lh0013 covers LH0013: a configuration struct is printed whole.
+// The audit is enabled by the package's .leakhound.yaml.
+package lh0013
+
+import (
+	"fmt"
+
+	"rules/lh0013/settings"
+)
+
+func bad(s settings.Settings) {
+	fmt.Println(s) // want `configuration struct 'settings.Settings' with 6 fields is printed whole and may leak untagged secrets in argument 1 of fmt.Println`
+}
+
+func good(s settings.Settings) {
+	fmt.Println(s.Addr, s.Env)
+}
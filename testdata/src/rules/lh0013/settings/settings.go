@@ -0,0 +1,5 @@
+package settings
+
+type Settings struct {
+	Addr, Env, DatabaseURL, RedisURL, SMTPUser, SMTPPassword string
+}
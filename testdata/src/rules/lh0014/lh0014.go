@@ -0,0 +1,20 @@
+// Package lh0014 covers LH0014: a String method returns a sensitive field.
+package lh0014
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func (u User) String() string {
+	return u.Name + ":" + u.Password // want `String method of 'User' returns sensitive data from 'User.Password', so every log of the type leaks it`
+}
+
+type Account struct {
+	Name  string
+	Token string `sensitive:"true"`
+}
+
+func (a Account) String() string {
+	return a.Name + ":[REDACTED]"
+}
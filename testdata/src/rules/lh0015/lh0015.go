@@ -0,0 +1,9 @@
+// Package lh0015 covers LH0015: a boundary package accepts a sensitive type.
+// The package is declared a boundary by its .leakhound.yaml.
+package lh0015
+
+import "rules/lh0015/models"
+
+func Track(event string, u *models.User) {} // want `Track in boundary package 'rules/lh0015' accepts parameter 'u' of sensitive type 'models.User'`
+
+func TrackUserID(event string, userID string) {}
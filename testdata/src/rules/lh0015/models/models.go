@@ -0,0 +1,6 @@
+package models
+
+type User struct {
+	ID       string
+	Password string `sensitive:"true"`
+}
@@ -0,0 +1,26 @@
+// Package lh0016 covers LH0016: a logger hook forwards log entries to an
+// external sink. logrus.AddHook is declared a registration by its
+// .leakhound.yaml.
+package lh0016
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+type WebhookHook struct {
+	url string
+}
+
+func (h *WebhookHook) Levels() []logrus.Level { return nil }
+
+func (h *WebhookHook) Fire(entry *logrus.Entry) error {
+	_, err := http.Post(h.url, "text/plain", strings.NewReader(entry.Message))
+	return err
+}
+
+func setup() {
+	logrus.AddHook(&WebhookHook{url: "https://hooks.example.com"}) // want `hook 'WebhookHook.Fire' forwards log entries to http.Post`
+}
@@ -0,0 +1,20 @@
+// Package lh0017 covers LH0017: sensitive data is published to a message
+// queue feeding a logging pipeline. The kafka-go writer is declared a
+// producer sink by its .leakhound.yaml.
+package lh0017
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+)
+
+type Session struct {
+	UserID string
+	Token  string `sensitive:"true"`
+}
+
+func publish(ctx context.Context, w *kafka.Writer, s Session) {
+	w.WriteMessages(ctx, kafka.Message{Value: []byte(s.Token)}) // want `sensitive data "Session.Token" is published to a message queue feeding a logging pipeline`
+	w.WriteMessages(ctx, kafka.Message{Value: []byte(s.UserID)})
+}
@@ -0,0 +1,17 @@
+// Package lh0019 covers LH0019: a crash handler dumping the stack logs a
+// local variable with a sensitive-looking name. The audit is enabled by the
+// package's .leakhound.yaml.
+package lh0019
+
+import (
+	"log"
+	"runtime/debug"
+)
+
+func handle(requestID, token string) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("panic: %v request=%s token=%s\n%s", r, requestID, token, debug.Stack()) // want `local variable 'token' is logged by a crash handler dumping the stack with debug.Stack in argument 4 of log.Printf \(matched default pattern 'token'\)`
+		}
+	}()
+}
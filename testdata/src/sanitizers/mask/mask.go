@@ -0,0 +1,10 @@
+// Package mask is a stand-in for a shared redaction helper package
+package mask
+
+type Redactor struct{}
+
+func (r *Redactor) Mask(s string) string   { return "***" }
+func (r *Redactor) Hash(s string) string   { return "sha256:..." }
+func (r *Redactor) Reveal(s string) string { return s }
+
+func Hash(s string) string { return "sha256:..." }
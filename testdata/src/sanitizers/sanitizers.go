@@ -0,0 +1,44 @@
+package sanitizers
+
+import (
+	"log/slog"
+
+	"sanitizers/mask"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func (u User) MaskedPassword() string {
+	return "***" + u.Password[len(u.Password)-2:]
+}
+
+func (u User) PasswordSuffix() string {
+	return u.Password[len(u.Password)-2:]
+}
+
+func fingerprint(s string) string {
+	return "fp:" + s[:4]
+}
+
+// Configured sanitizer functions and methods clear the taint of what flows in
+func sanitized(r *mask.Redactor, u User) {
+	slog.Info("login", "password", r.Mask(u.Password))
+	slog.Info("login", "password", r.Hash(u.Password))
+	slog.Info("login", "password", mask.Hash(u.Password))
+	slog.Info("login", "password", fingerprint(u.Password))
+	slog.Info("login", "password", u.MaskedPassword())
+
+	masked := r.Mask(u.Password)
+	slog.Info("login", "password", masked)
+	suffix := u.MaskedPassword()
+	slog.Info("login", "password", suffix)
+}
+
+// Other methods of the same receivers are not sanitizers
+func unsanitized(r *mask.Redactor, u User) {
+	slog.Info("login", "password", r.Reveal(u.Password)) // want `sensitive field 'User.Password' should not be logged`
+	slog.Info("login", "password", u.PasswordSuffix())   // want `function call returns sensitive field "User.Password"`
+}
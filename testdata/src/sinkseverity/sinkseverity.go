@@ -0,0 +1,34 @@
+package sinkseverity
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+	"testing"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+	Email    string `sensitive:"true,level=warning"`
+}
+
+func production(u User) {
+	slog.Info("login", "password", u.Password) // want `in argument 3 of slog.Info \(error\) \[LH0004\]$`
+}
+
+func tagLevel(u User) {
+	slog.Info("login", "email", u.Email) // want `in argument 3 of slog.Info \(warning\) \[LH0004\]$`
+}
+
+func debugging(u User) {
+	fmt.Println("password:", u.Password) // want `in argument 2 of fmt.Println \(warning\) \[LH0004\]$`
+}
+
+func unlisted(u User) {
+	log.Printf("password: %s", u.Password) // want `in argument 2 of log.Printf \(error\) \[LH0004\]$`
+}
+
+func testHelper(t *testing.T, u User) {
+	t.Logf("password: %s", u.Password) // want `in argument 2 of \(\*testing.common\).Logf \(note\) \[LH0004\]$`
+}
@@ -0,0 +1,75 @@
+package slogroles
+
+import (
+	"context"
+	"log/slog"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+const masked = "***masked***"
+
+// Keys, messages and literal values never carry field data, even when they
+// read like secrets
+func constants(ctx context.Context, l *slog.Logger) {
+	slog.Info("login", "password", "***masked***")
+	slog.Info("password reset", "password", masked)
+	slog.InfoContext(ctx, "login", "password", "***")
+	l.Log(ctx, slog.LevelInfo, "login", "password", "***")
+	l.With("password", "***").Info("login")
+	slog.Info("login", slog.String("password", "***"))
+	slog.Info("login", "password")
+}
+
+// Values are still checked in every position slog writes them
+func values(ctx context.Context, l *slog.Logger, u User) {
+	slog.Info("login", "password", masked, "user", u.Password)              // want "sensitive field 'User.Password' should not be logged"
+	slog.InfoContext(ctx, "login", "password", u.Password)                  // want "sensitive field 'User.Password' should not be logged"
+	l.Log(ctx, slog.LevelInfo, "login", "password", u.Password)             // want "sensitive field 'User.Password' should not be logged"
+	l.LogAttrs(ctx, slog.LevelInfo, "login", slog.String("pw", u.Password)) // want "sensitive field 'User.Password' should not be logged"
+	l.With("password", u.Password).Info("login")                            // want "sensitive field 'User.Password' should not be logged"
+	slog.Info("login", u.Password)                                          // want "sensitive field 'User.Password' should not be logged"
+	slog.Info(u.Password)                                                   // want "sensitive field 'User.Password' should not be logged"
+}
+
+// slog writes keys out verbatim, so a sensitive value used as a key is
+// still reported
+func sensitiveKey(u User) {
+	slog.Info("login", u.Password, "value") // want "sensitive field 'User.Password' should not be logged"
+}
+
+// authContext is a context carrying a credential; slog never writes the
+// context of a record
+type authContext struct {
+	context.Context
+	Token string `sensitive:"true"`
+}
+
+// The context and level arguments of Log and LogAttrs are not written
+func contextAndLevel(ctx authContext, l *slog.Logger, u User) {
+	level := slog.Level(len(u.Password))
+	l.Log(ctx, level, "login")
+	l.LogAttrs(ctx, slog.Level(len(u.Password)), "login")
+	slog.Log(ctx, level, "login", "name", u.Name)
+	slog.InfoContext(ctx, "login")
+	l.Log(ctx, level, "login", "password", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+// Attr slices spread into LogAttrs are unpacked element by element, however
+// they are built
+func attrSlices(ctx context.Context, l *slog.Logger, u User) {
+	l.LogAttrs(ctx, slog.LevelInfo, "login", []slog.Attr{slog.String("name", u.Name), slog.String("pw", u.Password)}...) // want "sensitive field 'User.Password' should not be logged"
+
+	var attrs []slog.Attr
+	attrs = append(attrs, slog.String("name", u.Name))
+	attrs = append(attrs, slog.String("pw", u.Password))
+	l.LogAttrs(ctx, slog.LevelInfo, "login", attrs...) // want `variable "attrs" contains sensitive field "User.Password"`
+
+	pw := slog.String("pw", u.Password)
+	more := append([]slog.Attr{slog.Int("n", 1)}, pw)
+	l.LogAttrs(ctx, slog.LevelInfo, "login", more...)                                     // want `variable "more" contains sensitive field "User.Password"`
+	l.LogAttrs(ctx, slog.LevelInfo, "login", append(attrs[:0], slog.Bool("ok", true))...) // want `variable "attrs" contains sensitive field "User.Password"`
+}
@@ -0,0 +1,56 @@
+package spreadcalls
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func literal(user User) {
+	args := []any{"user", user.Name, "pwd", user.Password}
+	slog.Info("login", args...) // want `variable "args" contains sensitive field "User.Password"`
+
+	vals := []any{user.Name, user.Password}
+	fmt.Printf("%s %s\n", vals...) // want `variable "vals" contains sensitive field "User.Password"`
+
+	safe := []any{"user", user.Name}
+	slog.Info("login", safe...)
+}
+
+func elementStore(user User) {
+	pwd := user.Password
+	args := make([]any, 2)
+	args[0] = "pwd"
+	args[1] = pwd
+	slog.Info("login", args...) // want `variable "args" contains sensitive field "User.Password"`
+
+	vals := make([]any, 1)
+	vals[0] = user.Password
+	log.Println(vals...) // want `variable "vals" contains sensitive field "User.Password"`
+
+	safe := make([]any, 2)
+	safe[0], safe[1] = "user", user.Name
+	log.Println(safe...)
+}
+
+func appended(user User) {
+	var args []any
+	for _, s := range []string{user.Password} {
+		args = append(args, s)
+	}
+	slog.Info("login", args...) // want `variable "args" contains sensitive field "User.Password"`
+}
+
+// event spreads its trailing arguments into the log call
+func event(msg string, args ...any) {
+	slog.Info(msg, args...) // want `variable "args" contains sensitive field "User.Password"`
+}
+
+func callsEvent(user User) {
+	event("login", "user", user.Name, "pwd", user.Password)
+}
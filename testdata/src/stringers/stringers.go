@@ -0,0 +1,101 @@
+package stringers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+// Hand-written String formatting a sensitive field
+func (u User) String() string {
+	return fmt.Sprintf("%s:%s", u.Name, u.Password) // want `String method of 'User' returns sensitive data from 'User.Password', so every log of the type leaks it`
+}
+
+// GoString on a pointer receiver, through a local variable
+func (u *User) GoString() string {
+	pw := u.Password
+	return "User{" + u.Name + ", " + pw + "}" // want `GoString method of 'User' returns sensitive data from 'User.Password', so every log of the type leaks it`
+}
+
+type Session struct {
+	ID    string
+	Token string `sensitive:"true"`
+}
+
+// Format writing a sensitive field to its state
+func (s Session) Format(f fmt.State, verb rune) {
+	fmt.Fprintf(f, "session %s ", s.ID)
+	fmt.Fprintf(f, "token %s", s.Token) // want `sensitive field 'Session.Token' should not be logged`
+	io.WriteString(f, s.Token)          // want `Format method of 'Session' writes sensitive data from 'Session.Token', so every log of the type leaks it`
+	f.Write([]byte(s.Token))            // want `Format method of 'Session' writes sensitive data from 'Session.Token', so every log of the type leaks it`
+}
+
+type Key struct {
+	ID     string
+	Secret string `sensitive:"true"`
+}
+
+// Redacted views are fine
+func (k Key) String() string {
+	return k.ID + ":" + strings.Repeat("*", len("secret"))
+}
+
+// Values used by a formatting method without being rendered are fine
+func (k Key) GoString() string {
+	if k.Secret == "" {
+		return "Key{" + k.ID + ", unset}"
+	}
+	return "Key{" + k.ID + ", set}"
+}
+
+type Credentials struct {
+	User     string
+	Password string `sensitive:"true"`
+}
+
+// Function literals return their own results
+func (c Credentials) String() string {
+	check := func() string { return c.Password }
+	_ = check
+	return c.User
+}
+
+// Format writing to another writer than its state is not checked here
+func (c Credentials) Format(f fmt.State, verb rune) {
+	var b strings.Builder
+	b.WriteString(c.Password)
+	fmt.Fprint(f, c.User)
+}
+
+// Not a fmt.Stringer: String takes an argument
+type Masker struct {
+	Secret string `sensitive:"true"`
+}
+
+func (m Masker) String(n int) string {
+	return m.Secret[:n]
+}
+
+type APIKey struct {
+	Name  string
+	Value string `sensitive:"true"`
+}
+
+// MarshalText is used by slog's handlers and encoding/json map keys
+func (k APIKey) MarshalText() ([]byte, error) {
+	return []byte(k.Name + "=" + k.Value), nil // want `MarshalText method of 'APIKey' returns sensitive data from 'APIKey.Value', so every log of the type leaks it`
+}
+
+type Token struct {
+	Value string `sensitive:"true"`
+}
+
+// Redacted text
+func (t Token) MarshalText() ([]byte, error) {
+	return []byte("[REDACTED]"), nil
+}
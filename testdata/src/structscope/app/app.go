@@ -0,0 +1,28 @@
+package app
+
+import (
+	"log/slog"
+
+	"example.com/structscope/model"
+	"example.com/vendorsdk"
+)
+
+type Session struct {
+	ID    string
+	Token string `sensitive:"true"`
+}
+
+// With struct-rule-scope: module, whole structs declared in the module are
+// reported, whichever package declares them
+func moduleStructs(s Session, u model.User, users []model.User) {
+	slog.Info("session", "session", s) // want `struct 'Session' contains sensitive fields`
+	slog.Info("user", "user", u)       // want `struct 'User' contains sensitive fields`
+	slog.Info("users", "users", users) // want `logged value contains type 'User' with sensitive fields`
+}
+
+// Third-party structs are not, but their sensitive fields still are
+func thirdPartyStructs(c vendorsdk.Credentials, all []vendorsdk.Credentials) {
+	slog.Info("credentials", "credentials", c)
+	slog.Info("credentials", "credentials", all)
+	slog.Info("credentials", "key", c.Key) // want `sensitive field 'Credentials.Key' should not be logged`
+}
@@ -0,0 +1,7 @@
+module example.com/structscope
+
+go 1.21
+
+require example.com/vendorsdk v0.0.0
+
+replace example.com/vendorsdk => ./third_party/vendorsdk
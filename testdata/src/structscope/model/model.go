@@ -0,0 +1,6 @@
+package model
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
@@ -0,0 +1,3 @@
+module example.com/vendorsdk
+
+go 1.21
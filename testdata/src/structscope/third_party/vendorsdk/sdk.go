@@ -0,0 +1,7 @@
+// Package vendorsdk is a third-party SDK whose types tag their own secrets
+package vendorsdk
+
+type Credentials struct {
+	ID  string
+	Key string `sensitive:"true"`
+}
@@ -53,4 +53,25 @@ func aliasFieldAccess(a AliasUser) {
 	slog.Info("a", "pw", a.Password)
 }
 
+// Session is itself tagged and holds tagged fields, so a selector chain
+// through it reaches two tagged fields.
+type Session struct {
+	Key   string `sensitive:"true"`
+	Owner string
+}
+
+type Account struct {
+	Session Session `sensitive:"true"`
+}
+
+func taggedChain(a Account) {
+	// Only the leaf is reported: Account.Session is the path to Session.Key,
+	// not a second leak.
+	slog.Info("x", "key", a.Session.Key)              // want `sensitive field 'Session.Key' should not be logged`
+	slog.Info("x", slog.String("key", a.Session.Key)) // want `sensitive field 'Session.Key' should not be logged`
+
+	// An untagged leaf still reveals part of the tagged Session.
+	slog.Info("x", "owner", a.Session.Owner) // want `sensitive field 'Account.Session' should not be logged`
+}
+
 func main() {}
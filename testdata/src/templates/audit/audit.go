@@ -0,0 +1,6 @@
+package audit
+
+import "io"
+
+// Log is the audit trail writer
+var Log io.Writer
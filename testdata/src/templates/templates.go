@@ -0,0 +1,70 @@
+package templates
+
+import (
+	"bytes"
+	htmltemplate "html/template"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"text/template"
+
+	"templates/audit"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+var greeting = template.Must(template.New("greeting").Parse("Hello {{.Name}}\n"))
+
+func execute(u User, logger *log.Logger) {
+	greeting.Execute(os.Stdout, u)                                  // want "struct 'User' contains sensitive fields and should not be logged entirely"
+	greeting.Execute(os.Stderr, map[string]string{"p": u.Password}) // want "sensitive field 'User.Password' should not be logged"
+	greeting.ExecuteTemplate(logger.Writer(), "greeting", &u)       // want "struct 'User' contains sensitive fields and should not be logged entirely"
+	greeting.Execute(log.Writer(), u.Name)
+	(*template.Template).Execute(greeting, os.Stdout, u) // want "struct 'User' contains sensitive fields and should not be logged entirely"
+
+	// Only the data is written
+	greeting.ExecuteTemplate(os.Stdout, u.Password, u.Name)
+}
+
+func respond(w http.ResponseWriter, u User) {
+	page := htmltemplate.Must(htmltemplate.New("page").Parse("<p>{{.Name}}</p>"))
+	page.Execute(w, u)                                     // want "struct 'User' contains sensitive fields and should not be logged entirely"
+	page.Execute(io.MultiWriter(w, os.Stdout), u.Password) // want "sensitive field 'User.Password' should not be logged"
+	page.Execute(w, u.Name)
+}
+
+func configured(u User) {
+	greeting.Execute(audit.Log, u) // want "struct 'User' contains sensitive fields and should not be logged entirely"
+}
+
+func otherWriters(w io.Writer, u User) {
+	var buf bytes.Buffer
+	greeting.Execute(&buf, u) // OK: not a sink writer
+	greeting.Execute(w, u)    // OK: unknown writer
+}
+
+func lookup(key string) string {
+	return key
+}
+
+func expand(u User) {
+	secret := func(key string) string {
+		if key == "PASSWORD" {
+			return u.Password
+		}
+		return ""
+	}
+	dsn := os.Expand("postgres://app:${PASSWORD}@db/app", secret)
+	log.Println(dsn) // want "variable \"dsn\" contains sensitive field \"User.Password\""
+
+	header := os.Expand("Basic $PASSWORD", func(string) string { return u.Password })
+	log.Println(header) // want "variable \"header\" contains sensitive field \"User.Password\""
+
+	log.Println(os.Expand("$PASSWORD", secret)) // want "function call returns sensitive field \"User.Password\""
+	log.Println(os.Expand("$NAME", lookup))
+	log.Println(os.Expand("$NAME", func(string) string { return u.Name }))
+}
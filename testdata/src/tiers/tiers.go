@@ -0,0 +1,15 @@
+package tiers
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func login(u User) {
+	slog.Info("login", "password", u.Password) // want `tier 1: sensitive field 'User.Password' should not be logged`
+	pw := u.Password
+	slog.Info("login", "password", pw) // want `tier 1: variable "pw" contains sensitive field .* \[LH0001\]`
+	slog.Info("login", "user", u)
+}
@@ -11,16 +11,22 @@ type User struct {
 	Password string `sensitive:"true"`
 }
 
-// These cases document a KNOWN LIMITATION: taint is not propagated through
-// transformation functions (fmt.Sprintf, strings.ToUpper, etc.). The return
-// value of such a call is treated as clean even when a sensitive field flowed
-// in. They are written with no expectation comment so the test asserts "no
-// diagnostic" today and trips if data flow through stdlib transforms is ever
-// added.
+// Taint is carried through fmt.Sprint*, string concatenation and the
+// strings.Trim*/Cut* prefix and suffix helpers, the usual ways of assembling
+// DSNs and URLs and extracting tokens, but not through other transformation
+// functions (strings.ToUpper, etc.): their return value is treated as clean
+// even when a sensitive field flowed in. The ToUpper case is written with no
+// expectation comment so the test asserts "no diagnostic" today and trips if
+// data flow through such transforms is ever added.
 
 func throughSprintf(u User) {
 	s := fmt.Sprintf("%s", u.Password)
-	slog.Info("x", "s", s) // not detected: taint lost through Sprintf
+	slog.Info("x", "s", s) // want `variable "s" contains sensitive field "User.Password"`
+}
+
+func throughTrimPrefix(u User) {
+	s := strings.TrimPrefix(u.Password, "pw:")
+	slog.Info("x", "s", s) // want `variable "s" contains sensitive field "User.Password"`
 }
 
 func throughToUpper(u User) {
@@ -30,12 +36,12 @@ func throughToUpper(u User) {
 
 func throughConcat(u User) {
 	s := "pw=" + u.Password
-	slog.Info("x", "s", s) // not detected: taint lost through string concat
+	slog.Info("x", "s", s) // want `variable "s" contains sensitive field "User.Password"`
 }
 
 // Counter-positive: passing the sensitive field DIRECTLY (no transform) is
-// still detected, proving the gap above is specific to the transform, not the
-// surrounding code.
+// still detected, proving the ToUpper gap above is specific to the transform,
+// not the surrounding code.
 func directNoTransform(u User) {
 	slog.Info("x", "pw", u.Password) // want `sensitive field 'User.Password' should not be logged`
 }
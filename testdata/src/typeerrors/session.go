@@ -0,0 +1,8 @@
+package typeerrors
+
+import "log/slog"
+
+// This file does not type-check, so it is skipped instead of the package
+func newSession(u User) {
+	slog.Info("session", "id", sessionID(), "password", u.Password)
+}
@@ -0,0 +1,16 @@
+package typeerrors
+
+import "log/slog"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+// hash is implemented in assembly
+func hash(s string) uint64
+
+func login(u User) {
+	slog.Info("login", "password", u.Password) // want "sensitive field 'User.Password' should not be logged"
+	slog.Info("login", "name", hash(u.Name))
+}
@@ -0,0 +1,67 @@
+package typeswitch
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+type Safe struct {
+	Name string
+}
+
+// A tainted interface value keeps its taint in every clause binding.
+func taintedInterface(u User) {
+	var x any = u.Password
+	switch v := x.(type) {
+	case string:
+		slog.Info("string", "v", v) // want `variable "v" contains sensitive field "User.Password"`
+	case fmt.Stringer:
+		slog.Info("stringer", "v", v) // want `variable "v" contains sensitive field "User.Password"`
+	default:
+		slog.Info("other", "v", v) // want `variable "v" contains sensitive field "User.Password"`
+	}
+}
+
+// A concrete case type with sensitive fields is reported as a struct when
+// logged directly, and stays tainted when passed on as an interface.
+func concreteCaseType(x any) {
+	switch v := x.(type) {
+	case User:
+		slog.Info("user", "v", v) // want `struct 'User' contains sensitive fields`
+		logValue(v)
+	case *User:
+		slog.Info("user", "pw", v.Password) // want `sensitive field 'User.Password' should not be logged`
+	case Safe:
+		slog.Info("safe", "v", v)
+	}
+}
+
+func logValue(v any) {
+	slog.Info("value", "v", v) // want `variable "v" contains sensitive field "User.Password"`
+}
+
+// Parenthesized and dereferenced operands are unwrapped.
+func derefOperand(u User) {
+	p := &u.Password
+	switch v := any(*p).(type) {
+	case string:
+		slog.Info("deref", "v", v) // want `variable "v" contains sensitive field "User.Password"`
+	}
+}
+
+// Type switches without a binding, or over untainted values, are safe.
+func safeSwitches(x any, s Safe) {
+	switch x.(type) {
+	case string:
+		slog.Info("no binding")
+	}
+	switch v := any(s.Name).(type) {
+	case string:
+		slog.Info("safe", "v", v)
+	}
+}
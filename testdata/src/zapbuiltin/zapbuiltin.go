@@ -0,0 +1,50 @@
+// Package zapbuiltin checks the built-in go.uber.org/zap sinks, without a
+// config file
+package zapbuiltin
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+	APIKey   string `sensitive:"true"`
+}
+
+type AppLogger struct {
+	*zap.Logger
+}
+
+func logger(l *zap.Logger, u User) {
+	l.Info("login", zap.String("user", u.Name))
+	l.Info("login", zap.String("password", u.Password))                // want "sensitive field 'User.Password' should not be logged"
+	l.Warn("login", zap.Any("user", u))                                // want "struct 'User' contains sensitive fields and should not be logged entirely"
+	l.Log(zapcore.WarnLevel, "login", zap.String("key", u.APIKey))     // want "sensitive field 'User.APIKey' should not be logged"
+	l.Named("auth").Error("login", zap.String("password", u.Password)) // want "sensitive field 'User.Password' should not be logged"
+	zap.L().Debug("login", zap.String("password", u.Password))         // want "sensitive field 'User.Password' should not be logged"
+
+	password := u.Password
+	l.Info("login", zap.String("pwd", password)) // want `variable "password" contains sensitive field "User.Password"`
+
+	// Not a logging method
+	l.Check(zapcore.InfoLevel, u.Password)
+}
+
+func sugared(s *zap.SugaredLogger, u User) {
+	s.Infow("login", "user", u.Name)
+	s.Infow("login", "password", u.Password)               // want "sensitive field 'User.Password' should not be logged"
+	s.Errorw("login", "user", u)                           // want "struct 'User' contains sensitive fields and should not be logged entirely"
+	s.Info("login ", u.APIKey)                             // want "sensitive field 'User.APIKey' should not be logged"
+	s.Infof("login %s", u.Password)                        // want "sensitive field 'User.Password' should not be logged"
+	s.Infof("login %T", u.Password)                        // Only the type is printed
+	s.Logf(zapcore.InfoLevel, "login %T", u.Password)      // Only the type is printed
+	s.Warnln("login", u.APIKey)                            // want "sensitive field 'User.APIKey' should not be logged"
+	zap.S().Debugw("login", "key", u.APIKey)               // want "sensitive field 'User.APIKey' should not be logged"
+	zap.L().Sugar().Infow("login", "password", u.Password) // want "sensitive field 'User.Password' should not be logged"
+}
+
+func wrapped(app AppLogger, u User) {
+	app.Info("login", zap.String("password", u.Password)) // want "sensitive field 'User.Password' should not be logged"
+}
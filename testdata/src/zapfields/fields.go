@@ -0,0 +1,29 @@
+package zapfields
+
+import "go.uber.org/zap"
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+func literal(logger *zap.Logger, user User) {
+	fields := []zap.Field{zap.String("pwd", user.Password)}
+	logger.Info("login", fields...) // want `variable "fields" contains sensitive field "User.Password"`
+}
+
+func appended(logger *zap.Logger, user User) {
+	fields := []zap.Field{zap.String("name", user.Name)}
+	fields = append(fields, zap.String("pwd", user.Password))
+	logger.Info("login", fields...) // want `variable "fields" contains sensitive field "User.Password"`
+
+	safe := make([]zap.Field, 0, 1)
+	safe = append(safe, zap.String("name", user.Name))
+	logger.Info("login", safe...)
+}
+
+func single(logger *zap.Logger, user User) {
+	pwd := user.Password
+	field := zap.Any("pwd", pwd)
+	logger.Info("login", field) // want `variable "field" contains sensitive field "User.Password"`
+}
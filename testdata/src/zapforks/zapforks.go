@@ -0,0 +1,27 @@
+package zapforks
+
+import (
+	"github.com/org/zap/v2"
+	"github.com/org/zapx"
+	otherzap "github.com/other/zap"
+	"zapforks/log"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+}
+
+// The glob package "github.com/org/zap*" matches every fork under the prefix
+func forks(fork *zapx.Logger, v2 *zap.Logger, u User) {
+	fork.Info("login", "password", u.Password) // want `sensitive field 'User.Password' should not be logged`
+	zapx.Info("login", "password", u.Password) // want `sensitive field 'User.Password' should not be logged`
+	v2.Info("login", "password", u.Password)   // want `sensitive field 'User.Password' should not be logged`
+	fork.Info("login", "name", u.Name)
+}
+
+// Packages outside the prefix are not sinks, nor is a local package named log
+func notForks(other *otherzap.Logger, u User) {
+	other.Info("login", "password", u.Password)
+	log.Printf("login %s", u.Password)
+}
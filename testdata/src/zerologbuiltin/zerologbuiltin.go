@@ -0,0 +1,44 @@
+// Package zerologbuiltin checks the built-in github.com/rs/zerolog sinks,
+// without a config file
+package zerologbuiltin
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type User struct {
+	Name     string
+	Password string `sensitive:"true"`
+	APIKey   string `sensitive:"true"`
+}
+
+func chained(u User) {
+	log.Info().Str("user", u.Name).Msg("login")
+	log.Info().Str("pwd", u.Password).Msg("login")                          // want "sensitive field 'User.Password' should not be logged"
+	log.Info().Interface("user", u).Msg("login")                            // want "struct 'User' contains sensitive fields and should not be logged entirely"
+	log.Error().Any("user", u).Send()                                       // want "struct 'User' contains sensitive fields and should not be logged entirely"
+	log.Debug().Str("user", u.Name).Int("n", 1).Str("key", u.APIKey).Send() // want "sensitive field 'User.APIKey' should not be logged"
+	log.Info().Strs("keys", []string{u.APIKey}).Msg("login")                // want "sensitive field 'User.APIKey' should not be logged"
+	log.Info().Dict("user", zerolog.Dict().Str("pwd", u.Password)).Send()   // want "sensitive field 'User.Password' should not be logged"
+	log.Info().Msg(u.Password)                                              // want "sensitive field 'User.Password' should not be logged"
+	log.Info().Msgf("login %s", u.APIKey)                                   // want "sensitive field 'User.APIKey' should not be logged"
+	log.Info().Msgf("login %T", u.APIKey)                                   // Only the type is printed
+	log.Error().Err(errors.New("failed")).Str("user", u.Name).Msg("login")
+
+	password := u.Password
+	log.Info().Str("pwd", password).Msg("login") // want `variable "password" contains sensitive field "User.Password"`
+}
+
+func logger(ctx context.Context, l *zerolog.Logger, u User) {
+	l.Warn().Str("pwd", u.Password).Msg("login")                              // want "sensitive field 'User.Password' should not be logged"
+	l.WithLevel(zerolog.WarnLevel).Str("key", u.APIKey).Send()                // want "sensitive field 'User.APIKey' should not be logged"
+	zerolog.Ctx(ctx).Info().Str("user", u.Name).Str("pwd", u.Password).Send() // want "sensitive field 'User.Password' should not be logged"
+	(l.Info().Str("pwd", u.Password)).Msg("login")                            // want "sensitive field 'User.Password' should not be logged"
+
+	// Not sent
+	l.Info().Str("pwd", u.Password)
+}
@@ -0,0 +1,312 @@
+// Package triage maintains the triage file written by `leakhound triage`: an
+// editable YAML list of the current findings in which reviewers record a
+// status and a reason for each. Later runs merge the file, suppressing
+// accepted findings and false positives and reporting fix-later findings
+// again once they are overdue.
+package triage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nilpoona/leakhound/config"
+	"github.com/nilpoona/leakhound/detector"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFile is the triage file used when none is given
+const DefaultFile = ".leakhound-triage.yaml"
+
+// Status is the review outcome of a finding
+type Status string
+
+const (
+	StatusUntriaged     Status = ""               // Not reviewed yet; the finding is reported
+	StatusAccept        Status = "accept"         // A known risk accepted as is; the finding is suppressed
+	StatusFalsePositive Status = "false-positive" // Not a leak; the finding is suppressed
+	StatusFixLater      Status = "fix-later"      // A leak to fix by the due date; suppressed until then
+)
+
+// dateLayout is the layout of due dates, e.g. "2026-03-31"
+const dateLayout = "2006-01-02"
+
+// Entry is the triage record of a finding
+type Entry struct {
+	Fingerprint string `yaml:"fingerprint"` // detector.Finding.ID, the content fingerprint made unique, which survives line shifts
+	Rule        string `yaml:"rule"`        // SARIF rule ID
+	Location    string `yaml:"location"`    // "path/to/file.go:42" relative to the working directory, for reviewers
+	Message     string `yaml:"message"`
+	Status      Status `yaml:"status"`
+	Reason      string `yaml:"reason"`
+	Due         string `yaml:"due,omitempty"` // Fix-later deadline, "2006-01-02"
+}
+
+// File is the content of a triage file
+type File struct {
+	Findings []Entry `yaml:"findings"`
+}
+
+// header explains the file to reviewers editing it
+const header = `# leakhound triage file. Set the status of each finding and give a reason:
+#   accept          a known risk accepted as is; the finding is suppressed
+#   false-positive  not a leak; the finding is suppressed
+#   fix-later       suppressed until the due date (YYYY-MM-DD), then reported again
+# Leave the status empty for findings not reviewed yet. Running
+# "leakhound triage" again adds new findings and drops fixed ones, keeping
+# the statuses recorded here.
+`
+
+// Load reads and validates the triage file at path
+func Load(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read triage file: %w", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return f, nil
+}
+
+// Parse decodes and validates the content of a triage file. Unknown keys
+// are rejected so a misspelled status field is not silently ignored.
+func Parse(data []byte) (*File, error) {
+	f := &File{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse triage file: %w", err)
+	}
+	if err := f.validate(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// validate checks the statuses and due dates of the entries
+func (f *File) validate() error {
+	seen := make(map[string]bool, len(f.Findings))
+	for i, e := range f.Findings {
+		if e.Fingerprint == "" {
+			return fmt.Errorf("finding %d: fingerprint is required", i+1)
+		}
+		if seen[e.Fingerprint] {
+			return fmt.Errorf("finding %d: duplicate fingerprint %s", i+1, e.Fingerprint)
+		}
+		seen[e.Fingerprint] = true
+
+		switch e.Status {
+		case StatusUntriaged, StatusAccept, StatusFalsePositive:
+			if e.Due != "" {
+				return fmt.Errorf("finding %d (%s): due is only allowed with status %q", i+1, e.Location, StatusFixLater)
+			}
+		case StatusFixLater:
+			if e.Due == "" {
+				return fmt.Errorf("finding %d (%s): status %q requires a due date", i+1, e.Location, StatusFixLater)
+			}
+			if _, err := time.Parse(dateLayout, e.Due); err != nil {
+				return fmt.Errorf("finding %d (%s): invalid due date %q (must be YYYY-MM-DD)", i+1, e.Location, e.Due)
+			}
+		default:
+			return fmt.Errorf("finding %d (%s): invalid status %q (must be %q, %q, %q or empty)",
+				i+1, e.Location, e.Status, StatusAccept, StatusFalsePositive, StatusFixLater)
+		}
+	}
+	return nil
+}
+
+// Overdue reports whether a fix-later entry is past its due date at now.
+// The due date itself is not overdue.
+func (e Entry) Overdue(now time.Time) bool {
+	if e.Status != StatusFixLater {
+		return false
+	}
+	due, err := time.Parse(dateLayout, e.Due)
+	if err != nil {
+		return false
+	}
+	y, m, d := now.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).After(due)
+}
+
+// Write encodes f with an explanatory header to path
+func Write(path string, f *File) error {
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(f); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write triage file: %w", err)
+	}
+	return nil
+}
+
+// MergeStats counts the changes made by Merge
+type MergeStats struct {
+	Total     int // Entries in the merged file
+	New       int // Findings not in the previous file
+	Resolved  int // Previous entries whose findings are gone
+	Untriaged int // Entries without a status
+	Overdue   int // Fix-later entries past their due date
+}
+
+// Merge returns the triage file for the current findings: one entry per
+// unsuppressed finding, keyed on its ID (see detector.AssignFingerprints),
+// keeping the status, reason and due date recorded for it in prev, which may
+// be nil. Entries of prev whose findings are gone are dropped. Findings
+// without an ID cannot be tracked across runs and are left out.
+func Merge(prev *File, findings []detector.Finding, fset *token.FileSet, workDir string, now time.Time) (*File, MergeStats) {
+	previous := make(map[string]Entry)
+	if prev != nil {
+		for _, e := range prev.Findings {
+			previous[e.Fingerprint] = e
+		}
+	}
+
+	var stats MergeStats
+	var positions []token.Position
+	merged := &File{}
+	seen := make(map[string]bool)
+	for _, f := range findings {
+		fp := f.ID
+		if f.Suppressed || fp == "" || seen[fp] {
+			continue
+		}
+		seen[fp] = true
+
+		e, ok := previous[fp]
+		if !ok {
+			stats.New++
+		}
+		e.Fingerprint = fp
+		e.Rule = f.SARIFRuleID()
+		pos := fset.Position(f.Pos)
+		e.Location = location(pos, workDir)
+		e.Message = f.Message
+		merged.Findings = append(merged.Findings, e)
+		positions = append(positions, pos)
+
+		if e.Status == StatusUntriaged {
+			stats.Untriaged++
+		}
+		if e.Overdue(now) {
+			stats.Overdue++
+		}
+	}
+	for fp := range previous {
+		if !seen[fp] {
+			stats.Resolved++
+		}
+	}
+
+	// Order by file and line so a rerun after unrelated changes yields a
+	// small diff
+	order := make([]int, len(merged.Findings))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		pi, pj := positions[order[i]], positions[order[j]]
+		if pi.Filename != pj.Filename {
+			return pi.Filename < pj.Filename
+		}
+		return pi.Line < pj.Line
+	})
+	sorted := make([]Entry, len(order))
+	for i, k := range order {
+		sorted[i] = merged.Findings[k]
+	}
+	merged.Findings = sorted
+	stats.Total = len(merged.Findings)
+	return merged, stats
+}
+
+// Apply merges the statuses of f into findings, matched by ID (see
+// detector.AssignFingerprints): accepted findings and false positives are
+// suppressed, and so are fix-later findings until their due date. Overdue
+// fix-later findings stay reported with the due date and reason appended to
+// their message. Untriaged findings are left alone.
+func Apply(findings []detector.Finding, f *File, now time.Time) []detector.Finding {
+	if f == nil || len(f.Findings) == 0 {
+		return findings
+	}
+	entries := make(map[string]Entry, len(f.Findings))
+	for _, e := range f.Findings {
+		entries[e.Fingerprint] = e
+	}
+
+	for i := range findings {
+		if findings[i].Suppressed {
+			continue
+		}
+		e, ok := entries[findings[i].ID]
+		if !ok || e.Status == StatusUntriaged {
+			continue
+		}
+		if e.Overdue(now) {
+			findings[i].Message += overdueSuffix(e)
+			continue
+		}
+		findings[i].Suppressed = true
+		findings[i].SuppressionKind = "external"
+	}
+	return findings
+}
+
+// Stage returns the baseline stage of the post-processing pipeline (see
+// detector.NewPipeline) applying the statuses of f at now (see Apply)
+func Stage(f *File, now time.Time) detector.Stage {
+	return func(findings []detector.Finding, _ *config.Config) []detector.Finding {
+		return Apply(findings, f, now)
+	}
+}
+
+// Untriaged counts the unsuppressed findings without a status in f: those
+// missing from the file, which are new since it was last updated, and those
+// not reviewed yet
+func (f *File) Untriaged(findings []detector.Finding) int {
+	statuses := make(map[string]Status)
+	if f != nil {
+		for _, e := range f.Findings {
+			statuses[e.Fingerprint] = e.Status
+		}
+	}
+	n := 0
+	for _, finding := range findings {
+		if !finding.Suppressed && statuses[finding.ID] == StatusUntriaged {
+			n++
+		}
+	}
+	return n
+}
+
+// overdueSuffix describes an overdue fix-later entry for finding messages,
+// e.g. " (fix-later overdue since 2026-03-31: rotate the key first)"
+func overdueSuffix(e Entry) string {
+	if e.Reason == "" {
+		return fmt.Sprintf(" (%s overdue since %s)", StatusFixLater, e.Due)
+	}
+	return fmt.Sprintf(" (%s overdue since %s: %s)", StatusFixLater, e.Due, e.Reason)
+}
+
+// location formats pos relative to workDir with forward slashes, so the
+// file reads the same on every platform
+func location(pos token.Position, workDir string) string {
+	path := pos.Filename
+	if rel, err := filepath.Rel(workDir, path); err == nil && !strings.HasPrefix(rel, "..") {
+		path = rel
+	}
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(path), pos.Line)
+}
@@ -0,0 +1,275 @@
+package triage
+
+import (
+	"errors"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nilpoona/leakhound/detector"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{"empty file", "", ""},
+		{"untriaged", "findings:\n  - fingerprint: a\n    status: \"\"\n", ""},
+		{"all statuses", `findings:
+  - fingerprint: a
+    status: accept
+    reason: internal tool
+  - fingerprint: b
+    status: false-positive
+    reason: hashed
+  - fingerprint: c
+    status: fix-later
+    reason: rotate first
+    due: 2026-03-31
+`, ""},
+		{"unknown status", "findings:\n  - fingerprint: a\n    status: ignore\n", `invalid status "ignore"`},
+		{"unknown key", "findings:\n  - fingerprint: a\n    stauts: accept\n", "field stauts not found"},
+		{"missing fingerprint", "findings:\n  - status: accept\n", "fingerprint is required"},
+		{"duplicate fingerprint", "findings:\n  - fingerprint: a\n  - fingerprint: a\n", "duplicate fingerprint a"},
+		{"fix-later without due", "findings:\n  - fingerprint: a\n    status: fix-later\n", "requires a due date"},
+		{"invalid due", "findings:\n  - fingerprint: a\n    status: fix-later\n    due: next week\n", `invalid due date "next week"`},
+		{"due without fix-later", "findings:\n  - fingerprint: a\n    status: accept\n    due: 2026-03-31\n", "due is only allowed"},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := Parse([]byte(tt.yaml))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Parse() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Parse() error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEntry_Overdue(t *testing.T) {
+	t.Parallel()
+
+	fixLater := Entry{Status: StatusFixLater, Due: "2026-03-31"}
+	tests := []struct {
+		name  string
+		entry Entry
+		now   time.Time
+		want  bool
+	}{
+		{"before due date", fixLater, time.Date(2026, 3, 30, 12, 0, 0, 0, time.UTC), false},
+		{"on due date", fixLater, time.Date(2026, 3, 31, 23, 59, 0, 0, time.UTC), false},
+		{"after due date", fixLater, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), true},
+		{"other status", Entry{Status: StatusAccept}, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		tt := tt // capture range variable
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.entry.Overdue(tt.now); got != tt.want {
+				t.Errorf("Overdue(%s) = %v, want %v", tt.now.Format(time.DateTime), got, tt.want)
+			}
+		})
+	}
+}
+
+// testFindings returns findings at lines 1 to 4 of /work/app/a.go with their
+// IDs assigned: two distinct leaks, a repeat of the first, which shares its
+// fingerprint, and an inline-suppressed leak
+func testFindings(t *testing.T) ([]detector.Finding, *token.FileSet) {
+	t.Helper()
+	fset := token.NewFileSet()
+	file := fset.AddFile("/work/app/a.go", 1, 100)
+	file.SetLines([]int{0, 20, 40, 60})
+
+	return detector.AssignFingerprints([]detector.Finding{
+		{Pos: token.Pos(61), RuleID: "sensitive-field", Message: "leak b", Func: "app.g", Expr: "u.Token"},
+		{Pos: token.Pos(1), RuleID: "sensitive-field", Message: "leak a", Func: "app.f", Expr: "u.Password"},
+		{Pos: token.Pos(21), RuleID: "sensitive-field", Message: "leak a", Func: "app.f", Expr: "u.Password"},
+		{Pos: token.Pos(41), RuleID: "sensitive-field", Message: "leak c", Func: "app.h", Expr: "u.Secret", Suppressed: true},
+	}, nil), fset
+}
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+
+	findings, fset := testFindings(t)
+	a, repeat, b := findings[1].ID, findings[2].ID, findings[0].ID
+	prev := &File{Findings: []Entry{
+		{Fingerprint: a, Location: "app/a.go:7", Status: StatusFixLater, Reason: "rotate first", Due: "2026-03-31"},
+		{Fingerprint: "gone", Status: StatusAccept, Reason: "fixed since"},
+	}}
+
+	merged, stats := Merge(prev, findings, fset, "/work", time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC))
+
+	want := MergeStats{Total: 3, New: 2, Resolved: 1, Untriaged: 2, Overdue: 1}
+	if stats != want {
+		t.Errorf("Merge() stats = %+v, want %+v", stats, want)
+	}
+	if len(merged.Findings) != 3 {
+		t.Fatalf("Merge() entries = %+v, want 3", merged.Findings)
+	}
+	first, second, third := merged.Findings[0], merged.Findings[1], merged.Findings[2]
+	if first.Fingerprint != a || first.Location != "app/a.go:1" || first.Status != StatusFixLater || first.Reason != "rotate first" || first.Due != "2026-03-31" {
+		t.Errorf("kept entry = %+v, want the recorded status at the new location", first)
+	}
+	if second.Fingerprint != repeat || second.Location != "app/a.go:2" || second.Status != StatusUntriaged {
+		t.Errorf("repeated entry = %+v, want an untriaged entry of its own", second)
+	}
+	if third.Fingerprint != b || third.Rule != "LH0004" || third.Message != "leak b" || third.Status != StatusUntriaged {
+		t.Errorf("new entry = %+v, want an untriaged LH0004 entry", third)
+	}
+}
+
+func TestApply(t *testing.T) {
+	t.Parallel()
+
+	findings, _ := testFindings(t)
+	f := &File{Findings: []Entry{
+		{Fingerprint: findings[1].ID, Status: StatusFixLater, Reason: "rotate first", Due: "2026-03-31"},
+		{Fingerprint: findings[0].ID, Status: StatusFalsePositive, Reason: "test token"},
+	}}
+
+	before := Apply(append([]detector.Finding(nil), findings...), f, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	for _, i := range []int{0, 1, 3} {
+		if !before[i].Suppressed {
+			t.Errorf("before the due date: finding %d not suppressed", i)
+		}
+	}
+	if before[2].Suppressed {
+		t.Errorf("untriaged repeat suppressed by the entry of the finding it repeats")
+	}
+	if before[0].SuppressionKind != "external" {
+		t.Errorf("SuppressionKind = %q, want %q", before[0].SuppressionKind, "external")
+	}
+
+	after := Apply(append([]detector.Finding(nil), findings...), f, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+	if !after[0].Suppressed {
+		t.Errorf("false positive reported after the due date of another entry")
+	}
+	if after[1].Suppressed {
+		t.Errorf("overdue finding suppressed")
+	}
+	if want := "leak a (fix-later overdue since 2026-03-31: rotate first)"; after[1].Message != want {
+		t.Errorf("overdue finding message = %q, want %q", after[1].Message, want)
+	}
+	if after[2].Message != "leak a" {
+		t.Errorf("untriaged repeat message = %q, want it unchanged", after[2].Message)
+	}
+	if after[3].SuppressionKind != "" {
+		t.Errorf("inline-suppressed finding changed: %+v", after[3])
+	}
+}
+
+func TestApply_SharedFingerprint(t *testing.T) {
+	t.Parallel()
+
+	// A debug print accepted in a function must not suppress a production
+	// log of the same value in it
+	fset := token.NewFileSet()
+	file := fset.AddFile("/work/app/a.go", 1, 100)
+	file.SetLines([]int{0, 20, 40, 60})
+	findings := detector.AssignFingerprints([]detector.Finding{
+		{Pos: token.Pos(1), RuleID: "sensitive-field", Message: "debug", Func: "app.f", Sink: "log.Println", Expr: "u.Password"},
+		{Pos: token.Pos(21), RuleID: "sensitive-field", Message: "production", Func: "app.f", Sink: "log.Println", Expr: "u.Password"},
+	}, nil)
+	if findings[0].Fingerprint() != findings[1].Fingerprint() {
+		t.Fatalf("test findings should share a fingerprint")
+	}
+
+	merged, _ := Merge(nil, findings, fset, "/work", time.Now())
+	if len(merged.Findings) != 2 || merged.Findings[0].Fingerprint == merged.Findings[1].Fingerprint {
+		t.Fatalf("Merge() entries = %+v, want one per finding", merged.Findings)
+	}
+
+	merged.Findings[0].Status, merged.Findings[0].Reason = StatusAccept, "debugging"
+	got := Apply(findings, merged, time.Now())
+	if !got[0].Suppressed {
+		t.Errorf("accepted finding not suppressed")
+	}
+	if got[1].Suppressed {
+		t.Errorf("finding sharing the fingerprint of an accepted one suppressed")
+	}
+	if n := merged.Untriaged(got); n != 1 {
+		t.Errorf("Untriaged() = %d, want 1", n)
+	}
+}
+
+func TestFile_Untriaged(t *testing.T) {
+	t.Parallel()
+
+	findings, _ := testFindings(t)
+	f := &File{Findings: []Entry{
+		{Fingerprint: findings[1].ID, Status: StatusAccept, Reason: "internal tool"},
+		{Fingerprint: findings[0].ID},
+	}}
+	if got := f.Untriaged(findings); got != 2 {
+		t.Errorf("Untriaged() = %d, want 2: the finding recorded without a status and the repeat missing from the file", got)
+	}
+
+	var missing *File
+	if got := missing.Untriaged(findings); got != 3 {
+		t.Errorf("without a file: Untriaged() = %d, want every unsuppressed finding", got)
+	}
+}
+
+func TestWriteLoad(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), DefaultFile)
+	f := &File{Findings: []Entry{
+		{Fingerprint: "a", Rule: "LH0001", Location: "app/a.go:3", Message: "leak", Status: StatusFixLater, Reason: "soon", Due: "2026-03-31"},
+		{Fingerprint: "b", Rule: "LH0004", Location: "app/a.go:9", Message: "leak"},
+	}}
+	if err := Write(path, f); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# leakhound triage file") {
+		t.Errorf("written file does not start with the header:\n%s", data)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(got.Findings) != 2 || got.Findings[0] != f.Findings[0] || got.Findings[1] != f.Findings[1] {
+		t.Errorf("Load() = %+v, want %+v", got.Findings, f.Findings)
+	}
+
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yaml")); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Load(missing) error = %v, want a not-exist error", err)
+	}
+}
+
+func TestStage(t *testing.T) {
+	t.Parallel()
+
+	findings, _ := testFindings(t)
+	f := &File{Findings: []Entry{{Fingerprint: findings[0].ID, Status: StatusAccept, Reason: "public"}}}
+
+	got := Stage(f, time.Now())(append([]detector.Finding(nil), findings...), nil)
+	if !got[0].Suppressed || got[0].SuppressionKind != "external" {
+		t.Errorf("accepted finding = %+v, want it suppressed externally", got[0])
+	}
+	if got[1].Suppressed {
+		t.Errorf("untriaged finding suppressed: %+v", got[1])
+	}
+}
@@ -1,9 +1,13 @@
 package leakhound_test
 
 import (
+	"errors"
+	"fmt"
 	"go/token"
+	"go/types"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 	"testing"
 
@@ -27,7 +31,8 @@ func TestWholeProgramCrossPackage(t *testing.T) {
 	cfg := &packages.Config{
 		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
 			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
-			packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo,
+			packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo |
+			packages.NeedModule,
 		Tests: false,
 		Dir:   dir,
 		Fset:  token.NewFileSet(),
@@ -122,6 +127,341 @@ func TestWholeProgramCrossPackage(t *testing.T) {
 	}
 }
 
+// TestWholeProgramCoverage verifies the coverage summary: analyzed packages,
+// inspected sink calls, declared sensitive fields and unmatched targets.
+func TestWholeProgramCoverage(t *testing.T) {
+	fset, all := loadWholeProgramTestdata(t, "testdata/crosspkgflow")
+
+	world := detector.NewWorldView(fset, all)
+	wp := detector.NewWholeProgramCollector(world, &config.Config{
+		Targets: []config.TargetConfig{{
+			Package:   "example.com/crosspkgflow/telemetry",
+			Functions: []string{"Init", "Flush"},
+		}},
+	})
+	wp.Collect()
+	cov := wp.Coverage()
+
+	byPath := make(map[string]detector.PackageCoverage)
+	for _, p := range cov.Packages {
+		byPath[p.Path] = p
+	}
+	for _, path := range []string{"example.com/crosspkgflow/app", "example.com/crosspkgflow/secret", "example.com/crosspkgflow/telemetry"} {
+		if _, ok := byPath[path]; !ok {
+			t.Errorf("package %s missing from coverage: %+v", path, cov.Packages)
+		}
+	}
+	if got := byPath["example.com/crosspkgflow/secret"].SensitiveFields; got != 1 {
+		t.Errorf("secret: SensitiveFields = %d, want 1", got)
+	}
+	if got := byPath["example.com/crosspkgflow/telemetry"].SinkCalls; got != 2 {
+		t.Errorf("telemetry: SinkCalls = %d, want 2", got)
+	}
+	if total := cov.Totals(); total.SinkCalls == 0 || total.Files < 3 {
+		t.Errorf("Totals() = %+v, want sink calls and at least 3 files", total)
+	}
+
+	if got := cov.TargetCalls["example.com/crosspkgflow/telemetry.Init"]; got == 0 {
+		t.Errorf("telemetry.Init matched no calls")
+	}
+	unmatched := cov.UnmatchedTargets()
+	if len(unmatched) != 1 || unmatched[0] != "example.com/crosspkgflow/telemetry.Flush" {
+		t.Errorf("UnmatchedTargets() = %v, want [example.com/crosspkgflow/telemetry.Flush]", unmatched)
+	}
+}
+
+// TestWholeProgramMemoryBudget verifies that packages over the max-memory
+// budget are degraded to direct-access-only detection with a notification,
+// and that a budget they stay within changes nothing.
+func TestWholeProgramMemoryBudget(t *testing.T) {
+	analyze := func(maxMemory string) ([]detector.Finding, []detector.Notification) {
+		fset, all := loadWholeProgramTestdata(t, "testdata/crosspkgflow")
+		wp := detector.NewWholeProgramCollector(detector.NewWorldView(fset, all), &config.Config{MaxMemory: maxMemory})
+		wp.Collect()
+		return wp.Analyze(), wp.Notifications()
+	}
+
+	unlimited, notes := analyze("")
+	if len(notes) != 0 {
+		t.Errorf("no budget: notifications = %v, want none", notes)
+	}
+	within, notes := analyze("1GiB")
+	if len(notes) != 0 || len(within) != len(unlimited) {
+		t.Errorf("1GiB budget: %d findings and notifications %v, want %d findings and none", len(within), notes, len(unlimited))
+	}
+
+	degraded, notes := analyze("1B")
+	var paths []string
+	for _, n := range notes {
+		if n.Level != detector.SeverityWarning || !strings.Contains(n.Message, "over the max-memory budget of 1B") {
+			t.Errorf("unexpected notification %v", n)
+		}
+		paths = append(paths, n.Package)
+	}
+	wantPaths := []string{"example.com/crosspkgflow/app", "example.com/crosspkgflow/secret", "example.com/crosspkgflow/telemetry"}
+	if strings.Join(paths, ",") != strings.Join(wantPaths, ",") {
+		t.Errorf("notified packages = %v, want %v", paths, wantPaths)
+	}
+	if len(degraded) >= len(unlimited) {
+		t.Errorf("1B budget: %d findings, want fewer than the %d without a budget", len(degraded), len(unlimited))
+	}
+	for _, f := range degraded {
+		switch f.RuleID {
+		case detector.RuleIDSensitiveVar, detector.RuleIDSensitiveCall,
+			detector.RuleIDCrossPkgSensitiveReturn, detector.RuleIDCrossPkgSensitiveSink:
+			t.Errorf("data flow finding despite the exceeded budget: %s %s", f.RuleID, f.Message)
+		}
+	}
+}
+
+// TestWholeProgramProgress verifies that every package is reported once
+// collected and once analyzed, with its findings, in import-path order.
+func TestWholeProgramProgress(t *testing.T) {
+	fset, all := loadWholeProgramTestdata(t, "testdata/crosspkgflow")
+	wp := detector.NewWholeProgramCollector(detector.NewWorldView(fset, all), &config.Config{})
+	var events []detector.Progress
+	wp.SetProgress(func(p detector.Progress) { events = append(events, p) })
+	wp.Collect()
+	findings := wp.Analyze()
+
+	counts := make(map[string]int)
+	var analyzed []string
+	total := 0
+	for _, e := range events {
+		counts[e.Phase]++
+		if e.Phase == detector.PhaseAnalyzed {
+			analyzed = append(analyzed, e.Package)
+			total += e.Findings
+			if e.Done != len(analyzed) || e.Total != len(all) {
+				t.Errorf("analyzed %s: %d/%d, want %d/%d", e.Package, e.Done, e.Total, len(analyzed), len(all))
+			}
+		}
+	}
+	if counts[detector.PhaseCollected] != len(all) || counts[detector.PhasePropagated] != 1 || counts[detector.PhaseAnalyzed] != len(all) {
+		t.Errorf("events per phase = %v, want %d collected, 1 propagated and %d analyzed", counts, len(all), len(all))
+	}
+	if !slices.IsSorted(analyzed) {
+		t.Errorf("analyzed packages %v, want import-path order", analyzed)
+	}
+	crossPkg := 0
+	for _, f := range findings {
+		if f.RuleID == detector.RuleIDCrossPkgSensitiveSink {
+			crossPkg++
+		}
+	}
+	if total != len(findings)-crossPkg {
+		t.Errorf("findings reported per package = %d, want %d (all but the %d LH0006 findings)", total, len(findings)-crossPkg, crossPkg)
+	}
+}
+
+// TestExplainTargets verifies target resolution for -explain-config.
+func TestExplainTargets(t *testing.T) {
+	fset, all := loadWholeProgramTestdata(t, "testdata/crosspkgflow")
+
+	reports := detector.ExplainTargets(&config.Config{
+		Targets: []config.TargetConfig{
+			{
+				Package:   "example.com/crosspkgflow/telemetry",
+				Functions: []string{"Init", "Flush"},
+			},
+			{
+				Package:   "example.com/missing",
+				Functions: []string{"Log"},
+			},
+		},
+	}, all, fset)
+	if len(reports) != 2 {
+		t.Fatalf("ExplainTargets() returned %d reports, want 2", len(reports))
+	}
+
+	telemetry := reports[0]
+	if telemetry.Package == nil || telemetry.Package.Name() != "telemetry" {
+		t.Errorf("telemetry: Package = %v, want package telemetry", telemetry.Package)
+	}
+	if len(telemetry.ImportedBy) != 1 || telemetry.ImportedBy[0] != "example.com/crosspkgflow/app" {
+		t.Errorf("telemetry: ImportedBy = %v, want [example.com/crosspkgflow/app]", telemetry.ImportedBy)
+	}
+	if len(telemetry.Entries) != 2 {
+		t.Fatalf("telemetry: %d entries, want 2", len(telemetry.Entries))
+	}
+	if init := telemetry.Entries[0]; !init.Declared || len(init.Calls) == 0 {
+		t.Errorf("telemetry.Init = %+v, want declared with call sites", init)
+	} else if pos := fset.Position(init.Calls[0]); filepath.Base(pos.Filename) != "app.go" {
+		t.Errorf("telemetry.Init call site = %v, want in app.go", pos)
+	}
+	if flush := telemetry.Entries[1]; flush.Declared || len(flush.Calls) != 0 {
+		t.Errorf("telemetry.Flush = %+v, want undeclared without call sites", flush)
+	}
+
+	if missing := reports[1]; missing.Package != nil || len(missing.ImportedBy) != 0 || len(missing.Candidates) != 0 {
+		t.Errorf("example.com/missing resolved to %v, imported by %v; want unresolved", missing.Package, missing.ImportedBy)
+	}
+
+	// A glob target resolves to the package it matches
+	reports = detector.ExplainTargets(&config.Config{
+		Targets: []config.TargetConfig{{
+			Package:   "example.com/crosspkgflow/tele*",
+			Functions: []string{"Init"},
+		}},
+	}, all, fset)
+	glob := reports[0]
+	if glob.Package == nil || glob.Package.Name() != "telemetry" || len(glob.ImportedBy) != 1 {
+		t.Errorf("glob: resolved to %v, imported by %v; want package telemetry imported by app", glob.Package, glob.ImportedBy)
+	}
+	if len(glob.Entries) != 1 || glob.Entries[0].Entry != "example.com/crosspkgflow/tele*.Init" ||
+		!glob.Entries[0].Declared || len(glob.Entries[0].Calls) != len(telemetry.Entries[0].Calls) {
+		t.Errorf("glob: entries = %+v, want tele*.Init declared with the call sites of telemetry.Init", glob.Entries)
+	}
+}
+
+// TestWholeProgramShadowedStdlibPath verifies that the dependencies of a
+// module without a dotted domain are analyzed: "shadowedlog/log" looks like a
+// standard library path but belongs to the module.
+func TestWholeProgramShadowedStdlibPath(t *testing.T) {
+	fset, all := loadWholeProgramTestdata(t, "testdata/shadowedlog", "./app")
+
+	var loaded []string
+	for _, p := range all {
+		loaded = append(loaded, p.PkgPath)
+	}
+	if !slices.Contains(loaded, "shadowedlog/log") || slices.Contains(loaded, "log/slog") {
+		t.Errorf("loaded packages = %v, want shadowedlog/log without the standard library", loaded)
+	}
+
+	wp := detector.NewWholeProgramCollector(detector.NewWorldView(fset, all), &config.Config{})
+	wp.Collect()
+	var sinks []string
+	for _, f := range wp.Analyze() {
+		if f.RuleID == detector.RuleIDCrossPkgSensitiveSink {
+			sinks = append(sinks, f.Message)
+		}
+	}
+	if len(sinks) != 1 || !strings.Contains(sinks[0], "Audit") {
+		t.Errorf("cross-package sink findings = %v, want one for log.Audit", sinks)
+	}
+}
+
+// TestWholeProgramTypeErrors verifies that files which do not type-check are
+// skipped with a notification listing them, here because they call into cgo
+// files that were not built, while the rest of the package is analyzed.
+func TestWholeProgramTypeErrors(t *testing.T) {
+	fset, all := loadWholeProgramTestdata(t, "testdata/partialtypes")
+
+	wp := detector.NewWholeProgramCollector(detector.NewWorldView(fset, all), &config.Config{})
+	wp.Collect()
+	var lines []string
+	for _, f := range wp.Analyze() {
+		pos := fset.Position(f.Pos)
+		lines = append(lines, fmt.Sprintf("%s:%d", filepath.Base(pos.Filename), pos.Line))
+	}
+	if want := []string{"app.go:14"}; !slices.Equal(lines, want) {
+		t.Errorf("findings at %v, want %v", lines, want)
+	}
+
+	notes := wp.Notifications()
+	if len(notes) != 1 {
+		t.Fatalf("notifications = %v, want one", notes)
+	}
+	want := "package partialtypes/app: only the files that type-check are analyzed, skipped session.go does not type-check (undefined: randomID); cgo.go uses cgo and was not built"
+	if notes[0].Level != detector.SeverityWarning || notes[0].Package != "partialtypes/app" || notes[0].Message != want {
+		t.Errorf("notification = %+v, want a warning %q", notes[0], want)
+	}
+}
+
+// TestWholeProgramAllowTypeErrors verifies that with allow-type-errors the
+// files which do not type-check are matched syntactically, with best-effort
+// findings for fields tagged in the files that do.
+func TestWholeProgramAllowTypeErrors(t *testing.T) {
+	fset, all := loadWholeProgramTestdata(t, "testdata/partialtypes")
+
+	wp := detector.NewWholeProgramCollector(detector.NewWorldView(fset, all), &config.Config{AllowTypeErrors: true})
+	wp.Collect()
+	findings := wp.Analyze()
+	if len(findings) != 2 {
+		t.Fatalf("findings = %+v, want 2", findings)
+	}
+	if findings[0].BestEffort {
+		t.Errorf("finding in app.go marked best-effort: %s", findings[0].Message)
+	}
+	f := findings[1]
+	pos := fset.Position(f.Pos)
+	want := "sensitive field 'User.Password' should not be logged (tagged with sensitive:\"true\") in argument 5 of slog.Info (best-effort: the file does not type-check)"
+	if filepath.Base(pos.Filename) != "session.go" || pos.Line != 8 || !f.BestEffort || f.Message != want {
+		t.Errorf("finding at %v: %+v, want a best-effort finding at session.go:8 with message %q", pos, f, want)
+	}
+	if f.Sink != "log/slog.Info" || f.Func != "partialtypes/app.newSession" || f.SARIFRuleID() != "LH0004" {
+		t.Errorf("finding sink %q in %q with rule %s, want log/slog.Info in partialtypes/app.newSession with LH0004", f.Sink, f.Func, f.SARIFRuleID())
+	}
+
+	notes := wp.Notifications()
+	if len(notes) != 1 || !strings.Contains(notes[0].Message, "files that do not type-check are only matched syntactically") {
+		t.Errorf("notifications = %v, want one for files matched syntactically", notes)
+	}
+}
+
+// TestWholeProgramPanickingPackage verifies that a package whose fact
+// collection panics contributes no facts, not even those collected before the
+// panic, while the other packages still get cross-package findings.
+func TestWholeProgramPanickingPackage(t *testing.T) {
+	fset, all := loadWholeProgramTestdata(t, "testdata/crosspkgflow")
+	for _, p := range all {
+		if p.PkgPath == "example.com/crosspkgflow/secret" {
+			// An initializer without variables panics once the package's
+			// functions have been collected
+			p.TypesInfo.InitOrder = append(p.TypesInfo.InitOrder, &types.Initializer{})
+		}
+	}
+
+	wp := detector.NewWholeProgramCollector(detector.NewWorldView(fset, all), &config.Config{})
+	wp.Collect()
+	findings := wp.Analyze()
+
+	var panicErr *detector.PanicError
+	if err := wp.Err(); !errors.As(err, &panicErr) || panicErr.Package != "example.com/crosspkgflow/secret" || panicErr.Step != "collecting facts" {
+		t.Fatalf("Err() = %v, want a panic collecting the facts of example.com/crosspkgflow/secret", err)
+	}
+
+	var sinks []string
+	for _, f := range findings {
+		pos := fset.Position(f.Pos)
+		switch {
+		case f.RuleID == detector.RuleIDCrossPkgSensitiveReturn, strings.Contains(f.Message, `"payload"`):
+			t.Errorf("%s: finding from the facts of the panicking package: %s", key(pos.Filename, pos.Line), f.Message)
+		case f.RuleID == detector.RuleIDCrossPkgSensitiveSink:
+			sinks = append(sinks, key(pos.Filename, pos.Line))
+		}
+	}
+	if want := []string{"app.go:57", "app.go:88", "app.go:95"}; !slices.Equal(sinks, want) {
+		t.Errorf("cross-package sink findings at %v, want %v", sinks, want)
+	}
+}
+
+// loadWholeProgramTestdata loads the packages of the module in dir matching
+// patterns, every package by default, the way the CLI driver does
+func loadWholeProgramTestdata(t *testing.T, dir string, patterns ...string) (*token.FileSet, []*packages.Package) {
+	t.Helper()
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		t.Fatalf("abs path: %v", err)
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+			packages.NeedImports | packages.NeedDeps | packages.NeedTypes |
+			packages.NeedTypesSizes | packages.NeedSyntax | packages.NeedTypesInfo |
+			packages.NeedModule,
+		Dir:  dir,
+		Fset: token.NewFileSet(),
+	}
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	roots, err := packages.Load(cfg, patterns...)
+	if err != nil {
+		t.Fatalf("packages.Load: %v", err)
+	}
+	return cfg.Fset, flattenForTest(roots)
+}
+
 func flattenForTest(roots []*packages.Package) []*packages.Package {
 	seen := make(map[string]*packages.Package)
 	var visit func(p *packages.Package, isRoot bool)
@@ -139,7 +479,7 @@ func flattenForTest(roots []*packages.Package) []*packages.Package {
 		// (detection still works because slog/log/fmt calls resolve via type
 		// info at the call site). Keeping this in sync proves cross-package
 		// detection does not depend on stdlib being loaded.
-		if !isRoot && detector.IsStdlibPackagePath(p.PkgPath) {
+		if !isRoot && detector.IsStdlibPackage(p) {
 			return
 		}
 		seen[p.PkgPath] = p